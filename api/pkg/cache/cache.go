@@ -0,0 +1,18 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores values by key for a limited amount of time
+type Cache interface {
+	// Get returns the value stored at a key and false when the key does not exist or has expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores a value at a key which expires after the ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the values stored at the keys
+	Delete(ctx context.Context, keys ...string) error
+}
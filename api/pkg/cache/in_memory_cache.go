@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+)
+
+type inMemoryCacheItem struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+type inMemoryCache struct {
+	logger   telemetry.Logger
+	tracer   telemetry.Tracer
+	capacity int
+	mutex    sync.Mutex
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+// NewInMemoryCache creates a Cache which evicts the least recently used key once it holds more than capacity keys.
+// Each process has its own cache, so a key which is deleted by one instance is still served by the other instances until it expires.
+func NewInMemoryCache(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	capacity int,
+) Cache {
+	return &inMemoryCache{
+		logger:   logger.WithService(fmt.Sprintf("%T", &inMemoryCache{})),
+		tracer:   tracer,
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Get returns the value stored at a key
+func (cache *inMemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	_, span := cache.tracer.Start(ctx)
+	defer span.End()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	item := element.Value.(*inMemoryCacheItem)
+	if time.Now().UTC().After(item.expiresAt) {
+		cache.remove(element)
+		return nil, false, nil
+	}
+
+	cache.order.MoveToFront(element)
+	return item.value, true, nil
+}
+
+// Set stores a value at a key
+func (cache *inMemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, span := cache.tracer.Start(ctx)
+	defer span.End()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	item := &inMemoryCacheItem{key: key, value: value, expiresAt: time.Now().UTC().Add(ttl)}
+	if element, ok := cache.items[key]; ok {
+		element.Value = item
+		cache.order.MoveToFront(element)
+		return nil
+	}
+
+	cache.items[key] = cache.order.PushFront(item)
+	for cache.order.Len() > cache.capacity {
+		cache.remove(cache.order.Back())
+	}
+
+	return nil
+}
+
+// Delete removes the values stored at the keys
+func (cache *inMemoryCache) Delete(ctx context.Context, keys ...string) error {
+	_, span := cache.tracer.Start(ctx)
+	defer span.End()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for _, key := range keys {
+		if element, ok := cache.items[key]; ok {
+			cache.remove(element)
+		}
+	}
+
+	return nil
+}
+
+func (cache *inMemoryCache) remove(element *list.Element) {
+	cache.order.Remove(element)
+	delete(cache.items, element.Value.(*inMemoryCacheItem).key)
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormCacheEntry is a value of the Cache which is persisted in the database
+type GormCacheEntry struct {
+	Key       string    `json:"key" gorm:"primaryKey"`
+	Value     []byte    `json:"value"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name used by GormCacheEntry to `cache_entries`
+func (GormCacheEntry) TableName() string {
+	return "cache_entries"
+}
+
+type postgresCache struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewPostgresCache creates a Cache which stores values in the `cache_entries` table.
+// The values are shared by every instance of the API so a key which is deleted by one instance is not served by the others.
+func NewPostgresCache(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) Cache {
+	return &postgresCache{
+		logger: logger.WithService(fmt.Sprintf("%T", &postgresCache{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+// Get returns the value stored at a key
+func (cache *postgresCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	ctx, span := cache.tracer.Start(ctx)
+	defer span.End()
+
+	entry := new(GormCacheEntry)
+	err := cache.db.WithContext(ctx).
+		Where("key = ?", key).
+		Where("expires_at > ?", time.Now().UTC()).
+		First(entry).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load cache entry with key [%s]", key)
+		return nil, false, cache.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return entry.Value, true, nil
+}
+
+// Set stores a value at a key
+func (cache *postgresCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	ctx, span := cache.tracer.Start(ctx)
+	defer span.End()
+
+	entry := &GormCacheEntry{
+		Key:       key,
+		Value:     value,
+		ExpiresAt: time.Now().UTC().Add(ttl),
+	}
+
+	err := cache.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "expires_at", "updated_at"}),
+		}).
+		Create(entry).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot store cache entry with key [%s]", key)
+		return cache.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// Delete removes the values stored at the keys
+func (cache *postgresCache) Delete(ctx context.Context, keys ...string) error {
+	ctx, span := cache.tracer.Start(ctx)
+	defer span.End()
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := cache.db.WithContext(ctx).Where("key IN ?", keys).Delete(&GormCacheEntry{}).Error; err != nil {
+		msg := fmt.Sprintf("cannot delete cache entries with keys [%+#v]", keys)
+		return cache.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
@@ -2,9 +2,12 @@ package di
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	lemonsqueezy "github.com/NdoleStudio/lemonsqueezy-go"
@@ -15,14 +18,19 @@ import (
 	firebase "firebase.google.com/go"
 	"firebase.google.com/go/auth"
 	cloudtrace "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace"
+	"github.com/NdoleStudio/superbutton/pkg/cache"
 	"github.com/NdoleStudio/superbutton/pkg/entities"
 	"github.com/NdoleStudio/superbutton/pkg/handlers"
+	"github.com/NdoleStudio/superbutton/pkg/identity"
+	"github.com/NdoleStudio/superbutton/pkg/mailer"
 	"github.com/NdoleStudio/superbutton/pkg/middlewares"
 	"github.com/NdoleStudio/superbutton/pkg/queue"
 	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
 	"github.com/NdoleStudio/superbutton/pkg/services"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
 	"github.com/NdoleStudio/superbutton/pkg/validators"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	fiberLogger "github.com/gofiber/fiber/v2/middleware/logger"
@@ -45,11 +53,15 @@ import (
 
 // Container is used to resolve services at runtime
 type Container struct {
-	projectID       string
-	db              *gorm.DB
-	app             *fiber.App
-	eventDispatcher *services.EventDispatcher
-	logger          telemetry.Logger
+	projectID           string
+	db                  *gorm.DB
+	app                 *fiber.App
+	eventDispatcher     *services.EventDispatcher
+	webhooksQueue       queue.Client
+	integrationRegistry *services.IntegrationRegistry
+	cache               cache.Cache
+	authProvider        identity.Provider
+	logger              telemetry.Logger
 }
 
 // NewContainer creates a new dependency injection container
@@ -68,19 +80,32 @@ func NewContainer(version string, projectID string) (container *Container) {
 
 	container.RegisterMarketingListeners()
 	container.RegisterUserListeners()
+	container.RegisterProjectListeners()
+	container.RegisterProjectSettingsListeners()
+	container.RegisterWebhookListeners()
+
+	container.MigratePersonalWorkspaces()
+
+	container.StartEventOutboxRelay()
+	container.StartWidgetEventRollups()
 
 	container.RegisterUserRoutes()
 	container.RegisterEventRoutes()
+	container.RegisterEventAdminRoutes()
+	container.RegisterWorkspaceRoutes()
+	container.RegisterWorkspaceInvitationRoutes()
+	container.RegisterAPITokenRoutes()
 	container.RegisterProjectRoutes()
-	container.RegisterWhatsappIntegrationRoutes()
+	container.RegisterIntegrationRoutes()
 	container.ProjectIntegrationRoutes()
-	container.RegisterContentIntegrationRoutes()
-	container.RegisterPhoneCallIntegrationRoutes()
-	container.RegisterLinkIntegrationRoutes()
+	container.RegisterContactFormSubmissionRoutes()
+	container.RegisterProjectAnalyticsRoutes()
+	container.RegisterWebhookRoutes()
 
 	// UnAuthenticated routes
 	container.RegisterProjectSettingsRoutes()
 	container.RegisterLemonsqueezyRoutes()
+	container.RegisterWidgetEventRoutes()
 
 	// this has to be last since it registers the /* route
 	container.RegisterSwaggerRoutes()
@@ -100,22 +125,93 @@ func (container *Container) AuthenticatedMiddleware() fiber.Handler {
 	return middlewares.Authenticated(container.Tracer())
 }
 
-// GoogleAuthMiddlewares creates router for authenticated requests
+// GoogleAuthMiddlewares creates router for requests which are authenticated with a google ID token of a service account.
+// Every request is rejected when the subject is empty because google signs ID tokens for any service account.
 func (container *Container) GoogleAuthMiddlewares(audience string, subject string) []fiber.Handler {
 	container.logger.Debug("creating GoogleAuthMiddlewares")
+	if subject == "" {
+		container.logger.Error(stacktrace.NewError(fmt.Sprintf("the subject of the google ID tokens for audience [%s] is not set, every request will be rejected", audience)))
+		return []fiber.Handler{container.AuthenticatedMiddleware()}
+	}
+
+	provider := identity.NewOIDCProvider(
+		container.Logger(),
+		container.Tracer(),
+		container.HTTPClient(),
+		identity.OIDCConfig{
+			Issuer:   "https://accounts.google.com",
+			Audience: audience,
+			Subject:  subject,
+		},
+	)
 	return []fiber.Handler{
-		middlewares.GoogleAuth(container.Logger(), container.Tracer(), audience, subject),
+		middlewares.IDTokenAuth(container.Logger(), container.Tracer(), provider),
 		container.AuthenticatedMiddleware(),
 	}
 }
 
-// FirebaseAuthMiddlewares creates router for authenticated requests
-func (container *Container) FirebaseAuthMiddlewares() []fiber.Handler {
-	container.logger.Debug("creating FirebaseAuthRouter")
+// AuthMiddlewares creates router for requests which are authenticated with an ID token of the identity.Provider or an entities.APIToken
+func (container *Container) AuthMiddlewares() []fiber.Handler {
+	container.logger.Debug("creating AuthMiddlewares")
 	return []fiber.Handler{
-		middlewares.FirebaseAuth(container.Logger(), container.Tracer(), container.FirebaseAuthClient()),
+		middlewares.APITokenAuth(container.Logger(), container.Tracer(), container.APITokenService()),
+		middlewares.IDTokenAuth(container.Logger(), container.Tracer(), container.AuthProvider()),
 		container.AuthenticatedMiddleware(),
+		middlewares.APITokenScope(container.Tracer()),
+	}
+}
+
+// AuthProvider creates a new instance of identity.Provider based on the AUTH_PROVIDER environment variable.
+// The signing keys of the OIDC provider are cached in memory so the provider is shared by every route.
+func (container *Container) AuthProvider() identity.Provider {
+	if container.authProvider != nil {
+		return container.authProvider
+	}
+
+	container.logger.Debug("creating identity.Provider")
+
+	switch os.Getenv("AUTH_PROVIDER") {
+	case "oidc":
+		if os.Getenv("OIDC_ISSUER") == "" || os.Getenv("OIDC_AUDIENCE") == "" {
+			container.logger.Fatal(stacktrace.NewError("the OIDC_ISSUER and OIDC_AUDIENCE environment variables are required when AUTH_PROVIDER is [oidc]"))
+		}
+		container.authProvider = identity.NewOIDCProvider(
+			container.Logger(),
+			container.Tracer(),
+			container.HTTPClient(),
+			identity.OIDCConfig{
+				Issuer:   os.Getenv("OIDC_ISSUER"),
+				Audience: os.Getenv("OIDC_AUDIENCE"),
+				JWKSURL:  os.Getenv("OIDC_JWKS_URL"),
+				Claims: identity.ClaimMapping{
+					ID:            os.Getenv("OIDC_CLAIM_ID"),
+					Email:         os.Getenv("OIDC_CLAIM_EMAIL"),
+					EmailVerified: os.Getenv("OIDC_CLAIM_EMAIL_VERIFIED"),
+					Name:          os.Getenv("OIDC_CLAIM_NAME"),
+				},
+			},
+		)
+	default:
+		container.authProvider = identity.NewFirebaseProvider(
+			container.Logger(),
+			container.Tracer(),
+			container.FirebaseAuthClient(),
+		)
 	}
+
+	return container.authProvider
+}
+
+// HTTPClient creates a new instance of http.Client
+func (container *Container) HTTPClient() *http.Client {
+	container.logger.Debug("creating http.Client")
+	return &http.Client{Timeout: 10 * time.Second}
+}
+
+// WebhookHTTPClient creates the http.Client which sends webhook deliveries only to public addresses
+func (container *Container) WebhookHTTPClient() *http.Client {
+	container.logger.Debug("creating webhook http.Client")
+	return services.NewWebhookHTTPClient(10 * time.Second)
 }
 
 // RegisterMarketingListeners registers the marketing handlers to events
@@ -131,6 +227,49 @@ func (container *Container) RegisterMarketingListeners() {
 	}
 }
 
+// RegisterProjectListeners registers the project handlers to events
+func (container *Container) RegisterProjectListeners() {
+	container.logger.Debug(fmt.Sprintf("registering %T listeners", &listeners.ProjectListener{}))
+	routes := listeners.ProjectListeners(
+		container.Tracer(),
+		container.Logger(),
+		container.ProjectIntegrationService(),
+		container.ContactFormSubmissionService(),
+		container.WidgetEventService(),
+		container.WidgetAnalyticsService(),
+		container.ProjectSettingService(),
+	)
+	for event, listener := range routes {
+		container.EventDispatcher().Subscribe(event, listener)
+	}
+}
+
+// RegisterProjectSettingsListeners registers the project settings handlers to events
+func (container *Container) RegisterProjectSettingsListeners() {
+	container.logger.Debug(fmt.Sprintf("registering %T listeners", &listeners.ProjectSettingsListener{}))
+	routes := listeners.ProjectSettingsListeners(
+		container.Tracer(),
+		container.Logger(),
+		container.ProjectSettingService(),
+	)
+	for event, listener := range routes {
+		container.EventDispatcher().Subscribe(event, listener)
+	}
+}
+
+// RegisterWebhookListeners registers the webhook handlers to events
+func (container *Container) RegisterWebhookListeners() {
+	container.logger.Debug(fmt.Sprintf("registering %T listeners", &listeners.WebhookListener{}))
+	routes := listeners.WebhookListeners(
+		container.Tracer(),
+		container.Logger(),
+		container.WebhookService(),
+	)
+	for event, listener := range routes {
+		container.EventDispatcher().Subscribe(event, listener)
+	}
+}
+
 // RegisterUserListeners registers the user handlers to events
 func (container *Container) RegisterUserListeners() {
 	container.logger.Debug(fmt.Sprintf("registering %T listeners", &listeners.UserListener{}))
@@ -147,13 +286,29 @@ func (container *Container) RegisterUserListeners() {
 // RegisterUserRoutes registers routes for the /users prefix
 func (container *Container) RegisterUserRoutes() {
 	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.UserHandler{}))
-	container.UserHandler().RegisterRoutes(container.App(), container.FirebaseAuthMiddlewares())
+	container.UserHandler().RegisterRoutes(container.App(), container.AuthMiddlewares())
 }
 
 // RegisterProjectSettingsRoutes registers routes for the /project-settings prefix
 func (container *Container) RegisterProjectSettingsRoutes() {
 	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.ProjectSettingsHandler{}))
 	container.ProjectSettingsHandler().RegisterRoutes(container.App())
+	container.ProjectSettingsHandler().RegisterAuthenticatedRoutes(container.App(), container.AuthMiddlewares())
+	container.ProjectSettingsHandler().RegisterAdminRoutes(container.App(), container.AdminMiddlewares())
+}
+
+// RegisterWidgetEventRoutes registers routes for the /widget-events prefix.
+// The widget events are not recorded without ANALYTICS_VISITOR_SALT because an unsalted visitor hash can be reversed by hashing every IPv4 address.
+func (container *Container) RegisterWidgetEventRoutes() {
+	if len(os.Getenv("ANALYTICS_VISITOR_SALT")) < 32 {
+		container.logger.Error(stacktrace.NewError("ANALYTICS_VISITOR_SALT must be a random secret with at least 32 characters, the widget events will not be recorded"))
+		return
+	}
+
+	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.WidgetEventHandler{}))
+	container.WidgetEventHandler().RegisterRoutes(container.App(), []fiber.Handler{
+		middlewares.RateLimit(container.Tracer(), 60, time.Minute),
+	})
 }
 
 // RegisterLemonsqueezyRoutes registers routes for the /project-settings prefix
@@ -165,37 +320,73 @@ func (container *Container) RegisterLemonsqueezyRoutes() {
 // RegisterProjectRoutes registers routes for the /projects prefix
 func (container *Container) RegisterProjectRoutes() {
 	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.ProjectHandler{}))
-	container.ProjectHandler().RegisterRoutes(container.App(), container.FirebaseAuthMiddlewares())
+	container.ProjectHandler().RegisterRoutes(container.App(), container.AuthMiddlewares())
+}
+
+// RegisterIntegrationRoutes registers routes for the /projects/:projectID/{type}-integrations prefix of every integration in the services.IntegrationRegistry
+func (container *Container) RegisterIntegrationRoutes() {
+	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.IntegrationHandler{}))
+	container.IntegrationHandler().RegisterRoutes(container.App(), container.AuthMiddlewares())
+}
+
+// RegisterContactFormSubmissionRoutes registers routes for the /projects/:projectID/contact-form-integrations/:integrationID/submissions prefix
+func (container *Container) RegisterContactFormSubmissionRoutes() {
+	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.ContactFormSubmissionHandler{}))
+	container.ContactFormSubmissionHandler().RegisterRoutes(container.App(), container.AuthMiddlewares(), []fiber.Handler{
+		middlewares.RateLimit(container.Tracer(), 60, time.Minute),
+	})
+}
+
+// RegisterProjectAnalyticsRoutes registers routes for the /projects/:projectID/analytics prefix
+func (container *Container) RegisterProjectAnalyticsRoutes() {
+	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.ProjectAnalyticsHandler{}))
+	container.ProjectAnalyticsHandler().RegisterRoutes(container.App(), container.AuthMiddlewares())
 }
 
-// RegisterWhatsappIntegrationRoutes registers routes for the /projects/:projectID/whatsapp-integrations prefix
-func (container *Container) RegisterWhatsappIntegrationRoutes() {
-	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.WhatsappIntegrationHandler{}))
-	container.WhatsappIntegrationHandler().RegisterRoutes(container.App(), container.FirebaseAuthMiddlewares())
+// RegisterWorkspaceRoutes registers routes for the /workspaces prefix
+func (container *Container) RegisterWorkspaceRoutes() {
+	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.WorkspaceHandler{}))
+	container.WorkspaceHandler().RegisterRoutes(container.App(), container.AuthMiddlewares())
 }
 
-// RegisterContentIntegrationRoutes registers routes for the /projects/:projectID/content-integrations prefix
-func (container *Container) RegisterContentIntegrationRoutes() {
-	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.ContentIntegrationHandler{}))
-	container.ContentIntegrationHandler().RegisterRoutes(container.App(), container.FirebaseAuthMiddlewares())
+// RegisterWebhookRoutes registers routes for the /projects/:projectID/webhooks and /webhook-deliveries prefixes
+func (container *Container) RegisterWebhookRoutes() {
+	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.WebhookHandler{}))
+	container.WebhookHandler().RegisterRoutes(container.App(), container.AuthMiddlewares())
+	container.WebhookHandler().RegisterQueueRoutes(
+		container.App(),
+		container.GoogleAuthMiddlewares(
+			os.Getenv("QUEUE_URL_WEBHOOKS"),
+			os.Getenv("QUEUE_AUTH_SUBJECT"),
+		),
+	)
 }
 
-// RegisterPhoneCallIntegrationRoutes registers routes for the /projects/:projectID/phone-call-integrations prefix
-func (container *Container) RegisterPhoneCallIntegrationRoutes() {
-	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.ContentIntegrationHandler{}))
-	container.PhoneCallIntegrationHandler().RegisterRoutes(container.App(), container.FirebaseAuthMiddlewares())
+// RegisterAPITokenRoutes registers routes for the /api-tokens prefix
+func (container *Container) RegisterAPITokenRoutes() {
+	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.APITokenHandler{}))
+	container.APITokenHandler().RegisterRoutes(container.App(), container.AuthMiddlewares())
 }
 
-// RegisterLinkIntegrationRoutes registers routes for the /projects/:projectID/link-integrations prefix
-func (container *Container) RegisterLinkIntegrationRoutes() {
-	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.ContentIntegrationHandler{}))
-	container.LinkIntegrationHandler().RegisterRoutes(container.App(), container.FirebaseAuthMiddlewares())
+// RegisterWorkspaceInvitationRoutes registers routes for the /workspaces/:workspaceID/invitations and /invitations prefixes
+func (container *Container) RegisterWorkspaceInvitationRoutes() {
+	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.WorkspaceInvitationHandler{}))
+	container.WorkspaceInvitationHandler().RegisterRoutes(container.App(), container.AuthMiddlewares())
 }
 
 // ProjectIntegrationRoutes registers routes for the /projects/:projectID/integrations prefix
 func (container *Container) ProjectIntegrationRoutes() {
 	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.ProjectIntegrationHandler{}))
-	container.ProjectIntegrationHandler().RegisterRoutes(container.App(), container.FirebaseAuthMiddlewares())
+	container.ProjectIntegrationHandler().RegisterRoutes(container.App(), container.AuthMiddlewares())
+}
+
+// ProjectIntegrationHandlerValidator creates a new instance of validators.ProjectIntegrationHandlerValidator
+func (container *Container) ProjectIntegrationHandlerValidator() (validator *validators.ProjectIntegrationHandlerValidator) {
+	container.logger.Debug(fmt.Sprintf("creating %T", validator))
+	return validators.NewProjectIntegrationHandlerValidator(
+		container.Logger(),
+		container.Tracer(),
+	)
 }
 
 // UserHandlerValidator creates a new instance of validators.UserHandlerValidator
@@ -217,37 +408,55 @@ func (container *Container) LemonsqueezyHandlerValidator() (validator *validator
 	)
 }
 
-// LinkIntegrationHandlerValidator creates a new instance of validators.LinkIntegrationHandlerValidator
-func (container *Container) LinkIntegrationHandlerValidator() (validator *validators.LinkIntegrationHandlerValidator) {
+// IntegrationHandlerValidator creates a new instance of validators.IntegrationHandlerValidator
+func (container *Container) IntegrationHandlerValidator() (validator *validators.IntegrationHandlerValidator) {
 	container.logger.Debug(fmt.Sprintf("creating %T", validator))
-	return validators.NewLinkIntegrationHandlerValidator(
+	return validators.NewIntegrationHandlerValidator(
 		container.Logger(),
 		container.Tracer(),
 	)
 }
 
-// ContentIntegrationHandlerValidator creates a new instance of validators.ContentIntegrationHandlerValidator
-func (container *Container) ContentIntegrationHandlerValidator() (validator *validators.ContentIntegrationHandlerValidator) {
+// ContactFormSubmissionHandlerValidator creates a new instance of validators.ContactFormSubmissionHandlerValidator
+func (container *Container) ContactFormSubmissionHandlerValidator() (validator *validators.ContactFormSubmissionHandlerValidator) {
 	container.logger.Debug(fmt.Sprintf("creating %T", validator))
-	return validators.NewContentIntegrationHandlerValidator(
+	return validators.NewContactFormSubmissionHandlerValidator(
 		container.Logger(),
 		container.Tracer(),
 	)
 }
 
-// PhoneCallIntegrationHandlerValidator creates a new instance of validators.PhoneCallIntegrationHandlerValidator
-func (container *Container) PhoneCallIntegrationHandlerValidator() (validator *validators.PhoneCallIntegrationHandlerValidator) {
+// WorkspaceHandlerValidator creates a new instance of validators.WorkspaceHandlerValidator
+func (container *Container) WorkspaceHandlerValidator() (validator *validators.WorkspaceHandlerValidator) {
 	container.logger.Debug(fmt.Sprintf("creating %T", validator))
-	return validators.NewPhoneCallIntegrationHandlerValidator(
+	return validators.NewWorkspaceHandlerValidator(
 		container.Logger(),
 		container.Tracer(),
 	)
 }
 
-// WhatsappIntegrationHandlerValidator creates a new instance of validators.WhatsappIntegrationHandlerValidator
-func (container *Container) WhatsappIntegrationHandlerValidator() (validator *validators.WhatsappIntegrationHandlerValidator) {
+// APITokenHandlerValidator creates a new instance of validators.APITokenHandlerValidator
+func (container *Container) APITokenHandlerValidator() (validator *validators.APITokenHandlerValidator) {
 	container.logger.Debug(fmt.Sprintf("creating %T", validator))
-	return validators.NewWhatsappIntegrationHandlerValidator(
+	return validators.NewAPITokenHandlerValidator(
+		container.Logger(),
+		container.Tracer(),
+	)
+}
+
+// WorkspaceInvitationHandlerValidator creates a new instance of validators.WorkspaceInvitationHandlerValidator
+func (container *Container) WorkspaceInvitationHandlerValidator() (validator *validators.WorkspaceInvitationHandlerValidator) {
+	container.logger.Debug(fmt.Sprintf("creating %T", validator))
+	return validators.NewWorkspaceInvitationHandlerValidator(
+		container.Logger(),
+		container.Tracer(),
+	)
+}
+
+// WebhookHandlerValidator creates a new instance of validators.WebhookHandlerValidator
+func (container *Container) WebhookHandlerValidator() (validator *validators.WebhookHandlerValidator) {
+	container.logger.Debug(fmt.Sprintf("creating %T", validator))
+	return validators.NewWebhookHandlerValidator(
 		container.Logger(),
 		container.Tracer(),
 	)
@@ -273,57 +482,80 @@ func (container *Container) UserHandler() (handler *handlers.UserHandler) {
 	)
 }
 
-// WhatsappIntegrationHandler creates a new instance of handlers.WhatsappIntegrationHandler
-func (container *Container) WhatsappIntegrationHandler() (handler *handlers.WhatsappIntegrationHandler) {
+// IntegrationHandler creates a new instance of handlers.IntegrationHandler
+func (container *Container) IntegrationHandler() (handler *handlers.IntegrationHandler) {
 	container.logger.Debug(fmt.Sprintf("creating %T", handler))
-	return handlers.NewWhatsappIntegrationHandler(
+	return handlers.NewIntegrationHandler(
 		container.Logger(),
 		container.Tracer(),
-		container.WhatsappIntegrationHandlerValidator(),
-		container.WhatsappIntegrationService(),
+		container.IntegrationHandlerValidator(),
+		container.IntegrationRegistry(),
 	)
 }
 
-// ContentIntegrationHandler creates a new instance of handlers.ContentIntegrationHandler
-func (container *Container) ContentIntegrationHandler() (handler *handlers.ContentIntegrationHandler) {
+// ContactFormSubmissionHandler creates a new instance of handlers.ContactFormSubmissionHandler
+func (container *Container) ContactFormSubmissionHandler() (handler *handlers.ContactFormSubmissionHandler) {
 	container.logger.Debug(fmt.Sprintf("creating %T", handler))
-	return handlers.NewContentIntegrationHandler(
+	return handlers.NewContactFormSubmissionHandler(
 		container.Logger(),
 		container.Tracer(),
-		container.ContentIntegrationHandlerValidator(),
-		container.ContentIntegrationService(),
+		container.ContactFormSubmissionHandlerValidator(),
+		container.ContactFormSubmissionService(),
 	)
 }
 
 // ProjectIntegrationHandler creates a new instance of handlers.ProjectIntegrationHandler
 func (container *Container) ProjectIntegrationHandler() (handler *handlers.ProjectIntegrationHandler) {
 	container.logger.Debug(fmt.Sprintf("creating %T", handler))
-	return handlers.NewIntegrationHandler(
+	return handlers.NewProjectIntegrationHandler(
 		container.Logger(),
 		container.Tracer(),
+		container.ProjectIntegrationHandlerValidator(),
 		container.ProjectIntegrationService(),
 	)
 }
 
-// PhoneCallIntegrationHandler creates a new instance of handlers.PhoneCallIntegrationHandler
-func (container *Container) PhoneCallIntegrationHandler() (handler *handlers.PhoneCallIntegrationHandler) {
+// WorkspaceHandler creates a new instance of handlers.WorkspaceHandler
+func (container *Container) WorkspaceHandler() (handler *handlers.WorkspaceHandler) {
+	container.logger.Debug(fmt.Sprintf("creating %T", handler))
+	return handlers.NewWorkspaceHandler(
+		container.Logger(),
+		container.Tracer(),
+		container.WorkspaceHandlerValidator(),
+		container.WorkspaceService(),
+	)
+}
+
+// APITokenHandler creates a new instance of handlers.APITokenHandler
+func (container *Container) APITokenHandler() (handler *handlers.APITokenHandler) {
+	container.logger.Debug(fmt.Sprintf("creating %T", handler))
+	return handlers.NewAPITokenHandler(
+		container.Logger(),
+		container.Tracer(),
+		container.APITokenHandlerValidator(),
+		container.APITokenService(),
+	)
+}
+
+// WorkspaceInvitationHandler creates a new instance of handlers.WorkspaceInvitationHandler
+func (container *Container) WorkspaceInvitationHandler() (handler *handlers.WorkspaceInvitationHandler) {
 	container.logger.Debug(fmt.Sprintf("creating %T", handler))
-	return handlers.NewPhoneCallIntegrationHandler(
+	return handlers.NewWorkspaceInvitationHandler(
 		container.Logger(),
 		container.Tracer(),
-		container.PhoneCallIntegrationHandlerValidator(),
-		container.PhoneCallIntegrationService(),
+		container.WorkspaceInvitationHandlerValidator(),
+		container.WorkspaceInvitationService(),
 	)
 }
 
-// LinkIntegrationHandler creates a new instance of handlers.LinkIntegrationHandler
-func (container *Container) LinkIntegrationHandler() (handler *handlers.LinkIntegrationHandler) {
+// WebhookHandler creates a new instance of handlers.WebhookHandler
+func (container *Container) WebhookHandler() (handler *handlers.WebhookHandler) {
 	container.logger.Debug(fmt.Sprintf("creating %T", handler))
-	return handlers.NewLinkIntegrationHandler(
+	return handlers.NewWebhookHandler(
 		container.Logger(),
 		container.Tracer(),
-		container.LinkIntegrationHandlerValidator(),
-		container.LinkIntegrationService(),
+		container.WebhookHandlerValidator(),
+		container.WebhookService(),
 	)
 }
 
@@ -338,6 +570,14 @@ func (container *Container) ProjectHandler() (handler *handlers.ProjectHandler)
 	)
 }
 
+// VisitorCountryHeader is the header in which the CDN sends the country of a website visitor. It defaults to the Cloudflare header.
+func (container *Container) VisitorCountryHeader() string {
+	if header := os.Getenv("VISITOR_COUNTRY_HEADER"); header != "" {
+		return header
+	}
+	return "CF-IPCountry"
+}
+
 // ProjectSettingsHandler creates a new instance of handlers.ProjectSettingsHandler
 func (container *Container) ProjectSettingsHandler() (handler *handlers.ProjectSettingsHandler) {
 	container.logger.Debug(fmt.Sprintf("creating %T", handler))
@@ -345,6 +585,73 @@ func (container *Container) ProjectSettingsHandler() (handler *handlers.ProjectS
 		container.Logger(),
 		container.Tracer(),
 		container.ProjectSettingService(),
+		container.VisitorCountryHeader(),
+	)
+}
+
+// WidgetEventHandler creates a new instance of handlers.WidgetEventHandler
+func (container *Container) WidgetEventHandler() (handler *handlers.WidgetEventHandler) {
+	container.logger.Debug(fmt.Sprintf("creating %T", handler))
+	return handlers.NewWidgetEventHandler(
+		container.Logger(),
+		container.Tracer(),
+		container.WidgetEventHandlerValidator(),
+		container.WidgetEventService(),
+	)
+}
+
+// WidgetEventHandlerValidator creates a new instance of validators.WidgetEventHandlerValidator
+func (container *Container) WidgetEventHandlerValidator() (validator *validators.WidgetEventHandlerValidator) {
+	container.logger.Debug(fmt.Sprintf("creating %T", validator))
+	return validators.NewWidgetEventHandlerValidator(
+		container.Logger(),
+		container.Tracer(),
+	)
+}
+
+// WidgetEventService creates a new instance of services.WidgetEventService
+func (container *Container) WidgetEventService() (service *services.WidgetEventService) {
+	container.logger.Debug(fmt.Sprintf("creating %T", service))
+	return services.NewWidgetEventService(
+		container.Logger(),
+		container.Tracer(),
+		container.EventDispatcher(),
+		container.Transactor(),
+		container.ProjectSettingService(),
+		container.WidgetEventRepository(),
+		os.Getenv("ANALYTICS_VISITOR_SALT"),
+	)
+}
+
+// ProjectAnalyticsHandler creates a new instance of handlers.ProjectAnalyticsHandler
+func (container *Container) ProjectAnalyticsHandler() (handler *handlers.ProjectAnalyticsHandler) {
+	container.logger.Debug(fmt.Sprintf("creating %T", handler))
+	return handlers.NewProjectAnalyticsHandler(
+		container.Logger(),
+		container.Tracer(),
+		container.ProjectAnalyticsHandlerValidator(),
+		container.WidgetAnalyticsService(),
+	)
+}
+
+// ProjectAnalyticsHandlerValidator creates a new instance of validators.ProjectAnalyticsHandlerValidator
+func (container *Container) ProjectAnalyticsHandlerValidator() (validator *validators.ProjectAnalyticsHandlerValidator) {
+	container.logger.Debug(fmt.Sprintf("creating %T", validator))
+	return validators.NewProjectAnalyticsHandlerValidator(
+		container.Logger(),
+		container.Tracer(),
+	)
+}
+
+// WidgetAnalyticsService creates a new instance of services.WidgetAnalyticsService
+func (container *Container) WidgetAnalyticsService() (service *services.WidgetAnalyticsService) {
+	container.logger.Debug(fmt.Sprintf("creating %T", service))
+	return services.NewWidgetAnalyticsService(
+		container.Logger(),
+		container.Tracer(),
+		container.AuthorizationService(),
+		container.ProjectIntegrationRepository(),
+		container.WidgetEventRollupRepository(),
 	)
 }
 
@@ -354,7 +661,7 @@ func (container *Container) MarketingService() (service *services.MarketingServi
 	return services.NewMarketingService(
 		container.Logger(),
 		container.Tracer(),
-		container.FirebaseAuthClient(),
+		container.UserRepository(),
 		os.Getenv("SENDGRID_API_KEY"),
 		os.Getenv("SENDGRID_LIST_ID"),
 	)
@@ -378,8 +685,10 @@ func (container *Container) UserService() (service *services.UserService) {
 		container.Logger(),
 		container.Tracer(),
 		container.EventDispatcher(),
+		container.Transactor(),
 		container.UserRepository(),
 		container.LemonsqueezyClient(),
+		container.WorkspaceInvitationService(),
 	)
 }
 
@@ -390,7 +699,10 @@ func (container *Container) ProjectIntegrationService() (service *services.Proje
 		container.Logger(),
 		container.Tracer(),
 		container.EventDispatcher(),
+		container.Transactor(),
+		container.AuthorizationService(),
 		container.ProjectIntegrationRepository(),
+		container.IntegrationRegistry(),
 	)
 }
 
@@ -401,70 +713,237 @@ func (container *Container) ProjectSettingService() (service *services.ProjectSe
 		container.Logger(),
 		container.Tracer(),
 		container.ProjectRepository(),
-		container.WhatsappIntegrationRepository(),
-		container.ContentIntegrationRepository(),
 		container.ProjectIntegrationRepository(),
-		container.PhoneCallIntegrationRepository(),
-		container.LinkIntegrationRepository(),
+		container.ProjectBlockedOriginRepository(),
+		container.ProjectSettingsSnapshotRepository(),
+		container.AuthorizationService(),
+		container.IntegrationRegistry(),
+		container.Cache(),
+		10*time.Minute,
 	)
 }
 
-// WhatsappIntegrationService creates a new instance of services.WhatsappIntegrationService
-func (container *Container) WhatsappIntegrationService() (service *services.WhatsappIntegrationService) {
+// ContactFormSubmissionService creates a new instance of services.ContactFormSubmissionService
+func (container *Container) ContactFormSubmissionService() (service *services.ContactFormSubmissionService) {
 	container.logger.Debug(fmt.Sprintf("creating %T", service))
-	return services.NewWhatsappIntegrationService(
+	return services.NewContactFormSubmissionService(
 		container.Logger(),
 		container.Tracer(),
 		container.EventDispatcher(),
-		container.ProjectRepository(),
-		container.WhatsappIntegrationRepository(),
+		container.Transactor(),
+		container.ContactFormIntegrationRepository(),
+		container.ContactFormSubmissionRepository(),
+		container.AuthorizationService(),
+		container.ProjectSettingService(),
 	)
 }
 
-// ContentIntegrationService creates a new instance of services.ContentIntegrationService
-func (container *Container) ContentIntegrationService() (service *services.ContentIntegrationService) {
+// IntegrationRegistry creates the services.IntegrationRegistry with every integration type supported by the API
+func (container *Container) IntegrationRegistry() (registry *services.IntegrationRegistry) {
+	if container.integrationRegistry != nil {
+		return container.integrationRegistry
+	}
+
+	container.logger.Debug(fmt.Sprintf("creating %T", registry))
+	registry = services.NewIntegrationRegistry()
+
+	registry.Register(services.NewIntegrationService(
+		container.Logger(),
+		container.Tracer(),
+		container.EventDispatcher(),
+		container.Transactor(),
+		container.AuthorizationService(),
+		container.EntitlementService(),
+		services.IntegrationDefinition[*entities.WhatsappIntegration, *requests.WhatsappIntegrationRequest]{
+			Type:       entities.IntegrationTypeWhatsapp,
+			Repository: repositories.NewGormIntegrationRepository[entities.WhatsappIntegration](container.Logger(), container.Tracer(), entities.IntegrationTypeWhatsapp, container.DB()),
+			NewEntity: func() *entities.WhatsappIntegration {
+				return &entities.WhatsappIntegration{Icon: string(entities.IntegrationTypeWhatsapp)}
+			},
+			NewPayload: func() *requests.WhatsappIntegrationRequest { return new(requests.WhatsappIntegrationRequest) },
+			Rules:      validators.WhatsappIntegrationRules(),
+			Validate:   validators.ValidateWhatsappIntegration,
+		},
+	))
+
+	registry.Register(services.NewIntegrationService(
+		container.Logger(),
+		container.Tracer(),
+		container.EventDispatcher(),
+		container.Transactor(),
+		container.AuthorizationService(),
+		container.EntitlementService(),
+		services.IntegrationDefinition[*entities.PhoneCallIntegration, *requests.PhoneCallIntegrationRequest]{
+			Type:       entities.IntegrationTypePhoneCall,
+			Repository: repositories.NewGormIntegrationRepository[entities.PhoneCallIntegration](container.Logger(), container.Tracer(), entities.IntegrationTypePhoneCall, container.DB()),
+			NewEntity: func() *entities.PhoneCallIntegration {
+				return &entities.PhoneCallIntegration{Icon: string(entities.IntegrationTypePhoneCall)}
+			},
+			NewPayload: func() *requests.PhoneCallIntegrationRequest { return new(requests.PhoneCallIntegrationRequest) },
+			Rules:      validators.PhoneCallIntegrationRules(),
+			Validate:   validators.ValidatePhoneCallIntegration,
+		},
+	))
+
+	registry.Register(services.NewIntegrationService(
+		container.Logger(),
+		container.Tracer(),
+		container.EventDispatcher(),
+		container.Transactor(),
+		container.AuthorizationService(),
+		container.EntitlementService(),
+		services.IntegrationDefinition[*entities.ContentIntegration, *requests.ContentIntegrationRequest]{
+			Type:       entities.IntegrationTypeContent,
+			Repository: repositories.NewGormIntegrationRepository[entities.ContentIntegration](container.Logger(), container.Tracer(), entities.IntegrationTypeContent, container.DB()),
+			NewEntity:  func() *entities.ContentIntegration { return new(entities.ContentIntegration) },
+			NewPayload: func() *requests.ContentIntegrationRequest { return new(requests.ContentIntegrationRequest) },
+			Rules:      validators.ContentIntegrationRules(),
+		},
+	))
+
+	registry.Register(services.NewIntegrationService(
+		container.Logger(),
+		container.Tracer(),
+		container.EventDispatcher(),
+		container.Transactor(),
+		container.AuthorizationService(),
+		container.EntitlementService(),
+		services.IntegrationDefinition[*entities.LinkIntegration, *requests.LinkIntegrationRequest]{
+			Type:       entities.IntegrationTypeLink,
+			Repository: repositories.NewGormIntegrationRepository[entities.LinkIntegration](container.Logger(), container.Tracer(), entities.IntegrationTypeLink, container.DB()),
+			NewEntity:  func() *entities.LinkIntegration { return new(entities.LinkIntegration) },
+			NewPayload: func() *requests.LinkIntegrationRequest { return new(requests.LinkIntegrationRequest) },
+			Rules:      validators.LinkIntegrationRules(),
+			Messages:   validators.LinkIntegrationMessages(),
+		},
+	))
+
+	registry.Register(services.NewIntegrationService(
+		container.Logger(),
+		container.Tracer(),
+		container.EventDispatcher(),
+		container.Transactor(),
+		container.AuthorizationService(),
+		container.EntitlementService(),
+		services.IntegrationDefinition[*entities.IntegrationContactForm, *requests.ContactFormIntegrationRequest]{
+			Type:       entities.IntegrationTypeContactForm,
+			Feature:    entities.PlanFeatureContactForm,
+			Repository: container.ContactFormIntegrationRepository(),
+			NewEntity: func() *entities.IntegrationContactForm {
+				return &entities.IntegrationContactForm{Icon: "mail"}
+			},
+			NewPayload: func() *requests.ContactFormIntegrationRequest { return new(requests.ContactFormIntegrationRequest) },
+			Rules:      validators.ContactFormIntegrationRules(),
+			Validate:   validators.ValidateContactFormIntegration,
+		},
+	))
+
+	container.integrationRegistry = registry
+	return container.integrationRegistry
+}
+
+// ProjectService creates a new instance of services.ProjectService
+func (container *Container) ProjectService() (service *services.ProjectService) {
 	container.logger.Debug(fmt.Sprintf("creating %T", service))
-	return services.NewContentIntegrationService(
+	return services.NewProjectService(
 		container.Logger(),
 		container.Tracer(),
 		container.EventDispatcher(),
+		container.Transactor(),
 		container.ProjectRepository(),
-		container.ContentIntegrationRepository(),
+		container.EntitlementService(),
+		container.WorkspaceService(),
+		container.AuthorizationService(),
+		container.ProjectSettingService(),
 	)
 }
 
-// PhoneCallIntegrationService creates a new instance of services.PhoneCallIntegrationService
-func (container *Container) PhoneCallIntegrationService() (service *services.PhoneCallIntegrationService) {
+// WorkspaceService creates a new instance of services.WorkspaceService
+func (container *Container) WorkspaceService() (service *services.WorkspaceService) {
 	container.logger.Debug(fmt.Sprintf("creating %T", service))
-	return services.NewPhoneCallIntegrationService(
+	return services.NewWorkspaceService(
 		container.Logger(),
 		container.Tracer(),
 		container.EventDispatcher(),
+		container.Transactor(),
+		container.WorkspaceRepository(),
+		container.WorkspaceMemberRepository(),
 		container.ProjectRepository(),
-		container.PhoneCallIntegrationRepository(),
+		container.UserRepository(),
+		container.AuthorizationService(),
 	)
 }
 
-// LinkIntegrationService creates a new instance of services.LinkIntegrationService
-func (container *Container) LinkIntegrationService() (service *services.LinkIntegrationService) {
+// APITokenService creates a new instance of services.APITokenService
+func (container *Container) APITokenService() (service *services.APITokenService) {
 	container.logger.Debug(fmt.Sprintf("creating %T", service))
-	return services.NewLinkIntegrationService(
+	return services.NewAPITokenService(
 		container.Logger(),
 		container.Tracer(),
 		container.EventDispatcher(),
-		container.ProjectRepository(),
-		container.LinkIntegrationRepository(),
+		container.Transactor(),
+		container.APITokenRepository(),
+		container.UserRepository(),
 	)
 }
 
-// ProjectService creates a new instance of services.ProjectService
-func (container *Container) ProjectService() (service *services.ProjectService) {
+// WorkspaceInvitationService creates a new instance of services.WorkspaceInvitationService
+func (container *Container) WorkspaceInvitationService() (service *services.WorkspaceInvitationService) {
 	container.logger.Debug(fmt.Sprintf("creating %T", service))
-	return services.NewProjectService(
+	return services.NewWorkspaceInvitationService(
 		container.Logger(),
 		container.Tracer(),
 		container.EventDispatcher(),
+		container.Transactor(),
+		container.WorkspaceInvitationRepository(),
+		container.WorkspaceMemberRepository(),
+		container.WorkspaceRepository(),
+		container.UserRepository(),
+		container.AuthorizationService(),
+		container.Mailer(),
+		os.Getenv("WEB_APP_URL"),
+	)
+}
+
+// WebhookService creates a new instance of services.WebhookService
+func (container *Container) WebhookService() (service *services.WebhookService) {
+	container.logger.Debug(fmt.Sprintf("creating %T", service))
+	return services.NewWebhookService(
+		container.Logger(),
+		container.Tracer(),
+		container.EventDispatcher(),
+		container.Transactor(),
+		container.WebhookRepository(),
+		container.WebhookDeliveryRepository(),
+		container.AuthorizationService(),
+		container.WebhooksQueue(),
+		os.Getenv("QUEUE_URL_WEBHOOKS"),
+		container.WebhookHTTPClient(),
+	)
+}
+
+// AuthorizationService creates a new instance of services.AuthorizationService
+func (container *Container) AuthorizationService() (service *services.AuthorizationService) {
+	container.logger.Debug(fmt.Sprintf("creating %T", service))
+	return services.NewAuthorizationService(
+		container.Logger(),
+		container.Tracer(),
 		container.ProjectRepository(),
+		container.WorkspaceMemberRepository(),
+	)
+}
+
+// EntitlementService creates a new instance of services.EntitlementService
+func (container *Container) EntitlementService() (service *services.EntitlementService) {
+	container.logger.Debug(fmt.Sprintf("creating %T", service))
+	return services.NewEntitlementService(
+		container.Logger(),
+		container.Tracer(),
+		container.UserService(),
+		container.UserRepository(),
+		container.WorkspaceRepository(),
+		container.ProjectRepository(),
+		container.ProjectIntegrationRepository(),
 	)
 }
 
@@ -478,7 +957,10 @@ func (container *Container) EventDispatcher() (dispatcher *services.EventDispatc
 	dispatcher = services.NewEventDispatcher(
 		container.Logger(),
 		container.Tracer(),
+		container.Transactor(),
 		container.EventRepository(),
+		container.EventOutboxRepository(),
+		container.EventListenerExecutionRepository(),
 		container.EventsQueue(),
 		os.Getenv("QUEUE_URL_EVENTS"),
 	)
@@ -487,17 +969,176 @@ func (container *Container) EventDispatcher() (dispatcher *services.EventDispatc
 	return dispatcher
 }
 
-// EventsQueue creates a new instance of services.PushQueue
+// Cache creates a new instance of cache.Cache based on the CACHE_DRIVER environment variable
+func (container *Container) Cache() cache.Cache {
+	if container.cache != nil {
+		return container.cache
+	}
+
+	container.logger.Debug("creating cache.Cache")
+
+	switch os.Getenv("CACHE_DRIVER") {
+	case "postgres":
+		container.cache = cache.NewPostgresCache(
+			container.Logger(),
+			container.Tracer(),
+			container.DB(),
+		)
+	default:
+		container.cache = cache.NewInMemoryCache(
+			container.Logger(),
+			container.Tracer(),
+			10_000,
+		)
+	}
+
+	return container.cache
+}
+
+// Mailer creates a new instance of mailer.Mailer based on the MAIL_DRIVER environment variable
+func (container *Container) Mailer() mailer.Mailer {
+	container.logger.Debug("creating mailer.Mailer")
+
+	switch os.Getenv("MAIL_DRIVER") {
+	case "sendgrid":
+		return mailer.NewSendgridMailer(
+			container.Logger(),
+			container.Tracer(),
+			os.Getenv("SENDGRID_API_KEY"),
+			os.Getenv("MAIL_FROM_EMAIL"),
+			os.Getenv("MAIL_FROM_NAME"),
+		)
+	default:
+		return mailer.NewLogMailer(
+			container.Logger(),
+			container.Tracer(),
+		)
+	}
+}
+
+// EventsQueue creates a new instance of queue.Client based on the QUEUE_DRIVER environment variable
 func (container *Container) EventsQueue() queue.Client {
 	container.logger.Debug("creating queue.Client")
 
-	return queue.NewGooglePushQueue(
-		container.Logger(),
-		container.Tracer(),
-		container.CloudTasksClient(),
-		os.Getenv("QUEUE_NAME_EVENTS"),
-		os.Getenv("QUEUE_AUTH_EMAIL"),
-	)
+	switch os.Getenv("QUEUE_DRIVER") {
+	case "memory":
+		return queue.NewInMemoryQueue(
+			container.Logger(),
+			container.Tracer(),
+			container.EventsQueueConsumer(),
+			10,
+			&queue.RetryPolicy{
+				MaxAttempts: 5,
+				MinBackoff:  time.Second,
+				MaxBackoff:  time.Minute,
+			},
+		)
+	case "postgres":
+		return queue.NewPostgresQueue(
+			container.Logger(),
+			container.Tracer(),
+			container.DB(),
+			container.EventsQueueConsumer(),
+			queue.PostgresQueueConfig{
+				Name:    "events",
+				Workers: 5,
+				RetryPolicy: &queue.RetryPolicy{
+					MaxAttempts: 10,
+					MinBackoff:  5 * time.Second,
+					MaxBackoff:  time.Hour,
+				},
+				PollInterval:      time.Second,
+				VisibilityTimeout: 5 * time.Minute,
+			},
+		)
+	default:
+		// the retry config of the Cloud Tasks queue must use the backoff of the events e.g. 5 seconds to 1 hour
+		return queue.NewGooglePushQueue(
+			container.Logger(),
+			container.Tracer(),
+			container.CloudTasksClient(),
+			os.Getenv("QUEUE_NAME_EVENTS"),
+			os.Getenv("QUEUE_AUTH_EMAIL"),
+		)
+	}
+}
+
+// EventsQueueConsumer creates a queue.Consumer which publishes events to the listeners of the EventDispatcher
+func (container *Container) EventsQueueConsumer() queue.Consumer {
+	container.logger.Debug("creating queue.Consumer")
+	return func(ctx context.Context, task *queue.Task) error {
+		var event cloudevents.Event
+		if err := json.Unmarshal(task.Body, &event); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot unmarshal [%s] into [%T]", task.Body, event))
+		}
+		return container.EventDispatcher().Publish(ctx, event)
+	}
+}
+
+// WebhooksQueue creates a new instance of queue.Client for sending webhook deliveries based on the QUEUE_DRIVER environment variable.
+// The queue is shared because the in-memory and postgres drivers start their workers when they are created.
+func (container *Container) WebhooksQueue() queue.Client {
+	if container.webhooksQueue != nil {
+		return container.webhooksQueue
+	}
+
+	container.logger.Debug("creating webhooks queue.Client")
+
+	switch os.Getenv("QUEUE_DRIVER") {
+	case "memory":
+		container.webhooksQueue = queue.NewInMemoryQueue(
+			container.Logger(),
+			container.Tracer(),
+			container.WebhooksQueueConsumer(),
+			10,
+			&queue.RetryPolicy{
+				MaxAttempts: 8,
+				MinBackoff:  30 * time.Second,
+				MaxBackoff:  2 * time.Hour,
+			},
+		)
+	case "postgres":
+		container.webhooksQueue = queue.NewPostgresQueue(
+			container.Logger(),
+			container.Tracer(),
+			container.DB(),
+			container.WebhooksQueueConsumer(),
+			queue.PostgresQueueConfig{
+				Name:    "webhooks",
+				Workers: 5,
+				RetryPolicy: &queue.RetryPolicy{
+					MaxAttempts: 8,
+					MinBackoff:  30 * time.Second,
+					MaxBackoff:  2 * time.Hour,
+				},
+				PollInterval:      time.Second,
+				VisibilityTimeout: 5 * time.Minute,
+			},
+		)
+	default:
+		// the retry config of the Cloud Tasks queue must use the backoff of the webhook deliveries e.g. 30 seconds to 2 hours
+		container.webhooksQueue = queue.NewGooglePushQueue(
+			container.Logger(),
+			container.Tracer(),
+			container.CloudTasksClient(),
+			os.Getenv("QUEUE_NAME_WEBHOOKS"),
+			os.Getenv("QUEUE_AUTH_EMAIL"),
+		)
+	}
+
+	return container.webhooksQueue
+}
+
+// WebhooksQueueConsumer creates a queue.Consumer which sends webhook deliveries with the services.WebhookService
+func (container *Container) WebhooksQueueConsumer() queue.Consumer {
+	container.logger.Debug("creating webhooks queue.Consumer")
+	return func(ctx context.Context, task *queue.Task) error {
+		var payload services.WebhookDeliveryTask
+		if err := json.Unmarshal(task.Body, &payload); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot unmarshal [%s] into [%T]", task.Body, payload))
+		}
+		return container.WebhookService().Deliver(ctx, "/di/container/webhooks-queue-consumer", payload.DeliveryID)
+	}
 }
 
 // CloudTasksClient creates a new instance of cloudtasks.Client
@@ -525,6 +1166,119 @@ func (container *Container) EventRepository() (repository repositories.EventRepo
 	)
 }
 
+// EventOutboxRepository creates a new instance of repositories.EventOutboxRepository
+func (container *Container) EventOutboxRepository() (repository repositories.EventOutboxRepository) {
+	container.logger.Debug("creating GORM repositories.EventOutboxRepository")
+	return repositories.NewGormEventOutboxRepository(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
+// ProjectBlockedOriginRepository creates a new instance of repositories.ProjectBlockedOriginRepository
+func (container *Container) ProjectBlockedOriginRepository() (repository repositories.ProjectBlockedOriginRepository) {
+	container.logger.Debug("creating GORM repositories.ProjectBlockedOriginRepository")
+	return repositories.NewGormProjectBlockedOriginRepository(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
+// ProjectSettingsSnapshotRepository creates a new instance of repositories.ProjectSettingsSnapshotRepository
+func (container *Container) ProjectSettingsSnapshotRepository() (repository repositories.ProjectSettingsSnapshotRepository) {
+	container.logger.Debug("creating GORM repositories.ProjectSettingsSnapshotRepository")
+	return repositories.NewGormProjectSettingsSnapshotRepository(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
+// WidgetEventRepository creates a new instance of repositories.WidgetEventRepository
+func (container *Container) WidgetEventRepository() (repository repositories.WidgetEventRepository) {
+	container.logger.Debug("creating GORM repositories.WidgetEventRepository")
+	return repositories.NewGormWidgetEventRepository(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
+// WidgetEventRollupRepository creates a new instance of repositories.WidgetEventRollupRepository
+func (container *Container) WidgetEventRollupRepository() (repository repositories.WidgetEventRollupRepository) {
+	container.logger.Debug("creating GORM repositories.WidgetEventRollupRepository")
+	return repositories.NewGormWidgetEventRollupRepository(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
+// Transactor creates a new instance of repositories.Transactor
+func (container *Container) Transactor() (transactor repositories.Transactor) {
+	container.logger.Debug("creating GORM repositories.Transactor")
+	return repositories.NewGormTransactor(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
+// StartEventOutboxRelay relays events from the outbox to the events queue in the background
+func (container *Container) StartEventOutboxRelay() {
+	container.logger.Debug("starting event outbox relay")
+	container.EventDispatcher().StartOutboxRelay(context.Background(), time.Second)
+}
+
+// MigratePersonalWorkspaces moves the projects which were created before workspaces existed into the personal workspace of their creator
+func (container *Container) MigratePersonalWorkspaces() {
+	container.logger.Debug("migrating projects into personal workspaces")
+	if err := container.WorkspaceService().MigratePersonalWorkspaces(context.Background(), "/di/container/migrate-personal-workspaces"); err != nil {
+		container.logger.Error(stacktrace.Propagate(err, "cannot migrate projects into personal workspaces"))
+	}
+}
+
+// migrateProjects migrates the projects table. The projects which were created before publishable keys existed get
+// a key in the same transaction as the new column so that a project without a key always means the key was revoked.
+func (container *Container) migrateProjects(db *gorm.DB) error {
+	assignKeys := !db.Migrator().HasColumn(&entities.Project{}, "PublishableKey")
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&entities.Project{}); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.Project{}))
+		}
+
+		if !assignKeys {
+			return nil
+		}
+
+		count, err := repositories.NewGormProjectRepository(container.Logger(), container.Tracer(), tx).AssignPublishableKeys(context.Background(), services.NewPublishableKey)
+		if err != nil {
+			return stacktrace.Propagate(err, "cannot assign publishable keys to existing projects")
+		}
+
+		container.logger.Info(fmt.Sprintf("assigned publishable keys to [%d] existing projects", count))
+		return nil
+	})
+}
+
+// StartWidgetEventRollups starts rolling up the widget events in the background
+func (container *Container) StartWidgetEventRollups() {
+	container.logger.Debug("starting widget event rollups")
+	container.WidgetAnalyticsService().StartRollups(context.Background(), 5*time.Minute)
+}
+
+// EventListenerExecutionRepository creates a new instance of repositories.EventListenerExecutionRepository
+func (container *Container) EventListenerExecutionRepository() (repository repositories.EventListenerExecutionRepository) {
+	container.logger.Debug("creating GORM repositories.EventListenerExecutionRepository")
+	return repositories.NewGormEventListenerExecutionRepository(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
 // RegisterEventRoutes registers routes for the /events prefix
 func (container *Container) RegisterEventRoutes() {
 	container.logger.Debug(fmt.Sprintf("registering %T routes", &handlers.EventsHandler{}))
@@ -539,6 +1293,12 @@ func (container *Container) RegisterEventRoutes() {
 		)
 }
 
+// RegisterEventAdminRoutes registers routes for the /admin/events prefix
+func (container *Container) RegisterEventAdminRoutes() {
+	container.logger.Debug(fmt.Sprintf("registering %T admin routes", &handlers.EventsHandler{}))
+	container.EventsHandler().RegisterAdminRoutes(container.App(), container.AdminMiddlewares())
+}
+
 // LemonsqueezyHandler creates a new instance of handlers.LemonsqueezyHandler
 func (container *Container) LemonsqueezyHandler() (handler *handlers.LemonsqueezyHandler) {
 	container.logger.Debug(fmt.Sprintf("creating %T", handler))
@@ -558,10 +1318,49 @@ func (container *Container) EventsHandler() (handler *handlers.EventsHandler) {
 	return handlers.NewEventsHandler(
 		container.Logger(),
 		container.Tracer(),
+		container.EventsHandlerValidator(),
 		container.EventDispatcher(),
 	)
 }
 
+// EventsHandlerValidator creates a new instance of validators.EventsHandlerValidator
+func (container *Container) EventsHandlerValidator() (validator *validators.EventsHandlerValidator) {
+	container.logger.Debug(fmt.Sprintf("creating %T", validator))
+	return validators.NewEventsHandlerValidator(
+		container.Logger(),
+		container.Tracer(),
+	)
+}
+
+// AdminMiddlewares creates router for requests made by admin users
+func (container *Container) AdminMiddlewares() []fiber.Handler {
+	container.logger.Debug("creating AdminMiddlewares")
+	var adminIDs []entities.UserID
+	for _, adminID := range strings.Split(os.Getenv("ADMIN_USER_IDS"), ",") {
+		if adminID = strings.TrimSpace(adminID); adminID != "" {
+			adminIDs = append(adminIDs, entities.UserID(adminID))
+		}
+	}
+
+	return append(container.AuthMiddlewares(), middlewares.Admin(container.Tracer(), adminIDs))
+}
+
+// TrustedProxies returns the number of proxies in front of the API which append to the X-Forwarded-For header.
+// It defaults to 1 for the load balancer of Cloud Run and can be changed with the TRUSTED_PROXIES environment variable.
+func (container *Container) TrustedProxies() int {
+	value := os.Getenv("TRUSTED_PROXIES")
+	if value == "" {
+		return 1
+	}
+
+	proxies, err := strconv.Atoi(value)
+	if err != nil || proxies < 0 {
+		container.logger.Fatal(stacktrace.NewError(fmt.Sprintf("TRUSTED_PROXIES [%s] must be a number greater than or equal to 0", value)))
+	}
+
+	return proxies
+}
+
 // App creates a new instance of fiber.App
 func (container *Container) App() (app *fiber.App) {
 	if container.app != nil {
@@ -575,6 +1374,7 @@ func (container *Container) App() (app *fiber.App) {
 	if isLocal() {
 		app.Use(fiberLogger.New())
 	}
+	app.Use(middlewares.ResolveClientIP(container.TrustedProxies()))
 	app.Use(
 		middlewares.OtelTraceContext(
 			container.Tracer(),
@@ -583,7 +1383,12 @@ func (container *Container) App() (app *fiber.App) {
 			os.Getenv("GCP_PROJECT_ID"),
 		),
 	)
-	app.Use(cors.New())
+	app.Use(cors.New(cors.Config{
+		// the public project settings routes emit the CORS headers of each project
+		Next: func(c *fiber.Ctx) bool {
+			return strings.HasPrefix(c.Path(), "/v1/settings/")
+		},
+	}))
 
 	container.app = app
 
@@ -622,40 +1427,81 @@ func (container *Container) ProjectRepository() repositories.ProjectRepository {
 	)
 }
 
-// WhatsappIntegrationRepository registers a new instance of repositories.WhatsappIntegrationRepository
-func (container *Container) WhatsappIntegrationRepository() repositories.WhatsappIntegrationRepository {
-	container.logger.Debug("creating GORM repositories.WhatsappIntegrationRepository")
-	return repositories.NewGormWhatsappIntegrationRepository(
+// WorkspaceRepository registers a new instance of repositories.WorkspaceRepository
+func (container *Container) WorkspaceRepository() repositories.WorkspaceRepository {
+	container.logger.Debug("creating GORM repositories.WorkspaceRepository")
+	return repositories.NewGormWorkspaceRepository(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
+// WorkspaceMemberRepository registers a new instance of repositories.WorkspaceMemberRepository
+func (container *Container) WorkspaceMemberRepository() repositories.WorkspaceMemberRepository {
+	container.logger.Debug("creating GORM repositories.WorkspaceMemberRepository")
+	return repositories.NewGormWorkspaceMemberRepository(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
+// APITokenRepository registers a new instance of repositories.APITokenRepository
+func (container *Container) APITokenRepository() repositories.APITokenRepository {
+	container.logger.Debug("creating GORM repositories.APITokenRepository")
+	return repositories.NewGormAPITokenRepository(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
+// WebhookRepository registers a new instance of repositories.WebhookRepository
+func (container *Container) WebhookRepository() repositories.WebhookRepository {
+	container.logger.Debug("creating GORM repositories.WebhookRepository")
+	return repositories.NewGormWebhookRepository(
+		container.Logger(),
+		container.Tracer(),
+		container.DB(),
+	)
+}
+
+// WebhookDeliveryRepository registers a new instance of repositories.WebhookDeliveryRepository
+func (container *Container) WebhookDeliveryRepository() repositories.WebhookDeliveryRepository {
+	container.logger.Debug("creating GORM repositories.WebhookDeliveryRepository")
+	return repositories.NewGormWebhookDeliveryRepository(
 		container.Logger(),
 		container.Tracer(),
 		container.DB(),
 	)
 }
 
-// ContentIntegrationRepository registers a new instance of repositories.ContentIntegrationRepository
-func (container *Container) ContentIntegrationRepository() repositories.ContentIntegrationRepository {
-	container.logger.Debug("creating GORM repositories.ContentIntegrationRepository")
-	return repositories.NewGormContentIntegrationRepository(
+// WorkspaceInvitationRepository registers a new instance of repositories.WorkspaceInvitationRepository
+func (container *Container) WorkspaceInvitationRepository() repositories.WorkspaceInvitationRepository {
+	container.logger.Debug("creating GORM repositories.WorkspaceInvitationRepository")
+	return repositories.NewGormWorkspaceInvitationRepository(
 		container.Logger(),
 		container.Tracer(),
 		container.DB(),
 	)
 }
 
-// PhoneCallIntegrationRepository registers a new instance of repositories.PhoneCallIntegrationRepository
-func (container *Container) PhoneCallIntegrationRepository() repositories.PhoneCallIntegrationRepository {
-	container.logger.Debug("creating GORM repositories.PhoneCallIntegrationRepository")
-	return repositories.NewGormPhoneCallIntegrationRepository(
+// ContactFormIntegrationRepository registers a new instance of repositories.IntegrationRepository for entities.IntegrationContactForm
+func (container *Container) ContactFormIntegrationRepository() repositories.IntegrationRepository[*entities.IntegrationContactForm] {
+	container.logger.Debug("creating GORM repositories.IntegrationRepository[*entities.IntegrationContactForm]")
+	return repositories.NewGormIntegrationRepository[entities.IntegrationContactForm](
 		container.Logger(),
 		container.Tracer(),
+		entities.IntegrationTypeContactForm,
 		container.DB(),
 	)
 }
 
-// LinkIntegrationRepository registers a new instance of repositories.LinkIntegrationRepository
-func (container *Container) LinkIntegrationRepository() repositories.LinkIntegrationRepository {
-	container.logger.Debug("creating GORM repositories.LinkIntegrationRepository")
-	return repositories.NewGormLinkIntegrationRepository(
+// ContactFormSubmissionRepository registers a new instance of repositories.ContactFormSubmissionRepository
+func (container *Container) ContactFormSubmissionRepository() repositories.ContactFormSubmissionRepository {
+	container.logger.Debug("creating GORM repositories.ContactFormSubmissionRepository")
+	return repositories.NewGormContactFormSubmissionRepository(
 		container.Logger(),
 		container.Tracer(),
 		container.DB(),
@@ -717,9 +1563,41 @@ func (container *Container) DB() (db *gorm.DB) {
 	if err = db.AutoMigrate(&repositories.GormEvent{}); err != nil {
 		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &repositories.GormEvent{})))
 	}
-	if err = db.AutoMigrate(&entities.Project{}); err != nil {
+	if err = db.AutoMigrate(&entities.OutboxEvent{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.OutboxEvent{})))
+	}
+	if err = db.AutoMigrate(&queue.GormTask{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &queue.GormTask{})))
+	}
+	if err = db.AutoMigrate(&cache.GormCacheEntry{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &cache.GormCacheEntry{})))
+	}
+	if err = db.AutoMigrate(&entities.EventListenerExecution{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.EventListenerExecution{})))
+	}
+	if err = container.migrateProjects(db); err != nil {
 		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.Project{})))
 	}
+	if err = db.AutoMigrate(&entities.Workspace{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.Workspace{})))
+	}
+	if err = db.AutoMigrate(&entities.WorkspaceMember{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.WorkspaceMember{})))
+	}
+
+	if err = db.AutoMigrate(&entities.WorkspaceInvitation{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.WorkspaceInvitation{})))
+	}
+
+	if err = db.AutoMigrate(&entities.APIToken{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.APIToken{})))
+	}
+	if err = db.AutoMigrate(&entities.Webhook{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.Webhook{})))
+	}
+	if err = db.AutoMigrate(&entities.WebhookDelivery{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.WebhookDelivery{})))
+	}
 	if err = db.AutoMigrate(&entities.ProjectIntegration{}); err != nil {
 		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.ProjectIntegration{})))
 	}
@@ -735,6 +1613,27 @@ func (container *Container) DB() (db *gorm.DB) {
 	if err = db.AutoMigrate(&entities.LinkIntegration{}); err != nil {
 		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.LinkIntegration{})))
 	}
+	if err = db.AutoMigrate(&entities.IntegrationContactForm{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.IntegrationContactForm{})))
+	}
+	if err = db.AutoMigrate(&entities.ContactFormSubmission{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.ContactFormSubmission{})))
+	}
+	if err = db.AutoMigrate(&entities.ProjectBlockedOrigin{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.ProjectBlockedOrigin{})))
+	}
+	if err = db.AutoMigrate(&entities.ProjectSettingsSnapshot{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.ProjectSettingsSnapshot{})))
+	}
+	if err = db.AutoMigrate(&entities.WidgetEvent{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.WidgetEvent{})))
+	}
+	if err = db.AutoMigrate(&entities.WidgetEventHourlyRollup{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.WidgetEventHourlyRollup{})))
+	}
+	if err = db.AutoMigrate(&entities.WidgetEventDailyRollup{}); err != nil {
+		container.logger.Fatal(stacktrace.Propagate(err, fmt.Sprintf("cannot migrate %T", &entities.WidgetEventDailyRollup{})))
+	}
 
 	return container.db
 }
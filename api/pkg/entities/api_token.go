@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APITokenPrefix is the prefix of every APIToken which is used to tell them apart from interactive ID tokens
+const APITokenPrefix = "sbt_"
+
+// APITokenScope is the access which is granted to an APIToken
+type APITokenScope string
+
+const (
+	// APITokenScopeProjectsRead can fetch projects and their settings
+	APITokenScopeProjectsRead = APITokenScope("projects:read")
+
+	// APITokenScopeProjectsWrite can create, update and delete projects
+	APITokenScopeProjectsWrite = APITokenScope("projects:write")
+
+	// APITokenScopeIntegrationsRead can fetch the integrations of a project
+	APITokenScopeIntegrationsRead = APITokenScope("integrations:read")
+
+	// APITokenScopeIntegrationsWrite can create, update and delete the integrations of a project
+	APITokenScopeIntegrationsWrite = APITokenScope("integrations:write")
+
+	// APITokenScopeAnalyticsRead can fetch the analytics of a project
+	APITokenScopeAnalyticsRead = APITokenScope("analytics:read")
+
+	// APITokenScopeWebhooksRead can fetch the webhooks of a project and their deliveries
+	APITokenScopeWebhooksRead = APITokenScope("webhooks:read")
+
+	// APITokenScopeWebhooksWrite can create, update, delete and test the webhooks of a project
+	APITokenScopeWebhooksWrite = APITokenScope("webhooks:write")
+)
+
+// APITokenScopes returns every APITokenScope
+func APITokenScopes() []APITokenScope {
+	return []APITokenScope{
+		APITokenScopeProjectsRead,
+		APITokenScopeProjectsWrite,
+		APITokenScopeIntegrationsRead,
+		APITokenScopeIntegrationsWrite,
+		APITokenScopeAnalyticsRead,
+		APITokenScopeWebhooksRead,
+		APITokenScopeWebhooksWrite,
+	}
+}
+
+// APIToken is a personal access token which authenticates a User in scripts.
+// Only the SHA-256 hash of the token is stored and the token is shown once when it is created.
+type APIToken struct {
+	ID     uuid.UUID `json:"id" gorm:"primaryKey;type:string;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	UserID UserID    `json:"user_id" gorm:"index" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	Name   string    `json:"name" example:"GitHub Actions"`
+	// Hint is the start of the token which helps the user to recognise it
+	Hint      string          `json:"hint" example:"sbt_3f2b6c"`
+	TokenHash string          `json:"-" gorm:"uniqueIndex"`
+	Scopes    []APITokenScope `json:"scopes" gorm:"serializer:json" example:"projects:read,integrations:write"`
+	// Token is only set in the response when the token is created
+	Token      string     `json:"token,omitempty" gorm:"-" example:"sbt_3f2b6c1e9a8d4f7b0c5e2a1d9f8b7c6e5d4a3b2c1f0e9d8c7b6a5f4e3d2c1b0a"`
+	ExpiresAt  time.Time  `json:"expires_at" example:"2023-06-05T14:26:02.302718+03:00"`
+	LastUsedAt *time.Time `json:"last_used_at" example:"2022-06-06T14:26:02.302718+03:00"`
+	RevokedAt  *time.Time `json:"revoked_at" example:"2022-06-07T14:26:02.302718+03:00"`
+	CreatedAt  time.Time  `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt  time.Time  `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
+
+// IsActive checks if the token can still be used at a point in time
+func (token *APIToken) IsActive(now time.Time) bool {
+	return token.RevokedAt == nil && now.Before(token.ExpiresAt)
+}
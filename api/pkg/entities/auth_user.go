@@ -1,13 +1,35 @@
 package entities
 
+import "github.com/google/uuid"
+
 // AuthUser is the user gotten from an auth request
 type AuthUser struct {
 	ID    UserID `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	// EmailVerified is true when the auth provider has verified that the user owns the email address
+	EmailVerified bool `json:"email_verified"`
+	// APITokenID is set when the user is authenticated with an APIToken instead of an interactive session
+	APITokenID *uuid.UUID `json:"api_token_id"`
+	// Scopes are the APITokenScope of the APIToken which authenticated the user
+	Scopes []APITokenScope `json:"scopes"`
 }
 
 // IsNoop checks if a user is empty
 func (user AuthUser) IsNoop() bool {
 	return user.ID == "" || user.Email == ""
 }
+
+// HasScope checks if the user can access an APITokenScope. Users with an interactive session can access every scope.
+func (user AuthUser) HasScope(scope APITokenScope) bool {
+	if user.APITokenID == nil {
+		return true
+	}
+
+	for _, value := range user.Scopes {
+		if value == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// AvailabilityDateFormat is the format of the dates of an AvailabilityHoliday
+const AvailabilityDateFormat = "2006-01-02"
+
+// AvailabilityTimeFormat is the format of the start and end of an AvailabilityWindow. The end of a day is "24:00".
+const AvailabilityTimeFormat = "15:04"
+
+// AvailabilityOfflineMode determines how the widget renders an integration outside its AvailabilitySchedule
+type AvailabilityOfflineMode string
+
+const (
+	// AvailabilityOfflineModeShow renders the integration as offline with its offline message
+	AvailabilityOfflineModeShow = AvailabilityOfflineMode("show")
+
+	// AvailabilityOfflineModeHide removes the integration from the widget
+	AvailabilityOfflineModeHide = AvailabilityOfflineMode("hide")
+)
+
+// AvailabilityWindow is a time range on a day of the week when an integration is available
+type AvailabilityWindow struct {
+	Day   string `json:"day" example:"monday"`
+	Start string `json:"start" example:"09:00"`
+	End   string `json:"end" example:"17:00"`
+}
+
+// AvailabilityHoliday is a date when an integration is not available even if it falls in an AvailabilityWindow
+type AvailabilityHoliday struct {
+	Date string `json:"date" example:"2022-12-25"`
+	Name string `json:"name" example:"Christmas"`
+}
+
+// AvailabilitySchedule is the weekly schedule of an integration in the timezone of its Project
+type AvailabilitySchedule struct {
+	WhenOffline AvailabilityOfflineMode `json:"when_offline" example:"show"`
+	Weekly      []*AvailabilityWindow   `json:"weekly"`
+	Holidays    []*AvailabilityHoliday  `json:"holidays"`
+}
+
+// IsAvailable checks if a time in the timezone of the project falls in a window of the schedule
+func (schedule *AvailabilitySchedule) IsAvailable(now time.Time) bool {
+	date := now.Format(AvailabilityDateFormat)
+	for _, holiday := range schedule.Holidays {
+		if holiday.Date == date {
+			return false
+		}
+	}
+
+	day := strings.ToLower(now.Weekday().String())
+	minute := now.Hour()*60 + now.Minute()
+	for _, window := range schedule.Weekly {
+		if window.Day != day {
+			continue
+		}
+
+		start, startOk := ParseAvailabilityMinute(window.Start)
+		end, endOk := ParseAvailabilityMinute(window.End)
+		if startOk && endOk && minute >= start && minute < end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseAvailabilityMinute returns the number of minutes since midnight of a time in the AvailabilityTimeFormat
+func ParseAvailabilityMinute(value string) (int, bool) {
+	if value == "24:00" {
+		return 24 * 60, true
+	}
+
+	parsed, err := time.Parse(AvailabilityTimeFormat, value)
+	if err != nil {
+		return 0, false
+	}
+	return parsed.Hour()*60 + parsed.Minute(), true
+}
+
+// IntegrationAvailability is embedded in the integrations which can have an AvailabilitySchedule
+type IntegrationAvailability struct {
+	Schedule       *AvailabilitySchedule `json:"schedule" gorm:"serializer:json"`
+	OfflineMessage string                `json:"offline_message" example:"We are closed, call us back tomorrow from 9am"`
+}
+
+// Availability returns the IntegrationAvailability so that it satisfies ScheduledIntegration
+func (availability *IntegrationAvailability) Availability() *IntegrationAvailability {
+	return availability
+}
+
+// ScheduledIntegration is implemented by an IntegrationEntity which can have an AvailabilitySchedule
+type ScheduledIntegration interface {
+	// Availability returns the schedule and offline message of the integration
+	Availability() *IntegrationAvailability
+}
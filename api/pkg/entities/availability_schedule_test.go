@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAvailabilitySchedule_IsAvailable(t *testing.T) {
+	schedule := &AvailabilitySchedule{
+		WhenOffline: AvailabilityOfflineModeShow,
+		Weekly: []*AvailabilityWindow{
+			{Day: "monday", Start: "09:00", End: "12:00"},
+			{Day: "monday", Start: "13:00", End: "17:30"},
+			{Day: "saturday", Start: "20:00", End: "24:00"},
+		},
+		Holidays: []*AvailabilityHoliday{{Date: "2022-12-26", Name: "Boxing Day"}},
+	}
+
+	// 2022-12-05 is a monday and 2022-12-10 is a saturday
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{name: "at the start of a window", now: time.Date(2022, 12, 5, 9, 0, 0, 0, time.UTC), want: true},
+		{name: "inside a window", now: time.Date(2022, 12, 5, 11, 59, 59, 0, time.UTC), want: true},
+		{name: "at the end of a window", now: time.Date(2022, 12, 5, 12, 0, 0, 0, time.UTC), want: false},
+		{name: "between windows", now: time.Date(2022, 12, 5, 12, 30, 0, 0, time.UTC), want: false},
+		{name: "inside the second window", now: time.Date(2022, 12, 5, 17, 29, 0, 0, time.UTC), want: true},
+		{name: "before the first window", now: time.Date(2022, 12, 5, 8, 59, 0, 0, time.UTC), want: false},
+		{name: "on a day without windows", now: time.Date(2022, 12, 6, 10, 0, 0, 0, time.UTC), want: false},
+		{name: "in a window until the end of the day", now: time.Date(2022, 12, 10, 23, 59, 0, 0, time.UTC), want: true},
+		{name: "on a holiday", now: time.Date(2022, 12, 26, 10, 0, 0, 0, time.UTC), want: false},
+	}
+
+	for _, test := range tests {
+		if got := schedule.IsAvailable(test.now); got != test.want {
+			t.Errorf("IsAvailable %s = [%t], want [%t]", test.name, got, test.want)
+		}
+	}
+}
+
+func TestAvailabilitySchedule_IsAvailableUsesTimezoneOfTime(t *testing.T) {
+	schedule := &AvailabilitySchedule{Weekly: []*AvailabilityWindow{{Day: "monday", Start: "09:00", End: "17:00"}}}
+
+	location, err := time.LoadLocation("Africa/Douala")
+	if err != nil {
+		t.Skipf("cannot load timezone: %v", err)
+	}
+
+	// 08:30 UTC on monday is 09:30 in Douala
+	now := time.Date(2022, 12, 5, 8, 30, 0, 0, time.UTC)
+	if schedule.IsAvailable(now) {
+		t.Error("the schedule is available at 08:30 UTC")
+	}
+	if !schedule.IsAvailable(now.In(location)) {
+		t.Error("the schedule is not available at 09:30 in the timezone of the project")
+	}
+}
+
+func TestAvailabilitySchedule_IsAvailableIgnoresInvalidWindows(t *testing.T) {
+	schedule := &AvailabilitySchedule{Weekly: []*AvailabilityWindow{{Day: "monday", Start: "9am", End: "17:00"}}}
+
+	if schedule.IsAvailable(time.Date(2022, 12, 5, 10, 0, 0, 0, time.UTC)) {
+		t.Error("a window with an invalid start is available")
+	}
+}
+
+func TestParseAvailabilityMinute(t *testing.T) {
+	tests := []struct {
+		value  string
+		minute int
+		ok     bool
+	}{
+		{value: "00:00", minute: 0, ok: true},
+		{value: "09:30", minute: 570, ok: true},
+		{value: "23:59", minute: 1439, ok: true},
+		{value: "24:00", minute: 1440, ok: true},
+		{value: "24:01", minute: 0, ok: false},
+		{value: "09-30", minute: 0, ok: false},
+		{value: "", minute: 0, ok: false},
+	}
+
+	for _, test := range tests {
+		minute, ok := ParseAvailabilityMinute(test.value)
+		if minute != test.minute || ok != test.ok {
+			t.Errorf("ParseAvailabilityMinute(%q) = [%d, %t], want [%d, %t]", test.value, minute, ok, test.minute, test.ok)
+		}
+	}
+}
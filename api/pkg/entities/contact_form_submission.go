@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ContactFormSubmission is a message sent by a website visitor through an IntegrationContactForm
+type ContactFormSubmission struct {
+	ID            uuid.UUID `json:"id" gorm:"primaryKey;type:string;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	UserID        UserID    `json:"user_id" gorm:"index" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	ProjectID     uuid.UUID `json:"project_id" gorm:"index" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	IntegrationID uuid.UUID `json:"integration_id" gorm:"index" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	Name          *string   `json:"name" example:"John Doe"`
+	Email         *string   `json:"email" example:"name@email.com"`
+	PhoneNumber   *string   `json:"phone_number" example:"+18005550199"`
+	Message       *string   `json:"message" example:"Do you ship to Canada?"`
+	PageURL       string    `json:"page_url" example:"https://example.com/products/1"`
+	CreatedAt     time.Time `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+}
+
+// TableName overrides the table name used by ContactFormSubmission to `contact_form_submissions`
+func (ContactFormSubmission) TableName() string {
+	return "contact_form_submissions"
+}
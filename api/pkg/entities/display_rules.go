@@ -0,0 +1,225 @@
+package entities
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DeviceClass is the type of device of a website visitor
+type DeviceClass string
+
+const (
+	// DeviceClassMobile is a phone
+	DeviceClassMobile = DeviceClass("mobile")
+
+	// DeviceClassTablet is a tablet
+	DeviceClassTablet = DeviceClass("tablet")
+
+	// DeviceClassDesktop is a laptop or desktop computer
+	DeviceClassDesktop = DeviceClass("desktop")
+)
+
+// DeviceClasses returns every DeviceClass
+func DeviceClasses() []DeviceClass {
+	return []DeviceClass{DeviceClassMobile, DeviceClassTablet, DeviceClassDesktop}
+}
+
+// DeviceClassFromUserAgent guesses the DeviceClass of a browser from its User-Agent header
+func DeviceClassFromUserAgent(userAgent string) DeviceClass {
+	userAgent = strings.ToLower(userAgent)
+	switch {
+	case strings.Contains(userAgent, "ipad") || strings.Contains(userAgent, "tablet") ||
+		(strings.Contains(userAgent, "android") && !strings.Contains(userAgent, "mobile")):
+		return DeviceClassTablet
+	case strings.Contains(userAgent, "mobi") || strings.Contains(userAgent, "iphone") || strings.Contains(userAgent, "android"):
+		return DeviceClassMobile
+	default:
+		return DeviceClassDesktop
+	}
+}
+
+// DisplayPathMatch is the syntax of the pattern of a DisplayPathRule
+type DisplayPathMatch string
+
+const (
+	// DisplayPathMatchGlob matches paths with "*" for a path segment and "**" for any number of segments e.g /products/**
+	DisplayPathMatchGlob = DisplayPathMatch("glob")
+
+	// DisplayPathMatchRegex matches paths with a regular expression e.g ^/products/[0-9]+$
+	DisplayPathMatchRegex = DisplayPathMatch("regex")
+)
+
+// displayPathPatternsCapacity is the maximum number of compiled patterns which are shared by the DisplayPathRule in memory
+const displayPathPatternsCapacity = 10000
+
+// displayPathPatterns are the compiled patterns of the DisplayPathRule which were decoded so that the snapshot of a
+// project which is read from the cache for every request does not compile its patterns again.
+var displayPathPatterns = struct {
+	sync.RWMutex
+	values map[string]*regexp.Regexp
+}{values: map[string]*regexp.Regexp{}}
+
+// DisplayPathRule is a pattern which is matched against the path of the page which embeds the widget
+type DisplayPathRule struct {
+	Match   DisplayPathMatch `json:"match" example:"glob"`
+	Pattern string           `json:"pattern" example:"/products/**"`
+
+	compiled *regexp.Regexp
+}
+
+// UnmarshalJSON decodes a DisplayPathRule and compiles its pattern. A pattern which does not compile never matches.
+func (rule *DisplayPathRule) UnmarshalJSON(data []byte) error {
+	type displayPathRule DisplayPathRule
+	value := new(displayPathRule)
+	if err := json.Unmarshal(data, value); err != nil {
+		return err
+	}
+
+	*rule = DisplayPathRule(*value)
+	_ = rule.Compile()
+	return nil
+}
+
+// Compile compiles the pattern of the DisplayPathRule once so that it can be matched against many paths.
+// The compiled patterns are shared by the rules with the same pattern.
+func (rule *DisplayPathRule) Compile() error {
+	key := string(rule.Match) + ":" + rule.Pattern
+
+	displayPathPatterns.RLock()
+	compiled, ok := displayPathPatterns.values[key]
+	displayPathPatterns.RUnlock()
+	if ok {
+		rule.compiled = compiled
+		return nil
+	}
+
+	compiled, err := rule.Regexp()
+	if err != nil {
+		rule.compiled = nil
+		return err
+	}
+
+	displayPathPatterns.Lock()
+	if len(displayPathPatterns.values) >= displayPathPatternsCapacity {
+		displayPathPatterns.values = map[string]*regexp.Regexp{}
+	}
+	displayPathPatterns.values[key] = compiled
+	displayPathPatterns.Unlock()
+
+	rule.compiled = compiled
+	return nil
+}
+
+// Regexp compiles the pattern of the DisplayPathRule
+func (rule *DisplayPathRule) Regexp() (*regexp.Regexp, error) {
+	if rule.Match == DisplayPathMatchRegex {
+		return regexp.Compile(rule.Pattern)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("^")
+	for i := 0; i < len(rule.Pattern); i++ {
+		switch {
+		case strings.HasPrefix(rule.Pattern[i:], "**"):
+			builder.WriteString(".*")
+			i++
+		case rule.Pattern[i] == '*':
+			builder.WriteString("[^/]*")
+		case rule.Pattern[i] == '?':
+			builder.WriteString("[^/]")
+		default:
+			builder.WriteString(regexp.QuoteMeta(rule.Pattern[i : i+1]))
+		}
+	}
+	builder.WriteString("$")
+	return regexp.Compile(builder.String())
+}
+
+// Matches checks if a path matches the DisplayPathRule.
+// The pattern is compiled when the rule is decoded or with Compile, a rule which is not compiled never matches.
+func (rule *DisplayPathRule) Matches(path string) bool {
+	return rule.compiled != nil && rule.compiled.MatchString(path)
+}
+
+// PageContext describes the page and visitor for which the widget is rendered
+type PageContext struct {
+	Path    string
+	Device  DeviceClass
+	Country string
+}
+
+// DisplayRules restrict the pages, devices and countries on which an integration is displayed.
+// An empty list does not restrict the integration.
+type DisplayRules struct {
+	IncludePaths []*DisplayPathRule `json:"include_paths"`
+	ExcludePaths []*DisplayPathRule `json:"exclude_paths"`
+	Devices      []DeviceClass      `json:"devices" example:"mobile,tablet"`
+	Countries    []string           `json:"countries" example:"US,CA"`
+}
+
+// IsEmpty checks if the DisplayRules do not restrict the integration
+func (rules *DisplayRules) IsEmpty() bool {
+	return len(rules.IncludePaths) == 0 && len(rules.ExcludePaths) == 0 && len(rules.Devices) == 0 && len(rules.Countries) == 0
+}
+
+// Compile compiles the patterns of the DisplayPathRule and returns the first pattern which does not compile
+func (rules *DisplayRules) Compile() error {
+	var result error
+	for _, rule := range append(append([]*DisplayPathRule{}, rules.IncludePaths...), rules.ExcludePaths...) {
+		if err := rule.Compile(); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+// Matches checks if an integration with the DisplayRules is displayed on a page.
+// The country rule is ignored when the country of the visitor is unknown.
+func (rules *DisplayRules) Matches(page *PageContext) bool {
+	if len(rules.IncludePaths) > 0 && !rules.matchesAny(rules.IncludePaths, page.Path) {
+		return false
+	}
+
+	if rules.matchesAny(rules.ExcludePaths, page.Path) {
+		return false
+	}
+
+	if len(rules.Devices) > 0 && !rules.containsDevice(page.Device) {
+		return false
+	}
+
+	if len(rules.Countries) > 0 && page.Country != "" && !rules.containsCountry(page.Country) {
+		return false
+	}
+
+	return true
+}
+
+func (rules *DisplayRules) matchesAny(pathRules []*DisplayPathRule, path string) bool {
+	for _, rule := range pathRules {
+		if rule.Matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rules *DisplayRules) containsDevice(device DeviceClass) bool {
+	for _, value := range rules.Devices {
+		if value == device {
+			return true
+		}
+	}
+	return false
+}
+
+func (rules *DisplayRules) containsCountry(country string) bool {
+	for _, value := range rules.Countries {
+		if strings.EqualFold(value, country) {
+			return true
+		}
+	}
+	return false
+}
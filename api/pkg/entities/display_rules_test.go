@@ -0,0 +1,174 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func newCompiledPathRule(t *testing.T, match DisplayPathMatch, pattern string) *DisplayPathRule {
+	t.Helper()
+	rule := &DisplayPathRule{Match: match, Pattern: pattern}
+	if err := rule.Compile(); err != nil {
+		t.Fatalf("cannot compile [%s] pattern [%s]: %v", match, pattern, err)
+	}
+	return rule
+}
+
+func TestDisplayPathRule_MatchesGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "/pricing", path: "/pricing", want: true},
+		{pattern: "/pricing", path: "/pricing/annual", want: false},
+		{pattern: "/products/*", path: "/products/shoes", want: true},
+		{pattern: "/products/*", path: "/products/shoes/red", want: false},
+		{pattern: "/products/**", path: "/products/shoes/red", want: true},
+		{pattern: "/products/**", path: "/blog/products", want: false},
+		{pattern: "/blog/?", path: "/blog/a", want: true},
+		{pattern: "/blog/?", path: "/blog/ab", want: false},
+		{pattern: "/search.html", path: "/searchxhtml", want: false},
+		{pattern: "/search.html", path: "/search.html", want: true},
+	}
+
+	for _, test := range tests {
+		rule := newCompiledPathRule(t, DisplayPathMatchGlob, test.pattern)
+		if got := rule.Matches(test.path); got != test.want {
+			t.Errorf("glob [%s] Matches(%q) = [%t], want [%t]", test.pattern, test.path, got, test.want)
+		}
+	}
+}
+
+func TestDisplayPathRule_MatchesRegex(t *testing.T) {
+	rule := newCompiledPathRule(t, DisplayPathMatchRegex, `^/products/[0-9]+$`)
+
+	if !rule.Matches("/products/42") {
+		t.Error("the regex does not match [/products/42]")
+	}
+	if rule.Matches("/products/shoes") {
+		t.Error("the regex matches [/products/shoes]")
+	}
+}
+
+func TestDisplayPathRule_InvalidPatternNeverMatches(t *testing.T) {
+	rule := &DisplayPathRule{Match: DisplayPathMatchRegex, Pattern: "(unclosed"}
+
+	if err := rule.Compile(); err == nil {
+		t.Error("compiling an invalid regex did not fail")
+	}
+	if rule.Matches("(unclosed") {
+		t.Error("a rule with an invalid pattern matches a path")
+	}
+}
+
+func TestDisplayPathRule_NotCompiledNeverMatches(t *testing.T) {
+	rule := &DisplayPathRule{Match: DisplayPathMatchGlob, Pattern: "/**"}
+
+	if rule.Matches("/pricing") {
+		t.Error("a rule which was not compiled matches a path")
+	}
+}
+
+func TestDisplayPathRule_CompileSharesPatterns(t *testing.T) {
+	first := newCompiledPathRule(t, DisplayPathMatchGlob, "/shared/*")
+	second := newCompiledPathRule(t, DisplayPathMatchGlob, "/shared/*")
+
+	if first.compiled != second.compiled {
+		t.Error("rules with the same pattern did not share the compiled pattern")
+	}
+
+	regex := newCompiledPathRule(t, DisplayPathMatchRegex, "/shared/*")
+	if regex.compiled == first.compiled {
+		t.Error("a regex and a glob with the same pattern share the compiled pattern")
+	}
+}
+
+func TestDisplayRules_UnmarshalJSONCompilesPatterns(t *testing.T) {
+	rules := new(DisplayRules)
+	err := json.Unmarshal([]byte(`{"include_paths":[{"match":"glob","pattern":"/products/**"}],"exclude_paths":[{"match":"regex","pattern":"/products/hidden$"}]}`), rules)
+	if err != nil {
+		t.Fatalf("cannot unmarshal display rules: %v", err)
+	}
+
+	if !rules.Matches(&PageContext{Path: "/products/shoes"}) {
+		t.Error("the decoded rules do not match an included path")
+	}
+	if rules.Matches(&PageContext{Path: "/products/hidden"}) {
+		t.Error("the decoded rules match an excluded path")
+	}
+}
+
+func TestDisplayRules_Matches(t *testing.T) {
+	rules := &DisplayRules{
+		IncludePaths: []*DisplayPathRule{{Match: DisplayPathMatchGlob, Pattern: "/products/**"}},
+		ExcludePaths: []*DisplayPathRule{{Match: DisplayPathMatchGlob, Pattern: "/products/private/**"}},
+		Devices:      []DeviceClass{DeviceClassMobile},
+		Countries:    []string{"US", "CA"},
+	}
+	if err := rules.Compile(); err != nil {
+		t.Fatalf("cannot compile display rules: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		page *PageContext
+		want bool
+	}{
+		{name: "matching page", page: &PageContext{Path: "/products/shoes", Device: DeviceClassMobile, Country: "US"}, want: true},
+		{name: "lowercase country", page: &PageContext{Path: "/products/shoes", Device: DeviceClassMobile, Country: "ca"}, want: true},
+		{name: "unknown country", page: &PageContext{Path: "/products/shoes", Device: DeviceClassMobile}, want: true},
+		{name: "path not included", page: &PageContext{Path: "/blog", Device: DeviceClassMobile, Country: "US"}, want: false},
+		{name: "excluded path", page: &PageContext{Path: "/products/private/1", Device: DeviceClassMobile, Country: "US"}, want: false},
+		{name: "other device", page: &PageContext{Path: "/products/shoes", Device: DeviceClassDesktop, Country: "US"}, want: false},
+		{name: "other country", page: &PageContext{Path: "/products/shoes", Device: DeviceClassMobile, Country: "FR"}, want: false},
+	}
+
+	for _, test := range tests {
+		if got := rules.Matches(test.page); got != test.want {
+			t.Errorf("Matches with %s = [%t], want [%t]", test.name, got, test.want)
+		}
+	}
+}
+
+func TestDisplayRules_EmptyRulesMatchEveryPage(t *testing.T) {
+	rules := &DisplayRules{}
+
+	if !rules.IsEmpty() || !rules.Matches(&PageContext{Path: "/anything", Device: DeviceClassTablet, Country: "CM"}) {
+		t.Error("empty display rules restrict the integration")
+	}
+}
+
+func TestDisplayRules_CompileReturnsFirstError(t *testing.T) {
+	rules := &DisplayRules{
+		IncludePaths: []*DisplayPathRule{{Match: DisplayPathMatchGlob, Pattern: "/products/**"}},
+		ExcludePaths: []*DisplayPathRule{{Match: DisplayPathMatchRegex, Pattern: "[invalid"}},
+	}
+
+	if err := rules.Compile(); err == nil {
+		t.Error("compiling rules with an invalid pattern did not fail")
+	}
+	if !rules.IncludePaths[0].Matches("/products/shoes") {
+		t.Error("the valid pattern was not compiled because another pattern is invalid")
+	}
+}
+
+func TestDeviceClassFromUserAgent(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      DeviceClass
+	}{
+		{userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X) Mobile/15E148", want: DeviceClassMobile},
+		{userAgent: "Mozilla/5.0 (Linux; Android 13; Pixel 7) Mobile Safari/537.36", want: DeviceClassMobile},
+		{userAgent: "Mozilla/5.0 (Linux; Android 13; SM-X700) Safari/537.36", want: DeviceClassTablet},
+		{userAgent: "Mozilla/5.0 (iPad; CPU OS 16_0 like Mac OS X)", want: DeviceClassTablet},
+		{userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/107.0", want: DeviceClassDesktop},
+		{userAgent: "", want: DeviceClassDesktop},
+	}
+
+	for _, test := range tests {
+		if got := DeviceClassFromUserAgent(test.userAgent); got != test.want {
+			t.Errorf("DeviceClassFromUserAgent(%q) = [%s], want [%s]", test.userAgent, got, test.want)
+		}
+	}
+}
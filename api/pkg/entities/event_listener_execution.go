@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventListenerExecutionStatus is the outcome of running an event listener
+type EventListenerExecutionStatus string
+
+const (
+	// EventListenerExecutionStatusProcessing means the listener is currently handling the event
+	EventListenerExecutionStatusProcessing = EventListenerExecutionStatus("processing")
+
+	// EventListenerExecutionStatusSucceeded means the listener handled the event successfully
+	EventListenerExecutionStatusSucceeded = EventListenerExecutionStatus("succeeded")
+
+	// EventListenerExecutionStatusFailed means the listener returned an error while handling the event
+	EventListenerExecutionStatusFailed = EventListenerExecutionStatus("failed")
+
+	// EventListenerExecutionStatusDeadLettered means the listener kept failing and the event will not be redelivered to it
+	EventListenerExecutionStatusDeadLettered = EventListenerExecutionStatus("dead-lettered")
+)
+
+// EventListenerExecution records the outcome of a listener consuming a cloud event.
+// ClaimedUntil is the end of the lease of a processing execution, another delivery of the event can claim it after the lease expires.
+type EventListenerExecution struct {
+	ID           uuid.UUID                    `json:"id" gorm:"primaryKey;type:uuid;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	EventID      string                       `json:"event_id" gorm:"uniqueIndex:idx_event_listener_executions_event_id_listener" example:"c9b1a4f0-6bb0-4bd3-9e9f-1c6c0a8e33e5"`
+	EventType    string                       `json:"event_type" example:"user.subscription.created"`
+	Listener     string                       `json:"listener" gorm:"uniqueIndex:idx_event_listener_executions_event_id_listener" example:"UserListener.OnUserSubscriptionCreated"`
+	Status       EventListenerExecutionStatus `json:"status" example:"succeeded"`
+	Attempts     uint                         `json:"attempts" example:"1"`
+	Error        *string                      `json:"error" example:"cannot load user"`
+	ClaimedUntil *time.Time                   `json:"claimed_until" example:"2022-06-05T14:29:02.302718+03:00"`
+	CreatedAt    time.Time                    `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt    time.Time                    `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 )
 
+// Integration contains the details shared by all integration types
 type Integration struct {
 	UserID        UserID          `json:"user_id" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
 	ProjectID     uuid.UUID       `json:"project_id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
@@ -15,3 +16,56 @@ type Integration struct {
 	CreatedAt     time.Time       `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
 	UpdatedAt     time.Time       `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
 }
+
+// IntegrationEntity is implemented by the settings of every integration type
+type IntegrationEntity interface {
+	// Base returns the IntegrationBase embedded in the integration settings
+	Base() *IntegrationBase
+
+	// PublicSettings returns the settings which are rendered by the widget
+	PublicSettings() any
+}
+
+// IntegrationBase contains the columns stored for every integration type
+type IntegrationBase struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey;type:string;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	UserID    UserID    `json:"user_id" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	ProjectID uuid.UUID `json:"project_id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	Enabled   bool      `json:"enabled" example:"true"`
+	Name      string    `json:"name" example:"FAQ"`
+	CreatedAt time.Time `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt time.Time `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
+
+// Base returns the IntegrationBase so that it satisfies IntegrationEntity
+func (base *IntegrationBase) Base() *IntegrationBase {
+	return base
+}
+
+// Integration converts IntegrationBase to Integration
+func (base *IntegrationBase) Integration(integrationType IntegrationType) *Integration {
+	return &Integration{
+		UserID:        base.UserID,
+		ProjectID:     base.ProjectID,
+		IntegrationID: base.ID,
+		Type:          integrationType,
+		Name:          base.Name,
+		CreatedAt:     base.CreatedAt,
+		UpdatedAt:     base.UpdatedAt,
+	}
+}
+
+// NewProjectIntegration creates the ProjectIntegration which positions an integration in a project
+func (base *IntegrationBase) NewProjectIntegration(integrationType IntegrationType, position uint) *ProjectIntegration {
+	return &ProjectIntegration{
+		ID:            uuid.New(),
+		UserID:        base.UserID,
+		ProjectID:     base.ProjectID,
+		IntegrationID: base.ID,
+		Type:          integrationType,
+		Name:          base.Name,
+		Position:      position,
+		CreatedAt:     base.CreatedAt,
+		UpdatedAt:     base.UpdatedAt,
+	}
+}
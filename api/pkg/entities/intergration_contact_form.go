@@ -1,27 +1,52 @@
 package entities
 
-import (
-	"time"
-
-	"github.com/google/uuid"
-)
-
 // IntegrationContactForm are contact form integration settings
 type IntegrationContactForm struct {
-	ID              uuid.UUID `json:"id" gorm:"primaryKey;type:string;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
-	UserID          UserID    `json:"user_id" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
-	ProjectID       uuid.UUID `json:"project_id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
-	Enabled         bool      `json:"enabled" example:"true"`
-	Name            string    `json:"name" example:"FAQ"`
-	NameText        string    `json:"name_text" example:"Name"`
-	NameEnabled     bool      `json:"name_enabled" example:"true"`
-	EmailText       string    `json:"email_text" example:"Email"`
-	EmailEnabled    string    `json:"email_enabled" example:"true"`
-	PhoneNumberText string    `json:"phone_number_text" example:"Phone Number"`
-	PhoneEnabled    string    `json:"phone_enabled" example:"true"`
-	MessageText     string    `json:"message_text" example:"Message"`
-	MessageEnabled  string    `json:"message_enabled" example:"true"`
-	Icon            string    `json:"icon" example:"https://cdn.superbutton.app/contact-form-icon.svg"`
-	CreatedAt       time.Time `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
-	UpdatedAt       time.Time `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+	IntegrationBase
+	Text            string `json:"text" example:"Send us a message"`
+	NameText        string `json:"name_text" example:"Name"`
+	NameEnabled     bool   `json:"name_enabled" example:"true"`
+	EmailText       string `json:"email_text" example:"Email"`
+	EmailEnabled    bool   `json:"email_enabled" example:"true"`
+	PhoneNumberText string `json:"phone_number_text" example:"Phone Number"`
+	PhoneEnabled    bool   `json:"phone_enabled" example:"true"`
+	MessageText     string `json:"message_text" example:"Message"`
+	MessageEnabled  bool   `json:"message_enabled" example:"true"`
+	SubmitText      string `json:"submit_text" example:"Send Message"`
+	SuccessMessage  string `json:"success_message" example:"Thanks for reaching out! We will get back to you shortly."`
+	Icon            string `json:"icon" example:"mail"`
+}
+
+// PublicIntegrationContactForm contains the contact form integration settings which are rendered by the widget
+type PublicIntegrationContactForm struct {
+	Text            string `json:"text" example:"Send us a message"`
+	NameText        string `json:"name_text" example:"Name"`
+	NameEnabled     bool   `json:"name_enabled" example:"true"`
+	EmailText       string `json:"email_text" example:"Email"`
+	EmailEnabled    bool   `json:"email_enabled" example:"true"`
+	PhoneNumberText string `json:"phone_number_text" example:"Phone Number"`
+	PhoneEnabled    bool   `json:"phone_enabled" example:"true"`
+	MessageText     string `json:"message_text" example:"Message"`
+	MessageEnabled  bool   `json:"message_enabled" example:"true"`
+	SubmitText      string `json:"submit_text" example:"Send Message"`
+	SuccessMessage  string `json:"success_message" example:"Thanks for reaching out! We will get back to you shortly."`
+	Icon            string `json:"icon" example:"mail"`
+}
+
+// PublicSettings returns the PublicIntegrationContactForm of the IntegrationContactForm
+func (integration *IntegrationContactForm) PublicSettings() any {
+	return &PublicIntegrationContactForm{
+		Text:            integration.Text,
+		NameText:        integration.NameText,
+		NameEnabled:     integration.NameEnabled,
+		EmailText:       integration.EmailText,
+		EmailEnabled:    integration.EmailEnabled,
+		PhoneNumberText: integration.PhoneNumberText,
+		PhoneEnabled:    integration.PhoneEnabled,
+		MessageText:     integration.MessageText,
+		MessageEnabled:  integration.MessageEnabled,
+		SubmitText:      integration.SubmitText,
+		SuccessMessage:  integration.SuccessMessage,
+		Icon:            integration.Icon,
+	}
 }
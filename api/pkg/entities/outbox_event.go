@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is a cloud event which is stored in the same transaction as the entity change and waits to be added to the queue
+type OutboxEvent struct {
+	ID           uuid.UUID  `json:"id" gorm:"primaryKey;type:uuid;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	Type         string     `json:"type" example:"project.created"`
+	Data         []byte     `json:"data"`
+	ScheduleTime *time.Time `json:"schedule_time" example:"2022-06-05T14:26:02.302718+03:00"`
+	AvailableAt  time.Time  `json:"available_at" gorm:"index" example:"2022-06-05T14:26:02.302718+03:00"`
+	Attempts     uint       `json:"attempts" example:"0"`
+	LastError    *string    `json:"last_error" example:"cannot create task"`
+	CreatedAt    time.Time  `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt    time.Time  `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
@@ -0,0 +1,52 @@
+package entities
+
+// PlanFeature is a feature which is only available on some plans
+type PlanFeature string
+
+// PlanFeatureContactForm allows a user to add contact forms to a project
+const PlanFeatureContactForm = PlanFeature("contact-form")
+
+// Plan contains the quotas and features of a subscription
+type Plan struct {
+	Name                      SubscriptionName `json:"name" example:"free"`
+	MaxProjects               int              `json:"max_projects" example:"1"`
+	MaxIntegrationsPerProject int              `json:"max_integrations_per_project" example:"3"`
+	Features                  []PlanFeature    `json:"features"`
+}
+
+// HasFeature checks if a PlanFeature is available on the Plan
+func (plan *Plan) HasFeature(feature PlanFeature) bool {
+	for _, value := range plan.Features {
+		if value == feature {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	planFree = &Plan{
+		Name:                      SubscriptionNameFree,
+		MaxProjects:               1,
+		MaxIntegrationsPerProject: 3,
+		Features:                  []PlanFeature{},
+	}
+	planPro = &Plan{
+		Name:                      SubscriptionNameProMonthly,
+		MaxProjects:               20,
+		MaxIntegrationsPerProject: 20,
+		Features:                  []PlanFeature{PlanFeatureContactForm},
+	}
+)
+
+// PlanFor returns the Plan of a SubscriptionName. Unknown subscriptions get the free plan.
+func PlanFor(name SubscriptionName) *Plan {
+	switch name {
+	case SubscriptionNameProMonthly, SubscriptionNameProYearly:
+		plan := *planPro
+		plan.Name = name
+		return &plan
+	default:
+		return planFree
+	}
+}
@@ -1,21 +1,75 @@
 package entities
 
 import (
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-// Project is a superbutton project belonging to a user
+// Project is a superbutton project belonging to a Workspace. UserID is the user who created the project.
 type Project struct {
 	ID                     uuid.UUID `json:"id" gorm:"primaryKey;type:string;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
 	UserID                 UserID    `json:"user_id" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	WorkspaceID            uuid.UUID `json:"workspace_id" gorm:"type:string;index" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
 	URL                    string    `json:"url" example:"https://example.com"`
 	Name                   string    `json:"name" example:"Joe's Store"`
 	Icon                   string    `json:"icon" example:"https://cdn.superbutton.app/chat-icon.svg"`
 	Greeting               string    `json:"greeting" example:"Need some help?"`
 	GreetingTimeoutSeconds uint      `json:"greeting_timeout_seconds" example:"0"`
 	Color                  string    `json:"color" example:"#283593"`
+	PublishableKey         *string   `json:"publishable_key" gorm:"uniqueIndex" example:"pk_5c1f0b1c4e2a4d7b9a3e6f8d2c1b0a9e8f7d6c5b4a3e2d1c"`
+	AllowedOrigins         []string  `json:"allowed_origins" gorm:"serializer:json" example:"https://example.com,https://*.example.com"`
+	Timezone               string    `json:"timezone" example:"Europe/Paris"`
 	CreatedAt              time.Time `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
 	UpdatedAt              time.Time `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
 }
+
+// Location returns the timezone of the project which is used by the AvailabilitySchedule of its integrations. It defaults to UTC.
+func (project *Project) Location() *time.Location {
+	if project.Timezone == "" {
+		return time.UTC
+	}
+
+	location, err := time.LoadLocation(project.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Origins returns the origins which can embed the widget of the project. It defaults to the origin of the project URL.
+func (project *Project) Origins() []string {
+	if len(project.AllowedOrigins) == 0 {
+		return []string{project.URL}
+	}
+	return project.AllowedOrigins
+}
+
+// IsOriginAllowed checks if an origin e.g "https://shop.example.com" matches one of the Origins of the project.
+// An origin like "https://*.example.com" matches every subdomain of example.com.
+func (project *Project) IsOriginAllowed(origin string) bool {
+	requested, err := url.Parse(origin)
+	if err != nil || requested.Host == "" {
+		return false
+	}
+
+	for _, value := range project.Origins() {
+		allowed, err := url.Parse(value)
+		if err != nil || !strings.EqualFold(allowed.Scheme, requested.Scheme) {
+			continue
+		}
+
+		host := strings.ToLower(allowed.Host)
+		if strings.HasPrefix(host, "*.") && strings.HasSuffix(strings.ToLower(requested.Host), host[1:]) {
+			return true
+		}
+
+		if host == strings.ToLower(requested.Host) {
+			return true
+		}
+	}
+
+	return false
+}
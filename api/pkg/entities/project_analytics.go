@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsGranularity is the size of the time buckets in ProjectAnalytics
+type AnalyticsGranularity string
+
+const (
+	// AnalyticsGranularityHour groups the analytics by hour
+	AnalyticsGranularityHour = AnalyticsGranularity("hour")
+
+	// AnalyticsGranularityDay groups the analytics by day
+	AnalyticsGranularityDay = AnalyticsGranularity("day")
+)
+
+// AnalyticsMetrics are the interactions of website visitors with the widget
+type AnalyticsMetrics struct {
+	Impressions      uint    `json:"impressions" example:"1200"`
+	Opens            uint    `json:"opens" example:"150"`
+	Clicks           uint    `json:"clicks" example:"60"`
+	ClickThroughRate float64 `json:"click_through_rate" example:"0.05"`
+}
+
+// Add increments the counter of a WidgetEventType
+func (metrics *AnalyticsMetrics) Add(eventType WidgetEventType, count uint) {
+	switch eventType {
+	case WidgetEventTypeWidgetLoaded:
+		metrics.Impressions += count
+	case WidgetEventTypeButtonOpened:
+		metrics.Opens += count
+	case WidgetEventTypeIntegrationClicked:
+		metrics.Clicks += count
+	}
+}
+
+// ComputeRates sets the click-through rate which is the number of clicks per impression of the widget
+func (metrics *AnalyticsMetrics) ComputeRates(impressions uint) {
+	metrics.ClickThroughRate = 0
+	if impressions > 0 {
+		metrics.ClickThroughRate = float64(metrics.Clicks) / float64(impressions)
+	}
+}
+
+// AnalyticsBucket are the AnalyticsMetrics of a project in a time bucket
+type AnalyticsBucket struct {
+	Start time.Time `json:"start" example:"2022-06-05T00:00:00+02:00"`
+	AnalyticsMetrics
+}
+
+// IntegrationAnalytics are the AnalyticsMetrics of a ProjectIntegration.
+// The impressions and opens are the ones of the widget because every enabled integration is displayed when the widget is opened.
+type IntegrationAnalytics struct {
+	IntegrationID uuid.UUID       `json:"integration_id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	Type          IntegrationType `json:"type" example:"whatsapp"`
+	Name          string          `json:"name" example:"Customer Support"`
+	Position      uint            `json:"position" example:"1"`
+	AnalyticsMetrics
+}
+
+// ProjectAnalytics are the AnalyticsMetrics of a project over a date range
+type ProjectAnalytics struct {
+	ProjectID    uuid.UUID               `json:"project_id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	From         time.Time               `json:"from" example:"2022-06-01T00:00:00+02:00"`
+	To           time.Time               `json:"to" example:"2022-07-01T00:00:00+02:00"`
+	Granularity  AnalyticsGranularity    `json:"granularity" example:"day"`
+	Timezone     string                  `json:"timezone" example:"Europe/Paris"`
+	Totals       AnalyticsMetrics        `json:"totals"`
+	Series       []*AnalyticsBucket      `json:"series"`
+	Integrations []*IntegrationAnalytics `json:"integrations"`
+}
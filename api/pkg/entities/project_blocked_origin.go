@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectBlockedOrigin counts the requests for the settings of a project from an origin which is not allowed
+type ProjectBlockedOrigin struct {
+	ID            uuid.UUID `json:"id" gorm:"primaryKey;type:string;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	UserID        UserID    `json:"user_id" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	ProjectID     uuid.UUID `json:"project_id" gorm:"uniqueIndex:idx_project_blocked_origins_project_origin" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	Origin        string    `json:"origin" gorm:"uniqueIndex:idx_project_blocked_origins_project_origin" example:"https://copycat.example.org"`
+	Count         uint      `json:"count" example:"12"`
+	LastBlockedAt time.Time `json:"last_blocked_at" example:"2022-06-05T14:26:10.303278+03:00"`
+	CreatedAt     time.Time `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt     time.Time `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
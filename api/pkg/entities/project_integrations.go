@@ -24,6 +24,7 @@ type ProjectIntegration struct {
 	Type          IntegrationType `json:"type" example:"whatsapp"`
 	Name          string          `json:"name"`
 	Position      uint            `json:"position" example:"1"`
+	DisplayRules  *DisplayRules   `json:"display_rules" gorm:"serializer:json"`
 	CreatedAt     time.Time       `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
 	UpdatedAt     time.Time       `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
 }
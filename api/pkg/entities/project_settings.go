@@ -10,7 +10,8 @@ type ProjectSettings struct {
 
 // ProjectSettingsIntegration represents a project integration
 type ProjectSettingsIntegration struct {
-	Type     IntegrationType `json:"type"`
-	ID       uuid.UUID       `json:"id"`
-	Settings any             `json:"settings"`
+	Type         IntegrationType `json:"type"`
+	ID           uuid.UUID       `json:"id"`
+	DisplayRules *DisplayRules   `json:"display_rules"`
+	Settings     any             `json:"settings"`
 }
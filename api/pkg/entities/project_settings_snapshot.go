@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// ProjectSettingsSnapshot is the pre-rendered PublicProjectSettings of a project which is served to the widget with a single read.
+// It also contains the fields of the Project which are needed to authorize a request without loading the project
+// and the IntegrationAvailability and DisplayRules of the integrations which are applied when the snapshot is served.
+type ProjectSettingsSnapshot struct {
+	ProjectID      uuid.UUID                              `json:"project_id" gorm:"primaryKey;type:uuid;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	UserID         UserID                                 `json:"user_id" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	PublishableKey *string                                `json:"publishable_key" gorm:"uniqueIndex" example:"pk_5c1f0b1c4e2a4d7b9a3e6f8d2c1b0a9e8f7d6c5b4a3e2d1c"`
+	URL            string                                 `json:"url" example:"https://example.com"`
+	AllowedOrigins []string                               `json:"allowed_origins" gorm:"serializer:json" example:"https://example.com"`
+	Timezone       string                                 `json:"timezone" example:"Europe/Paris"`
+	Availability   map[uuid.UUID]*IntegrationAvailability `json:"availability" gorm:"serializer:json"`
+	DisplayRules   map[uuid.UUID]*DisplayRules            `json:"display_rules" gorm:"serializer:json"`
+	Version        uint                                   `json:"version" example:"1"`
+	ETag           string                                 `json:"etag" gorm:"column:etag" example:"\"5c1f0b1c4e2a4d7b9a3e6f8d2c1b0a9e\""`
+	Settings       datatypes.JSON                         `json:"settings"`
+	CreatedAt      time.Time                              `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt      time.Time                              `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
+
+// IsCurrent checks if the snapshot was rendered with the current PublicProjectSettingsVersion
+func (snapshot *ProjectSettingsSnapshot) IsCurrent() bool {
+	return snapshot.Version == PublicProjectSettingsVersion
+}
+
+// Project returns the fields of the Project which were copied into the snapshot
+func (snapshot *ProjectSettingsSnapshot) Project() *Project {
+	return &Project{
+		ID:             snapshot.ProjectID,
+		UserID:         snapshot.UserID,
+		PublishableKey: snapshot.PublishableKey,
+		URL:            snapshot.URL,
+		AllowedOrigins: snapshot.AllowedOrigins,
+		Timezone:       snapshot.Timezone,
+	}
+}
@@ -0,0 +1,62 @@
+package entities
+
+import "testing"
+
+func TestProject_OriginsDefaultsToURL(t *testing.T) {
+	project := &Project{URL: "https://example.com"}
+
+	if origins := project.Origins(); len(origins) != 1 || origins[0] != "https://example.com" {
+		t.Errorf("got origins [%v], want the URL of the project", origins)
+	}
+
+	project.AllowedOrigins = []string{"https://shop.example.com"}
+	if origins := project.Origins(); len(origins) != 1 || origins[0] != "https://shop.example.com" {
+		t.Errorf("got origins [%v], want the allowed origins", origins)
+	}
+}
+
+func TestProject_IsOriginAllowed(t *testing.T) {
+	project := &Project{
+		URL:            "https://example.com",
+		AllowedOrigins: []string{"https://example.com", "https://*.shop.com", "http://localhost:3000"},
+	}
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{origin: "https://example.com", want: true},
+		{origin: "https://EXAMPLE.com", want: true},
+		{origin: "http://example.com", want: false},
+		{origin: "https://www.example.com", want: false},
+		{origin: "https://example.com.evil.com", want: false},
+		{origin: "https://a.shop.com", want: true},
+		{origin: "https://a.b.shop.com", want: true},
+		{origin: "https://shop.com", want: false},
+		{origin: "https://evilshop.com", want: false},
+		{origin: "http://a.shop.com", want: false},
+		{origin: "http://localhost:3000", want: true},
+		{origin: "http://localhost:4000", want: false},
+		{origin: "example.com", want: false},
+		{origin: "", want: false},
+		{origin: "null", want: false},
+	}
+
+	for _, test := range tests {
+		if got := project.IsOriginAllowed(test.origin); got != test.want {
+			t.Errorf("IsOriginAllowed(%q) = [%t], want [%t]", test.origin, got, test.want)
+		}
+	}
+}
+
+func TestProject_IsOriginAllowedWithoutAllowedOrigins(t *testing.T) {
+	project := &Project{URL: "https://example.com/pricing"}
+
+	if !project.IsOriginAllowed("https://example.com") {
+		t.Error("the origin of the project URL is not allowed when there are no allowed origins")
+	}
+
+	if project.IsOriginAllowed("https://other.com") {
+		t.Error("an origin which is not the project URL is allowed when there are no allowed origins")
+	}
+}
@@ -0,0 +1,44 @@
+package entities
+
+import "github.com/google/uuid"
+
+// PublicProjectSettingsVersion is the schema version of PublicProjectSettings.
+// It must be incremented whenever a field is removed or its meaning changes so that the widget can detect breaking changes.
+const PublicProjectSettingsVersion = 1
+
+// PublicProjectSettings is the privacy-safe projection of ProjectSettings which is rendered by the widget
+type PublicProjectSettings struct {
+	Version      uint                        `json:"version" example:"1"`
+	Project      *PublicProject              `json:"project"`
+	Integrations []*PublicProjectIntegration `json:"integrations"`
+}
+
+// PublicProject contains the fields of a Project which are rendered by the widget
+type PublicProject struct {
+	Name                   string `json:"name" example:"Joe's Store"`
+	Icon                   string `json:"icon" example:"https://cdn.superbutton.app/chat-icon.svg"`
+	Greeting               string `json:"greeting" example:"Need some help?"`
+	GreetingTimeoutSeconds uint   `json:"greeting_timeout_seconds" example:"0"`
+	Color                  string `json:"color" example:"#283593"`
+}
+
+// PublicProjectIntegration is an enabled integration which is rendered by the widget.
+// Available is false when the integration is outside its AvailabilitySchedule and the widget should show the OfflineMessage.
+type PublicProjectIntegration struct {
+	ID             uuid.UUID       `json:"id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	Type           IntegrationType `json:"type" example:"whatsapp"`
+	Available      bool            `json:"available" example:"true"`
+	OfflineMessage string          `json:"offline_message,omitempty" example:"We are closed, call us back tomorrow from 9am"`
+	Settings       any             `json:"settings"`
+}
+
+// Public returns the PublicProject of a Project
+func (project *Project) Public() *PublicProject {
+	return &PublicProject{
+		Name:                   project.Name,
+		Icon:                   project.Icon,
+		Greeting:               project.Greeting,
+		GreetingTimeoutSeconds: project.GreetingTimeoutSeconds,
+		Color:                  project.Color,
+	}
+}
@@ -21,14 +21,46 @@ const SubscriptionNameProYearly = SubscriptionName("pro-yearly")
 
 // User stores information about a user
 type User struct {
-	ID                   UserID           `json:"id" gorm:"primaryKey;type:string;" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
-	Email                string           `json:"email" example:"name@email.com"`
-	Name                 string           `json:"name" example:"John Doe"`
-	SubscriptionName     SubscriptionName `json:"subscription_name" example:"free"`
-	SubscriptionID       string           `json:"subscription_id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
-	SubscriptionStatus   string           `json:"subscription_status" example:"on_trial"`
-	SubscriptionRenewsAt *time.Time       `json:"subscription_renews_at" example:"2022-06-05T14:26:02.302718+03:00"`
-	SubscriptionEndsAt   *time.Time       `json:"subscription_ends_at" example:"2022-06-05T14:26:02.302718+03:00"`
-	CreatedAt            time.Time        `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
-	UpdatedAt            time.Time        `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+	ID                           UserID           `json:"id" gorm:"primaryKey;type:string;" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	Email                        string           `json:"email" example:"name@email.com"`
+	Name                         string           `json:"name" example:"John Doe"`
+	SubscriptionName             SubscriptionName `json:"subscription_name" example:"free"`
+	SubscriptionID               string           `json:"subscription_id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	SubscriptionStatus           string           `json:"subscription_status" example:"on_trial"`
+	SubscriptionRenewsAt         *time.Time       `json:"subscription_renews_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	SubscriptionEndsAt           *time.Time       `json:"subscription_ends_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	SubscriptionResumesAt        *time.Time       `json:"subscription_resumes_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	SubscriptionPaymentFailedAt  *time.Time       `json:"subscription_payment_failed_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	SubscriptionUpdatedAt        *time.Time       `json:"subscription_updated_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	SubscriptionPaymentUpdatedAt *time.Time       `json:"subscription_payment_updated_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	CreatedAt                    time.Time        `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt                    time.Time        `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
+
+// IsStaleSubscriptionUpdate checks if a subscription update from lemonsqueezy is older than the update which was already applied.
+// Updates without a timestamp are never stale.
+func (user *User) IsStaleSubscriptionUpdate(updatedAt time.Time) bool {
+	return isStaleUpdate(user.SubscriptionUpdatedAt, updatedAt)
+}
+
+// IsStaleSubscriptionPaymentUpdate checks if a subscription payment from lemonsqueezy is older than the payment which was already applied
+func (user *User) IsStaleSubscriptionPaymentUpdate(updatedAt time.Time) bool {
+	return isStaleUpdate(user.SubscriptionPaymentUpdatedAt, updatedAt)
+}
+
+func isStaleUpdate(applied *time.Time, updatedAt time.Time) bool {
+	return applied != nil && !updatedAt.IsZero() && updatedAt.Before(*applied)
+}
+
+// SubscriptionExpired checks if a paid subscription ended before the given time
+func (user *User) SubscriptionExpired(now time.Time) bool {
+	return user.SubscriptionName != SubscriptionNameFree && user.SubscriptionEndsAt != nil && !user.SubscriptionEndsAt.After(now)
+}
+
+// Plan returns the Plan of the user's current subscription
+func (user *User) Plan() *Plan {
+	if user.SubscriptionExpired(time.Now().UTC()) {
+		return PlanFor(SubscriptionNameFree)
+	}
+	return PlanFor(user.SubscriptionName)
 }
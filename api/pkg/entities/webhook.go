@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSecretPrefix is the prefix of the secret which signs the deliveries of a Webhook
+const WebhookSecretPrefix = "whsec_"
+
+// Webhook is an endpoint of a customer which receives the events of a Project
+type Webhook struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey;type:uuid;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	UserID    UserID    `json:"user_id" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	ProjectID uuid.UUID `json:"project_id" gorm:"index" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	URL       string    `json:"url" example:"https://example.com/webhooks/superbutton"`
+	// EventTypes are the types of the events which are delivered to the URL
+	EventTypes []string `json:"event_types" gorm:"serializer:json" example:"contact-form.submitted,integration.updated"`
+	// SigningSecret is the key of the HMAC-SHA256 signature in the X-Superbutton-Signature header of every delivery
+	SigningSecret string `json:"-"`
+	// Secret is the SigningSecret which is only set in the response when the webhook is created
+	Secret  string `json:"signing_secret,omitempty" gorm:"-" example:"whsec_3f2b6c1e9a8d4f7b0c5e2a1d9f8b7c6e5d4a3b2c1f0e9d8c7b6a5f4e3d2c1b0a"`
+	Enabled bool   `json:"enabled" example:"true"`
+	// ConsecutiveFailures is the number of deliveries in a row which failed after every retry
+	ConsecutiveFailures uint       `json:"consecutive_failures" example:"0"`
+	DisabledAt          *time.Time `json:"disabled_at" example:"2022-06-07T14:26:02.302718+03:00"`
+	CreatedAt           time.Time  `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt           time.Time  `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
+
+// Subscribes checks if the Webhook receives events of a type
+func (webhook *Webhook) Subscribes(eventType string) bool {
+	for _, value := range webhook.EventTypes {
+		if value == eventType {
+			return true
+		}
+	}
+	return false
+}
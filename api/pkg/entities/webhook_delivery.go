@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDeliveryStatus is the outcome of sending an event to a Webhook
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryStatusPending means the event is waiting to be sent or will be retried
+	WebhookDeliveryStatusPending = WebhookDeliveryStatus("pending")
+
+	// WebhookDeliveryStatusSucceeded means the URL of the webhook responded with a 2xx status code
+	WebhookDeliveryStatusSucceeded = WebhookDeliveryStatus("succeeded")
+
+	// WebhookDeliveryStatusFailed means every attempt to send the event failed
+	WebhookDeliveryStatusFailed = WebhookDeliveryStatus("failed")
+)
+
+// WebhookDelivery records the attempts to send a cloud event to a Webhook
+type WebhookDelivery struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey;type:uuid;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	WebhookID uuid.UUID `json:"webhook_id" gorm:"index" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	ProjectID uuid.UUID `json:"project_id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	EventID   string    `json:"event_id" example:"c9b1a4f0-6bb0-4bd3-9e9f-1c6c0a8e33e5"`
+	EventType string    `json:"event_type" example:"contact-form.submitted"`
+	// Payload is the cloud event which is sent in the body of the request
+	Payload []byte                `json:"-"`
+	Status  WebhookDeliveryStatus `json:"status" example:"succeeded"`
+	// RedeliveryOf is the ID of the WebhookDelivery which was sent again
+	RedeliveryOf *uuid.UUID `json:"redelivery_of" gorm:"type:uuid;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	Attempts     uint       `json:"attempts" example:"1"`
+	ResponseCode *int       `json:"response_code" example:"200"`
+	ResponseBody *string    `json:"response_body" example:"OK"`
+	Error        *string    `json:"error" example:"context deadline exceeded"`
+	DeliveredAt  *time.Time `json:"delivered_at" example:"2022-06-05T14:26:10.303278+03:00"`
+	CreatedAt    time.Time  `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt    time.Time  `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
+
+// IsSuccessful checks if a status code of the URL of the Webhook is a 2xx status code
+func (delivery *WebhookDelivery) IsSuccessful() bool {
+	return delivery.ResponseCode != nil && *delivery.ResponseCode >= 200 && *delivery.ResponseCode < 300
+}
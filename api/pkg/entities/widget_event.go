@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WidgetEventType is the interaction of a website visitor with the widget
+type WidgetEventType string
+
+const (
+	// WidgetEventTypeWidgetLoaded is recorded when the widget is rendered on a page
+	WidgetEventTypeWidgetLoaded = WidgetEventType("widget.loaded")
+
+	// WidgetEventTypeGreetingShown is recorded when the greeting of the project is displayed
+	WidgetEventTypeGreetingShown = WidgetEventType("greeting.shown")
+
+	// WidgetEventTypeButtonOpened is recorded when the visitor opens the list of integrations
+	WidgetEventTypeButtonOpened = WidgetEventType("button.opened")
+
+	// WidgetEventTypeIntegrationClicked is recorded when the visitor clicks on an integration
+	WidgetEventTypeIntegrationClicked = WidgetEventType("integration.clicked")
+)
+
+// WidgetEventTypes returns all the supported WidgetEventType
+func WidgetEventTypes() []WidgetEventType {
+	return []WidgetEventType{
+		WidgetEventTypeWidgetLoaded,
+		WidgetEventTypeGreetingShown,
+		WidgetEventTypeButtonOpened,
+		WidgetEventTypeIntegrationClicked,
+	}
+}
+
+// WidgetEvent is an interaction of a website visitor with the widget of a project.
+// The visitor is identified by a salted hash which changes every day so the IP address of the visitor is never stored.
+type WidgetEvent struct {
+	ID            uuid.UUID       `json:"id" gorm:"primaryKey;type:uuid;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	UserID        UserID          `json:"user_id" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	ProjectID     uuid.UUID       `json:"project_id" gorm:"index:idx_widget_events_project_created_at" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	IntegrationID *uuid.UUID      `json:"integration_id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	Type          WidgetEventType `json:"type" example:"integration.clicked"`
+	VisitorID     string          `json:"visitor_id" example:"5c1f0b1c4e2a4d7b9a3e6f8d2c1b0a9e"`
+	PageURL       string          `json:"page_url" example:"https://example.com/products/1"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"index:idx_widget_events_project_created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+}
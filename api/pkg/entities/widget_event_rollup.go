@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WidgetEventRollup is the number of WidgetEvent of a type which were recorded for an integration of a project in a time bucket.
+// Events which are not about an integration e.g. WidgetEventTypeWidgetLoaded are counted with the uuid.Nil integration ID.
+type WidgetEventRollup struct {
+	ProjectID     uuid.UUID       `json:"project_id" gorm:"primaryKey" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	IntegrationID uuid.UUID       `json:"integration_id" gorm:"primaryKey" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	Type          WidgetEventType `json:"type" gorm:"primaryKey" example:"integration.clicked"`
+	BucketStart   time.Time       `json:"bucket_start" gorm:"primaryKey" example:"2022-06-05T14:00:00Z"`
+	UserID        UserID          `json:"user_id" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	Count         uint            `json:"count" example:"42"`
+	UpdatedAt     time.Time       `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
+
+// WidgetEventHourlyRollup is a WidgetEventRollup for a UTC hour
+type WidgetEventHourlyRollup struct {
+	WidgetEventRollup
+}
+
+// TableName overrides the table name used by WidgetEventHourlyRollup to `widget_event_hourly_rollups`
+func (WidgetEventHourlyRollup) TableName() string {
+	return "widget_event_hourly_rollups"
+}
+
+// WidgetEventDailyRollup is a WidgetEventRollup for a UTC day
+type WidgetEventDailyRollup struct {
+	WidgetEventRollup
+}
+
+// TableName overrides the table name used by WidgetEventDailyRollup to `widget_event_daily_rollups`
+func (WidgetEventDailyRollup) TableName() string {
+	return "widget_event_daily_rollups"
+}
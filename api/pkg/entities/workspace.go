@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Workspace owns projects which are shared with its WorkspaceMember
+type Workspace struct {
+	ID uuid.UUID `json:"id" gorm:"primaryKey;type:string;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	// OwnerID is the user who created the workspace. The entities.Plan of the owner applies to every project in the workspace.
+	// A user owns at most one Personal workspace.
+	OwnerID UserID `json:"owner_id" gorm:"index;uniqueIndex:idx_workspaces_owner_id_personal,where:personal = true" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	Name    string `json:"name" example:"Acme Agency"`
+	// Personal is true for the workspace which is created for every user to hold their own projects
+	Personal  bool      `json:"personal" example:"false"`
+	CreatedAt time.Time `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt time.Time `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
+
+// UserWorkspace is a Workspace together with the WorkspaceRole of the authenticated user
+type UserWorkspace struct {
+	Workspace
+	Role WorkspaceRole `json:"role" example:"editor"`
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceInvitationStatus is the state of a WorkspaceInvitation
+type WorkspaceInvitationStatus string
+
+const (
+	// WorkspaceInvitationStatusPending can still be accepted
+	WorkspaceInvitationStatusPending = WorkspaceInvitationStatus("pending")
+
+	// WorkspaceInvitationStatusAccepted was used to join the workspace
+	WorkspaceInvitationStatusAccepted = WorkspaceInvitationStatus("accepted")
+
+	// WorkspaceInvitationStatusRevoked was cancelled by a member of the workspace
+	WorkspaceInvitationStatusRevoked = WorkspaceInvitationStatus("revoked")
+
+	// WorkspaceInvitationStatusExpired was not accepted before it expired
+	WorkspaceInvitationStatusExpired = WorkspaceInvitationStatus("expired")
+)
+
+// WorkspaceInvitation invites an email address to join a Workspace with a WorkspaceRole.
+// Only the SHA-256 hash of the token is stored. The token is sent to the email address and can be used once.
+type WorkspaceInvitation struct {
+	ID          uuid.UUID     `json:"id" gorm:"primaryKey;type:string;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	WorkspaceID uuid.UUID     `json:"workspace_id" gorm:"type:string;index" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	Email       string        `json:"email" gorm:"index" example:"name@email.com"`
+	Role        WorkspaceRole `json:"role" example:"editor"`
+	TokenHash   string        `json:"-" gorm:"uniqueIndex"`
+	InvitedBy   UserID        `json:"invited_by" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	ExpiresAt   time.Time     `json:"expires_at" example:"2022-06-12T14:26:02.302718+03:00"`
+	AcceptedAt  *time.Time    `json:"accepted_at" example:"2022-06-06T14:26:02.302718+03:00"`
+	AcceptedBy  *UserID       `json:"accepted_by" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	RevokedAt   *time.Time    `json:"revoked_at" example:"2022-06-06T14:26:02.302718+03:00"`
+	CreatedAt   time.Time     `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt   time.Time     `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
+
+// Status returns the WorkspaceInvitationStatus of the invitation at a point in time
+func (invitation *WorkspaceInvitation) Status(now time.Time) WorkspaceInvitationStatus {
+	switch {
+	case invitation.AcceptedAt != nil:
+		return WorkspaceInvitationStatusAccepted
+	case invitation.RevokedAt != nil:
+		return WorkspaceInvitationStatusRevoked
+	case !now.Before(invitation.ExpiresAt):
+		return WorkspaceInvitationStatusExpired
+	default:
+		return WorkspaceInvitationStatusPending
+	}
+}
+
+// IsPending checks if the invitation can still be accepted
+func (invitation *WorkspaceInvitation) IsPending(now time.Time) bool {
+	return invitation.Status(now) == WorkspaceInvitationStatusPending
+}
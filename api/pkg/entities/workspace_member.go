@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceRole is the role of a WorkspaceMember
+type WorkspaceRole string
+
+const (
+	// WorkspaceRoleOwner can do everything including managing other owners
+	WorkspaceRoleOwner = WorkspaceRole("owner")
+
+	// WorkspaceRoleAdmin can create and delete projects and manage the members who are not owners
+	WorkspaceRoleAdmin = WorkspaceRole("admin")
+
+	// WorkspaceRoleEditor can edit the projects and integrations in the workspace
+	WorkspaceRoleEditor = WorkspaceRole("editor")
+
+	// WorkspaceRoleViewer can only view the projects, integrations and analytics in the workspace
+	WorkspaceRoleViewer = WorkspaceRole("viewer")
+)
+
+// WorkspaceRoles returns every WorkspaceRole from the most to the least privileged
+func WorkspaceRoles() []WorkspaceRole {
+	return []WorkspaceRole{WorkspaceRoleOwner, WorkspaceRoleAdmin, WorkspaceRoleEditor, WorkspaceRoleViewer}
+}
+
+// WorkspacePermission is an action which can be carried out on a Workspace and its projects
+type WorkspacePermission string
+
+const (
+	// WorkspacePermissionView allows reading projects, integrations, analytics and members
+	WorkspacePermissionView = WorkspacePermission("view")
+
+	// WorkspacePermissionEdit allows changing the settings of projects and their integrations
+	WorkspacePermissionEdit = WorkspacePermission("edit")
+
+	// WorkspacePermissionManage allows creating and deleting projects, rotating keys and managing members
+	WorkspacePermissionManage = WorkspacePermission("manage")
+
+	// WorkspacePermissionOwn allows managing the owners of the workspace
+	WorkspacePermissionOwn = WorkspacePermission("own")
+)
+
+// workspaceRolePermissions are the permissions of every WorkspaceRole
+var workspaceRolePermissions = map[WorkspaceRole][]WorkspacePermission{
+	WorkspaceRoleOwner:  {WorkspacePermissionView, WorkspacePermissionEdit, WorkspacePermissionManage, WorkspacePermissionOwn},
+	WorkspaceRoleAdmin:  {WorkspacePermissionView, WorkspacePermissionEdit, WorkspacePermissionManage},
+	WorkspaceRoleEditor: {WorkspacePermissionView, WorkspacePermissionEdit},
+	WorkspaceRoleViewer: {WorkspacePermissionView},
+}
+
+// Can checks if the WorkspaceRole has a WorkspacePermission
+func (role WorkspaceRole) Can(permission WorkspacePermission) bool {
+	for _, value := range workspaceRolePermissions[role] {
+		if value == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// GrantPermission is the WorkspacePermission which is needed to give the WorkspaceRole to another user
+func (role WorkspaceRole) GrantPermission() WorkspacePermission {
+	if role == WorkspaceRoleOwner {
+		return WorkspacePermissionOwn
+	}
+	return WorkspacePermissionManage
+}
+
+// WorkspaceMember is a user who has access to the projects of a Workspace
+type WorkspaceMember struct {
+	WorkspaceID uuid.UUID     `json:"workspace_id" gorm:"primaryKey;type:string;" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	UserID      UserID        `json:"user_id" gorm:"primaryKey;type:string;index" example:"WB7DRDWrJZRGbYrv2CKGkqbzvqdC"`
+	Email       string        `json:"email" example:"name@email.com"`
+	Role        WorkspaceRole `json:"role" example:"editor"`
+	CreatedAt   time.Time     `json:"created_at" example:"2022-06-05T14:26:02.302718+03:00"`
+	UpdatedAt   time.Time     `json:"updated_at" example:"2022-06-05T14:26:10.303278+03:00"`
+}
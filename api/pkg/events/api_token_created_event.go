@@ -0,0 +1,22 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// APITokenCreated is raised when a user creates a personal access token
+const APITokenCreated = "api-token.created"
+
+// APITokenCreatedPayload stores the data for the APITokenCreated event
+type APITokenCreatedPayload struct {
+	UserID            entities.UserID          `json:"user_id"`
+	APITokenID        uuid.UUID                `json:"api_token_id"`
+	APITokenName      string                   `json:"api_token_name"`
+	APITokenScopes    []entities.APITokenScope `json:"api_token_scopes"`
+	APITokenExpiresAt time.Time                `json:"api_token_expires_at"`
+	APITokenCreatedAt time.Time                `json:"api_token_created_at"`
+}
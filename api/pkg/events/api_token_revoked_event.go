@@ -0,0 +1,20 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// APITokenRevoked is raised when a user revokes a personal access token
+const APITokenRevoked = "api-token.revoked"
+
+// APITokenRevokedPayload stores the data for the APITokenRevoked event
+type APITokenRevokedPayload struct {
+	UserID            entities.UserID `json:"user_id"`
+	APITokenID        uuid.UUID       `json:"api_token_id"`
+	APITokenName      string          `json:"api_token_name"`
+	APITokenRevokedAt time.Time       `json:"api_token_revoked_at"`
+}
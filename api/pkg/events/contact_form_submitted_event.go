@@ -0,0 +1,26 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// ContactFormSubmitted is raised when a website visitor submits a contact form
+const ContactFormSubmitted = "contact-form.submitted"
+
+// ContactFormSubmittedPayload stores the data for the ContactFormSubmitted event
+type ContactFormSubmittedPayload struct {
+	UserID        entities.UserID `json:"user_id"`
+	ProjectID     uuid.UUID       `json:"project_id"`
+	IntegrationID uuid.UUID       `json:"integration_id"`
+	SubmissionID  uuid.UUID       `json:"submission_id"`
+	Name          *string         `json:"name"`
+	Email         *string         `json:"email"`
+	PhoneNumber   *string         `json:"phone_number"`
+	Message       *string         `json:"message"`
+	PageURL       string          `json:"page_url"`
+	SubmittedAt   time.Time       `json:"submitted_at"`
+}
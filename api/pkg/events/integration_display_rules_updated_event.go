@@ -0,0 +1,22 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// IntegrationDisplayRulesUpdated is raised when the display rules of an integration in a project are updated
+const IntegrationDisplayRulesUpdated = "integration.display-rules.updated"
+
+// IntegrationDisplayRulesUpdatedPayload stores the data for the IntegrationDisplayRulesUpdated event
+type IntegrationDisplayRulesUpdatedPayload struct {
+	UserID                entities.UserID          `json:"user_id"`
+	ProjectID             uuid.UUID                `json:"project_id"`
+	IntegrationID         uuid.UUID                `json:"integration_id"`
+	IntegrationType       entities.IntegrationType `json:"integration_type"`
+	DisplayRules          *entities.DisplayRules   `json:"display_rules"`
+	DisplayRulesUpdatedAt time.Time                `json:"display_rules_updated_at"`
+}
@@ -0,0 +1,20 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// IntegrationReordered is raised when the integrations of a project are reordered
+const IntegrationReordered = "integration.reordered"
+
+// IntegrationReorderedPayload stores the data for the IntegrationReordered event
+type IntegrationReorderedPayload struct {
+	UserID                 entities.UserID `json:"user_id"`
+	ProjectID              uuid.UUID       `json:"project_id"`
+	IntegrationIDs         []uuid.UUID     `json:"integration_ids"`
+	IntegrationReorderedAt time.Time       `json:"integration_reordered_at"`
+}
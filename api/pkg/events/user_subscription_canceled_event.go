@@ -13,6 +13,7 @@ const UserSubscriptionCancelled = "user.subscription.cancelled"
 type UserSubscriptionCancelledPayload struct {
 	UserID                  entities.UserID           `json:"user_id"`
 	SubscriptionCancelledAt time.Time                 `json:"subscription_cancelled_at"`
+	SubscriptionUpdatedAt   time.Time                 `json:"subscription_updated_at"`
 	SubscriptionEndsAt      time.Time                 `json:"subscription_ends_at"`
 	SubscriptionID          string                    `json:"subscription_id"`
 	SubscriptionName        entities.SubscriptionName `json:"subscription_name"`
@@ -13,6 +13,7 @@ const UserSubscriptionCreated = "user.subscription.created"
 type UserSubscriptionCreatedPayload struct {
 	UserID                entities.UserID           `json:"user_id"`
 	SubscriptionCreatedAt time.Time                 `json:"subscription_created_at"`
+	SubscriptionUpdatedAt time.Time                 `json:"subscription_updated_at"`
 	SubscriptionID        string                    `json:"subscription_id"`
 	SubscriptionName      entities.SubscriptionName `json:"subscription_name"`
 	SubscriptionRenewsAt  time.Time                 `json:"subscription_renews_at"`
@@ -0,0 +1,20 @@
+package events
+
+import (
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// UserSubscriptionExpired is raised when a user subscription has ended
+const UserSubscriptionExpired = "user.subscription.expired"
+
+// UserSubscriptionExpiredPayload stores the data for the UserSubscriptionExpired event
+type UserSubscriptionExpiredPayload struct {
+	UserID                entities.UserID           `json:"user_id"`
+	SubscriptionExpiredAt time.Time                 `json:"subscription_expired_at"`
+	SubscriptionUpdatedAt time.Time                 `json:"subscription_updated_at"`
+	SubscriptionID        string                    `json:"subscription_id"`
+	SubscriptionName      entities.SubscriptionName `json:"subscription_name"`
+	SubscriptionStatus    string                    `json:"subscription_status"`
+}
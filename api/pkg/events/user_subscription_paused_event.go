@@ -0,0 +1,21 @@
+package events
+
+import (
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// UserSubscriptionPaused is raised when payment collection of a user subscription is paused
+const UserSubscriptionPaused = "user.subscription.paused"
+
+// UserSubscriptionPausedPayload stores the data for the UserSubscriptionPaused event
+type UserSubscriptionPausedPayload struct {
+	UserID                entities.UserID           `json:"user_id"`
+	SubscriptionPausedAt  time.Time                 `json:"subscription_paused_at"`
+	SubscriptionUpdatedAt time.Time                 `json:"subscription_updated_at"`
+	SubscriptionResumesAt *time.Time                `json:"subscription_resumes_at"`
+	SubscriptionID        string                    `json:"subscription_id"`
+	SubscriptionName      entities.SubscriptionName `json:"subscription_name"`
+	SubscriptionStatus    string                    `json:"subscription_status"`
+}
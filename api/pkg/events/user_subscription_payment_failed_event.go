@@ -0,0 +1,22 @@
+package events
+
+import (
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// UserSubscriptionPaymentFailed is raised when a renewal payment of a user subscription fails
+const UserSubscriptionPaymentFailed = "user.subscription.payment-failed"
+
+// UserSubscriptionPaymentFailedPayload stores the data for the UserSubscriptionPaymentFailed event
+type UserSubscriptionPaymentFailedPayload struct {
+	UserID                entities.UserID `json:"user_id"`
+	SubscriptionID        string          `json:"subscription_id"`
+	InvoiceID             string          `json:"invoice_id"`
+	InvoiceStatus         string          `json:"invoice_status"`
+	InvoiceTotal          int             `json:"invoice_total"`
+	InvoiceCurrency       string          `json:"invoice_currency"`
+	InvoiceBillingReason  string          `json:"invoice_billing_reason"`
+	SubscriptionPaymentAt time.Time       `json:"subscription_payment_at"`
+}
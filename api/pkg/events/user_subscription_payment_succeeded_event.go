@@ -0,0 +1,22 @@
+package events
+
+import (
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// UserSubscriptionPaymentSucceeded is raised when a payment of a user subscription is successful
+const UserSubscriptionPaymentSucceeded = "user.subscription.payment-succeeded"
+
+// UserSubscriptionPaymentSucceededPayload stores the data for the UserSubscriptionPaymentSucceeded event
+type UserSubscriptionPaymentSucceededPayload struct {
+	UserID                entities.UserID `json:"user_id"`
+	SubscriptionID        string          `json:"subscription_id"`
+	InvoiceID             string          `json:"invoice_id"`
+	InvoiceStatus         string          `json:"invoice_status"`
+	InvoiceTotal          int             `json:"invoice_total"`
+	InvoiceCurrency       string          `json:"invoice_currency"`
+	InvoiceBillingReason  string          `json:"invoice_billing_reason"`
+	SubscriptionPaymentAt time.Time       `json:"subscription_payment_at"`
+}
@@ -0,0 +1,21 @@
+package events
+
+import (
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// UserSubscriptionResumed is raised when a cancelled user subscription is resumed before it ends
+const UserSubscriptionResumed = "user.subscription.resumed"
+
+// UserSubscriptionResumedPayload stores the data for the UserSubscriptionResumed event
+type UserSubscriptionResumedPayload struct {
+	UserID                entities.UserID           `json:"user_id"`
+	SubscriptionResumedAt time.Time                 `json:"subscription_resumed_at"`
+	SubscriptionUpdatedAt time.Time                 `json:"subscription_updated_at"`
+	SubscriptionID        string                    `json:"subscription_id"`
+	SubscriptionName      entities.SubscriptionName `json:"subscription_name"`
+	SubscriptionRenewsAt  time.Time                 `json:"subscription_renews_at"`
+	SubscriptionStatus    string                    `json:"subscription_status"`
+}
@@ -0,0 +1,21 @@
+package events
+
+import (
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// UserSubscriptionUnpaused is raised when payment collection of a paused user subscription is restarted
+const UserSubscriptionUnpaused = "user.subscription.unpaused"
+
+// UserSubscriptionUnpausedPayload stores the data for the UserSubscriptionUnpaused event
+type UserSubscriptionUnpausedPayload struct {
+	UserID                 entities.UserID           `json:"user_id"`
+	SubscriptionUnpausedAt time.Time                 `json:"subscription_unpaused_at"`
+	SubscriptionUpdatedAt  time.Time                 `json:"subscription_updated_at"`
+	SubscriptionID         string                    `json:"subscription_id"`
+	SubscriptionName       entities.SubscriptionName `json:"subscription_name"`
+	SubscriptionRenewsAt   time.Time                 `json:"subscription_renews_at"`
+	SubscriptionStatus     string                    `json:"subscription_status"`
+}
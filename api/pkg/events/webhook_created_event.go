@@ -0,0 +1,22 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WebhookCreated is raised when a user adds a webhook to a project
+const WebhookCreated = "webhook.created"
+
+// WebhookCreatedPayload stores the data for the WebhookCreated event
+type WebhookCreatedPayload struct {
+	UserID           entities.UserID `json:"user_id"`
+	ProjectID        uuid.UUID       `json:"project_id"`
+	WebhookID        uuid.UUID       `json:"webhook_id"`
+	WebhookURL       string          `json:"webhook_url"`
+	WebhookEvents    []string        `json:"webhook_events"`
+	WebhookCreatedAt time.Time       `json:"webhook_created_at"`
+}
@@ -0,0 +1,21 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WebhookDeleted is raised when a user removes a webhook from a project
+const WebhookDeleted = "webhook.deleted"
+
+// WebhookDeletedPayload stores the data for the WebhookDeleted event
+type WebhookDeletedPayload struct {
+	UserID           entities.UserID `json:"user_id"`
+	ProjectID        uuid.UUID       `json:"project_id"`
+	WebhookID        uuid.UUID       `json:"webhook_id"`
+	WebhookURL       string          `json:"webhook_url"`
+	WebhookDeletedAt time.Time       `json:"webhook_deleted_at"`
+}
@@ -0,0 +1,22 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WebhookDisabled is raised when a webhook is disabled because too many deliveries in a row failed
+const WebhookDisabled = "webhook.disabled"
+
+// WebhookDisabledPayload stores the data for the WebhookDisabled event
+type WebhookDisabledPayload struct {
+	UserID              entities.UserID `json:"user_id"`
+	ProjectID           uuid.UUID       `json:"project_id"`
+	WebhookID           uuid.UUID       `json:"webhook_id"`
+	WebhookURL          string          `json:"webhook_url"`
+	ConsecutiveFailures uint            `json:"consecutive_failures"`
+	WebhookDisabledAt   time.Time       `json:"webhook_disabled_at"`
+}
@@ -0,0 +1,20 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WebhookTest is sent to a webhook when a user tests it. It is not dispatched to the event listeners.
+const WebhookTest = "webhook.test"
+
+// WebhookTestPayload stores the data for the WebhookTest event
+type WebhookTestPayload struct {
+	UserID    entities.UserID `json:"user_id"`
+	ProjectID uuid.UUID       `json:"project_id"`
+	WebhookID uuid.UUID       `json:"webhook_id"`
+	SentAt    time.Time       `json:"sent_at"`
+}
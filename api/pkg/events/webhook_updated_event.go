@@ -0,0 +1,23 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WebhookUpdated is raised when a user changes the URL, events or status of a webhook
+const WebhookUpdated = "webhook.updated"
+
+// WebhookUpdatedPayload stores the data for the WebhookUpdated event
+type WebhookUpdatedPayload struct {
+	UserID           entities.UserID `json:"user_id"`
+	ProjectID        uuid.UUID       `json:"project_id"`
+	WebhookID        uuid.UUID       `json:"webhook_id"`
+	WebhookURL       string          `json:"webhook_url"`
+	WebhookEvents    []string        `json:"webhook_events"`
+	WebhookEnabled   bool            `json:"webhook_enabled"`
+	WebhookUpdatedAt time.Time       `json:"webhook_updated_at"`
+}
@@ -0,0 +1,20 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WidgetEventsRecorded is raised when a batch of widget events is recorded for a project
+const WidgetEventsRecorded = "widget.events.recorded"
+
+// WidgetEventsRecordedPayload stores the data for the WidgetEventsRecorded event
+type WidgetEventsRecordedPayload struct {
+	UserID     entities.UserID                  `json:"user_id"`
+	ProjectID  uuid.UUID                        `json:"project_id"`
+	Counts     map[entities.WidgetEventType]int `json:"counts"`
+	RecordedAt time.Time                        `json:"recorded_at"`
+}
@@ -0,0 +1,21 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WorkspaceCreated is raised when a workspace is created
+const WorkspaceCreated = "workspace.created"
+
+// WorkspaceCreatedPayload stores the data for the WorkspaceCreated event
+type WorkspaceCreatedPayload struct {
+	UserID             entities.UserID `json:"user_id"`
+	WorkspaceID        uuid.UUID       `json:"workspace_id"`
+	WorkspaceName      string          `json:"workspace_name"`
+	WorkspacePersonal  bool            `json:"workspace_personal"`
+	WorkspaceCreatedAt time.Time       `json:"workspace_created_at"`
+}
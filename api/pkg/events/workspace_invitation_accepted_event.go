@@ -0,0 +1,22 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WorkspaceInvitationAccepted is raised when a user joins a workspace with an invitation
+const WorkspaceInvitationAccepted = "workspace.invitation.accepted"
+
+// WorkspaceInvitationAcceptedPayload stores the data for the WorkspaceInvitationAccepted event
+type WorkspaceInvitationAcceptedPayload struct {
+	WorkspaceID          uuid.UUID              `json:"workspace_id"`
+	InvitationID         uuid.UUID              `json:"invitation_id"`
+	UserID               entities.UserID        `json:"user_id"`
+	InvitationEmail      string                 `json:"invitation_email"`
+	InvitationRole       entities.WorkspaceRole `json:"invitation_role"`
+	InvitationAcceptedAt time.Time              `json:"invitation_accepted_at"`
+}
@@ -0,0 +1,23 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WorkspaceInvitationCreated is raised when an email address is invited to join a workspace
+const WorkspaceInvitationCreated = "workspace.invitation.created"
+
+// WorkspaceInvitationCreatedPayload stores the data for the WorkspaceInvitationCreated event
+type WorkspaceInvitationCreatedPayload struct {
+	WorkspaceID         uuid.UUID              `json:"workspace_id"`
+	InvitationID        uuid.UUID              `json:"invitation_id"`
+	InvitationEmail     string                 `json:"invitation_email"`
+	InvitationRole      entities.WorkspaceRole `json:"invitation_role"`
+	InvitedBy           entities.UserID        `json:"invited_by"`
+	InvitationExpiresAt time.Time              `json:"invitation_expires_at"`
+	InvitationCreatedAt time.Time              `json:"invitation_created_at"`
+}
@@ -0,0 +1,21 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WorkspaceInvitationRevoked is raised when a pending invitation to a workspace is cancelled
+const WorkspaceInvitationRevoked = "workspace.invitation.revoked"
+
+// WorkspaceInvitationRevokedPayload stores the data for the WorkspaceInvitationRevoked event
+type WorkspaceInvitationRevokedPayload struct {
+	WorkspaceID         uuid.UUID       `json:"workspace_id"`
+	InvitationID        uuid.UUID       `json:"invitation_id"`
+	InvitationEmail     string          `json:"invitation_email"`
+	RevokedBy           entities.UserID `json:"revoked_by"`
+	InvitationRevokedAt time.Time       `json:"invitation_revoked_at"`
+}
@@ -0,0 +1,22 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WorkspaceMemberAdded is raised when a user is added to a workspace
+const WorkspaceMemberAdded = "workspace.member.added"
+
+// WorkspaceMemberAddedPayload stores the data for the WorkspaceMemberAdded event
+type WorkspaceMemberAddedPayload struct {
+	WorkspaceID   uuid.UUID              `json:"workspace_id"`
+	UserID        entities.UserID        `json:"user_id"`
+	MemberEmail   string                 `json:"member_email"`
+	MemberRole    entities.WorkspaceRole `json:"member_role"`
+	AddedBy       entities.UserID        `json:"added_by"`
+	MemberAddedAt time.Time              `json:"member_added_at"`
+}
@@ -0,0 +1,21 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WorkspaceMemberRemoved is raised when a user is removed from a workspace or leaves it
+const WorkspaceMemberRemoved = "workspace.member.removed"
+
+// WorkspaceMemberRemovedPayload stores the data for the WorkspaceMemberRemoved event
+type WorkspaceMemberRemovedPayload struct {
+	WorkspaceID     uuid.UUID              `json:"workspace_id"`
+	UserID          entities.UserID        `json:"user_id"`
+	MemberRole      entities.WorkspaceRole `json:"member_role"`
+	RemovedBy       entities.UserID        `json:"removed_by"`
+	MemberRemovedAt time.Time              `json:"member_removed_at"`
+}
@@ -0,0 +1,22 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WorkspaceMemberRoleUpdated is raised when the role of a member of a workspace is changed
+const WorkspaceMemberRoleUpdated = "workspace.member.role-updated"
+
+// WorkspaceMemberRoleUpdatedPayload stores the data for the WorkspaceMemberRoleUpdated event
+type WorkspaceMemberRoleUpdatedPayload struct {
+	WorkspaceID         uuid.UUID              `json:"workspace_id"`
+	UserID              entities.UserID        `json:"user_id"`
+	PreviousRole        entities.WorkspaceRole `json:"previous_role"`
+	MemberRole          entities.WorkspaceRole `json:"member_role"`
+	UpdatedBy           entities.UserID        `json:"updated_by"`
+	MemberRoleUpdatedAt time.Time              `json:"member_role_updated_at"`
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/NdoleStudio/superbutton/pkg/validators"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// APITokenHandler handles /api-tokens http requests.
+type APITokenHandler struct {
+	handler
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	validator *validators.APITokenHandlerValidator
+	service   *services.APITokenService
+}
+
+// NewAPITokenHandler creates a new APITokenHandler
+func NewAPITokenHandler(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	validator *validators.APITokenHandlerValidator,
+	service *services.APITokenService,
+) (h *APITokenHandler) {
+	return &APITokenHandler{
+		logger:    logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:    tracer,
+		validator: validator,
+		service:   service,
+	}
+}
+
+// RegisterRoutes registers the routes for the APITokenHandler
+func (h *APITokenHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	router := app.Group("/v1/api-tokens")
+	router.Get("/", h.computeRoute(middlewares, h.index)...)
+	router.Post("/", h.computeRoute(middlewares, h.create)...)
+	router.Delete("/:tokenID", h.computeRoute(middlewares, h.delete)...)
+}
+
+// @Summary      List of API tokens
+// @Description  Fetches the API tokens of the currently authenticated user which are not revoked. The tokens themselves are never returned.
+// @Security	 BearerAuth
+// @Tags         APITokens
+// @Produce      json
+// @Success      200 		{object}	responses.Ok[[]entities.APIToken]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      403		{object}	responses.Forbidden
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /api-tokens [get]
+func (h *APITokenHandler) index(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	authUser := h.userFromContext(c)
+
+	tokens, err := h.service.Index(ctx, authUser.ID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch api tokens for user with ID [%s]", authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "api tokens fetched successfully", tokens)
+}
+
+// @Summary      Create an API token
+// @Description  This endpoint creates an API token which can be used as a bearer token in scripts. The token is only returned in this response.
+// @Security	 BearerAuth
+// @Tags         APITokens
+// @Accept       json
+// @Produce      json
+// @Param        payload   body 		requests.APITokenCreateRequest  true  "API token payload"
+// @Success      200 		{object}	responses.Ok[entities.APIToken]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      403		{object}	responses.Forbidden
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /api-tokens [post]
+func (h *APITokenHandler) create(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.APITokenCreateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	if errors := h.validator.ValidateCreate(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while creating api token with request [%s]", spew.Sdump(errors), c.Body())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while creating api token")
+	}
+
+	authUser := h.userFromContext(c)
+	token, err := h.service.Create(ctx, request.ToCreateParams(c.OriginalURL(), authUser.ID))
+	if err != nil {
+		msg := fmt.Sprintf("cannot create api token for user with ID [%s]", authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "api token created successfully", token)
+}
+
+// @Summary      Revoke an API token
+// @Description  This endpoint revokes an API token so that it can no longer be used
+// @Security	 BearerAuth
+// @Tags         APITokens
+// @Produce      json
+// @Param 		 tokenID	path 		string true "API token ID"
+// @Success      200 		{object}	responses.NoContent
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      403		{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /api-tokens/{tokenID} [delete]
+func (h *APITokenHandler) delete(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "tokenID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while revoking api token with url [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while revoking api token")
+	}
+
+	authUser := h.userFromContext(c)
+	tokenID := uuid.MustParse(c.Params("tokenID"))
+
+	err := h.service.Revoke(ctx, &services.APITokenRevokeParams{
+		Source:  c.OriginalURL(),
+		UserID:  authUser.ID,
+		TokenID: tokenID,
+	})
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find api token [%s] for user [%s]", tokenID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot revoke api token [%s] for user with ID [%s]", tokenID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseNoContent(c, "api token revoked successfully")
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/davecgh/go-spew/spew"
+
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/NdoleStudio/superbutton/pkg/validators"
+	"github.com/gofiber/fiber/v2"
+	"github.com/palantir/stacktrace"
+)
+
+// ContactFormSubmissionHandler handles contact form submission http requests.
+type ContactFormSubmissionHandler struct {
+	handler
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	validator *validators.ContactFormSubmissionHandlerValidator
+	service   *services.ContactFormSubmissionService
+}
+
+// NewContactFormSubmissionHandler creates a new ContactFormSubmissionHandler
+func NewContactFormSubmissionHandler(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	validator *validators.ContactFormSubmissionHandlerValidator,
+	service *services.ContactFormSubmissionService,
+) (h *ContactFormSubmissionHandler) {
+	return &ContactFormSubmissionHandler{
+		logger:    logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:    tracer,
+		validator: validator,
+		service:   service,
+	}
+}
+
+// RegisterRoutes registers the routes for the ContactFormSubmissionHandler.
+// Website visitors submit the form without authentication so the publicMiddlewares should rate limit the requests.
+func (h *ContactFormSubmissionHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Handler, publicMiddlewares []fiber.Handler) {
+	router := app.Group("/v1/projects/:projectID/contact-form-integrations")
+	router.Get("/:integrationID/submissions", h.computeRoute(middlewares, h.indexSubmissions)...)
+	router.Post("/:integrationID/submissions", h.computeRoute(publicMiddlewares, h.submit)...)
+}
+
+// @Summary      List contact form submissions
+// @Description  Fetches the messages which website visitors sent through a contact form integration
+// @Security	 BearerAuth
+// @Tags         ContactFormSubmission
+// @Produce      json
+// @Param 		 projectID		path 		string true "Project ID"
+// @Param 		 integrationID	path 		string true "Integration ID"
+// @Param        skip			query  		int  	false	"number of submissions to skip"		minimum(0)
+// @Param        query			query  		string  false 	"filter submissions containing query"
+// @Param        limit			query  		int  	false 	"number of submissions to return"	minimum(1)	maximum(100)
+// @Success      200 			{object}	responses.Ok[[]entities.ContactFormSubmission]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/contact-form-integrations/{integrationID}/submissions [get]
+func (h *ContactFormSubmissionHandler) indexSubmissions(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.ContactFormSubmissionIndexRequest
+	if err := c.QueryParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+	request.ProjectID = c.Params("projectID")
+	request.IntegrationID = c.Params("integrationID")
+
+	if errors := h.validator.ValidateIndexSubmissions(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while fetching contact form submissions with request [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while fetching contact form submissions")
+	}
+
+	authUser := h.userFromContext(c)
+	projectID := uuid.MustParse(request.ProjectID)
+	integrationID := uuid.MustParse(request.IntegrationID)
+
+	submissions, err := h.service.IndexSubmissions(ctx, authUser.ID, projectID, integrationID, request.ToIndexParams())
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find contact form integration with id [%s] in project [%s] for user [%s]", integrationID, projectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch submissions for contact form integration [%s] and user with ID [%s]", integrationID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, fmt.Sprintf("fetched %d contact form %s", len(submissions), h.pluralize("submission", len(submissions))), submissions)
+}
+
+// @Summary      Submit a contact form
+// @Description  This endpoint is used by website visitors to send a message through a contact form integration
+// @Tags         ContactFormSubmission
+// @Accept       json
+// @Produce      json
+// @Param 		 projectID		path 		string true "Project ID"
+// @Param 		 integrationID	path 		string true "Integration ID"
+// @Param        payload		body 		requests.ContactFormSubmissionCreateRequest	true 	"contact form submission payload"
+// @Success      200 			{object}	responses.Ok[entities.ContactFormSubmission]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 403    		{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/contact-form-integrations/{integrationID}/submissions [post]
+func (h *ContactFormSubmissionHandler) submit(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "projectID"), h.validateUUID(c, "integrationID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while submitting contact form with URL [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while submitting contact form")
+	}
+
+	var request requests.ContactFormSubmissionCreateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	projectID := uuid.MustParse(c.Params("projectID"))
+	integrationID := uuid.MustParse(c.Params("integrationID"))
+
+	origin := h.requestOrigin(c)
+	integration, err := h.service.GetEnabled(ctx, projectID, integrationID, origin)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find contact form integration with id [%s] for project [%s]", integrationID, projectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeOriginNotAllowed {
+		msg := fmt.Sprintf("origin [%s] is not allowed to submit contact form integration [%s]", origin, integrationID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseOriginNotAllowed(c, msg)
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load contact form integration [%s] for project [%s]", integrationID, projectID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	if errors := h.validator.ValidateSubmit(ctx, request.Sanitize(), integration); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while submitting contact form with request [%s]", spew.Sdump(errors), c.Body())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while submitting contact form")
+	}
+
+	submission, err := h.service.Submit(ctx, request.ToSubmitParams(c.OriginalURL(), integration))
+	if err != nil {
+		msg := fmt.Sprintf("cannot submit contact form integration [%s] for project [%s]", integrationID, projectID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "contact form submitted successfully", submission)
+}
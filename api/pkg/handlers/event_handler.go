@@ -2,11 +2,18 @@ package handlers
 
 import (
 	"fmt"
+	"strconv"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/google/uuid"
 
+	"github.com/NdoleStudio/superbutton/pkg/queue"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
 	"github.com/NdoleStudio/superbutton/pkg/services"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/NdoleStudio/superbutton/pkg/validators"
 	"github.com/gofiber/fiber/v2"
 	"github.com/palantir/stacktrace"
 )
@@ -14,21 +21,24 @@ import (
 // EventsHandler handles heartbeat http requests.
 type EventsHandler struct {
 	handler
-	logger  telemetry.Logger
-	tracer  telemetry.Tracer
-	service *services.EventDispatcher
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	validator *validators.EventsHandlerValidator
+	service   *services.EventDispatcher
 }
 
 // NewEventsHandler creates a new EventsHandler
 func NewEventsHandler(
 	logger telemetry.Logger,
 	tracer telemetry.Tracer,
+	validator *validators.EventsHandlerValidator,
 	service *services.EventDispatcher,
 ) (h *EventsHandler) {
 	return &EventsHandler{
-		logger:  logger.WithService(fmt.Sprintf("%T", h)),
-		tracer:  tracer,
-		service: service,
+		logger:    logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:    tracer,
+		validator: validator,
+		service:   service,
 	}
 }
 
@@ -38,6 +48,13 @@ func (h *EventsHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Handl
 	router.Post("/consume", h.computeRoute(middlewares, h.Consume)...)
 }
 
+// RegisterAdminRoutes registers the admin routes for the EventsHandler
+func (h *EventsHandler) RegisterAdminRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	router := app.Group("/v1/admin/events")
+	router.Get("/dead-letters", h.computeRoute(middlewares, h.indexDeadLetters)...)
+	router.Post("/dead-letters/:executionID/replay", h.computeRoute(middlewares, h.replayDeadLetter)...)
+}
+
 // Consume a cloudevents.Event
 // @Summary      Consume a cloud event
 // @Description  Publish a cloud event to the registered listeners
@@ -71,7 +88,124 @@ func (h *EventsHandler) Consume(c *fiber.Ctx) error {
 		return h.responseUnprocessableEntity(c, map[string][]string{"event": {err.Error()}}, "validation errors while consuming event")
 	}
 
-	h.service.Publish(ctx, request)
+	lastAttempt := h.isLastAttempt(c)
+	if lastAttempt {
+		ctx = queue.WithLastAttempt(ctx)
+	}
+
+	if err := h.service.Publish(ctx, request); err != nil {
+		msg := fmt.Sprintf("cannot consume event [%s] with ID [%s]", request.Type(), request.ID())
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		if lastAttempt {
+			return h.responseNoContent(c, "event could not be consumed on the last attempt")
+		}
+		return h.responseInternalServerError(c)
+	}
 
 	return h.responseNoContent(c, "event consumed successfully")
 }
+
+// @Summary      List dead-lettered events
+// @Description  Fetches the listener executions which failed on every delivery of an event
+// @Security	 BearerAuth
+// @Tags         Events
+// @Produce      json
+// @Param        skip		query  		int  	false	"number of dead letters to skip"		minimum(0)
+// @Param        query		query  		string  false 	"filter dead letters by event type, event ID or listener"
+// @Param        limit		query  		int  	false 	"number of dead letters to return"		minimum(1)	maximum(100)
+// @Success      200 		{object}	responses.Ok[[]entities.EventListenerExecution]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure 	 403    	{object}	responses.Forbidden
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /admin/events/dead-letters [get]
+func (h *EventsHandler) indexDeadLetters(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.EventDeadLetterIndexRequest
+	if err := c.QueryParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	if errors := h.validator.ValidateIndexDeadLetters(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while fetching dead letters with request [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while fetching dead letters")
+	}
+
+	executions, err := h.service.DeadLetters(ctx, request.ToIndexParams())
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch dead letters with request [%s]", c.OriginalURL())
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, fmt.Sprintf("fetched %d %s", len(executions), h.pluralize("dead letter", len(executions))), executions)
+}
+
+// @Summary      Replay a dead-lettered event
+// @Description  Runs the listener of a dead-lettered event again and returns the outcome
+// @Security	 BearerAuth
+// @Tags         Events
+// @Produce      json
+// @Param 		 executionID	path 		string true "Listener execution ID"
+// @Success      200 			{object}	responses.Ok[entities.EventListenerExecution]
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure 	 403    		{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /admin/events/dead-letters/{executionID}/replay [post]
+func (h *EventsHandler) replayDeadLetter(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.validateUUID(c, "executionID"); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while replaying dead letter [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while replaying dead letter")
+	}
+
+	executionID := uuid.MustParse(c.Params("executionID"))
+
+	execution, err := h.service.Replay(ctx, executionID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find dead letter with ID [%s]", executionID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeEventNotDeadLettered {
+		msg := fmt.Sprintf("listener execution with ID [%s] is not dead-lettered", executionID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseUnprocessableEntity(c, map[string][]string{"executionID": {msg}}, "validation errors while replaying dead letter")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot replay dead letter with ID [%s]", executionID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, fmt.Sprintf("dead letter replayed with status [%s]", execution.Status), execution)
+}
+
+// isLastAttempt checks if Cloud Tasks will not retry the task when the request fails.
+// Cloud Tasks only supports retry policies per queue so the per-task limit is sent in a header.
+func (h *EventsHandler) isLastAttempt(c *fiber.Ctx) bool {
+	maxAttempts, err := strconv.Atoi(c.Get(queue.HeaderMaxAttempts))
+	if err != nil {
+		return false
+	}
+
+	retryCount, err := strconv.Atoi(c.Get(queue.HeaderCloudTasksRetryCount))
+	if err != nil {
+		return false
+	}
+
+	return retryCount+1 >= maxAttempts
+}
@@ -42,12 +42,37 @@ func (h *handler) responseInternalServerError(c *fiber.Ctx) error {
 //	})
 //}
 
-//func (h *handler) responseForbidden(c *fiber.Ctx) error {
-//	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-//		"status":  "error",
-//		"message": fiber.ErrForbidden.Message,
-//	})
-//}
+func (h *handler) responsePlanLimitReached(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{
+		"status":  "error",
+		"code":    "plan_limit_reached",
+		"message": message,
+	})
+}
+
+func (h *handler) responsePlanFeatureUnavailable(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"status":  "error",
+		"code":    "plan_feature_unavailable",
+		"message": message,
+	})
+}
+
+func (h *handler) responseForbidden(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"status":  "error",
+		"code":    "forbidden",
+		"message": message,
+	})
+}
+
+func (h *handler) responseOriginNotAllowed(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"status":  "error",
+		"code":    "origin_not_allowed",
+		"message": message,
+	})
+}
 
 func (h *handler) responseUnprocessableEntity(c *fiber.Ctx, errors url.Values, message string) error {
 	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
@@ -111,12 +136,12 @@ func (h *handler) validateUUID(c *fiber.Ctx, param string) url.Values {
 //	})
 //}
 
-//func (h *handler) pluralize(value string, count int) string {
-//	if count == 1 {
-//		return value
-//	}
-//	return value + "s"
-//}
+func (h *handler) pluralize(value string, count int) string {
+	if count == 1 {
+		return value
+	}
+	return value + "s"
+}
 
 func (h *handler) userFromContext(c *fiber.Ctx) entities.AuthUser {
 	if tokenUser, ok := c.Locals(middlewares.ContextKeyAuthUserID).(entities.AuthUser); ok && !tokenUser.IsNoop() {
@@ -128,3 +153,16 @@ func (h *handler) userFromContext(c *fiber.Ctx) entities.AuthUser {
 func (h *handler) userIDFomContext(c *fiber.Ctx) entities.UserID {
 	return h.userFromContext(c).ID
 }
+
+// requestOrigin returns the Origin header or the origin of the Referer header when the browser did not send an Origin
+func (h *handler) requestOrigin(c *fiber.Ctx) string {
+	if origin := c.Get(fiber.HeaderOrigin); origin != "" {
+		return origin
+	}
+
+	referer, err := url.Parse(c.Get(fiber.HeaderReferer))
+	if err != nil || referer.Host == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s://%s", referer.Scheme, referer.Host)
+}
@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/davecgh/go-spew/spew"
+
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/NdoleStudio/superbutton/pkg/validators"
+	"github.com/gofiber/fiber/v2"
+	"github.com/palantir/stacktrace"
+)
+
+// IntegrationHandler handles the http requests of every integration type in the services.IntegrationRegistry
+type IntegrationHandler struct {
+	handler
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	validator *validators.IntegrationHandlerValidator
+	registry  *services.IntegrationRegistry
+}
+
+// NewIntegrationHandler creates a new IntegrationHandler
+func NewIntegrationHandler(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	validator *validators.IntegrationHandlerValidator,
+	registry *services.IntegrationRegistry,
+) (h *IntegrationHandler) {
+	return &IntegrationHandler{
+		logger:    logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:    tracer,
+		validator: validator,
+		registry:  registry,
+	}
+}
+
+// RegisterRoutes registers the /v1/projects/:projectID/{type}-integrations routes for every registered integration
+func (h *IntegrationHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	for _, integration := range h.registry.All() {
+		router := app.Group(fmt.Sprintf("/v1/projects/:projectID/%s-integrations", integration.Type()))
+		router.Post("/", h.computeRoute(middlewares, h.create(integration))...)
+		router.Get("/:integrationID", h.computeRoute(middlewares, h.show(integration))...)
+		router.Put("/:integrationID", h.computeRoute(middlewares, h.update(integration))...)
+		router.Delete("/:integrationID", h.computeRoute(middlewares, h.delete(integration))...)
+	}
+}
+
+// @Summary      Get an integration
+// @Description  Fetches a specific integration of a project
+// @Security	 BearerAuth
+// @Tags         Integration
+// @Produce      json
+// @Param 		 projectID			path 		string true "Project ID"
+// @Param 		 integrationType	path 		string true "Integration type" Enums(whatsapp, phone-call, link, content, contact-form)
+// @Param 		 integrationID		path 		string true "Integration ID"
+// @Success      200 				{object}	responses.Ok[any]
+// @Failure      400				{object}	responses.BadRequest
+// @Failure 	 401    			{object}	responses.Unauthorized
+// @Failure 	 404    			{object}	responses.NotFound
+// @Failure      422				{object}	responses.UnprocessableEntity
+// @Failure      500				{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/{integrationType}-integrations/{integrationID} 	[get]
+func (h *IntegrationHandler) show(integration services.RegisteredIntegration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+		defer span.End()
+
+		if errors := h.mergeErrors(h.validateUUID(c, "projectID"), h.validateUUID(c, "integrationID")); len(errors) != 0 {
+			msg := fmt.Sprintf("validation errors [%s], while fetching [%s] integration with URL [%s]", spew.Sdump(errors), integration.Type(), c.OriginalURL())
+			ctxLogger.Warn(stacktrace.NewError(msg))
+			return h.responseUnprocessableEntity(c, errors, "validation errors while fetching integration")
+		}
+
+		integrationID := uuid.MustParse(c.Params("integrationID"))
+		projectID := uuid.MustParse(c.Params("projectID"))
+		authUser := h.userFromContext(c)
+
+		result, err := integration.Get(ctx, authUser.ID, projectID, integrationID)
+		if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+			msg := fmt.Sprintf("cannot find [%s] integration with id [%s] in project [%s] for user [%s]", integration.Type(), integrationID, projectID, authUser.ID)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responseNotFound(c, msg)
+		}
+
+		if err != nil {
+			msg := fmt.Sprintf("cannot fetch [%s] intergration [%s] for user with ID [%s]", integration.Type(), integrationID, authUser.ID)
+			ctxLogger.Error(stacktrace.Propagate(err, msg))
+			return h.responseInternalServerError(c)
+		}
+
+		return h.responseOK(c, "integration fetched successfully", result)
+	}
+}
+
+// @Summary      Create an integration
+// @Description  This endpoint creates a new integration for a project. The payload depends on the integration type.
+// @Security	 BearerAuth
+// @Tags         Integration
+// @Accept       json
+// @Produce      json
+// @Param 		 projectID			path 		string true "Project ID"
+// @Param 		 integrationType	path 		string true "Integration type" Enums(whatsapp, phone-call, link, content, contact-form)
+// @Param        payload			body 		object	true 	"integration create payload"
+// @Success      200 				{object}	responses.Ok[any]
+// @Failure      400				{object}	responses.BadRequest
+// @Failure 	 401    			{object}	responses.Unauthorized
+// @Failure      402				{object}	responses.PaymentRequired
+// @Failure      403				{object}	responses.Forbidden
+// @Failure 	 404    			{object}	responses.NotFound
+// @Failure      422				{object}	responses.UnprocessableEntity
+// @Failure      500				{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/{integrationType}-integrations [post]
+func (h *IntegrationHandler) create(integration services.RegisteredIntegration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+		defer span.End()
+
+		request := integration.NewRequest()
+		if err := c.BodyParser(request); err != nil {
+			msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responseBadRequest(c, err)
+		}
+		request.Sanitize()
+
+		errors := h.mergeErrors(h.validateUUID(c, "projectID"), h.validator.ValidateRequest(ctx, integration, request))
+		if len(errors) != 0 {
+			msg := fmt.Sprintf("validation errors [%s], while creating [%s] integration with request [%s]", spew.Sdump(errors), integration.Type(), c.Body())
+			ctxLogger.Warn(stacktrace.NewError(msg))
+			return h.responseUnprocessableEntity(c, errors, "validation errors while creating integration")
+		}
+
+		authUser := h.userFromContext(c)
+		projectID := uuid.MustParse(c.Params("projectID"))
+
+		result, err := integration.Create(ctx, &services.IntegrationCreateParams{
+			Source:    c.OriginalURL(),
+			ProjectID: projectID,
+			UserID:    authUser.ID,
+			Request:   request,
+		})
+		if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+			msg := fmt.Sprintf("cannot find project with id [%s] for user [%s]", projectID, authUser.ID)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responseNotFound(c, msg)
+		}
+
+		if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+			msg := fmt.Sprintf("user [%s] cannot create [%s] integrations in project [%s]", authUser.ID, integration.Type(), projectID)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responseForbidden(c, "you do not have permission to edit this project")
+		}
+
+		if stacktrace.GetCode(err) == services.ErrCodePlanLimitReached {
+			msg := fmt.Sprintf("user with ID [%s] reached the integration limit of their plan for project [%s]", authUser.ID, projectID)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responsePlanLimitReached(c, "you have reached the maximum number of integrations per project on your plan")
+		}
+
+		if stacktrace.GetCode(err) == services.ErrCodePlanFeatureUnavailable {
+			msg := fmt.Sprintf("[%s] integrations are not available on the plan of user with ID [%s]", integration.Type(), authUser.ID)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responsePlanFeatureUnavailable(c, fmt.Sprintf("%s integrations are not available on your plan", integration.Type()))
+		}
+
+		if err != nil {
+			msg := fmt.Sprintf("cannot create [%s] integration for user with ID [%s]", integration.Type(), authUser.ID)
+			ctxLogger.Error(stacktrace.Propagate(err, msg))
+			return h.responseInternalServerError(c)
+		}
+
+		return h.responseOK(c, fmt.Sprintf("%s integration created successfully", integration.Type()), result)
+	}
+}
+
+// @Summary      Update an integration
+// @Description  This endpoint updates an integration of a project. The payload depends on the integration type.
+// @Security	 BearerAuth
+// @Tags         Integration
+// @Accept       json
+// @Produce      json
+// @Param 		 projectID			path 		string true "Project ID"
+// @Param 		 integrationType	path 		string true "Integration type" Enums(whatsapp, phone-call, link, content, contact-form)
+// @Param 		 integrationID		path 		string true "Integration ID"
+// @Param        payload			body 		object	true 	"integration update payload"
+// @Success      200 				{object}	responses.Ok[any]
+// @Failure      400				{object}	responses.BadRequest
+// @Failure 	 401    			{object}	responses.Unauthorized
+// @Failure      403				{object}	responses.Forbidden
+// @Failure 	 404    			{object}	responses.NotFound
+// @Failure      422				{object}	responses.UnprocessableEntity
+// @Failure      500				{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/{integrationType}-integrations/{integrationID} [put]
+func (h *IntegrationHandler) update(integration services.RegisteredIntegration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+		defer span.End()
+
+		request := integration.NewRequest()
+		if err := c.BodyParser(request); err != nil {
+			msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responseBadRequest(c, err)
+		}
+		request.Sanitize()
+
+		errors := h.mergeErrors(h.validateUUID(c, "projectID"), h.validateUUID(c, "integrationID"), h.validator.ValidateRequest(ctx, integration, request))
+		if len(errors) != 0 {
+			msg := fmt.Sprintf("validation errors [%s], while updating [%s] integration with request [%s]", spew.Sdump(errors), integration.Type(), c.Body())
+			ctxLogger.Warn(stacktrace.NewError(msg))
+			return h.responseUnprocessableEntity(c, errors, "validation errors while updating integration")
+		}
+
+		authUser := h.userFromContext(c)
+		integrationID := uuid.MustParse(c.Params("integrationID"))
+		projectID := uuid.MustParse(c.Params("projectID"))
+
+		result, err := integration.Update(ctx, &services.IntegrationUpdateParams{
+			Source:        c.OriginalURL(),
+			ProjectID:     projectID,
+			IntegrationID: integrationID,
+			UserID:        authUser.ID,
+			Request:       request,
+		})
+		if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+			msg := fmt.Sprintf("cannot find [%s] integration with id [%s] in project [%s] for user [%s]", integration.Type(), integrationID, projectID, authUser.ID)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responseNotFound(c, msg)
+		}
+
+		if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+			msg := fmt.Sprintf("user [%s] cannot update [%s] integration in project [%s]", authUser.ID, integration.Type(), projectID)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responseForbidden(c, "you do not have permission to edit this project")
+		}
+
+		if err != nil {
+			msg := fmt.Sprintf("cannot update [%s] integration [%s] for user with ID [%s]", integration.Type(), integrationID, authUser.ID)
+			ctxLogger.Error(stacktrace.Propagate(err, msg))
+			return h.responseInternalServerError(c)
+		}
+
+		return h.responseOK(c, "integration updated successfully", result)
+	}
+}
+
+// @Summary      Delete an integration
+// @Description  This endpoint deletes an integration of a project
+// @Security	 BearerAuth
+// @Tags         Integration
+// @Produce      json
+// @Param 		 projectID			path 		string true "Project ID"
+// @Param 		 integrationType	path 		string true "Integration type" Enums(whatsapp, phone-call, link, content, contact-form)
+// @Param 		 integrationID		path 		string true "Integration ID"
+// @Success      200 				{object}	responses.NoContent
+// @Failure      400				{object}	responses.BadRequest
+// @Failure 	 401    			{object}	responses.Unauthorized
+// @Failure      403				{object}	responses.Forbidden
+// @Failure 	 404    			{object}	responses.NotFound
+// @Failure      422				{object}	responses.UnprocessableEntity
+// @Failure      500				{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/{integrationType}-integrations/{integrationID} [delete]
+func (h *IntegrationHandler) delete(integration services.RegisteredIntegration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+		defer span.End()
+
+		if errors := h.mergeErrors(h.validateUUID(c, "projectID"), h.validateUUID(c, "integrationID")); len(errors) != 0 {
+			msg := fmt.Sprintf("validation errors [%s], while deleting [%s] integration with URL [%s]", spew.Sdump(errors), integration.Type(), c.OriginalURL())
+			ctxLogger.Warn(stacktrace.NewError(msg))
+			return h.responseUnprocessableEntity(c, errors, "validation errors while deleting integration")
+		}
+
+		authUser := h.userFromContext(c)
+		integrationID := uuid.MustParse(c.Params("integrationID"))
+		projectID := uuid.MustParse(c.Params("projectID"))
+
+		err := integration.Delete(ctx, &services.IntegrationDeleteParams{
+			Source:        c.OriginalURL(),
+			IntegrationID: integrationID,
+			ProjectID:     projectID,
+			UserID:        authUser.ID,
+		})
+		if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+			msg := fmt.Sprintf("cannot find [%s] integration with id [%s] in project [%s] for user [%s]", integration.Type(), integrationID, projectID, authUser.ID)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responseNotFound(c, msg)
+		}
+
+		if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+			msg := fmt.Sprintf("user [%s] cannot delete [%s] integration in project [%s]", authUser.ID, integration.Type(), projectID)
+			ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			return h.responseForbidden(c, "you do not have permission to edit this project")
+		}
+
+		if err != nil {
+			msg := fmt.Sprintf("cannot delete [%s] integration [%s] for user with ID [%s]", integration.Type(), integrationID, authUser.ID)
+			ctxLogger.Error(stacktrace.Propagate(err, msg))
+			return h.responseInternalServerError(c)
+		}
+
+		return h.responseNoContent(c, "integration deleted successfully")
+	}
+}
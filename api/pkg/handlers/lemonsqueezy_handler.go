@@ -81,20 +81,48 @@ func (h *LemonsqueezyHandler) handleRequest(ctx context.Context, c *fiber.Ctx) e
 	eventName := c.Get("X-Event-Name")
 	switch eventName {
 	case "subscription_created":
-		var request lemonsqueezy.WebHookRequestSubscription
-		err := json.Unmarshal(c.Body(), &request)
-		if err != nil {
-			return stacktrace.Propagate(err, fmt.Sprintf("cannot marshall [%s] to [%T]", c.Body(), request))
-		}
-		return h.service.HandleSubscriptionCreatedEvent(ctx, c.OriginalURL(), &request)
+		return h.handleSubscriptionRequest(ctx, c, h.service.HandleSubscriptionCreatedEvent)
 	case "subscription_cancelled":
-		var request lemonsqueezy.WebHookRequestSubscription
-		err := json.Unmarshal(c.Body(), &request)
-		if err != nil {
-			return stacktrace.Propagate(err, fmt.Sprintf("cannot marshall [%s] to [%T]", c.Body(), request))
-		}
-		return h.service.HandleSubscriptionCanceledEvent(ctx, c.OriginalURL(), &request)
+		return h.handleSubscriptionRequest(ctx, c, h.service.HandleSubscriptionCanceledEvent)
+	case "subscription_updated":
+		return h.handleSubscriptionRequest(ctx, c, h.service.HandleSubscriptionUpdatedEvent)
+	case "subscription_resumed":
+		return h.handleSubscriptionRequest(ctx, c, h.service.HandleSubscriptionResumedEvent)
+	case "subscription_expired":
+		return h.handleSubscriptionRequest(ctx, c, h.service.HandleSubscriptionExpiredEvent)
+	case "subscription_paused":
+		return h.handleSubscriptionRequest(ctx, c, h.service.HandleSubscriptionPausedEvent)
+	case "subscription_unpaused":
+		return h.handleSubscriptionRequest(ctx, c, h.service.HandleSubscriptionUnpausedEvent)
+	case "subscription_payment_failed":
+		return h.handleSubscriptionInvoiceRequest(ctx, c, h.service.HandleSubscriptionPaymentFailedEvent)
+	case "subscription_payment_success":
+		return h.handleSubscriptionInvoiceRequest(ctx, c, h.service.HandleSubscriptionPaymentSuccessEvent)
 	default:
 		return stacktrace.NewError(fmt.Sprintf("invalid event [%s] received with request [%s]", eventName, c.Body()))
 	}
 }
+
+func (h *LemonsqueezyHandler) handleSubscriptionRequest(
+	ctx context.Context,
+	c *fiber.Ctx,
+	handle func(ctx context.Context, source string, request *lemonsqueezy.WebHookRequestSubscription) error,
+) error {
+	var request lemonsqueezy.WebHookRequestSubscription
+	if err := json.Unmarshal(c.Body(), &request); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot marshall [%s] to [%T]", c.Body(), request))
+	}
+	return handle(ctx, c.OriginalURL(), &request)
+}
+
+func (h *LemonsqueezyHandler) handleSubscriptionInvoiceRequest(
+	ctx context.Context,
+	c *fiber.Ctx,
+	handle func(ctx context.Context, source string, request *services.LemonsqueezySubscriptionInvoiceRequest) error,
+) error {
+	var request services.LemonsqueezySubscriptionInvoiceRequest
+	if err := json.Unmarshal(c.Body(), &request); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot marshall [%s] to [%T]", c.Body(), request))
+	}
+	return handle(ctx, c.OriginalURL(), &request)
+}
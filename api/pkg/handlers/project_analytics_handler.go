@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/NdoleStudio/superbutton/pkg/validators"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/gofiber/fiber/v2"
+	"github.com/palantir/stacktrace"
+)
+
+// ProjectAnalyticsHandler handles project analytics http requests.
+type ProjectAnalyticsHandler struct {
+	handler
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	validator *validators.ProjectAnalyticsHandlerValidator
+	service   *services.WidgetAnalyticsService
+}
+
+// NewProjectAnalyticsHandler creates a new ProjectAnalyticsHandler
+func NewProjectAnalyticsHandler(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	validator *validators.ProjectAnalyticsHandlerValidator,
+	service *services.WidgetAnalyticsService,
+) (h *ProjectAnalyticsHandler) {
+	return &ProjectAnalyticsHandler{
+		logger:    logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:    tracer,
+		validator: validator,
+		service:   service,
+	}
+}
+
+// RegisterRoutes registers the routes for the ProjectAnalyticsHandler
+func (h *ProjectAnalyticsHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	router := app.Group("/v1/projects/:projectID/analytics")
+	router.Get("/", h.computeRoute(middlewares, h.get)...)
+}
+
+// @Summary      Get project analytics
+// @Description  Fetches the impressions, opens, clicks and click-through rate of a project and its integrations over a date range
+// @Security	 BearerAuth
+// @Tags         ProjectAnalytics
+// @Produce      json
+// @Param 		 projectID		path 		string 	true 	"Project ID"
+// @Param        from			query  		string  false	"first day of the range in the format YYYY-MM-DD. Defaults to 29 days before today"
+// @Param        to				query  		string  false 	"last day of the range in the format YYYY-MM-DD. Defaults to today"
+// @Param        granularity	query  		string  false 	"size of the buckets in the series" Enums(hour, day) default(day)
+// @Param        timezone		query  		string  false 	"IANA timezone of the dates and buckets" default(UTC)
+// @Success      200 			{object}	responses.Ok[entities.ProjectAnalytics]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/analytics [get]
+func (h *ProjectAnalyticsHandler) get(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.ProjectAnalyticsRequest
+	if err := c.QueryParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+	request.ProjectID = c.Params("projectID")
+
+	if errors := h.validator.ValidateGet(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while fetching project analytics with request [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while fetching project analytics")
+	}
+
+	authUser := h.userFromContext(c)
+	analytics, err := h.service.Get(ctx, request.ToParams(authUser.ID))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find project with id [%s] for user [%s]", request.ProjectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch analytics for project [%s] and user with ID [%s]", request.ProjectID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, fmt.Sprintf("fetched analytics for %d %s", len(analytics.Series), h.pluralize(string(analytics.Granularity), len(analytics.Series))), analytics)
+}
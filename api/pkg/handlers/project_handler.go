@@ -47,10 +47,12 @@ func (h *ProjectHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Hand
 	router.Post("/", h.computeRoute(middlewares, h.create)...)
 	router.Put("/:projectID", h.computeRoute(middlewares, h.update)...)
 	router.Delete("/:projectID", h.computeRoute(middlewares, h.delete)...)
+	router.Post("/:projectID/publishable-key", h.computeRoute(middlewares, h.rotatePublishableKey)...)
+	router.Delete("/:projectID/publishable-key", h.computeRoute(middlewares, h.revokePublishableKey)...)
 }
 
 // @Summary      List of projects
-// @Description  Fetches the list of all projects available to the currently authenticated user
+// @Description  Fetches the list of all projects in the workspaces of the currently authenticated user
 // @Security	 BearerAuth
 // @Tags         Projects
 // @Produce      json
@@ -65,7 +67,7 @@ func (h *ProjectHandler) index(c *fiber.Ctx) error {
 	defer span.End()
 
 	authUser := h.userFromContext(c)
-	projects, err := h.service.Index(ctx, authUser.ID)
+	projects, err := h.service.Index(ctx, c.OriginalURL(), authUser.ID)
 	if err != nil {
 		msg := fmt.Sprintf("cannot fetch projects for user with ID [%s]", authUser.ID)
 		ctxLogger.Error(stacktrace.Propagate(err, msg))
@@ -84,6 +86,8 @@ func (h *ProjectHandler) index(c *fiber.Ctx) error {
 // @Success      200 		{object}	responses.Ok[entities.Project]
 // @Failure      400		{object}	responses.BadRequest
 // @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      402		{object}	responses.PaymentRequired
+// @Failure      403		{object}	responses.Forbidden
 // @Failure      422		{object}	responses.UnprocessableEntity
 // @Failure      500		{object}	responses.InternalServerError
 // @Router       /projects 	[post]
@@ -106,6 +110,18 @@ func (h *ProjectHandler) create(c *fiber.Ctx) error {
 
 	authUser := h.userFromContext(c)
 	project, err := h.service.Create(ctx, request.ToProjectCreateParams(c.OriginalURL(), authUser.ID))
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user with ID [%s] cannot create projects in workspace [%s]", authUser.ID, request.WorkspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to create projects in this workspace")
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodePlanLimitReached {
+		msg := fmt.Sprintf("user with ID [%s] reached the project limit of their plan", authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responsePlanLimitReached(c, "you have reached the maximum number of projects on your plan")
+	}
+
 	if err != nil {
 		msg := fmt.Sprintf("cannot get user with ID [%s]", authUser.ID)
 		ctxLogger.Error(stacktrace.Propagate(err, msg))
@@ -125,6 +141,7 @@ func (h *ProjectHandler) create(c *fiber.Ctx) error {
 // @Success      200 		{object}	responses.Ok[entities.Project]
 // @Failure      400		{object}	responses.BadRequest
 // @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      403		{object}	responses.Forbidden
 // @Failure      422		{object}	responses.UnprocessableEntity
 // @Failure      500		{object}	responses.InternalServerError
 // @Router       /projects/{projectID} 	[put]
@@ -154,6 +171,12 @@ func (h *ProjectHandler) update(c *fiber.Ctx) error {
 		return h.responseNotFound(c, msg)
 	}
 
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot update project [%s]", authUser.ID, request.ProjectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to update this project")
+	}
+
 	if err != nil {
 		msg := fmt.Sprintf("cannot update project [%s] user with ID [%s]", request.ProjectID, authUser.ID)
 		ctxLogger.Error(stacktrace.Propagate(err, msg))
@@ -172,6 +195,7 @@ func (h *ProjectHandler) update(c *fiber.Ctx) error {
 // @Success      200 		{object}	responses.NoContent
 // @Failure      400		{object}	responses.BadRequest
 // @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      403		{object}	responses.Forbidden
 // @Failure 	 404    	{object}	responses.NotFound
 // @Failure      422		{object}	responses.UnprocessableEntity
 // @Failure      500		{object}	responses.InternalServerError
@@ -196,6 +220,12 @@ func (h *ProjectHandler) delete(c *fiber.Ctx) error {
 		return h.responseNotFound(c, msg)
 	}
 
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot delete project [%s]", authUser.ID, projectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to delete this project")
+	}
+
 	if err != nil {
 		msg := fmt.Sprintf("cannot delete project [%s] for user with ID [%s]", projectID, authUser.ID)
 		ctxLogger.Error(stacktrace.Propagate(err, msg))
@@ -204,3 +234,101 @@ func (h *ProjectHandler) delete(c *fiber.Ctx) error {
 
 	return h.responseNoContent(c, "project deleted successfully")
 }
+
+// @Summary      Rotate the publishable key of a project
+// @Description  This endpoint generates a new publishable key for a project. The previous key stops working immediately.
+// @Security	 BearerAuth
+// @Tags         Projects
+// @Produce      json
+// @Param 		 projectID	path 		string true "Project ID"
+// @Success      200 		{object}	responses.Ok[entities.Project]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      403		{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/publishable-key [post]
+func (h *ProjectHandler) rotatePublishableKey(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "projectID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while rotating publishable key with url [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while rotating publishable key")
+	}
+
+	authUser := h.userFromContext(c)
+	projectID := uuid.MustParse(c.Params("projectID"))
+
+	project, err := h.service.RotatePublishableKey(ctx, c.OriginalURL(), authUser.ID, projectID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find project with id [%s] for user [%s]", projectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot rotate the publishable key of project [%s]", authUser.ID, projectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to manage the publishable key of this project")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot rotate publishable key of project [%s] for user with ID [%s]", projectID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "publishable key rotated successfully", project)
+}
+
+// @Summary      Revoke the publishable key of a project
+// @Description  This endpoint removes the publishable key of a project so that the widget can no longer load its settings
+// @Security	 BearerAuth
+// @Tags         Projects
+// @Produce      json
+// @Param 		 projectID	path 		string true "Project ID"
+// @Success      200 		{object}	responses.Ok[entities.Project]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      403		{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/publishable-key [delete]
+func (h *ProjectHandler) revokePublishableKey(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "projectID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while revoking publishable key with url [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while revoking publishable key")
+	}
+
+	authUser := h.userFromContext(c)
+	projectID := uuid.MustParse(c.Params("projectID"))
+
+	project, err := h.service.RevokePublishableKey(ctx, c.OriginalURL(), authUser.ID, projectID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find project with id [%s] for user [%s]", projectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot revoke the publishable key of project [%s]", authUser.ID, projectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to manage the publishable key of this project")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot revoke publishable key of project [%s] for user with ID [%s]", projectID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "publishable key revoked successfully", project)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/NdoleStudio/superbutton/pkg/services"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/NdoleStudio/superbutton/pkg/validators"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -17,21 +18,24 @@ import (
 // ProjectIntegrationHandler handles /integration http requests.
 type ProjectIntegrationHandler struct {
 	handler
-	logger  telemetry.Logger
-	tracer  telemetry.Tracer
-	service *services.ProjectIntegrationService
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	validator *validators.ProjectIntegrationHandlerValidator
+	service   *services.ProjectIntegrationService
 }
 
-// NewIntegrationHandler creates a new ProjectIntegrationHandler
-func NewIntegrationHandler(
+// NewProjectIntegrationHandler creates a new ProjectIntegrationHandler
+func NewProjectIntegrationHandler(
 	logger telemetry.Logger,
 	tracer telemetry.Tracer,
+	validator *validators.ProjectIntegrationHandlerValidator,
 	service *services.ProjectIntegrationService,
 ) (h *ProjectIntegrationHandler) {
 	return &ProjectIntegrationHandler{
-		logger:  logger.WithService(fmt.Sprintf("%T", h)),
-		tracer:  tracer,
-		service: service,
+		logger:    logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:    tracer,
+		validator: validator,
+		service:   service,
 	}
 }
 
@@ -40,6 +44,7 @@ func (h *ProjectIntegrationHandler) RegisterRoutes(app *fiber.App, middlewares [
 	router := app.Group("/v1/projects/:projectID/integrations")
 	router.Get("/", h.computeRoute(middlewares, h.index)...)
 	router.Put("/", h.computeRoute(middlewares, h.update)...)
+	router.Put("/:integrationID/display-rules", h.computeRoute(middlewares, h.updateDisplayRules)...)
 }
 
 // @Summary      List of project integrations
@@ -51,6 +56,7 @@ func (h *ProjectIntegrationHandler) RegisterRoutes(app *fiber.App, middlewares [
 // @Success      200 		{object}	responses.Ok[[]entities.ProjectIntegration]
 // @Failure      400		{object}	responses.BadRequest
 // @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure 	 404    	{object}	responses.NotFound
 // @Failure      422		{object}	responses.UnprocessableEntity
 // @Failure      500		{object}	responses.InternalServerError
 // @Router       /projects/{projectID}/integrations 	[get]
@@ -66,6 +72,12 @@ func (h *ProjectIntegrationHandler) index(c *fiber.Ctx) error {
 
 	authUser := h.userFromContext(c)
 	projects, err := h.service.Index(ctx, authUser.ID, uuid.MustParse(c.Params("projectID")))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find project with id [%s] for user [%s]", c.Params("projectID"), authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
 	if err != nil {
 		msg := fmt.Sprintf("cannot fetch projects intergrations for user with ID [%s]", authUser.ID)
 		ctxLogger.Error(stacktrace.Propagate(err, msg))
@@ -76,7 +88,7 @@ func (h *ProjectIntegrationHandler) index(c *fiber.Ctx) error {
 }
 
 // @Summary      Update project integrations
-// @Description  This endpoint updates project integrations for a user
+// @Description  This endpoint updates the order of the integrations in a project. The order must contain every integration of the project exactly once.
 // @Security	 BearerAuth
 // @Tags         ProjectIntegrations
 // @Produce      json
@@ -85,6 +97,8 @@ func (h *ProjectIntegrationHandler) index(c *fiber.Ctx) error {
 // @Success      200 		{object}	responses.NoContent
 // @Failure      400		{object}	responses.BadRequest
 // @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      403		{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
 // @Failure      422		{object}	responses.UnprocessableEntity
 // @Failure      500		{object}	responses.InternalServerError
 // @Router       /projects/{projectID}/integrations 	[put]
@@ -99,13 +113,31 @@ func (h *ProjectIntegrationHandler) update(c *fiber.Ctx) error {
 		return h.responseBadRequest(c, err)
 	}
 
-	err := h.service.Update(ctx, h.userIDFomContext(c), request.Order)
+	if errors := h.mergeErrors(h.validateUUID(c, "projectID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while updating integrations for project [%s]", spew.Sdump(errors), c.Params("projectID"))
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while updating integrations")
+	}
+
+	err := h.service.Update(ctx, c.OriginalURL(), h.userIDFomContext(c), uuid.MustParse(c.Params("projectID")), request.Order)
+	if stacktrace.GetCode(err) == services.ErrCodeIntegrationOrderMismatch {
+		msg := fmt.Sprintf("invalid order [%+#v] for project [%s] and user [%s]", request.Order, c.Params("projectID"), h.userIDFomContext(c))
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseUnprocessableEntity(c, map[string][]string{"order": {"the order must contain every integration of the project exactly once"}}, "validation errors while updating integrations")
+	}
+
 	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
 		msg := fmt.Sprintf("cannot find project with id [%s] for user [%s]", c.Params("projectID"), h.userIDFomContext(c))
 		ctxLogger.Warn(stacktrace.Propagate(err, msg))
 		return h.responseNotFound(c, msg)
 	}
 
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot reorder the integrations of project [%s]", h.userIDFomContext(c), c.Params("projectID"))
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to edit this project")
+	}
+
 	if err != nil {
 		msg := fmt.Sprintf("cannot update project [%s] integrations for  user with ID [%s]", c.Params("projectID"), h.userIDFomContext(c))
 		ctxLogger.Error(stacktrace.Propagate(err, msg))
@@ -114,3 +146,62 @@ func (h *ProjectIntegrationHandler) update(c *fiber.Ctx) error {
 
 	return h.responseNoContent(c, "project integrations updated successfully")
 }
+
+// @Summary      Update the display rules of an integration
+// @Description  This endpoint replaces the page, device and country rules which restrict where an integration is displayed. Send empty lists to display the integration everywhere.
+// @Security	 BearerAuth
+// @Tags         ProjectIntegrations
+// @Accept       json
+// @Produce      json
+// @Param 		 projectID		path 		string true "Project ID"
+// @Param 		 integrationID	path 		string true "Integration ID"
+// @Param        payload		body 		requests.ProjectIntegrationDisplayRulesUpdateRequest	true 	"display rules update payload"
+// @Success      200 			{object}	responses.Ok[entities.ProjectIntegration]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/integrations/{integrationID}/display-rules 	[put]
+func (h *ProjectIntegrationHandler) updateDisplayRules(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.ProjectIntegrationDisplayRulesUpdateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	request.ProjectID = c.Params("projectID")
+	request.IntegrationID = c.Params("integrationID")
+
+	if errors := h.validator.ValidateUpdateDisplayRules(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while updating display rules [%+#v]", spew.Sdump(errors), request)
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while updating display rules")
+	}
+
+	integration, err := h.service.UpdateDisplayRules(ctx, request.ToUpdateParams(c.OriginalURL(), h.userIDFomContext(c)))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find integration [%s] in project [%s] for user [%s]", request.IntegrationID, request.ProjectID, h.userIDFomContext(c))
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot update display rules in project [%s]", h.userIDFomContext(c), request.ProjectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to edit this project")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot update display rules of integration [%s] for user with ID [%s]", request.IntegrationID, h.userIDFomContext(c))
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "display rules updated successfully", integration)
+}
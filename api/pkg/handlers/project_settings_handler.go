@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/NdoleStudio/superbutton/pkg/entities"
 	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/google/uuid"
 
@@ -14,40 +16,63 @@ import (
 	"github.com/palantir/stacktrace"
 )
 
+// publicSettingsCacheControl lets browsers reuse the public settings for a minute and CDNs serve them for 5 minutes.
+// Changes to a project invalidate the server side cache immediately so the CDN is the upper bound for stale settings.
+const publicSettingsCacheControl = "public, max-age=60, s-maxage=300, stale-while-revalidate=60"
+
 // ProjectSettingsHandler handles user http requests.
 type ProjectSettingsHandler struct {
 	handler
-	logger  telemetry.Logger
-	tracer  telemetry.Tracer
-	service *services.ProjectSettingsService
+	logger        telemetry.Logger
+	tracer        telemetry.Tracer
+	service       *services.ProjectSettingsService
+	countryHeader string
 }
 
-// NewProjectSettingsHandler creates a new ProjectSettingsHandler
+// NewProjectSettingsHandler creates a new ProjectSettingsHandler.
+// The countryHeader is the header in which the CDN sends the ISO 3166-1 alpha-2 country of the visitor e.g CF-IPCountry
 func NewProjectSettingsHandler(
 	logger telemetry.Logger,
 	tracer telemetry.Tracer,
 	service *services.ProjectSettingsService,
+	countryHeader string,
 ) (h *ProjectSettingsHandler) {
 	return &ProjectSettingsHandler{
-		logger:  logger.WithService(fmt.Sprintf("%T", h)),
-		tracer:  tracer,
-		service: service,
+		logger:        logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:        tracer,
+		service:       service,
+		countryHeader: countryHeader,
 	}
 }
 
-// RegisterRoutes registers the routes for the MessageHandler
+// RegisterRoutes registers the public routes which are used by the widget.
+// These routes emit their own CORS headers for the origins which are allowed by each project.
 func (h *ProjectSettingsHandler) RegisterRoutes(app *fiber.App, middlewares ...fiber.Handler) {
-	router := app.Group("/v1/settings/:userID/projects")
-	router.Get("/:projectID", h.computeRoute(middlewares, h.show)...)
+	router := app.Group("/v1/settings")
+	router.Get("/:publishableKey", h.computeRoute(middlewares, h.showByKey)...)
+
+	// Deprecated: the user ID leaks into the website which embeds the widget. Use /v1/settings/:publishableKey instead.
+	router.Get("/:userID/projects/:projectID", h.computeRoute(middlewares, h.show)...)
+}
+
+// RegisterAuthenticatedRoutes registers the routes which return the full settings of a project to its owner
+func (h *ProjectSettingsHandler) RegisterAuthenticatedRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	router := app.Group("/v1/projects/:projectID/settings")
+	router.Get("/", h.computeRoute(middlewares, h.index)...)
+	router.Get("/blocked-origins", h.computeRoute(middlewares, h.indexBlockedOrigins)...)
+}
+
+// RegisterAdminRoutes registers the maintenance routes for the settings snapshots of every project
+func (h *ProjectSettingsHandler) RegisterAdminRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	router := app.Group("/v1/admin/project-settings")
+	router.Post("/snapshots/rebuild", h.computeRoute(middlewares, h.rebuildSnapshots)...)
 }
 
-// show returns all the settings for a project
 // @Summary      Project Settings
-// @Description  Fetches all the settings and integrations of a project
+// @Description  Fetches all the settings and integrations of a project including the disabled integrations
 // @Security	 BearerAuth
 // @Tags         ProjectSettings
 // @Produce      json
-// @Param 		 userID			path 		string true "User ID"
 // @Param 		 projectID		path 		string true "Project ID"
 // @Success      200 			{object}	responses.Ok[entities.ProjectSettings]
 // @Failure      400			{object}	responses.BadRequest
@@ -55,6 +80,104 @@ func (h *ProjectSettingsHandler) RegisterRoutes(app *fiber.App, middlewares ...f
 // @Failure 	 404    		{object}	responses.NotFound
 // @Failure      422			{object}	responses.UnprocessableEntity
 // @Failure      500			{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/settings 	[get]
+func (h *ProjectSettingsHandler) index(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "projectID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while fetching settings for project with URL [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while fetching project settings")
+	}
+
+	authUser := h.userFromContext(c)
+	projectID := uuid.MustParse(c.Params("projectID"))
+
+	settings, err := h.service.Get(ctx, authUser.ID, projectID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find settings for project with id [%s] for user [%s]", projectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot get settings for project [%s] and user [%s]", projectID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "project settings fetched successfully", settings)
+}
+
+// @Summary      Public project settings
+// @Description  Fetches the public settings and enabled integrations of the project with a publishable key
+// @Tags         ProjectSettings
+// @Produce      json
+// @Param 		 publishableKey	path 		string true "Publishable key of the project"
+// @Param        path			query  		string false "path of the page which embeds the widget e.g /products/shoes"
+// @Param        device			query  		string false "device class of the visitor which is guessed from the User-Agent when it is empty" Enums(mobile, tablet, desktop)
+// @Param 		 If-None-Match	header 		string false "ETag of the settings which are cached by the client"
+// @Success      200 			{object}	responses.Ok[entities.PublicProjectSettings]
+// @Success      304			"The settings have not been modified"
+// @Failure      400			{object}	responses.BadRequest
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /settings/{publishableKey} 	[get]
+func (h *ProjectSettingsHandler) showByKey(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	page, err := h.pageContext(c)
+	if err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), page)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	origin := h.requestOrigin(c)
+	settings, err := h.service.GetPublicByKey(ctx, c.Params("publishableKey"), origin, page)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := "cannot find settings for the project with the publishable key"
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeOriginNotAllowed {
+		msg := fmt.Sprintf("origin [%s] is not allowed to load the settings of the project", origin)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseOriginNotAllowed(c, msg)
+	}
+
+	if err != nil {
+		msg := "cannot get settings for the project with the publishable key"
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	h.setCORSHeaders(c, origin)
+	return h.responsePublicSettings(c, settings)
+}
+
+// show returns the public settings of a project which are rendered by the widget
+// @Summary      Project Settings
+// @Description  Deprecated: use /settings/{publishableKey}. Fetches the public settings and enabled integrations of a project which are rendered by the widget
+// @Tags         ProjectSettings
+// @Deprecated
+// @Produce      json
+// @Param 		 userID			path 		string true "User ID"
+// @Param 		 projectID		path 		string true "Project ID"
+// @Param        path			query  		string false "path of the page which embeds the widget e.g /products/shoes"
+// @Param        device			query  		string false "device class of the visitor which is guessed from the User-Agent when it is empty" Enums(mobile, tablet, desktop)
+// @Param 		 If-None-Match	header 		string false "ETag of the settings which are cached by the client"
+// @Success      200 			{object}	responses.Ok[entities.PublicProjectSettings]
+// @Success      304			"The settings have not been modified"
+// @Failure      400			{object}	responses.BadRequest
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
 // @Router       /settings/{userID}/projects/{projectID} 	[get]
 func (h *ProjectSettingsHandler) show(c *fiber.Ctx) error {
 	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
@@ -69,18 +192,161 @@ func (h *ProjectSettingsHandler) show(c *fiber.Ctx) error {
 	userID := entities.UserID(c.Params("userID"))
 	projectID := uuid.MustParse(c.Params("projectID"))
 
-	settings, err := h.service.Get(ctx, userID, projectID)
+	ctxLogger.Warn(stacktrace.NewError(fmt.Sprintf("project [%s] is loading its settings with the deprecated route [%s]", projectID, c.OriginalURL())))
+	c.Set("Deprecation", "true")
+	c.Set("Link", `</v1/settings/{publishableKey}>; rel="successor-version"`)
+
+	page, err := h.pageContext(c)
+	if err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), page)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	origin := h.requestOrigin(c)
+	settings, err := h.service.GetPublic(ctx, userID, projectID, origin, page)
 	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
 		msg := fmt.Sprintf("cannot find settings for project with id [%s] for user [%s]", projectID, userID)
 		ctxLogger.Warn(stacktrace.Propagate(err, msg))
 		return h.responseNotFound(c, msg)
 	}
 
+	if stacktrace.GetCode(err) == services.ErrCodeOriginNotAllowed {
+		msg := fmt.Sprintf("origin [%s] is not allowed to load the settings of project [%s]", origin, projectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseOriginNotAllowed(c, msg)
+	}
+
 	if err != nil {
 		msg := fmt.Sprintf("cannot get settings for project [%s] and user [%s]", projectID, userID)
 		ctxLogger.Error(stacktrace.Propagate(err, msg))
 		return h.responseInternalServerError(c)
 	}
 
-	return h.responseOK(c, "project settings fetched successfully", settings)
+	h.setCORSHeaders(c, origin)
+	return h.responsePublicSettings(c, settings)
+}
+
+// @Summary      Blocked origins of a project
+// @Description  Fetches the origins which were blocked from loading the widget of a project because they are not in its allowed origins
+// @Security	 BearerAuth
+// @Tags         ProjectSettings
+// @Produce      json
+// @Param 		 projectID		path 		string true "Project ID"
+// @Success      200 			{object}	responses.Ok[[]entities.ProjectBlockedOrigin]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/settings/blocked-origins 	[get]
+func (h *ProjectSettingsHandler) indexBlockedOrigins(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "projectID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while fetching blocked origins with URL [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while fetching blocked origins")
+	}
+
+	authUser := h.userFromContext(c)
+	projectID := uuid.MustParse(c.Params("projectID"))
+
+	origins, err := h.service.IndexBlockedOrigins(ctx, authUser.ID, projectID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find project with id [%s] for user [%s]", projectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch blocked origins of project [%s] for user [%s]", projectID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, fmt.Sprintf("fetched %d blocked %s", len(origins), h.pluralize("origin", len(origins))), origins)
+}
+
+// @Summary      Rebuild settings snapshots
+// @Description  Renders the settings snapshot of every project from the live data and reports the snapshots which are missing or have drifted
+// @Security	 BearerAuth
+// @Tags         ProjectSettings
+// @Produce      json
+// @Param        dry_run	query  		bool  	false	"only report the drift without replacing the snapshots"
+// @Success      200 		{object}	responses.Ok[services.ProjectSettingsSnapshotRebuildResult]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure 	 403    	{object}	responses.Forbidden
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /admin/project-settings/snapshots/rebuild [post]
+func (h *ProjectSettingsHandler) rebuildSnapshots(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.ProjectSettingsSnapshotRebuildRequest
+	if err := c.QueryParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	result, err := h.service.RebuildSnapshots(ctx, request.ToRebuildParams())
+	if err != nil {
+		msg := fmt.Sprintf("cannot rebuild settings snapshots with request [%s]", c.OriginalURL())
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, fmt.Sprintf("checked %d settings %s", result.Projects, h.pluralize("snapshot", result.Projects)), result)
+}
+
+// responsePublicSettings responds with 304 when the client already has the current version of the settings
+func (h *ProjectSettingsHandler) responsePublicSettings(c *fiber.Ctx, settings *entities.ProjectSettingsSnapshot) error {
+	c.Set(fiber.HeaderETag, settings.ETag)
+	c.Set(fiber.HeaderCacheControl, publicSettingsCacheControl)
+
+	if h.isNotModified(c.Get(fiber.HeaderIfNoneMatch), settings.ETag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	return h.responseOK(c, "project settings fetched successfully", settings.Settings)
+}
+
+// isNotModified checks if the If-None-Match header contains the ETag using the weak comparison of RFC 7232
+func (h *ProjectSettingsHandler) isNotModified(ifNoneMatch string, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// pageContext reads the page of the widget from the query and the country of the visitor from the CDN header.
+// The response varies with the headers which are used to evaluate the entities.DisplayRules.
+func (h *ProjectSettingsHandler) pageContext(c *fiber.Ctx) (*entities.PageContext, error) {
+	var request requests.ProjectSettingsPublicRequest
+	if err := c.QueryParser(&request); err != nil {
+		return nil, err
+	}
+
+	if h.countryHeader != "" {
+		c.Vary(h.countryHeader)
+	}
+
+	if !request.HasDevice() {
+		c.Vary(fiber.HeaderUserAgent)
+	}
+
+	return request.ToPageContext(c.Get(fiber.HeaderUserAgent), c.Get(h.countryHeader)), nil
+}
+
+// setCORSHeaders allows an origin which was authorized by the project to read the response
+func (h *ProjectSettingsHandler) setCORSHeaders(c *fiber.Ctx, origin string) {
+	c.Set(fiber.HeaderAccessControlAllowOrigin, origin)
+	c.Set(fiber.HeaderAccessControlAllowMethods, fiber.MethodGet)
+	c.Vary(fiber.HeaderOrigin)
 }
@@ -38,10 +38,38 @@ func NewUserHandler(
 func (h *UserHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Handler) {
 	router := app.Group("/v1/users")
 	router.Get("/me", h.computeRoute(middlewares, h.me)...)
+	router.Get("/plan", h.computeRoute(middlewares, h.plan)...)
 	router.Get("/subscription-update-url", h.computeRoute(middlewares, h.subscriptionUpdateURL)...)
 	router.Delete("/subscription", h.computeRoute(middlewares, h.cancelSubscription)...)
 }
 
+// plan returns the entities.Plan of the currently authenticated entities.User
+// @Summary      Plan of the authenticated user
+// @Description  Fetches the quotas and features of the plan of the currently authenticated user
+// @Security	 BearerAuth
+// @Tags         Users
+// @Produce      json
+// @Success      200 		{object}	responses.Ok[entities.Plan]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /users/plan 	[get]
+func (h *UserHandler) plan(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	authUser := h.userFromContext(c)
+
+	user, err := h.service.Get(ctx, c.OriginalURL(), authUser)
+	if err != nil {
+		msg := fmt.Sprintf("cannot get user with ID [%s]", authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "plan fetched successfully", user.Plan())
+}
+
 // me returns the currently authenticated entities.User
 // @Summary      Currently authenticated user
 // @Description  Fetches the currently authenticated user. This method creates the user if one doesn't exist
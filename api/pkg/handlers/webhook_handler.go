@@ -0,0 +1,475 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/NdoleStudio/superbutton/pkg/validators"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// WebhookHandler handles /projects/:projectID/webhooks http requests.
+type WebhookHandler struct {
+	handler
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	validator *validators.WebhookHandlerValidator
+	service   *services.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler
+func NewWebhookHandler(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	validator *validators.WebhookHandlerValidator,
+	service *services.WebhookService,
+) (h *WebhookHandler) {
+	return &WebhookHandler{
+		logger:    logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:    tracer,
+		validator: validator,
+		service:   service,
+	}
+}
+
+// RegisterRoutes registers the routes for the WebhookHandler
+func (h *WebhookHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	router := app.Group("/v1/projects/:projectID/webhooks")
+	router.Get("/", h.computeRoute(middlewares, h.index)...)
+	router.Post("/", h.computeRoute(middlewares, h.create)...)
+	router.Put("/:webhookID", h.computeRoute(middlewares, h.update)...)
+	router.Delete("/:webhookID", h.computeRoute(middlewares, h.delete)...)
+	router.Post("/:webhookID/test", h.computeRoute(middlewares, h.test)...)
+	router.Get("/:webhookID/deliveries", h.computeRoute(middlewares, h.indexDeliveries)...)
+	router.Post("/:webhookID/deliveries/:deliveryID/redeliver", h.computeRoute(middlewares, h.redeliver)...)
+}
+
+// RegisterQueueRoutes registers the routes which the queue calls to send an entities.WebhookDelivery
+func (h *WebhookHandler) RegisterQueueRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	router := app.Group("/v1/webhook-deliveries")
+	router.Post("/consume", h.computeRoute(middlewares, h.consume)...)
+}
+
+// @Summary      List webhooks
+// @Description  Fetches the webhooks of a project
+// @Security	 BearerAuth
+// @Tags         Webhooks
+// @Produce      json
+// @Param 		 projectID	path 		string true "Project ID"
+// @Success      200 		{object}	responses.Ok[[]entities.Webhook]
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure 	 403    	{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/webhooks [get]
+func (h *WebhookHandler) index(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.validateUUID(c, "projectID"); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while fetching webhooks with url [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while fetching webhooks")
+	}
+
+	authUser := h.userFromContext(c)
+	projectID := uuid.MustParse(c.Params("projectID"))
+
+	webhooks, err := h.service.Index(ctx, authUser.ID, projectID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find project [%s] for user [%s]", projectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot fetch the webhooks of project [%s]", authUser.ID, projectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to manage the webhooks of this project")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch webhooks of project [%s] for user [%s]", projectID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, fmt.Sprintf("fetched %d %s", len(webhooks), h.pluralize("webhook", len(webhooks))), webhooks)
+}
+
+// @Summary      Create a webhook
+// @Description  Adds a webhook to a project. Every delivery is signed with the signing secret of the webhook which is only returned in this response.
+// @Security	 BearerAuth
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param 		 projectID	path 		string 							true "Project ID"
+// @Param        payload	body 		requests.WebhookCreateRequest	true "webhook payload"
+// @Success      200 		{object}	responses.Ok[entities.Webhook]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure 	 403    	{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/webhooks [post]
+func (h *WebhookHandler) create(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.WebhookCreateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+	request.ProjectID = c.Params("projectID")
+
+	if errors := h.validator.ValidateCreate(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while creating webhook with request [%s]", spew.Sdump(errors), c.Body())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while creating webhook")
+	}
+
+	authUser := h.userFromContext(c)
+
+	webhook, err := h.service.Create(ctx, request.ToCreateParams(c.OriginalURL(), authUser.ID))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find project [%s] for user [%s]", request.ProjectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot add a webhook to project [%s]", authUser.ID, request.ProjectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to manage the webhooks of this project")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot create webhook for project [%s] and user [%s]", request.ProjectID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "webhook created successfully", webhook)
+}
+
+// @Summary      Update a webhook
+// @Description  Changes the URL and events of a webhook. Enabling a webhook which was disabled clears its consecutive failures.
+// @Security	 BearerAuth
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param 		 projectID	path 		string 							true "Project ID"
+// @Param 		 webhookID	path 		string 							true "Webhook ID"
+// @Param        payload	body 		requests.WebhookUpdateRequest	true "webhook payload"
+// @Success      200 		{object}	responses.Ok[entities.Webhook]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure 	 403    	{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/webhooks/{webhookID} [put]
+func (h *WebhookHandler) update(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.WebhookUpdateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+	request.ProjectID = c.Params("projectID")
+	request.WebhookID = c.Params("webhookID")
+
+	if errors := h.validator.ValidateUpdate(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while updating webhook with request [%s]", spew.Sdump(errors), c.Body())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while updating webhook")
+	}
+
+	authUser := h.userFromContext(c)
+
+	webhook, err := h.service.Update(ctx, request.ToUpdateParams(c.OriginalURL(), authUser.ID))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find webhook [%s] in project [%s] for user [%s]", request.WebhookID, request.ProjectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot update webhook [%s] of project [%s]", authUser.ID, request.WebhookID, request.ProjectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to manage the webhooks of this project")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot update webhook [%s] of project [%s] for user [%s]", request.WebhookID, request.ProjectID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "webhook updated successfully", webhook)
+}
+
+// @Summary      Delete a webhook
+// @Description  Removes a webhook and its delivery log from a project
+// @Security	 BearerAuth
+// @Tags         Webhooks
+// @Produce      json
+// @Param 		 projectID	path 		string true "Project ID"
+// @Param 		 webhookID	path 		string true "Webhook ID"
+// @Success      204 		{object}	responses.NoContent
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure 	 403    	{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/webhooks/{webhookID} [delete]
+func (h *WebhookHandler) delete(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "projectID"), h.validateUUID(c, "webhookID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while deleting webhook with url [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while deleting webhook")
+	}
+
+	authUser := h.userFromContext(c)
+	projectID := uuid.MustParse(c.Params("projectID"))
+	webhookID := uuid.MustParse(c.Params("webhookID"))
+
+	err := h.service.Delete(ctx, &services.WebhookDeleteParams{
+		Source:    c.OriginalURL(),
+		UserID:    authUser.ID,
+		ProjectID: projectID,
+		WebhookID: webhookID,
+	})
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find webhook [%s] in project [%s] for user [%s]", webhookID, projectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot delete webhook [%s] of project [%s]", authUser.ID, webhookID, projectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to manage the webhooks of this project")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete webhook [%s] of project [%s] for user [%s]", webhookID, projectID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseNoContent(c, "webhook deleted successfully")
+}
+
+// @Summary      Send a test event
+// @Description  Queues a webhook.test event for a webhook and returns the delivery which records the response
+// @Security	 BearerAuth
+// @Tags         Webhooks
+// @Produce      json
+// @Param 		 projectID	path 		string true "Project ID"
+// @Param 		 webhookID	path 		string true "Webhook ID"
+// @Success      200 		{object}	responses.Ok[entities.WebhookDelivery]
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure 	 403    	{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/webhooks/{webhookID}/test [post]
+func (h *WebhookHandler) test(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "projectID"), h.validateUUID(c, "webhookID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while testing webhook with url [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while testing webhook")
+	}
+
+	authUser := h.userFromContext(c)
+	projectID := uuid.MustParse(c.Params("projectID"))
+	webhookID := uuid.MustParse(c.Params("webhookID"))
+
+	delivery, err := h.service.Test(ctx, &services.WebhookTestParams{
+		Source:    c.OriginalURL(),
+		UserID:    authUser.ID,
+		ProjectID: projectID,
+		WebhookID: webhookID,
+	})
+	if err != nil {
+		return h.responseDeliveryError(c, ctxLogger, stacktrace.Propagate(err, fmt.Sprintf("cannot test webhook [%s] of project [%s] for user [%s]", webhookID, projectID, authUser.ID)))
+	}
+
+	return h.responseOK(c, "test event queued successfully", delivery)
+}
+
+// @Summary      List webhook deliveries
+// @Description  Fetches the delivery log of a webhook with the response code of every delivery
+// @Security	 BearerAuth
+// @Tags         Webhooks
+// @Produce      json
+// @Param 		 projectID	path 		string 	true 	"Project ID"
+// @Param 		 webhookID	path 		string 	true 	"Webhook ID"
+// @Param        skip		query  		int  	false	"number of deliveries to skip"		minimum(0)
+// @Param        query		query  		string  false 	"filter deliveries by event type, event ID or status"
+// @Param        limit		query  		int  	false 	"number of deliveries to return"	minimum(1)	maximum(100)
+// @Success      200 		{object}	responses.Ok[[]entities.WebhookDelivery]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure 	 403    	{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/webhooks/{webhookID}/deliveries [get]
+func (h *WebhookHandler) indexDeliveries(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.WebhookDeliveryIndexRequest
+	if err := c.QueryParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+	request.ProjectID = c.Params("projectID")
+	request.WebhookID = c.Params("webhookID")
+
+	if errors := h.validator.ValidateIndexDeliveries(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while fetching webhook deliveries with request [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while fetching webhook deliveries")
+	}
+
+	authUser := h.userFromContext(c)
+	projectID := uuid.MustParse(request.ProjectID)
+	webhookID := uuid.MustParse(request.WebhookID)
+
+	deliveries, err := h.service.Deliveries(ctx, authUser.ID, projectID, webhookID, request.ToIndexParams())
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find webhook [%s] in project [%s] for user [%s]", webhookID, projectID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot fetch the deliveries of webhook [%s] in project [%s]", authUser.ID, webhookID, projectID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to manage the webhooks of this project")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch deliveries of webhook [%s] in project [%s] for user [%s]", webhookID, projectID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, fmt.Sprintf("fetched %d webhook %s", len(deliveries), h.pluralize("delivery", len(deliveries))), deliveries)
+}
+
+// @Summary      Redeliver a webhook delivery
+// @Description  Sends the event of a delivery to the webhook again and returns the new delivery
+// @Security	 BearerAuth
+// @Tags         Webhooks
+// @Produce      json
+// @Param 		 projectID	path 		string true "Project ID"
+// @Param 		 webhookID	path 		string true "Webhook ID"
+// @Param 		 deliveryID	path 		string true "Delivery ID"
+// @Success      200 		{object}	responses.Ok[entities.WebhookDelivery]
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure 	 403    	{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /projects/{projectID}/webhooks/{webhookID}/deliveries/{deliveryID}/redeliver [post]
+func (h *WebhookHandler) redeliver(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "projectID"), h.validateUUID(c, "webhookID"), h.validateUUID(c, "deliveryID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while redelivering webhook delivery with url [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while redelivering webhook delivery")
+	}
+
+	authUser := h.userFromContext(c)
+	projectID := uuid.MustParse(c.Params("projectID"))
+	webhookID := uuid.MustParse(c.Params("webhookID"))
+	deliveryID := uuid.MustParse(c.Params("deliveryID"))
+
+	delivery, err := h.service.Redeliver(ctx, &services.WebhookRedeliverParams{
+		UserID:     authUser.ID,
+		ProjectID:  projectID,
+		WebhookID:  webhookID,
+		DeliveryID: deliveryID,
+	})
+	if err != nil {
+		return h.responseDeliveryError(c, ctxLogger, stacktrace.Propagate(err, fmt.Sprintf("cannot redeliver delivery [%s] of webhook [%s] for user [%s]", deliveryID, webhookID, authUser.ID)))
+	}
+
+	return h.responseOK(c, "webhook delivery queued successfully", delivery)
+}
+
+// @Summary      Consume a webhook delivery
+// @Description  Sends a queued delivery to the URL of its webhook. The queue retries the request when the delivery fails.
+// @Security	 BearerAuth
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        payload	body 		services.WebhookDeliveryTask	true 	"delivery task payload"
+// @Success      204 		{object}	responses.NoContent
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /webhook-deliveries/consume [post]
+func (h *WebhookHandler) consume(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var task services.WebhookDeliveryTask
+	if err := c.BodyParser(&task); err != nil {
+		msg := fmt.Sprintf("cannot marshall [%s] into %T", c.Body(), task)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	if err := h.service.Deliver(ctx, c.OriginalURL(), task.DeliveryID); err != nil {
+		msg := fmt.Sprintf("cannot send webhook delivery [%s]", task.DeliveryID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseNoContent(c, "webhook delivery consumed successfully")
+}
+
+// responseDeliveryError maps the errors of the services.WebhookService methods which queue a delivery to a response
+func (h *WebhookHandler) responseDeliveryError(c *fiber.Ctx, ctxLogger telemetry.Logger, err error) error {
+	switch stacktrace.GetCode(err) {
+	case repositories.ErrCodeNotFound:
+		ctxLogger.Warn(err)
+		return h.responseNotFound(c, "cannot find the webhook delivery")
+	case services.ErrCodeForbidden:
+		ctxLogger.Warn(err)
+		return h.responseForbidden(c, "you do not have permission to manage the webhooks of this project")
+	case services.ErrCodeWebhookDisabled:
+		ctxLogger.Warn(err)
+		return h.responseUnprocessableEntity(c, map[string][]string{"webhookID": {"the webhook is disabled"}}, "enable the webhook before sending events to it")
+	default:
+		ctxLogger.Error(err)
+		return h.responseInternalServerError(c)
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/middlewares"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/NdoleStudio/superbutton/pkg/validators"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/gofiber/fiber/v2"
+	"github.com/palantir/stacktrace"
+)
+
+// WidgetEventHandler handles the analytics events which are sent by the widget
+type WidgetEventHandler struct {
+	handler
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	validator *validators.WidgetEventHandlerValidator
+	service   *services.WidgetEventService
+}
+
+// NewWidgetEventHandler creates a new WidgetEventHandler
+func NewWidgetEventHandler(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	validator *validators.WidgetEventHandlerValidator,
+	service *services.WidgetEventService,
+) (h *WidgetEventHandler) {
+	return &WidgetEventHandler{
+		logger:    logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:    tracer,
+		validator: validator,
+		service:   service,
+	}
+}
+
+// RegisterRoutes registers the routes for the WidgetEventHandler.
+// The widget sends events without authentication so the middlewares should rate limit the requests.
+func (h *WidgetEventHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	router := app.Group("/v1/widget-events")
+	router.Post("/", h.computeRoute(middlewares, h.store)...)
+}
+
+// @Summary      Record widget events
+// @Description  Records a batch of interactions of a website visitor with the widget of a project
+// @Tags         WidgetEvents
+// @Accept       json
+// @Produce      json
+// @Param        payload	body 		requests.WidgetEventsCreateRequest	true 	"batch of widget events"
+// @Success      204		{object}	responses.NoContent
+// @Failure      400		{object}	responses.BadRequest
+// @Failure      403		{object}	responses.Forbidden
+// @Failure 	 404    	{object}	responses.NotFound
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      429		{object}	responses.TooManyRequests
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /widget-events [post]
+func (h *WidgetEventHandler) store(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.WidgetEventsCreateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	if errors := h.validator.ValidateCreate(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while recording widget events with request [%s]", spew.Sdump(errors), c.Body())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while recording widget events")
+	}
+
+	origin := h.requestOrigin(c)
+	count, err := h.service.Record(ctx, request.ToRecordParams(c.OriginalURL(), origin, middlewares.ClientIP(c), c.Get(fiber.HeaderUserAgent)))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := "cannot find the project with the publishable key of the widget events"
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeOriginNotAllowed {
+		msg := fmt.Sprintf("origin [%s] is not allowed to record widget events for the project", origin)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseOriginNotAllowed(c, msg)
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot record [%d] widget events", len(request.Events))
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseNoContent(c, fmt.Sprintf("recorded %d widget %s", count, h.pluralize("event", count)))
+}
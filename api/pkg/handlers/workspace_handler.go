@@ -0,0 +1,391 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/NdoleStudio/superbutton/pkg/validators"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// WorkspaceHandler handles /workspaces http requests.
+type WorkspaceHandler struct {
+	handler
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	validator *validators.WorkspaceHandlerValidator
+	service   *services.WorkspaceService
+}
+
+// NewWorkspaceHandler creates a new WorkspaceHandler
+func NewWorkspaceHandler(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	validator *validators.WorkspaceHandlerValidator,
+	service *services.WorkspaceService,
+) (h *WorkspaceHandler) {
+	return &WorkspaceHandler{
+		logger:    logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:    tracer,
+		validator: validator,
+		service:   service,
+	}
+}
+
+// RegisterRoutes registers the routes for the WorkspaceHandler
+func (h *WorkspaceHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	router := app.Group("/v1/workspaces")
+	router.Get("/", h.computeRoute(middlewares, h.index)...)
+	router.Post("/", h.computeRoute(middlewares, h.create)...)
+	router.Put("/:workspaceID", h.computeRoute(middlewares, h.update)...)
+	router.Get("/:workspaceID/members", h.computeRoute(middlewares, h.indexMembers)...)
+	router.Post("/:workspaceID/members", h.computeRoute(middlewares, h.createMember)...)
+	router.Put("/:workspaceID/members/:userID", h.computeRoute(middlewares, h.updateMember)...)
+	router.Delete("/:workspaceID/members/:userID", h.computeRoute(middlewares, h.deleteMember)...)
+}
+
+// @Summary      List of workspaces
+// @Description  Fetches the workspaces of the currently authenticated user with their role in each workspace
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Success      200 		{object}	responses.Ok[[]entities.UserWorkspace]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /workspaces 	[get]
+func (h *WorkspaceHandler) index(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	authUser := h.userFromContext(c)
+	workspaces, err := h.service.Index(ctx, c.OriginalURL(), authUser.ID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch workspaces for user with ID [%s]", authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "workspaces fetched successfully", workspaces)
+}
+
+// @Summary      Create a workspace
+// @Description  This endpoint creates a new workspace with the currently authenticated user as its owner
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Param        payload	body 		requests.WorkspaceCreateRequest	true 	"workspace create payload"
+// @Success      200 		{object}	responses.Ok[entities.Workspace]
+// @Failure      400		{object}	responses.BadRequest
+// @Failure 	 401    	{object}	responses.Unauthorized
+// @Failure      422		{object}	responses.UnprocessableEntity
+// @Failure      500		{object}	responses.InternalServerError
+// @Router       /workspaces 	[post]
+func (h *WorkspaceHandler) create(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.WorkspaceCreateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	if errors := h.validator.ValidateCreate(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while creating workspace with request [%s]", spew.Sdump(errors), c.Body())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while creating workspace")
+	}
+
+	authUser := h.userFromContext(c)
+	workspace, err := h.service.Create(ctx, request.ToCreateParams(c.OriginalURL(), authUser.ID))
+	if err != nil {
+		msg := fmt.Sprintf("cannot create workspace for user with ID [%s]", authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "workspace created successfully", workspace)
+}
+
+// @Summary      Update a workspace
+// @Description  This endpoint updates the name of a workspace. Only the owners and admins of the workspace can update it.
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Param 		 workspaceID	path 		string true "Workspace ID"
+// @Param        payload		body 		requests.WorkspaceUpdateRequest	true 	"workspace update payload"
+// @Success      200 			{object}	responses.Ok[entities.Workspace]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /workspaces/{workspaceID} 	[put]
+func (h *WorkspaceHandler) update(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.WorkspaceUpdateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+	request.WorkspaceID = c.Params("workspaceID")
+
+	if errors := h.validator.ValidateUpdate(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while updating workspace with request [%s]", spew.Sdump(errors), c.Body())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while updating workspace")
+	}
+
+	authUser := h.userFromContext(c)
+	workspace, err := h.service.Update(ctx, request.ToUpdateParams(c.OriginalURL(), authUser.ID))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find workspace with id [%s] for user [%s]", request.WorkspaceID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot update workspace [%s]", authUser.ID, request.WorkspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to update this workspace")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot update workspace [%s] for user with ID [%s]", request.WorkspaceID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "workspace updated successfully", workspace)
+}
+
+// @Summary      List of workspace members
+// @Description  Fetches the members of a workspace with their roles
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Param 		 workspaceID	path 		string true "Workspace ID"
+// @Success      200 			{object}	responses.Ok[[]entities.WorkspaceMember]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /workspaces/{workspaceID}/members 	[get]
+func (h *WorkspaceHandler) indexMembers(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "workspaceID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while fetching members of workspace [%s]", spew.Sdump(errors), c.Params("workspaceID"))
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while fetching workspace members")
+	}
+
+	authUser := h.userFromContext(c)
+	workspaceID := uuid.MustParse(c.Params("workspaceID"))
+
+	members, err := h.service.IndexMembers(ctx, authUser.ID, workspaceID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find workspace with id [%s] for user [%s]", workspaceID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch members of workspace [%s] for user with ID [%s]", workspaceID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "workspace members fetched successfully", members)
+}
+
+// @Summary      Add a workspace member
+// @Description  This endpoint adds the user with an email address to a workspace. Only owners can add another owner.
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Param 		 workspaceID	path 		string true "Workspace ID"
+// @Param        payload		body 		requests.WorkspaceMemberCreateRequest	true 	"workspace member payload"
+// @Success      200 			{object}	responses.Ok[entities.WorkspaceMember]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /workspaces/{workspaceID}/members 	[post]
+func (h *WorkspaceHandler) createMember(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.WorkspaceMemberCreateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+	request.WorkspaceID = c.Params("workspaceID")
+
+	if errors := h.validator.ValidateCreateMember(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while adding workspace member with request [%s]", spew.Sdump(errors), c.Body())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while adding workspace member")
+	}
+
+	authUser := h.userFromContext(c)
+	member, err := h.service.AddMember(ctx, request.ToAddParams(c.OriginalURL(), authUser.ID))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find workspace [%s] or user with email [%s] for user [%s]", request.WorkspaceID, request.Email, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, "cannot find a user with this email address")
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot add an [%s] to workspace [%s]", authUser.ID, request.Role, request.WorkspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, fmt.Sprintf("you do not have permission to add an %s to this workspace", request.Role))
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeWorkspaceMemberExists {
+		msg := fmt.Sprintf("user with email [%s] is already a member of workspace [%s]", request.Email, request.WorkspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseUnprocessableEntity(c, map[string][]string{"email": {"this user is already a member of the workspace"}}, "validation errors while adding workspace member")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot add member to workspace [%s] for user with ID [%s]", request.WorkspaceID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "workspace member added successfully", member)
+}
+
+// @Summary      Update a workspace member
+// @Description  This endpoint changes the role of a member of a workspace. The role of the user who created the workspace cannot be changed.
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Param 		 workspaceID	path 		string true "Workspace ID"
+// @Param 		 userID			path 		string true "User ID of the member"
+// @Param        payload		body 		requests.WorkspaceMemberUpdateRequest	true 	"workspace member payload"
+// @Success      200 			{object}	responses.Ok[entities.WorkspaceMember]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /workspaces/{workspaceID}/members/{userID} 	[put]
+func (h *WorkspaceHandler) updateMember(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.WorkspaceMemberUpdateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+	request.WorkspaceID = c.Params("workspaceID")
+	request.MemberID = c.Params("userID")
+
+	if errors := h.validator.ValidateUpdateMember(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while updating workspace member with request [%s]", spew.Sdump(errors), c.Body())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while updating workspace member")
+	}
+
+	authUser := h.userFromContext(c)
+	member, err := h.service.UpdateMember(ctx, request.ToUpdateParams(c.OriginalURL(), authUser.ID))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find member [%s] of workspace [%s] for user [%s]", request.MemberID, request.WorkspaceID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot change the role of member [%s] in workspace [%s]", authUser.ID, request.MemberID, request.WorkspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to change the role of this member")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot update member [%s] of workspace [%s] for user with ID [%s]", request.MemberID, request.WorkspaceID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "workspace member updated successfully", member)
+}
+
+// @Summary      Remove a workspace member
+// @Description  This endpoint removes a member from a workspace. Members can remove themselves except the user who created the workspace.
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Param 		 workspaceID	path 		string true "Workspace ID"
+// @Param 		 userID			path 		string true "User ID of the member"
+// @Success      200 			{object}	responses.NoContent
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /workspaces/{workspaceID}/members/{userID} 	[delete]
+func (h *WorkspaceHandler) deleteMember(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "workspaceID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while removing member with url [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while removing workspace member")
+	}
+
+	authUser := h.userFromContext(c)
+	workspaceID := uuid.MustParse(c.Params("workspaceID"))
+	memberID := entities.UserID(c.Params("userID"))
+
+	err := h.service.RemoveMember(ctx, &services.WorkspaceMemberRemoveParams{
+		Source:      c.OriginalURL(),
+		UserID:      authUser.ID,
+		WorkspaceID: workspaceID,
+		MemberID:    memberID,
+	})
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find member [%s] of workspace [%s] for user [%s]", memberID, workspaceID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot remove member [%s] from workspace [%s]", authUser.ID, memberID, workspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to remove this member")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot remove member [%s] of workspace [%s] for user with ID [%s]", memberID, workspaceID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseNoContent(c, "workspace member removed successfully")
+}
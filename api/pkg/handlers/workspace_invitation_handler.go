@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/NdoleStudio/superbutton/pkg/validators"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// WorkspaceInvitationHandler handles workspace invitation http requests.
+type WorkspaceInvitationHandler struct {
+	handler
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	validator *validators.WorkspaceInvitationHandlerValidator
+	service   *services.WorkspaceInvitationService
+}
+
+// NewWorkspaceInvitationHandler creates a new WorkspaceInvitationHandler
+func NewWorkspaceInvitationHandler(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	validator *validators.WorkspaceInvitationHandlerValidator,
+	service *services.WorkspaceInvitationService,
+) (h *WorkspaceInvitationHandler) {
+	return &WorkspaceInvitationHandler{
+		logger:    logger.WithService(fmt.Sprintf("%T", h)),
+		tracer:    tracer,
+		validator: validator,
+		service:   service,
+	}
+}
+
+// RegisterRoutes registers the routes for the WorkspaceInvitationHandler
+func (h *WorkspaceInvitationHandler) RegisterRoutes(app *fiber.App, middlewares []fiber.Handler) {
+	router := app.Group("/v1")
+	router.Get("/workspaces/:workspaceID/invitations", h.computeRoute(middlewares, h.index)...)
+	router.Post("/workspaces/:workspaceID/invitations", h.computeRoute(middlewares, h.create)...)
+	router.Delete("/workspaces/:workspaceID/invitations/:invitationID", h.computeRoute(middlewares, h.delete)...)
+	router.Post("/invitations/accept", h.computeRoute(middlewares, h.accept)...)
+}
+
+// @Summary      List of workspace invitations
+// @Description  Fetches the invitations of a workspace with the newest first
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Param 		 workspaceID	path 		string true "Workspace ID"
+// @Success      200 			{object}	responses.Ok[[]entities.WorkspaceInvitation]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /workspaces/{workspaceID}/invitations 	[get]
+func (h *WorkspaceInvitationHandler) index(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "workspaceID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while fetching invitations of workspace [%s]", spew.Sdump(errors), c.Params("workspaceID"))
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while fetching workspace invitations")
+	}
+
+	authUser := h.userFromContext(c)
+	workspaceID := uuid.MustParse(c.Params("workspaceID"))
+
+	invitations, err := h.service.Index(ctx, authUser.ID, workspaceID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find workspace with id [%s] for user [%s]", workspaceID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot view the invitations of workspace [%s]", authUser.ID, workspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to view the invitations of this workspace")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch invitations of workspace [%s] for user with ID [%s]", workspaceID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "workspace invitations fetched successfully", invitations)
+}
+
+// @Summary      Invite to a workspace
+// @Description  This endpoint emails an invitation to join a workspace which expires after 7 days. Pending invitations for the same email address are revoked. Only owners can invite another owner.
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Param 		 workspaceID	path 		string true "Workspace ID"
+// @Param        payload		body 		requests.WorkspaceInvitationCreateRequest	true 	"workspace invitation payload"
+// @Success      200 			{object}	responses.Ok[entities.WorkspaceInvitation]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /workspaces/{workspaceID}/invitations 	[post]
+func (h *WorkspaceInvitationHandler) create(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.WorkspaceInvitationCreateRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+	request.WorkspaceID = c.Params("workspaceID")
+
+	if errors := h.validator.ValidateCreate(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while creating workspace invitation with request [%s]", spew.Sdump(errors), c.Body())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while creating workspace invitation")
+	}
+
+	authUser := h.userFromContext(c)
+	invitation, err := h.service.Create(ctx, request.ToCreateParams(c.OriginalURL(), authUser.ID))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find workspace [%s] for user [%s]", request.WorkspaceID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot invite an [%s] to workspace [%s]", authUser.ID, request.Role, request.WorkspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, fmt.Sprintf("you do not have permission to invite an %s to this workspace", request.Role))
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeWorkspaceMemberExists {
+		msg := fmt.Sprintf("user with email [%s] is already a member of workspace [%s]", request.Email, request.WorkspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseUnprocessableEntity(c, map[string][]string{"email": {"this user is already a member of the workspace"}}, "validation errors while creating workspace invitation")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot invite [%s] to workspace [%s] for user with ID [%s]", request.Email, request.WorkspaceID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "workspace invitation sent successfully", invitation)
+}
+
+// @Summary      Revoke a workspace invitation
+// @Description  This endpoint revokes a pending invitation so that it can no longer be accepted
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Param 		 workspaceID	path 		string true "Workspace ID"
+// @Param 		 invitationID	path 		string true "Invitation ID"
+// @Success      200 			{object}	responses.Ok[entities.WorkspaceInvitation]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /workspaces/{workspaceID}/invitations/{invitationID} 	[delete]
+func (h *WorkspaceInvitationHandler) delete(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	if errors := h.mergeErrors(h.validateUUID(c, "workspaceID"), h.validateUUID(c, "invitationID")); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while revoking invitation with url [%s]", spew.Sdump(errors), c.OriginalURL())
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while revoking workspace invitation")
+	}
+
+	authUser := h.userFromContext(c)
+	workspaceID := uuid.MustParse(c.Params("workspaceID"))
+	invitationID := uuid.MustParse(c.Params("invitationID"))
+
+	invitation, err := h.service.Revoke(ctx, &services.WorkspaceInvitationRevokeParams{
+		Source:       c.OriginalURL(),
+		UserID:       authUser.ID,
+		WorkspaceID:  workspaceID,
+		InvitationID: invitationID,
+	})
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find invitation [%s] of workspace [%s] for user [%s]", invitationID, workspaceID, authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, msg)
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot revoke invitation [%s] of workspace [%s]", authUser.ID, invitationID, workspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "you do not have permission to revoke the invitations of this workspace")
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeInvitationNotPending {
+		msg := fmt.Sprintf("invitation [%s] of workspace [%s] is not pending", invitationID, workspaceID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseUnprocessableEntity(c, map[string][]string{"invitationID": {"this invitation has already been accepted, revoked or has expired"}}, "validation errors while revoking workspace invitation")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot revoke invitation [%s] of workspace [%s] for user with ID [%s]", invitationID, workspaceID, authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "workspace invitation revoked successfully", invitation)
+}
+
+// @Summary      Accept a workspace invitation
+// @Description  This endpoint adds the currently authenticated user to a workspace with the token which was sent in the invitation email. The email address of the user must match the invited email address.
+// @Security	 BearerAuth
+// @Tags         Workspaces
+// @Produce      json
+// @Param        payload		body 		requests.WorkspaceInvitationAcceptRequest	true 	"accept invitation payload"
+// @Success      200 			{object}	responses.Ok[entities.WorkspaceMember]
+// @Failure      400			{object}	responses.BadRequest
+// @Failure 	 401    		{object}	responses.Unauthorized
+// @Failure      403			{object}	responses.Forbidden
+// @Failure 	 404    		{object}	responses.NotFound
+// @Failure      422			{object}	responses.UnprocessableEntity
+// @Failure      500			{object}	responses.InternalServerError
+// @Router       /invitations/accept 	[post]
+func (h *WorkspaceInvitationHandler) accept(c *fiber.Ctx) error {
+	ctx, span, ctxLogger := h.tracer.StartFromFiberCtxWithLogger(c, h.logger)
+	defer span.End()
+
+	var request requests.WorkspaceInvitationAcceptRequest
+	if err := c.BodyParser(&request); err != nil {
+		msg := fmt.Sprintf("cannot marshall params [%s] into %T", c.OriginalURL(), request)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseBadRequest(c, err)
+	}
+
+	if errors := h.validator.ValidateAccept(ctx, request.Sanitize()); len(errors) != 0 {
+		msg := fmt.Sprintf("validation errors [%s], while accepting workspace invitation", spew.Sdump(errors))
+		ctxLogger.Warn(stacktrace.NewError(msg))
+		return h.responseUnprocessableEntity(c, errors, "validation errors while accepting workspace invitation")
+	}
+
+	authUser := h.userFromContext(c)
+	member, err := h.service.Accept(ctx, request.ToAcceptParams(c.OriginalURL(), authUser.ID))
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot find invitation for user [%s]", authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseNotFound(c, "cannot find an invitation with this token")
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeForbidden {
+		msg := fmt.Sprintf("user [%s] cannot accept an invitation sent to another email address", authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseForbidden(c, "this invitation was sent to a different email address")
+	}
+
+	if stacktrace.GetCode(err) == services.ErrCodeInvitationNotPending {
+		msg := fmt.Sprintf("user [%s] cannot accept an invitation which is not pending", authUser.ID)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+		return h.responseUnprocessableEntity(c, map[string][]string{"token": {"this invitation has already been accepted, revoked or has expired"}}, "validation errors while accepting workspace invitation")
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot accept invitation for user with ID [%s]", authUser.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return h.responseInternalServerError(c)
+	}
+
+	return h.responseOK(c, "workspace invitation accepted successfully", member)
+}
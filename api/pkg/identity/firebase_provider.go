@@ -0,0 +1,57 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	"firebase.google.com/go/auth"
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/palantir/stacktrace"
+)
+
+type firebaseProvider struct {
+	logger  telemetry.Logger
+	tracer  telemetry.Tracer
+	client  *auth.Client
+	mapping ClaimMapping
+}
+
+// NewFirebaseProvider creates a Provider which verifies firebase ID tokens with the firebase admin SDK
+func NewFirebaseProvider(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	client *auth.Client,
+) Provider {
+	return &firebaseProvider{
+		logger: logger.WithService(fmt.Sprintf("%T", &firebaseProvider{})),
+		tracer: tracer,
+		client: client,
+		mapping: ClaimMapping{
+			ID:            "user_id",
+			Email:         "email",
+			EmailVerified: "email_verified",
+			Name:          "name",
+		},
+	}
+}
+
+// Verify checks a firebase ID token
+func (provider *firebaseProvider) Verify(ctx context.Context, token string) (*entities.AuthUser, error) {
+	ctx, span := provider.tracer.Start(ctx)
+	defer span.End()
+
+	idToken, err := provider.client.VerifyIDToken(ctx, token)
+	if err != nil {
+		msg := "cannot verify firebase id token"
+		return nil, provider.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeInvalidToken, msg))
+	}
+
+	user, err := provider.mapping.AuthUser(idToken.Claims)
+	if err != nil {
+		msg := fmt.Sprintf("cannot map claims of firebase id token for user [%s]", idToken.UID)
+		return nil, provider.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeInvalidToken, msg))
+	}
+
+	return user, nil
+}
@@ -0,0 +1,237 @@
+package identity
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/palantir/stacktrace"
+)
+
+// jwksMinRefreshInterval limits how often the keys are fetched again when a token is signed with an unknown key
+const jwksMinRefreshInterval = time.Minute
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type openIDConfiguration struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// jwksCache fetches the JSON Web Key Set of an issuer and caches the keys in memory.
+// The URL of the keys is discovered from the OpenID configuration of the issuer when it is not set.
+type jwksCache struct {
+	logger      telemetry.Logger
+	tracer      telemetry.Tracer
+	client      *http.Client
+	issuer      string
+	url         string
+	ttl         time.Duration
+	mutex       sync.Mutex
+	keys        map[string]crypto.PublicKey
+	expiresAt   time.Time
+	refreshedAt time.Time
+}
+
+func newJWKSCache(logger telemetry.Logger, tracer telemetry.Tracer, client *http.Client, issuer string, url string, ttl time.Duration) *jwksCache {
+	return &jwksCache{
+		logger: logger.WithService(fmt.Sprintf("%T", &jwksCache{})),
+		tracer: tracer,
+		client: client,
+		issuer: issuer,
+		url:    url,
+		ttl:    ttl,
+		keys:   map[string]crypto.PublicKey{},
+	}
+}
+
+// Key returns the public key with an ID. The keys are fetched again when they have expired or when the ID is unknown.
+func (cache *jwksCache) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	ctx, span, ctxLogger := cache.tracer.StartWithLogger(ctx, cache.logger)
+	defer span.End()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	now := time.Now()
+	key, ok := cache.keys[kid]
+	if ok && now.Before(cache.expiresAt) {
+		return key, nil
+	}
+
+	if !ok && now.Before(cache.expiresAt) && now.Sub(cache.refreshedAt) < jwksMinRefreshInterval {
+		msg := fmt.Sprintf("the key [%s] does not exist in the keys of issuer [%s]", kid, cache.issuer)
+		return nil, cache.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeInvalidToken, msg))
+	}
+
+	keys, err := cache.fetch(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch the keys of issuer [%s]", cache.issuer)
+		return nil, cache.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	cache.keys = keys
+	cache.refreshedAt = now
+	cache.expiresAt = now.Add(cache.ttl)
+	ctxLogger.Info(fmt.Sprintf("fetched [%d] keys of issuer [%s]", len(keys), cache.issuer))
+
+	if key, ok = cache.keys[kid]; !ok {
+		msg := fmt.Sprintf("the key [%s] does not exist in the keys of issuer [%s]", kid, cache.issuer)
+		return nil, cache.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeInvalidToken, msg))
+	}
+
+	return key, nil
+}
+
+func (cache *jwksCache) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	if cache.url == "" {
+		url, err := cache.discover(ctx)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot discover the jwks uri of issuer [%s]", cache.issuer))
+		}
+		cache.url = url
+	}
+
+	set := new(jsonWebKeySet)
+	if err := cache.get(ctx, cache.url, set); err != nil {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot fetch keys from [%s]", cache.url))
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			cache.logger.Warn(stacktrace.Propagate(err, fmt.Sprintf("cannot parse key [%s] from [%s]", jwk.Kid, cache.url)))
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (cache *jwksCache) discover(ctx context.Context) (string, error) {
+	url := strings.TrimRight(cache.issuer, "/") + "/.well-known/openid-configuration"
+
+	configuration := new(openIDConfiguration)
+	if err := cache.get(ctx, url, configuration); err != nil {
+		return "", stacktrace.Propagate(err, fmt.Sprintf("cannot fetch openid configuration from [%s]", url))
+	}
+
+	if configuration.Issuer != cache.issuer {
+		return "", stacktrace.NewError(fmt.Sprintf("the issuer [%s] in [%s] does not match [%s]", configuration.Issuer, url, cache.issuer))
+	}
+
+	if configuration.JWKSURI == "" {
+		return "", stacktrace.NewError(fmt.Sprintf("the openid configuration at [%s] has no jwks_uri", url))
+	}
+
+	return configuration.JWKSURI, nil
+}
+
+func (cache *jwksCache) get(ctx context.Context, url string, value any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create request for [%s]", url))
+	}
+
+	response, err := cache.client.Do(request)
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot send request to [%s]", url))
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return stacktrace.NewError(fmt.Sprintf("[%s] responded with status [%d]", url, response.StatusCode))
+	}
+
+	if err = json.NewDecoder(response.Body).Decode(value); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot decode response from [%s] into [%T]", url, value))
+	}
+
+	return nil
+}
+
+// publicKey parses an RSA or an elliptic curve jsonWebKey
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "cannot decode the modulus of the RSA key")
+		}
+
+		e, err := decodeBigInt(jwk.E)
+		if err != nil || !e.IsInt64() {
+			return nil, stacktrace.NewError("cannot decode the exponent of the RSA key")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, stacktrace.NewError(fmt.Sprintf("the curve [%s] is not supported", jwk.Crv))
+		}
+
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "cannot decode the x coordinate of the EC key")
+		}
+
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "cannot decode the y coordinate of the EC key")
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, stacktrace.NewError(fmt.Sprintf("the EC key is not on the curve [%s]", jwk.Crv))
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, stacktrace.NewError(fmt.Sprintf("the key type [%s] is not supported", jwk.Kty))
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot decode [%s] as base64url", value))
+	}
+	return new(big.Int).SetBytes(bytes), nil
+}
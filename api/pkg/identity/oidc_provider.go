@@ -0,0 +1,235 @@
+package identity
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/palantir/stacktrace"
+)
+
+// oidcClockSkew is the difference between the clocks of the issuer and the API which is tolerated when checking the time claims
+const oidcClockSkew = time.Minute
+
+// OIDCConfig configures a Provider for an OpenID Connect issuer e.g Keycloak or Auth0
+type OIDCConfig struct {
+	// Issuer must match the iss claim of the tokens
+	Issuer string
+	// Audience must be one of the values in the aud claim of the tokens
+	Audience string
+	// JWKSURL is the URL of the signing keys. It is discovered from the OpenID configuration of the issuer when it is empty.
+	JWKSURL string
+	// Subject restricts the tokens to a single sub claim e.g the service account which sends push requests. Every subject is allowed when it is empty.
+	Subject string
+	// Claims maps the claims of the tokens to an entities.AuthUser
+	Claims ClaimMapping
+	// CacheTTL is how long the signing keys are cached
+	CacheTTL time.Duration
+}
+
+type oidcHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type oidcProvider struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	config OIDCConfig
+	keys   *jwksCache
+}
+
+// NewOIDCProvider creates a Provider which verifies JSON web tokens which are signed with the keys of an OpenID Connect issuer.
+// Tokens which are signed with RSA or ECDSA keys are supported.
+func NewOIDCProvider(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	client *http.Client,
+	config OIDCConfig,
+) Provider {
+	config.Claims = config.Claims.WithDefaults()
+	if config.CacheTTL == 0 {
+		config.CacheTTL = time.Hour
+	}
+
+	return &oidcProvider{
+		logger: logger.WithService(fmt.Sprintf("%T", &oidcProvider{})),
+		tracer: tracer,
+		config: config,
+		keys:   newJWKSCache(logger, tracer, client, config.Issuer, config.JWKSURL, config.CacheTTL),
+	}
+}
+
+// Verify checks the signature, issuer, audience and expiry of a JSON web token
+func (provider *oidcProvider) Verify(ctx context.Context, token string) (*entities.AuthUser, error) {
+	ctx, span := provider.tracer.Start(ctx)
+	defer span.End()
+
+	claims, err := provider.verifySignature(ctx, token)
+	if err != nil {
+		msg := fmt.Sprintf("cannot verify the signature of a token from issuer [%s]", provider.config.Issuer)
+		return nil, provider.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeInvalidToken, msg))
+	}
+
+	if err = provider.verifyClaims(claims, time.Now()); err != nil {
+		msg := fmt.Sprintf("cannot verify the claims of a token from issuer [%s]", provider.config.Issuer)
+		return nil, provider.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeInvalidToken, msg))
+	}
+
+	user, err := provider.config.Claims.AuthUser(claims)
+	if err != nil {
+		msg := fmt.Sprintf("cannot map the claims of a token from issuer [%s]", provider.config.Issuer)
+		return nil, provider.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeInvalidToken, msg))
+	}
+
+	return user, nil
+}
+
+// verifySignature checks the signature of a token with the key in its header and returns its claims
+func (provider *oidcProvider) verifySignature(ctx context.Context, token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, stacktrace.NewError(fmt.Sprintf("the token has [%d] parts instead of 3", len(parts)))
+	}
+
+	header := new(oidcHeader)
+	if err := provider.decodeSegment(parts[0], header); err != nil {
+		return nil, stacktrace.Propagate(err, "cannot decode the header of the token")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "cannot decode the signature of the token")
+	}
+
+	key, err := provider.keys.Key(ctx, header.Kid)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot load the key [%s] of the token", header.Kid))
+	}
+
+	if err = provider.verify(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("invalid [%s] signature with key [%s]", header.Alg, header.Kid))
+	}
+
+	claims := map[string]any{}
+	if err = provider.decodeSegment(parts[1], &claims); err != nil {
+		return nil, stacktrace.Propagate(err, "cannot decode the claims of the token")
+	}
+
+	return claims, nil
+}
+
+func (provider *oidcProvider) verify(alg string, key crypto.PublicKey, input string, signature []byte) error {
+	if len(alg) != 5 {
+		return stacktrace.NewError(fmt.Sprintf("the algorithm [%s] is not supported", alg))
+	}
+
+	var hash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return stacktrace.NewError(fmt.Sprintf("the algorithm [%s] is not supported", alg))
+	}
+
+	hasher := hash.New()
+	hasher.Write([]byte(input))
+	digest := hasher.Sum(nil)
+
+	switch {
+	case strings.HasPrefix(alg, "RS"), strings.HasPrefix(alg, "PS"):
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return stacktrace.NewError(fmt.Sprintf("the algorithm [%s] needs an RSA key", alg))
+		}
+		if strings.HasPrefix(alg, "PS") {
+			return rsa.VerifyPSS(rsaKey, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		return rsa.VerifyPKCS1v15(rsaKey, hash, digest, signature)
+	case strings.HasPrefix(alg, "ES"):
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return stacktrace.NewError(fmt.Sprintf("the algorithm [%s] needs an EC key", alg))
+		}
+
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return stacktrace.NewError(fmt.Sprintf("the [%s] signature has [%d] bytes instead of [%d]", alg, len(signature), 2*size))
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return stacktrace.NewError("the ECDSA signature is invalid")
+		}
+		return nil
+	default:
+		return stacktrace.NewError(fmt.Sprintf("the algorithm [%s] is not supported", alg))
+	}
+}
+
+// verifyClaims checks the registered claims of a token at a point in time
+func (provider *oidcProvider) verifyClaims(claims map[string]any, now time.Time) error {
+	if issuer, _ := claims["iss"].(string); issuer != provider.config.Issuer {
+		return stacktrace.NewError(fmt.Sprintf("the issuer [%s] is not [%s]", issuer, provider.config.Issuer))
+	}
+
+	if !provider.hasAudience(claims["aud"]) {
+		return stacktrace.NewError(fmt.Sprintf("the audience [%v] does not contain [%s]", claims["aud"], provider.config.Audience))
+	}
+
+	expiresAt, ok := claims["exp"].(float64)
+	if !ok {
+		return stacktrace.NewError("the token has no exp claim")
+	}
+
+	if now.Add(-oidcClockSkew).After(time.Unix(int64(expiresAt), 0)) {
+		return stacktrace.NewError(fmt.Sprintf("the token expired at [%s]", time.Unix(int64(expiresAt), 0)))
+	}
+
+	if notBefore, ok := claims["nbf"].(float64); ok && now.Add(oidcClockSkew).Before(time.Unix(int64(notBefore), 0)) {
+		return stacktrace.NewError(fmt.Sprintf("the token cannot be used before [%s]", time.Unix(int64(notBefore), 0)))
+	}
+
+	if subject, _ := claims["sub"].(string); provider.config.Subject != "" && subject != provider.config.Subject {
+		return stacktrace.NewError(fmt.Sprintf("the subject [%s] is not [%s]", subject, provider.config.Subject))
+	}
+
+	return nil
+}
+
+func (provider *oidcProvider) hasAudience(claim any) bool {
+	switch audience := claim.(type) {
+	case string:
+		return audience == provider.config.Audience
+	case []any:
+		for _, value := range audience {
+			if value == provider.config.Audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (provider *oidcProvider) decodeSegment(segment string, value any) error {
+	bytes, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return stacktrace.Propagate(err, "cannot decode segment as base64url")
+	}
+	return json.Unmarshal(bytes, value)
+}
@@ -0,0 +1,270 @@
+package identity
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/hirosassa/zerodriver"
+	"github.com/palantir/stacktrace"
+	"github.com/rs/zerolog"
+)
+
+const testAudience = "superbutton"
+
+// testIssuer serves the OpenID configuration and the signing keys of an issuer
+type testIssuer struct {
+	server     *httptest.Server
+	rsaKey     *rsa.PrivateKey
+	ecKey      *ecdsa.PrivateKey
+	keyFetches int32
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("cannot generate RSA key: %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate EC key: %v", err)
+	}
+
+	issuer := &testIssuer{rsaKey: rsaKey, ecKey: ecKey}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(openIDConfiguration{Issuer: issuer.server.URL, JWKSURI: issuer.server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&issuer.keyFetches, 1)
+		_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{
+			{
+				Kty: "RSA",
+				Kid: "rsa",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				Kty: "EC",
+				Kid: "ec",
+				Crv: "P-256",
+				X:   base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+				Y:   base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+			},
+			{Kty: "RSA", Kid: "encryption", Use: "enc", N: "AQAB", E: "AQAB"},
+		}})
+	})
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (issuer *testIssuer) provider(config OIDCConfig) Provider {
+	nop := zerolog.Nop()
+	logger := telemetry.NewZerologLogger("test", map[string]string{}, &zerodriver.Logger{Logger: &nop}, nil)
+	config.Issuer = issuer.server.URL
+	if config.Audience == "" {
+		config.Audience = testAudience
+	}
+	return NewOIDCProvider(logger, telemetry.NewOtelLogger("test", logger), issuer.server.Client(), config)
+}
+
+func (issuer *testIssuer) claims() map[string]any {
+	return map[string]any{
+		"iss":            issuer.server.URL,
+		"aud":            testAudience,
+		"sub":            "user-1",
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"name":           "Jane",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func (issuer *testIssuer) sign(t *testing.T, alg string, kid string, claims map[string]any) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("cannot marshal claims: %v", err)
+	}
+
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(input))
+
+	var signature []byte
+	switch alg {
+	case "RS256":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, issuer.rsaKey, crypto.SHA256, digest[:])
+	case "ES256":
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, issuer.ecKey, digest[:])
+		if err == nil {
+			signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		}
+	case "none":
+	default:
+		t.Fatalf("cannot sign with algorithm [%s]", alg)
+	}
+	if err != nil {
+		t.Fatalf("cannot sign token: %v", err)
+	}
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestOIDCProvider_VerifyRSAToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	user, err := issuer.provider(OIDCConfig{}).Verify(context.Background(), issuer.sign(t, "RS256", "rsa", issuer.claims()))
+	if err != nil {
+		t.Fatalf("cannot verify token: %v", err)
+	}
+
+	if user.ID != "user-1" || user.Email != "jane@example.com" || user.Name != "Jane" || !user.EmailVerified {
+		t.Errorf("got user [%+v], want the claims of the token", user)
+	}
+}
+
+func TestOIDCProvider_VerifyECToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	if _, err := issuer.provider(OIDCConfig{}).Verify(context.Background(), issuer.sign(t, "ES256", "ec", issuer.claims())); err != nil {
+		t.Fatalf("cannot verify token: %v", err)
+	}
+}
+
+func TestOIDCProvider_VerifyRejectsInvalidTokens(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider(OIDCConfig{})
+
+	valid := issuer.sign(t, "RS256", "rsa", issuer.claims())
+	parts := strings.Split(valid, ".")
+	tampered := issuer.claims()
+	tampered["sub"] = "admin"
+	tamperedPayload, _ := json.Marshal(tampered)
+
+	// a token signed with the EC key which claims the ID of the RSA key
+	ecParts := strings.Split(issuer.sign(t, "ES256", "ec", issuer.claims()), ".")
+	mismatchedHeader, _ := json.Marshal(map[string]string{"alg": "ES256", "kid": "rsa"})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "malformed token", token: "not-a-token"},
+		{name: "tampered claims", token: parts[0] + "." + base64.RawURLEncoding.EncodeToString(tamperedPayload) + "." + parts[2]},
+		{name: "unsigned token", token: issuer.sign(t, "none", "rsa", issuer.claims())},
+		{name: "unknown key", token: issuer.sign(t, "RS256", "unknown", issuer.claims())},
+		{name: "encryption key", token: issuer.sign(t, "RS256", "encryption", issuer.claims())},
+		{name: "EC algorithm with RSA key", token: base64.RawURLEncoding.EncodeToString(mismatchedHeader) + "." + ecParts[1] + "." + ecParts[2]},
+	}
+
+	for _, test := range tests {
+		_, err := provider.Verify(context.Background(), test.token)
+		if stacktrace.GetCode(err) != ErrCodeInvalidToken {
+			t.Errorf("got error [%v] for %s, want code [%d]", err, test.name, ErrCodeInvalidToken)
+		}
+	}
+}
+
+func TestOIDCProvider_VerifyClaims(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider(OIDCConfig{Subject: "user-1"}).(*oidcProvider)
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		change func(claims map[string]any)
+		valid  bool
+	}{
+		{name: "valid claims", change: func(claims map[string]any) {}, valid: true},
+		{name: "audience list", change: func(claims map[string]any) { claims["aud"] = []any{"other", testAudience} }, valid: true},
+		{name: "expired within the clock skew", change: func(claims map[string]any) { claims["exp"] = float64(now.Add(-30 * time.Second).Unix()) }, valid: true},
+		{name: "other issuer", change: func(claims map[string]any) { claims["iss"] = "https://evil.com" }, valid: false},
+		{name: "other audience", change: func(claims map[string]any) { claims["aud"] = "other" }, valid: false},
+		{name: "audience list without the audience", change: func(claims map[string]any) { claims["aud"] = []any{"other"} }, valid: false},
+		{name: "expired token", change: func(claims map[string]any) { claims["exp"] = float64(now.Add(-2 * time.Minute).Unix()) }, valid: false},
+		{name: "missing expiry", change: func(claims map[string]any) { delete(claims, "exp") }, valid: false},
+		{name: "not yet valid", change: func(claims map[string]any) { claims["nbf"] = float64(now.Add(5 * time.Minute).Unix()) }, valid: false},
+		{name: "other subject", change: func(claims map[string]any) { claims["sub"] = "user-2" }, valid: false},
+	}
+
+	for _, test := range tests {
+		claims := issuer.claims()
+		claims["exp"] = float64(now.Add(time.Hour).Unix())
+		test.change(claims)
+
+		if err := provider.verifyClaims(claims, now); (err == nil) != test.valid {
+			t.Errorf("verifyClaims with %s returned [%v], want valid [%t]", test.name, err, test.valid)
+		}
+	}
+}
+
+func TestOIDCProvider_CachesKeys(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider(OIDCConfig{})
+
+	for i := 0; i < 3; i++ {
+		if _, err := provider.Verify(context.Background(), issuer.sign(t, "RS256", "rsa", issuer.claims())); err != nil {
+			t.Fatalf("cannot verify token: %v", err)
+		}
+	}
+
+	// an unknown key does not fetch the keys again within the refresh interval
+	_, _ = provider.Verify(context.Background(), issuer.sign(t, "RS256", "unknown", issuer.claims()))
+
+	if fetches := atomic.LoadInt32(&issuer.keyFetches); fetches != 1 {
+		t.Errorf("got [%d] key fetches, want [1]", fetches)
+	}
+}
+
+func TestOIDCProvider_UsesConfiguredJWKSURL(t *testing.T) {
+	issuer := newTestIssuer(t)
+	provider := issuer.provider(OIDCConfig{JWKSURL: issuer.server.URL + "/missing"})
+
+	if _, err := provider.Verify(context.Background(), issuer.sign(t, "RS256", "rsa", issuer.claims())); err == nil {
+		t.Error("the token was verified with the discovered keys instead of the configured URL")
+	}
+}
+
+func TestClaimMapping_AuthUser(t *testing.T) {
+	mapping := ClaimMapping{ID: "user_id", Email: "mail"}.WithDefaults()
+
+	user, err := mapping.AuthUser(map[string]any{"user_id": "user-1", "mail": "jane@example.com", "email_verified": "true"})
+	if err != nil {
+		t.Fatalf("cannot map claims: %v", err)
+	}
+
+	if user.ID != "user-1" || user.Email != "jane@example.com" || !user.EmailVerified || user.Name != "" {
+		t.Errorf("got user [%+v], want the mapped claims", user)
+	}
+
+	if _, err = mapping.AuthUser(map[string]any{"user_id": "user-1"}); stacktrace.GetCode(err) != ErrCodeInvalidToken {
+		t.Errorf("got error [%v] without an email, want code [%d]", err, ErrCodeInvalidToken)
+	}
+
+	if _, err = mapping.AuthUser(map[string]any{"sub": "user-1", "mail": "jane@example.com"}); err == nil {
+		t.Error("the default ID claim was used instead of the mapped claim")
+	}
+}
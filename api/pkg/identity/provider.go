@@ -0,0 +1,91 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/palantir/stacktrace"
+)
+
+// ErrCodeInvalidToken is returned when an ID token cannot be verified
+const ErrCodeInvalidToken = stacktrace.ErrorCode(7000)
+
+// Provider verifies the ID tokens which are issued by an identity provider
+type Provider interface {
+	// Verify checks the signature and the claims of an ID token and maps it to an entities.AuthUser
+	Verify(ctx context.Context, token string) (*entities.AuthUser, error)
+}
+
+// ClaimMapping is the name of the claims in an ID token which are mapped to an entities.AuthUser
+type ClaimMapping struct {
+	ID            string
+	Email         string
+	EmailVerified string
+	Name          string
+}
+
+// DefaultClaimMapping returns the ClaimMapping of the standard OpenID Connect claims
+func DefaultClaimMapping() ClaimMapping {
+	return ClaimMapping{
+		ID:            "sub",
+		Email:         "email",
+		EmailVerified: "email_verified",
+		Name:          "name",
+	}
+}
+
+// WithDefaults sets the standard OpenID Connect claim for every claim which is not mapped
+func (mapping ClaimMapping) WithDefaults() ClaimMapping {
+	defaults := DefaultClaimMapping()
+	if mapping.ID == "" {
+		mapping.ID = defaults.ID
+	}
+	if mapping.Email == "" {
+		mapping.Email = defaults.Email
+	}
+	if mapping.EmailVerified == "" {
+		mapping.EmailVerified = defaults.EmailVerified
+	}
+	if mapping.Name == "" {
+		mapping.Name = defaults.Name
+	}
+	return mapping
+}
+
+// AuthUser maps the claims of a verified ID token to an entities.AuthUser.
+// The ID and email claims are required, the name and email verified claims are optional.
+func (mapping ClaimMapping) AuthUser(claims map[string]any) (*entities.AuthUser, error) {
+	id, ok := claims[mapping.ID].(string)
+	if !ok || id == "" {
+		return nil, stacktrace.NewErrorWithCode(ErrCodeInvalidToken, fmt.Sprintf("the [%s] claim is missing in the token", mapping.ID))
+	}
+
+	email, ok := claims[mapping.Email].(string)
+	if !ok || email == "" {
+		return nil, stacktrace.NewErrorWithCode(ErrCodeInvalidToken, fmt.Sprintf("the [%s] claim is missing in the token", mapping.Email))
+	}
+
+	name, _ := claims[mapping.Name].(string)
+
+	return &entities.AuthUser{
+		ID:            entities.UserID(id),
+		Name:          name,
+		Email:         email,
+		EmailVerified: mapping.boolClaim(claims[mapping.EmailVerified]),
+	}, nil
+}
+
+// boolClaim parses a boolean claim which some providers encode as a string
+func (mapping ClaimMapping) boolClaim(value any) bool {
+	switch claim := value.(type) {
+	case bool:
+		return claim
+	case string:
+		parsed, _ := strconv.ParseBool(claim)
+		return parsed
+	default:
+		return false
+	}
+}
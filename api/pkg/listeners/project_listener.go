@@ -0,0 +1,101 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/events"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/palantir/stacktrace"
+)
+
+// ProjectListener listens for events with project handlers
+type ProjectListener struct {
+	tracer            telemetry.Tracer
+	logger            telemetry.Logger
+	service           *services.ProjectIntegrationService
+	submissionService *services.ContactFormSubmissionService
+	widgetService     *services.WidgetEventService
+	analyticsService  *services.WidgetAnalyticsService
+	settingsService   *services.ProjectSettingsService
+}
+
+// ProjectListeners returns the list of project listeners to events
+func ProjectListeners(
+	tracer telemetry.Tracer,
+	logger telemetry.Logger,
+	service *services.ProjectIntegrationService,
+	submissionService *services.ContactFormSubmissionService,
+	widgetService *services.WidgetEventService,
+	analyticsService *services.WidgetAnalyticsService,
+	settingsService *services.ProjectSettingsService,
+) map[string]services.EventListener {
+	listener := &ProjectListener{
+		tracer:            tracer,
+		logger:            logger.WithService(fmt.Sprintf("%T", &ProjectListener{})),
+		service:           service,
+		submissionService: submissionService,
+		widgetService:     widgetService,
+		analyticsService:  analyticsService,
+		settingsService:   settingsService,
+	}
+	return map[string]services.EventListener{
+		events.ProjectDeleted: listener.OnProjectDeleted,
+	}
+}
+
+// OnProjectDeleted handles the events.ProjectDeleted event by deleting the data of the project.
+// The webhooks of the project are deleted by the WebhookListener after the event is delivered to them.
+func (listener *ProjectListener) OnProjectDeleted(ctx context.Context, event cloudevents.Event) error {
+	ctx, span, ctxLogger := listener.tracer.StartWithLogger(ctx, listener.logger)
+	defer span.End()
+
+	var payload events.ProjectDeletedPayload
+	if err := event.DataAs(&payload); err != nil {
+		msg := fmt.Sprintf("cannot decode [%s] into [%T]", event.Data(), payload)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	result, err := listener.service.DeleteAll(ctx, &services.ProjectIntegrationsDeleteParams{
+		Source:    event.Source(),
+		ProjectID: payload.ProjectID,
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete integrations of project [%s] for event with ID [%s]", payload.ProjectID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	for integrationType, count := range result.Integrations {
+		ctxLogger.Info(fmt.Sprintf("deleted [%d] [%s] integrations of project [%s]", count, integrationType, payload.ProjectID))
+	}
+	ctxLogger.Info(fmt.Sprintf("deleted [%d] project integrations of project [%s]", result.ProjectIntegrations, payload.ProjectID))
+
+	count, err := listener.submissionService.DeleteAll(ctx, payload.ProjectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete contact form submissions of project [%s] for event with ID [%s]", payload.ProjectID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+	ctxLogger.Info(fmt.Sprintf("deleted [%d] contact form submissions of project [%s]", count, payload.ProjectID))
+
+	if count, err = listener.widgetService.DeleteAll(ctx, payload.ProjectID); err != nil {
+		msg := fmt.Sprintf("cannot delete widget events of project [%s] for event with ID [%s]", payload.ProjectID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+	ctxLogger.Info(fmt.Sprintf("deleted [%d] widget events of project [%s]", count, payload.ProjectID))
+
+	if count, err = listener.analyticsService.DeleteAll(ctx, payload.ProjectID); err != nil {
+		msg := fmt.Sprintf("cannot delete widget event rollups of project [%s] for event with ID [%s]", payload.ProjectID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+	ctxLogger.Info(fmt.Sprintf("deleted [%d] widget event rollups of project [%s]", count, payload.ProjectID))
+
+	if count, err = listener.settingsService.DeleteBlockedOrigins(ctx, payload.ProjectID); err != nil {
+		msg := fmt.Sprintf("cannot delete blocked origins of project [%s] for event with ID [%s]", payload.ProjectID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+	ctxLogger.Info(fmt.Sprintf("deleted [%d] blocked origins of project [%s]", count, payload.ProjectID))
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/events"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// ProjectSettingsListener refreshes the settings snapshot of a project when the project or its integrations change
+type ProjectSettingsListener struct {
+	tracer  telemetry.Tracer
+	logger  telemetry.Logger
+	service *services.ProjectSettingsService
+}
+
+// projectSettingsPayload contains the fields which are common to the payloads of the project and integration events
+type projectSettingsPayload struct {
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+// ProjectSettingsListeners returns the list of project settings listeners to events
+func ProjectSettingsListeners(tracer telemetry.Tracer, logger telemetry.Logger, service *services.ProjectSettingsService) map[string]services.EventListener {
+	listener := &ProjectSettingsListener{
+		tracer:  tracer,
+		logger:  logger.WithService(fmt.Sprintf("%T", &ProjectSettingsListener{})),
+		service: service,
+	}
+	return map[string]services.EventListener{
+		events.ProjectUpdated:                 listener.OnProjectChanged,
+		events.ProjectDeleted:                 listener.OnProjectChanged,
+		events.IntegrationCreated:             listener.OnProjectChanged,
+		events.IntegrationUpdated:             listener.OnProjectChanged,
+		events.IntegrationDeleted:             listener.OnProjectChanged,
+		events.IntegrationReordered:           listener.OnProjectChanged,
+		events.IntegrationDisplayRulesUpdated: listener.OnProjectChanged,
+	}
+}
+
+// OnProjectChanged rebuilds the settings snapshot of the project in an event from the live data.
+// Events can be delivered out of order, so the snapshot is never built from the payload of the event.
+func (listener *ProjectSettingsListener) OnProjectChanged(ctx context.Context, event cloudevents.Event) error {
+	ctx, span, ctxLogger := listener.tracer.StartWithLogger(ctx, listener.logger)
+	defer span.End()
+
+	var payload projectSettingsPayload
+	if err := event.DataAs(&payload); err != nil {
+		msg := fmt.Sprintf("cannot decode [%s] into [%T]", event.Data(), payload)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err := listener.service.RefreshSnapshot(ctx, payload.ProjectID); err != nil {
+		msg := fmt.Sprintf("cannot refresh settings snapshot of project [%s] for [%s] event with ID [%s]", payload.ProjectID, event.Type(), event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("refreshed settings snapshot of project [%s] for [%s] event with ID [%s]", payload.ProjectID, event.Type(), event.ID()))
+	return nil
+}
@@ -26,8 +26,15 @@ func UserListeners(tracer telemetry.Tracer, logger telemetry.Logger, service *se
 		service: service,
 	}
 	return map[string]services.EventListener{
-		events.UserSubscriptionCreated:   listener.OnUserSubscriptionCreated,
-		events.UserSubscriptionCancelled: listener.OnUserSubscriptionCancelled,
+		events.UserSubscriptionCreated:          listener.OnUserSubscriptionCreated,
+		events.UserSubscriptionCancelled:        listener.OnUserSubscriptionCancelled,
+		events.UserSubscriptionUpdated:          listener.OnUserSubscriptionUpdated,
+		events.UserSubscriptionResumed:          listener.OnUserSubscriptionResumed,
+		events.UserSubscriptionExpired:          listener.OnUserSubscriptionExpired,
+		events.UserSubscriptionPaused:           listener.OnUserSubscriptionPaused,
+		events.UserSubscriptionUnpaused:         listener.OnUserSubscriptionUnpaused,
+		events.UserSubscriptionPaymentFailed:    listener.OnUserSubscriptionPaymentFailed,
+		events.UserSubscriptionPaymentSucceeded: listener.OnUserSubscriptionPaymentSucceeded,
 	}
 }
 
@@ -68,3 +75,136 @@ func (listener *UserListener) OnUserSubscriptionCancelled(ctx context.Context, e
 
 	return nil
 }
+
+// OnUserSubscriptionUpdated handles the events.UserSubscriptionUpdated event
+func (listener *UserListener) OnUserSubscriptionUpdated(ctx context.Context, event cloudevents.Event) error {
+	ctx, span := listener.tracer.Start(ctx)
+	defer span.End()
+
+	var payload events.UserSubscriptionUpdatedPayload
+	if err := event.DataAs(&payload); err != nil {
+		msg := fmt.Sprintf("cannot decode [%s] into [%T]", event.Data(), payload)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err := listener.service.UpdateSubscription(ctx, event.Source(), &payload); err != nil {
+		msg := fmt.Sprintf("cannot update subscription for user with ID [%s] for event with ID [%s]", payload.UserID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// OnUserSubscriptionResumed handles the events.UserSubscriptionResumed event
+func (listener *UserListener) OnUserSubscriptionResumed(ctx context.Context, event cloudevents.Event) error {
+	ctx, span := listener.tracer.Start(ctx)
+	defer span.End()
+
+	var payload events.UserSubscriptionResumedPayload
+	if err := event.DataAs(&payload); err != nil {
+		msg := fmt.Sprintf("cannot decode [%s] into [%T]", event.Data(), payload)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err := listener.service.ResumeSubscription(ctx, event.Source(), &payload); err != nil {
+		msg := fmt.Sprintf("cannot resume subscription for user with ID [%s] for event with ID [%s]", payload.UserID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// OnUserSubscriptionExpired handles the events.UserSubscriptionExpired event
+func (listener *UserListener) OnUserSubscriptionExpired(ctx context.Context, event cloudevents.Event) error {
+	ctx, span := listener.tracer.Start(ctx)
+	defer span.End()
+
+	var payload events.UserSubscriptionExpiredPayload
+	if err := event.DataAs(&payload); err != nil {
+		msg := fmt.Sprintf("cannot decode [%s] into [%T]", event.Data(), payload)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err := listener.service.ExpireSubscription(ctx, event.Source(), &payload); err != nil {
+		msg := fmt.Sprintf("cannot expire subscription for user with ID [%s] for event with ID [%s]", payload.UserID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// OnUserSubscriptionPaused handles the events.UserSubscriptionPaused event
+func (listener *UserListener) OnUserSubscriptionPaused(ctx context.Context, event cloudevents.Event) error {
+	ctx, span := listener.tracer.Start(ctx)
+	defer span.End()
+
+	var payload events.UserSubscriptionPausedPayload
+	if err := event.DataAs(&payload); err != nil {
+		msg := fmt.Sprintf("cannot decode [%s] into [%T]", event.Data(), payload)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err := listener.service.PauseSubscription(ctx, event.Source(), &payload); err != nil {
+		msg := fmt.Sprintf("cannot pause subscription for user with ID [%s] for event with ID [%s]", payload.UserID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// OnUserSubscriptionUnpaused handles the events.UserSubscriptionUnpaused event
+func (listener *UserListener) OnUserSubscriptionUnpaused(ctx context.Context, event cloudevents.Event) error {
+	ctx, span := listener.tracer.Start(ctx)
+	defer span.End()
+
+	var payload events.UserSubscriptionUnpausedPayload
+	if err := event.DataAs(&payload); err != nil {
+		msg := fmt.Sprintf("cannot decode [%s] into [%T]", event.Data(), payload)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err := listener.service.UnpauseSubscription(ctx, event.Source(), &payload); err != nil {
+		msg := fmt.Sprintf("cannot unpause subscription for user with ID [%s] for event with ID [%s]", payload.UserID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// OnUserSubscriptionPaymentFailed handles the events.UserSubscriptionPaymentFailed event
+func (listener *UserListener) OnUserSubscriptionPaymentFailed(ctx context.Context, event cloudevents.Event) error {
+	ctx, span := listener.tracer.Start(ctx)
+	defer span.End()
+
+	var payload events.UserSubscriptionPaymentFailedPayload
+	if err := event.DataAs(&payload); err != nil {
+		msg := fmt.Sprintf("cannot decode [%s] into [%T]", event.Data(), payload)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err := listener.service.RecordSubscriptionPaymentFailure(ctx, event.Source(), &payload); err != nil {
+		msg := fmt.Sprintf("cannot record failed subscription payment for user with ID [%s] for event with ID [%s]", payload.UserID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// OnUserSubscriptionPaymentSucceeded handles the events.UserSubscriptionPaymentSucceeded event
+func (listener *UserListener) OnUserSubscriptionPaymentSucceeded(ctx context.Context, event cloudevents.Event) error {
+	ctx, span := listener.tracer.Start(ctx)
+	defer span.End()
+
+	var payload events.UserSubscriptionPaymentSucceededPayload
+	if err := event.DataAs(&payload); err != nil {
+		msg := fmt.Sprintf("cannot decode [%s] into [%T]", event.Data(), payload)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err := listener.service.RecordSubscriptionPaymentSuccess(ctx, event.Source(), &payload); err != nil {
+		msg := fmt.Sprintf("cannot record successful subscription payment for user with ID [%s] for event with ID [%s]", payload.UserID, event.ID())
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// WebhookListener sends the events of a project to the webhooks which subscribe to them
+type WebhookListener struct {
+	tracer  telemetry.Tracer
+	logger  telemetry.Logger
+	service *services.WebhookService
+}
+
+// webhookPayload contains the fields which are common to the payloads of the events which are sent to webhooks
+type webhookPayload struct {
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+// WebhookListeners returns the list of webhook listeners to events
+func WebhookListeners(tracer telemetry.Tracer, logger telemetry.Logger, service *services.WebhookService) map[string]services.EventListener {
+	listener := &WebhookListener{
+		tracer:  tracer,
+		logger:  logger.WithService(fmt.Sprintf("%T", &WebhookListener{})),
+		service: service,
+	}
+
+	listeners := map[string]services.EventListener{}
+	for _, eventType := range services.WebhookEventTypes() {
+		listeners[eventType] = listener.OnProjectEvent
+	}
+	return listeners
+}
+
+// OnProjectEvent queues a delivery of the event for every enabled webhook of the project which subscribes to it
+func (listener *WebhookListener) OnProjectEvent(ctx context.Context, event cloudevents.Event) error {
+	ctx, span := listener.tracer.Start(ctx)
+	defer span.End()
+
+	var payload webhookPayload
+	if err := event.DataAs(&payload); err != nil {
+		msg := fmt.Sprintf("cannot decode [%s] into [%T]", event.Data(), payload)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err := listener.service.Dispatch(ctx, payload.ProjectID, event); err != nil {
+		msg := fmt.Sprintf("cannot dispatch [%s] event with ID [%s] to the webhooks of project [%s]", event.Type(), event.ID(), payload.ProjectID)
+		return listener.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+)
+
+type logMailer struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewLogMailer creates a Mailer which logs emails instead of sending them.
+// It is used when developing locally so that links in emails can be copied from the logs.
+func NewLogMailer(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) Mailer {
+	return &logMailer{
+		logger: logger.WithService(fmt.Sprintf("%T", &logMailer{})),
+		tracer: tracer,
+	}
+}
+
+// Send logs the Message
+func (mailer *logMailer) Send(ctx context.Context, message *Message) error {
+	_, span, ctxLogger := mailer.tracer.StartWithLogger(ctx, mailer.logger)
+	defer span.End()
+
+	ctxLogger.Info(fmt.Sprintf("email [%s] to [%s]\n%s", message.Subject, message.ToEmail, message.Text))
+	return nil
+}
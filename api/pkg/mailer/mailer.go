@@ -0,0 +1,20 @@
+package mailer
+
+import (
+	"context"
+)
+
+// Message is an email which is sent to a single recipient
+type Message struct {
+	ToEmail string
+	ToName  string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Mailer sends transactional emails
+type Mailer interface {
+	// Send delivers the Message to the recipient
+	Send(ctx context.Context, message *Message) error
+}
@@ -0,0 +1,65 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/palantir/stacktrace"
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+type sendgridMailer struct {
+	logger    telemetry.Logger
+	tracer    telemetry.Tracer
+	client    *sendgrid.Client
+	fromEmail string
+	fromName  string
+}
+
+// NewSendgridMailer creates a Mailer which sends emails with the sendgrid API
+func NewSendgridMailer(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	apiKey string,
+	fromEmail string,
+	fromName string,
+) Mailer {
+	return &sendgridMailer{
+		logger:    logger.WithService(fmt.Sprintf("%T", &sendgridMailer{})),
+		tracer:    tracer,
+		client:    sendgrid.NewSendClient(apiKey),
+		fromEmail: fromEmail,
+		fromName:  fromName,
+	}
+}
+
+// Send delivers the Message with the sendgrid API
+func (mailer *sendgridMailer) Send(ctx context.Context, message *Message) error {
+	ctx, span, ctxLogger := mailer.tracer.StartWithLogger(ctx, mailer.logger)
+	defer span.End()
+
+	email := mail.NewSingleEmail(
+		mail.NewEmail(mailer.fromName, mailer.fromEmail),
+		message.Subject,
+		mail.NewEmail(message.ToName, message.ToEmail),
+		message.Text,
+		message.HTML,
+	)
+
+	response, err := mailer.client.SendWithContext(ctx, email)
+	if err != nil {
+		msg := fmt.Sprintf("cannot send email [%s] to [%s]", message.Subject, message.ToEmail)
+		return mailer.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if response.StatusCode >= http.StatusBadRequest {
+		msg := fmt.Sprintf("sendgrid responded with status [%d] and body [%s] for email [%s]", response.StatusCode, response.Body, message.Subject)
+		return mailer.tracer.WrapErrorSpan(span, stacktrace.NewError(msg))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("email [%s] sent to [%s] with status [%d]", message.Subject, message.ToEmail, response.StatusCode))
+	return nil
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Admin checks if the authenticated user is one of the admin users
+func Admin(tracer telemetry.Tracer, adminIDs []entities.UserID) fiber.Handler {
+	admins := make(map[entities.UserID]bool, len(adminIDs))
+	for _, adminID := range adminIDs {
+		admins[adminID] = true
+	}
+
+	return func(c *fiber.Ctx) error {
+		_, span := tracer.StartFromFiberCtx(c, "middlewares.Admin")
+		defer span.End()
+
+		if tokenUser, ok := c.Locals(ContextKeyAuthUserID).(entities.AuthUser); !ok || !admins[tokenUser.ID] {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"status":  "error",
+				"message": "You are not allowed to carry out this request.",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,98 @@
+package middlewares
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/gofiber/fiber/v2"
+	"github.com/palantir/stacktrace"
+)
+
+// APITokenAuth authenticates a user with an entities.APIToken in the bearer token
+func APITokenAuth(logger telemetry.Logger, tracer telemetry.Tracer, service *services.APITokenService) fiber.Handler {
+	logger = logger.WithService("middlewares.APITokenAuth")
+	return func(c *fiber.Ctx) error {
+		ctx, span := tracer.StartFromFiberCtx(c, "middlewares.APITokenAuth")
+		defer span.End()
+
+		authToken := strings.TrimPrefix(c.Get(authHeaderBearer), bearerScheme+" ")
+		if !strings.HasPrefix(authToken, entities.APITokenPrefix) {
+			span.AddEvent(fmt.Sprintf("The request header has no [%s] token with prefix [%s]", bearerScheme, entities.APITokenPrefix))
+			return c.Next()
+		}
+
+		ctxLogger := tracer.CtxLogger(logger, span)
+
+		authUser, err := service.Authenticate(ctx, authToken)
+		if err != nil {
+			ctxLogger.Warn(tracer.WrapErrorSpan(span, stacktrace.Propagate(err, "invalid api token")))
+			return c.Next()
+		}
+
+		c.Locals(ContextKeyAuthUserID, *authUser)
+
+		ctxLogger.Info(fmt.Sprintf("[%T] set successfully for user with ID [%s] and api token [%s]", authUser, authUser.ID, authUser.APITokenID))
+		return c.Next()
+	}
+}
+
+// APITokenScope checks if the user is allowed to access the route with the scopes of their entities.APIToken.
+// The routes which are not mapped to an entities.APITokenScope can only be accessed with an interactive session.
+func APITokenScope(tracer telemetry.Tracer) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		_, span := tracer.StartFromFiberCtx(c, "middlewares.APITokenScope")
+		defer span.End()
+
+		authUser, ok := c.Locals(ContextKeyAuthUserID).(entities.AuthUser)
+		if !ok || authUser.APITokenID == nil {
+			return c.Next()
+		}
+
+		scope, ok := routeScope(c.Method(), c.Route().Path)
+		if !ok {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"status":  "error",
+				"message": "This request cannot be carried out with an API token.",
+			})
+		}
+
+		if !authUser.HasScope(scope) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"status":  "error",
+				"message": fmt.Sprintf("Your API token needs the [%s] scope to carry out this request.", scope),
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// routeScope returns the entities.APITokenScope which is needed to access a route and false if the route cannot be accessed with an entities.APIToken
+func routeScope(method string, path string) (entities.APITokenScope, bool) {
+	read := method == fiber.MethodGet || method == fiber.MethodHead
+
+	switch {
+	case strings.HasPrefix(path, "/v1/projects/:projectID/analytics"):
+		return entities.APITokenScopeAnalyticsRead, read
+	case strings.HasPrefix(path, "/v1/projects/:projectID/webhooks"):
+		if read {
+			return entities.APITokenScopeWebhooksRead, true
+		}
+		return entities.APITokenScopeWebhooksWrite, true
+	case strings.HasPrefix(path, "/v1/projects/:projectID/") && strings.Contains(path, "integrations"):
+		if read {
+			return entities.APITokenScopeIntegrationsRead, true
+		}
+		return entities.APITokenScopeIntegrationsWrite, true
+	case strings.HasPrefix(path, "/v1/projects"):
+		if read {
+			return entities.APITokenScopeProjectsRead, true
+		}
+		return entities.APITokenScopeProjectsWrite, true
+	default:
+		return "", false
+	}
+}
@@ -1,22 +1,21 @@
 package middlewares
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
-	"firebase.google.com/go/auth"
 	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/identity"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
 	"github.com/gofiber/fiber/v2"
 	"github.com/palantir/stacktrace"
 )
 
-// FirebaseAuth authenticates a user based on the bearer token
-func FirebaseAuth(logger telemetry.Logger, tracer telemetry.Tracer, authClient *auth.Client) fiber.Handler {
-	logger = logger.WithService("middlewares.FirebaseAuth")
+// IDTokenAuth authenticates a user with the ID token in the bearer token which is verified by an identity.Provider
+func IDTokenAuth(logger telemetry.Logger, tracer telemetry.Tracer, provider identity.Provider) fiber.Handler {
+	logger = logger.WithService("middlewares.IDTokenAuth")
 	return func(c *fiber.Ctx) error {
-		_, span := tracer.StartFromFiberCtx(c, "middlewares.FirebaseAuth")
+		ctx, span := tracer.StartFromFiberCtx(c, "middlewares.IDTokenAuth")
 		defer span.End()
 
 		authToken := c.Get(authHeaderBearer)
@@ -29,29 +28,22 @@ func FirebaseAuth(logger telemetry.Logger, tracer telemetry.Tracer, authClient *
 			authToken = authToken[len(bearerScheme)+1:]
 		}
 
+		if strings.HasPrefix(authToken, entities.APITokenPrefix) {
+			span.AddEvent(fmt.Sprintf("The [%s] token is an api token", bearerScheme))
+			return c.Next()
+		}
+
 		ctxLogger := tracer.CtxLogger(logger, span)
 
-		token, err := authClient.VerifyIDToken(context.Background(), authToken)
+		authUser, err := provider.Verify(ctx, authToken)
 		if err != nil {
-			msg := fmt.Sprintf("invalid firebase id token %s", authToken)
-			ctxLogger.Error(tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg)))
+			ctxLogger.Warn(tracer.WrapErrorSpan(span, stacktrace.Propagate(err, fmt.Sprintf("invalid [%s] id token", bearerScheme))))
 			return c.Next()
 		}
 
 		span.AddEvent(fmt.Sprintf("[%s] token is valid", bearerScheme))
 
-		name := ""
-		if val, ok := token.Claims["name"].(string); ok {
-			name = val
-		}
-
-		authUser := entities.AuthUser{
-			Email: token.Claims["email"].(string),
-			Name:  name,
-			ID:    entities.UserID(token.Claims["user_id"].(string)),
-		}
-
-		c.Locals(ContextKeyAuthUserID, authUser)
+		c.Locals(ContextKeyAuthUserID, *authUser)
 
 		ctxLogger.Info(fmt.Sprintf("[%T] set successfully for user with ID [%s]", authUser, authUser.ID))
 		return c.Next()
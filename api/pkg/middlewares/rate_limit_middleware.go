@@ -0,0 +1,66 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+const (
+	// ContextKeyClientIP is the context key used to store the IP address of the client
+	ContextKeyClientIP = "client.ip"
+)
+
+// RateLimit allows at most max requests from the same client IP address in the expiration window.
+// The counters are stored in memory so the limit applies to each instance of the API.
+func RateLimit(tracer telemetry.Tracer, max int, expiration time.Duration) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        max,
+		Expiration: expiration,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return ClientIP(c)
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			_, span := tracer.StartFromFiberCtx(c, "middlewares.RateLimit")
+			defer span.End()
+
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"status":  "error",
+				"message": "You have sent too many requests, please try again later.",
+			})
+		},
+	})
+}
+
+// ResolveClientIP stores the IP address of the client which was appended to the X-Forwarded-For header by the
+// trusted proxies in front of the API. The client can set the first entries of the header to any value, so the
+// entry which is trustedProxies positions from the end is used. The remote address is used when trustedProxies is 0.
+func ResolveClientIP(trustedProxies int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(ContextKeyClientIP, clientIP(c, trustedProxies))
+		return c.Next()
+	}
+}
+
+// ClientIP returns the IP address of the client which is stored by the ResolveClientIP middleware
+func ClientIP(c *fiber.Ctx) string {
+	if ip, ok := c.Locals(ContextKeyClientIP).(string); ok && ip != "" {
+		return ip
+	}
+	return c.IP()
+}
+
+func clientIP(c *fiber.Ctx, trustedProxies int) string {
+	ips := c.IPs()
+	if trustedProxies <= 0 || len(ips) == 0 {
+		return c.IP()
+	}
+
+	if len(ips) < trustedProxies {
+		return ips[0]
+	}
+
+	return ips[len(ips)-trustedProxies]
+}
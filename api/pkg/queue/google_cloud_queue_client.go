@@ -2,15 +2,27 @@ package queue
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	cloudtasks "cloud.google.com/go/cloudtasks/apiv2"
 	"cloud.google.com/go/cloudtasks/apiv2/cloudtaskspb"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
 	"github.com/palantir/stacktrace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// HeaderMaxAttempts is the header which carries the RetryPolicy.MaxAttempts of a task delivered by Cloud Tasks
+// because Cloud Tasks only supports retry policies per queue.
+const HeaderMaxAttempts = "X-Task-Max-Attempts"
+
+// HeaderCloudTasksRetryCount is the header which Cloud Tasks sets to the number of times a task has been retried
+const HeaderCloudTasksRetryCount = "X-CloudTasks-TaskRetryCount"
+
 type googlePushQueue struct {
 	logger    telemetry.Logger
 	tracer    telemetry.Tracer
@@ -19,7 +31,8 @@ type googlePushQueue struct {
 	authEmail string
 }
 
-// NewGooglePushQueue creates a new googlePushQueue
+// NewGooglePushQueue creates a new googlePushQueue.
+// The backoff of a Task.RetryPolicy is ignored so the retry config of the Cloud Tasks queue must match the tasks which are added to it.
 func NewGooglePushQueue(
 	logger telemetry.Logger,
 	tracer telemetry.Tracer,
@@ -55,9 +68,7 @@ func (queue *googlePushQueue) Enqueue(ctx context.Context, task *Task) (queueID
 							ServiceAccountEmail: queue.authEmail,
 						},
 					},
-					Headers: map[string]string{
-						"Content-Type": "application/json",
-					},
+					Headers: queue.headers(task),
 				},
 			},
 		},
@@ -66,7 +77,20 @@ func (queue *googlePushQueue) Enqueue(ctx context.Context, task *Task) (queueID
 	// Add a payload message if one is present.
 	req.Task.GetHttpRequest().Body = task.Body
 
+	if task.ScheduleTime != nil {
+		req.Task.ScheduleTime = timestamppb.New(*task.ScheduleTime)
+	}
+
+	if task.DeduplicationKey != "" {
+		req.Task.Name = fmt.Sprintf("%s/tasks/%x", queue.queueName, sha256.Sum256([]byte(task.DeduplicationKey)))
+	}
+
 	queueTask, err := queue.client.CreateTask(ctx, req)
+	if status.Code(err) == codes.AlreadyExists {
+		ctxLogger.Info(fmt.Sprintf("task [%s] with deduplication key [%s] already exists in [%s] queue", req.Task.Name, task.DeduplicationKey, queue.queueName))
+		return req.Task.Name, nil
+	}
+
 	if err != nil {
 		msg := fmt.Sprintf("cannot schedule task %s to URL: %s", string(task.Body), task.URL)
 		return queueID, queue.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
@@ -82,6 +106,22 @@ func (queue *googlePushQueue) Enqueue(ctx context.Context, task *Task) (queueID
 	return queueTask.Name, nil
 }
 
+func (queue *googlePushQueue) headers(task *Task) map[string]string {
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+
+	for key, value := range task.Headers {
+		headers[key] = value
+	}
+
+	if task.RetryPolicy != nil && task.RetryPolicy.MaxAttempts > 0 {
+		headers[HeaderMaxAttempts] = strconv.FormatUint(uint64(task.RetryPolicy.MaxAttempts), 10)
+	}
+
+	return headers
+}
+
 func (queue *googlePushQueue) httpMethodToProtoHTTPMethod(httpMethod string) cloudtaskspb.HttpMethod {
 	method, ok := map[string]cloudtaskspb.HttpMethod{
 		http.MethodGet:  cloudtaskspb.HttpMethod_GET,
@@ -0,0 +1,150 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+type inMemoryQueueTask struct {
+	id       string
+	task     *Task
+	attempts uint
+}
+
+type inMemoryQueue struct {
+	logger      telemetry.Logger
+	tracer      telemetry.Tracer
+	consumer    Consumer
+	tasks       chan *inMemoryQueueTask
+	retryPolicy *RetryPolicy
+	mutex       sync.Mutex
+	keys        map[string]string
+}
+
+// NewInMemoryQueue creates a Client which processes tasks with a pool of goroutines.
+// Tasks are lost when the process exits, so it should only be used for local development and tests.
+func NewInMemoryQueue(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	consumer Consumer,
+	workers uint,
+	retryPolicy *RetryPolicy,
+) Client {
+	queue := &inMemoryQueue{
+		logger:      logger.WithService(fmt.Sprintf("%T", &inMemoryQueue{})),
+		tracer:      tracer,
+		consumer:    consumer,
+		tasks:       make(chan *inMemoryQueueTask, 1000),
+		retryPolicy: retryPolicy,
+		keys:        make(map[string]string),
+	}
+
+	for i := uint(0); i < workers; i++ {
+		go queue.work()
+	}
+
+	return queue
+}
+
+// Enqueue a task to the queue
+func (queue *inMemoryQueue) Enqueue(ctx context.Context, task *Task) (queueID string, err error) {
+	_, span, ctxLogger := queue.tracer.StartWithLogger(ctx, queue.logger)
+	defer span.End()
+
+	item := &inMemoryQueueTask{id: uuid.NewString(), task: task}
+	if id, exists := queue.reserveKey(task.DeduplicationKey, item.id); exists {
+		ctxLogger.Info(fmt.Sprintf("task [%s] with deduplication key [%s] already exists in the in-memory queue", id, task.DeduplicationKey))
+		return id, nil
+	}
+
+	if task.ScheduleTime != nil && task.ScheduleTime.After(time.Now()) {
+		time.AfterFunc(time.Until(*task.ScheduleTime), func() {
+			queue.tasks <- item
+		})
+		return item.id, nil
+	}
+
+	select {
+	case queue.tasks <- item:
+		return item.id, nil
+	default:
+		queue.releaseKey(task.DeduplicationKey, item.id)
+		msg := fmt.Sprintf("cannot enqueue task to URL [%s] because the in-memory queue is full", task.URL)
+		return queueID, queue.tracer.WrapErrorSpan(span, stacktrace.NewError(msg))
+	}
+}
+
+// reserveKey stores the ID of the task with a deduplication key and returns the existing ID if the key is taken
+func (queue *inMemoryQueue) reserveKey(key string, id string) (string, bool) {
+	if key == "" {
+		return id, false
+	}
+
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if existing, ok := queue.keys[key]; ok {
+		return existing, true
+	}
+
+	queue.keys[key] = id
+	return id, false
+}
+
+// releaseKey frees the deduplication key of a task which is no longer in the queue so the key can be enqueued again
+func (queue *inMemoryQueue) releaseKey(key string, id string) {
+	if key == "" {
+		return
+	}
+
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if queue.keys[key] == id {
+		delete(queue.keys, key)
+	}
+}
+
+func (queue *inMemoryQueue) work() {
+	for item := range queue.tasks {
+		queue.process(item)
+	}
+}
+
+func (queue *inMemoryQueue) process(item *inMemoryQueueTask) {
+	ctx, span, ctxLogger := queue.tracer.StartWithLogger(context.Background(), queue.logger)
+	defer span.End()
+
+	item.attempts++
+	policy := item.task.retryPolicy(queue.retryPolicy)
+	if item.attempts >= policy.MaxAttempts {
+		ctx = WithLastAttempt(ctx)
+	}
+
+	err := queue.consumer(ctx, item.task)
+	if err == nil {
+		queue.releaseKey(item.task.DeduplicationKey, item.id)
+		ctxLogger.Info(fmt.Sprintf("in-memory queue task [%s] processed after [%d] attempts", item.id, item.attempts))
+		return
+	}
+
+	if item.attempts >= policy.MaxAttempts {
+		queue.releaseKey(item.task.DeduplicationKey, item.id)
+		msg := fmt.Sprintf("dropping in-memory queue task [%s] after [%d] attempts", item.id, item.attempts)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return
+	}
+
+	backoff := policy.Backoff(item.attempts)
+	ctxLogger.Warn(stacktrace.Propagate(err, fmt.Sprintf("in-memory queue task [%s] failed on attempt [%d], retrying in [%s]", item.id, item.attempts, backoff)))
+
+	time.AfterFunc(backoff, func() {
+		queue.tasks <- item
+	})
+}
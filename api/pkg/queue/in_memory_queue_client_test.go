@@ -0,0 +1,202 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/hirosassa/zerodriver"
+	"github.com/rs/zerolog"
+)
+
+func newTestTelemetry() (telemetry.Logger, telemetry.Tracer) {
+	nop := zerolog.Nop()
+	logger := telemetry.NewZerologLogger("test", map[string]string{}, &zerodriver.Logger{Logger: &nop}, nil)
+	return logger, telemetry.NewOtelLogger("test", logger)
+}
+
+// recordingConsumer fails the first failures deliveries of every task and records the deliveries
+type recordingConsumer struct {
+	mutex        sync.Mutex
+	failures     int
+	attempts     map[string]int
+	lastAttempts map[string]bool
+	delivered    chan string
+}
+
+func newRecordingConsumer(failures int) *recordingConsumer {
+	return &recordingConsumer{
+		failures:     failures,
+		attempts:     map[string]int{},
+		lastAttempts: map[string]bool{},
+		delivered:    make(chan string, 100),
+	}
+}
+
+func (consumer *recordingConsumer) consume(ctx context.Context, task *Task) error {
+	consumer.mutex.Lock()
+	consumer.attempts[task.URL]++
+	attempts := consumer.attempts[task.URL]
+	consumer.lastAttempts[task.URL] = IsLastAttempt(ctx)
+	consumer.mutex.Unlock()
+
+	consumer.delivered <- task.URL
+	if attempts <= consumer.failures {
+		return errors.New("consumer failed")
+	}
+	return nil
+}
+
+func (consumer *recordingConsumer) attemptsOf(url string) (int, bool) {
+	consumer.mutex.Lock()
+	defer consumer.mutex.Unlock()
+	return consumer.attempts[url], consumer.lastAttempts[url]
+}
+
+func (consumer *recordingConsumer) wait(t *testing.T, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		select {
+		case <-consumer.delivered:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("only [%d] out of [%d] deliveries happened", i, count)
+		}
+	}
+}
+
+func newTestInMemoryQueue(consumer *recordingConsumer, maxAttempts uint) *inMemoryQueue {
+	logger, tracer := newTestTelemetry()
+	return NewInMemoryQueue(logger, tracer, consumer.consume, 2, &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}).(*inMemoryQueue)
+}
+
+func (queue *inMemoryQueue) hasKey(key string) bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	_, ok := queue.keys[key]
+	return ok
+}
+
+func TestInMemoryQueue_EnqueueDeliversTask(t *testing.T) {
+	consumer := newRecordingConsumer(0)
+	queue := newTestInMemoryQueue(consumer, 3)
+
+	id, err := queue.Enqueue(context.Background(), &Task{URL: "/tasks/1"})
+	if err != nil {
+		t.Fatalf("cannot enqueue task: %v", err)
+	}
+	if id == "" {
+		t.Fatal("the ID of the task is empty")
+	}
+
+	consumer.wait(t, 1)
+	if attempts, lastAttempt := consumer.attemptsOf("/tasks/1"); attempts != 1 || lastAttempt {
+		t.Errorf("got [%d] attempts with last attempt [%t], want [1] attempt which is not the last", attempts, lastAttempt)
+	}
+}
+
+func TestInMemoryQueue_RetriesFailedTask(t *testing.T) {
+	consumer := newRecordingConsumer(2)
+	queue := newTestInMemoryQueue(consumer, 3)
+
+	if _, err := queue.Enqueue(context.Background(), &Task{URL: "/tasks/1"}); err != nil {
+		t.Fatalf("cannot enqueue task: %v", err)
+	}
+
+	consumer.wait(t, 3)
+	if attempts, lastAttempt := consumer.attemptsOf("/tasks/1"); attempts != 3 || !lastAttempt {
+		t.Errorf("got [%d] attempts with last attempt [%t], want [3] attempts with the last attempt", attempts, lastAttempt)
+	}
+}
+
+func TestInMemoryQueue_DropsTaskAfterMaxAttempts(t *testing.T) {
+	consumer := newRecordingConsumer(10)
+	queue := newTestInMemoryQueue(consumer, 2)
+
+	if _, err := queue.Enqueue(context.Background(), &Task{URL: "/tasks/1", DeduplicationKey: "key"}); err != nil {
+		t.Fatalf("cannot enqueue task: %v", err)
+	}
+
+	consumer.wait(t, 2)
+	time.Sleep(50 * time.Millisecond)
+
+	if attempts, _ := consumer.attemptsOf("/tasks/1"); attempts != 2 {
+		t.Errorf("got [%d] attempts, want [2]", attempts)
+	}
+	if queue.hasKey("key") {
+		t.Error("the deduplication key of the dropped task was not released")
+	}
+}
+
+func TestInMemoryQueue_DeduplicatesPendingTasks(t *testing.T) {
+	consumer := newRecordingConsumer(0)
+	queue := newTestInMemoryQueue(consumer, 3)
+
+	scheduleTime := time.Now().Add(100 * time.Millisecond)
+	first, err := queue.Enqueue(context.Background(), &Task{URL: "/tasks/1", DeduplicationKey: "key", ScheduleTime: &scheduleTime})
+	if err != nil {
+		t.Fatalf("cannot enqueue task: %v", err)
+	}
+
+	second, err := queue.Enqueue(context.Background(), &Task{URL: "/tasks/1", DeduplicationKey: "key"})
+	if err != nil {
+		t.Fatalf("cannot enqueue duplicate task: %v", err)
+	}
+
+	if first != second {
+		t.Errorf("got ID [%s] for the duplicate task, want [%s]", second, first)
+	}
+
+	consumer.wait(t, 1)
+	time.Sleep(50 * time.Millisecond)
+	if attempts, _ := consumer.attemptsOf("/tasks/1"); attempts != 1 {
+		t.Errorf("got [%d] deliveries, want [1]", attempts)
+	}
+}
+
+func TestInMemoryQueue_ReleasesKeyAfterSuccess(t *testing.T) {
+	consumer := newRecordingConsumer(0)
+	queue := newTestInMemoryQueue(consumer, 3)
+
+	first, err := queue.Enqueue(context.Background(), &Task{URL: "/tasks/1", DeduplicationKey: "key"})
+	if err != nil {
+		t.Fatalf("cannot enqueue task: %v", err)
+	}
+	consumer.wait(t, 1)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for queue.hasKey("key") {
+		if time.Now().After(deadline) {
+			t.Fatal("the deduplication key was not released after the task succeeded")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	second, err := queue.Enqueue(context.Background(), &Task{URL: "/tasks/1", DeduplicationKey: "key"})
+	if err != nil {
+		t.Fatalf("cannot enqueue task again: %v", err)
+	}
+	if first == second {
+		t.Errorf("got the ID [%s] of the finished task, want a new task", second)
+	}
+
+	consumer.wait(t, 1)
+}
+
+func TestInMemoryQueue_ReleaseKeyKeepsNewerTask(t *testing.T) {
+	consumer := newRecordingConsumer(0)
+	queue := newTestInMemoryQueue(consumer, 3)
+
+	queue.reserveKey("key", "new")
+	queue.releaseKey("key", "old")
+
+	if !queue.hasKey("key") {
+		t.Error("releasing the key of an older task removed the key of the newer task")
+	}
+}
@@ -0,0 +1,270 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbgorm"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormTaskStatus is the status of a GormTask
+type GormTaskStatus string
+
+const (
+	// GormTaskStatusPending means the task is waiting to be processed
+	GormTaskStatusPending = GormTaskStatus("pending")
+
+	// GormTaskStatusFailed means the task could not be processed after the maximum number of attempts.
+	// Failed tasks do not keep their deduplication key.
+	GormTaskStatusFailed = GormTaskStatus("failed")
+)
+
+// GormTask is a Task which is persisted in the database
+type GormTask struct {
+	ID               uuid.UUID      `json:"id" gorm:"primaryKey;type:uuid;"`
+	Queue            string         `json:"queue" gorm:"index:idx_queue_tasks_queue_status_available_at;uniqueIndex:idx_queue_tasks_queue_deduplication_key"`
+	DeduplicationKey *string        `json:"deduplication_key" gorm:"uniqueIndex:idx_queue_tasks_queue_deduplication_key"`
+	Status           GormTaskStatus `json:"status" gorm:"index:idx_queue_tasks_queue_status_available_at"`
+	AvailableAt      time.Time      `json:"available_at" gorm:"index:idx_queue_tasks_queue_status_available_at"`
+	Method           string         `json:"method"`
+	URL              string         `json:"url"`
+	Headers          datatypes.JSON `json:"headers"`
+	Body             []byte         `json:"body"`
+	Attempts         uint           `json:"attempts"`
+	MaxAttempts      uint           `json:"max_attempts"`
+	MinBackoff       time.Duration  `json:"min_backoff"`
+	MaxBackoff       time.Duration  `json:"max_backoff"`
+	LastError        *string        `json:"last_error"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+// TableName overrides the table name used by GormTask to `queue_tasks`
+func (GormTask) TableName() string {
+	return "queue_tasks"
+}
+
+// PostgresQueueConfig configures the workers of the postgres queue
+type PostgresQueueConfig struct {
+	Name              string
+	Workers           uint
+	RetryPolicy       *RetryPolicy
+	PollInterval      time.Duration
+	VisibilityTimeout time.Duration
+}
+
+type postgresQueue struct {
+	logger   telemetry.Logger
+	tracer   telemetry.Tracer
+	db       *gorm.DB
+	consumer Consumer
+	config   PostgresQueueConfig
+}
+
+// NewPostgresQueue creates a durable Client which stores tasks in the `queue_tasks` table.
+// Workers lock tasks with `FOR UPDATE SKIP LOCKED` and a task becomes visible again when a worker
+// does not finish it within the visibility timeout.
+func NewPostgresQueue(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+	consumer Consumer,
+	config PostgresQueueConfig,
+) Client {
+	queue := &postgresQueue{
+		logger:   logger.WithService(fmt.Sprintf("%T", &postgresQueue{})),
+		tracer:   tracer,
+		db:       db,
+		consumer: consumer,
+		config:   config,
+	}
+
+	for i := uint(0); i < config.Workers; i++ {
+		go queue.work()
+	}
+
+	return queue
+}
+
+// Enqueue a task to the queue
+func (queue *postgresQueue) Enqueue(ctx context.Context, task *Task) (queueID string, err error) {
+	ctx, span := queue.tracer.Start(ctx)
+	defer span.End()
+
+	headers, err := json.Marshal(task.Headers)
+	if err != nil {
+		msg := fmt.Sprintf("cannot marshal headers [%+#v] of task to URL [%s]", task.Headers, task.URL)
+		return queueID, queue.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	policy := task.retryPolicy(queue.config.RetryPolicy)
+	item := &GormTask{
+		ID:          uuid.New(),
+		Queue:       queue.config.Name,
+		Status:      GormTaskStatusPending,
+		AvailableAt: time.Now().UTC(),
+		Method:      task.Method,
+		URL:         task.URL,
+		Headers:     datatypes.JSON(headers),
+		Body:        task.Body,
+		MaxAttempts: policy.MaxAttempts,
+		MinBackoff:  policy.MinBackoff,
+		MaxBackoff:  policy.MaxBackoff,
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	}
+
+	if task.ScheduleTime != nil {
+		item.AvailableAt = task.ScheduleTime.UTC()
+	}
+
+	if task.DeduplicationKey != "" {
+		item.DeduplicationKey = &task.DeduplicationKey
+	}
+
+	// the task is stored in the transaction of the context so that it is only enqueued when the transaction is committed
+	result := repositories.ContextDB(ctx, queue.db).Clauses(clause.OnConflict{DoNothing: true}).Create(item)
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot store task to URL [%s] in the [%s] queue", task.URL, queue.config.Name)
+		return queueID, queue.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	if result.RowsAffected == 0 {
+		existing := new(GormTask)
+		err = repositories.ContextDB(ctx, queue.db).
+			Where("queue = ?", queue.config.Name).
+			Where("deduplication_key = ?", task.DeduplicationKey).
+			First(existing).
+			Error
+		if err != nil {
+			msg := fmt.Sprintf("cannot load task with deduplication key [%s] in the [%s] queue", task.DeduplicationKey, queue.config.Name)
+			return queueID, queue.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+		return existing.ID.String(), nil
+	}
+
+	return item.ID.String(), nil
+}
+
+func (queue *postgresQueue) work() {
+	for {
+		processed, err := queue.processNext()
+		if err != nil {
+			queue.logger.Error(stacktrace.Propagate(err, fmt.Sprintf("cannot process task in the [%s] queue", queue.config.Name)))
+		}
+
+		if !processed || err != nil {
+			time.Sleep(queue.config.PollInterval)
+		}
+	}
+}
+
+func (queue *postgresQueue) processNext() (bool, error) {
+	ctx, span, ctxLogger := queue.tracer.StartWithLogger(context.Background(), queue.logger)
+	defer span.End()
+
+	item, err := queue.reserve(ctx)
+	if err != nil {
+		return false, queue.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, "cannot reserve task"))
+	}
+
+	if item == nil {
+		return false, nil
+	}
+
+	task, err := queue.task(item)
+	if err != nil {
+		return true, queue.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, fmt.Sprintf("cannot decode task [%s]", item.ID)))
+	}
+
+	if item.Attempts >= item.MaxAttempts {
+		ctx = WithLastAttempt(ctx)
+	}
+
+	consumerErr := queue.consumer(ctx, task)
+	if consumerErr == nil {
+		if err = queue.db.WithContext(ctx).Delete(item).Error; err != nil {
+			msg := fmt.Sprintf("cannot delete processed task [%s] from the [%s] queue", item.ID, queue.config.Name)
+			return true, queue.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+		ctxLogger.Info(fmt.Sprintf("task [%s] in the [%s] queue processed after [%d] attempts", item.ID, queue.config.Name, item.Attempts))
+		return true, nil
+	}
+
+	message := consumerErr.Error()
+	item.LastError = &message
+	item.UpdatedAt = time.Now().UTC()
+	item.AvailableAt = time.Now().UTC().Add(task.RetryPolicy.Backoff(item.Attempts))
+	if item.Attempts >= item.MaxAttempts {
+		// the deduplication key is freed so the same task can be enqueued again after it failed
+		item.Status = GormTaskStatusFailed
+		item.DeduplicationKey = nil
+	}
+
+	ctxLogger.Warn(stacktrace.Propagate(consumerErr, fmt.Sprintf("task [%s] in the [%s] queue failed on attempt [%d] with status [%s]", item.ID, queue.config.Name, item.Attempts, item.Status)))
+
+	if err = queue.db.WithContext(ctx).Save(item).Error; err != nil {
+		msg := fmt.Sprintf("cannot update failed task [%s] in the [%s] queue", item.ID, queue.config.Name)
+		return true, queue.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return true, nil
+}
+
+func (queue *postgresQueue) task(item *GormTask) (*Task, error) {
+	var headers map[string]string
+	if err := json.Unmarshal(item.Headers, &headers); err != nil {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot unmarshal [%s] into [%T]", item.Headers, headers))
+	}
+
+	return &Task{
+		Method:  item.Method,
+		URL:     item.URL,
+		Body:    item.Body,
+		Headers: headers,
+		RetryPolicy: &RetryPolicy{
+			MaxAttempts: item.MaxAttempts,
+			MinBackoff:  item.MinBackoff,
+			MaxBackoff:  item.MaxBackoff,
+		},
+	}, nil
+}
+
+// reserve locks the next available task and hides it from other workers for the visibility timeout
+func (queue *postgresQueue) reserve(ctx context.Context) (item *GormTask, err error) {
+	err = crdbgorm.ExecuteTx(ctx, queue.db, nil, func(tx *gorm.DB) error {
+		item = new(GormTask)
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("queue = ?", queue.config.Name).
+			Where("status = ?", GormTaskStatusPending).
+			Where("available_at <= ?", time.Now().UTC()).
+			Order("available_at ASC").
+			First(item).
+			Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			item = nil
+			return nil
+		}
+
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot lock task in the [%s] queue", queue.config.Name))
+		}
+
+		item.Attempts++
+		item.AvailableAt = time.Now().UTC().Add(queue.config.VisibilityTimeout)
+		item.UpdatedAt = time.Now().UTC()
+		return tx.Save(item).Error
+	})
+
+	return item, err
+}
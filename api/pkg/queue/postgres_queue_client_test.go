@@ -0,0 +1,45 @@
+package queue
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPostgresQueue_TaskRestoresStoredTask(t *testing.T) {
+	queue := &postgresQueue{}
+
+	task, err := queue.task(&GormTask{
+		Method:      http.MethodPost,
+		URL:         "/v1/events/consume",
+		Body:        []byte(`{"id":"1"}`),
+		Headers:     []byte(`{"X-Event-Type":"user.created"}`),
+		MaxAttempts: 4,
+		MinBackoff:  time.Second,
+		MaxBackoff:  time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("cannot restore task: %v", err)
+	}
+
+	if task.Method != http.MethodPost || task.URL != "/v1/events/consume" || string(task.Body) != `{"id":"1"}` {
+		t.Errorf("got task [%s %s %s], want the stored method, URL and body", task.Method, task.URL, task.Body)
+	}
+
+	if task.Headers["X-Event-Type"] != "user.created" {
+		t.Errorf("got headers [%v], want the stored headers", task.Headers)
+	}
+
+	want := RetryPolicy{MaxAttempts: 4, MinBackoff: time.Second, MaxBackoff: time.Minute}
+	if task.RetryPolicy == nil || *task.RetryPolicy != want {
+		t.Errorf("got retry policy [%+v], want [%+v]", task.RetryPolicy, want)
+	}
+}
+
+func TestPostgresQueue_TaskRejectsInvalidHeaders(t *testing.T) {
+	queue := &postgresQueue{}
+
+	if _, err := queue.task(&GormTask{Headers: []byte("not json")}); err == nil {
+		t.Error("restoring a task with invalid headers did not fail")
+	}
+}
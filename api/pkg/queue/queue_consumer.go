@@ -0,0 +1,23 @@
+package queue
+
+import (
+	"context"
+)
+
+type contextKey string
+
+const contextKeyLastAttempt = contextKey("queue.last-attempt")
+
+// Consumer processes a Task which is delivered by a queue Client running in-process
+type Consumer func(ctx context.Context, task *Task) error
+
+// WithLastAttempt marks the context of a Task delivery which will not be retried if it fails
+func WithLastAttempt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyLastAttempt, true)
+}
+
+// IsLastAttempt checks if the Task which is being delivered will not be retried if it fails
+func IsLastAttempt(ctx context.Context) bool {
+	lastAttempt, ok := ctx.Value(contextKeyLastAttempt).(bool)
+	return ok && lastAttempt
+}
@@ -1,8 +1,59 @@
 package queue
 
+import (
+	"time"
+)
+
 // Task represents a push queue task
 type Task struct {
 	Method string
 	URL    string
 	Body   []byte
+
+	// Headers are added to the HTTP request which delivers the task
+	Headers map[string]string
+
+	// ScheduleTime is the earliest time the task is delivered. The task is delivered immediately when it is nil.
+	ScheduleTime *time.Time
+
+	// DeduplicationKey ensures that a task with the same key is enqueued only once
+	DeduplicationKey string
+
+	// RetryPolicy overrides how the queue retries the task when it fails.
+	// Cloud Tasks only supports retry configs per queue so the google push queue sends the MaxAttempts in the HeaderMaxAttempts header
+	// and the backoff is the one which is configured on the Cloud Tasks queue.
+	RetryPolicy *RetryPolicy
+}
+
+// RetryPolicy configures how a failed Task is retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the task is delivered
+	MaxAttempts uint
+
+	// MinBackoff is the delay before the first retry. The delay doubles after every attempt.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries
+	MaxBackoff time.Duration
+}
+
+// Backoff returns the delay before retrying a task which failed on the given attempt
+func (policy *RetryPolicy) Backoff(attempt uint) time.Duration {
+	backoff := policy.MinBackoff
+	for i := uint(1); i < attempt && backoff < policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		return policy.MaxBackoff
+	}
+	return backoff
+}
+
+// retryPolicy returns the RetryPolicy of the task or the fallback when the task has none
+func (task *Task) retryPolicy(fallback *RetryPolicy) *RetryPolicy {
+	if task.RetryPolicy == nil {
+		return fallback
+	}
+	return task.RetryPolicy
 }
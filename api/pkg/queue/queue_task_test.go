@@ -0,0 +1,123 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_BackoffDoublesUntilMax(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 10, MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		attempt uint
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 4, want: 8 * time.Second},
+		{attempt: 5, want: 10 * time.Second},
+		{attempt: 20, want: 10 * time.Second},
+	}
+
+	for _, test := range tests {
+		if got := policy.Backoff(test.attempt); got != test.want {
+			t.Errorf("Backoff(%d) = [%s], want [%s]", test.attempt, got, test.want)
+		}
+	}
+}
+
+func TestRetryPolicy_BackoffWithoutMaxStaysAtMin(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: time.Second}
+
+	if got := policy.Backoff(4); got != time.Second {
+		t.Errorf("Backoff(4) = [%s], want [%s]", got, time.Second)
+	}
+}
+
+func TestTask_RetryPolicyFallsBackToQueuePolicy(t *testing.T) {
+	fallback := &RetryPolicy{MaxAttempts: 3}
+	override := &RetryPolicy{MaxAttempts: 8}
+
+	if got := (&Task{}).retryPolicy(fallback); got != fallback {
+		t.Errorf("got policy [%+v] for a task without a policy, want the queue policy", got)
+	}
+
+	if got := (&Task{RetryPolicy: override}).retryPolicy(fallback); got != override {
+		t.Errorf("got policy [%+v] for a task with a policy, want the task policy", got)
+	}
+}
+
+func TestInMemoryQueue_UsesRetryPolicyOfTask(t *testing.T) {
+	consumer := newRecordingConsumer(10)
+	queue := newTestInMemoryQueue(consumer, 5)
+
+	task := &Task{URL: "/tasks/1", RetryPolicy: &RetryPolicy{MaxAttempts: 1}}
+	if _, err := queue.Enqueue(context.Background(), task); err != nil {
+		t.Fatalf("cannot enqueue task: %v", err)
+	}
+
+	consumer.wait(t, 1)
+	time.Sleep(50 * time.Millisecond)
+
+	if attempts, lastAttempt := consumer.attemptsOf("/tasks/1"); attempts != 1 || !lastAttempt {
+		t.Errorf("got [%d] attempts with last attempt [%t], want [1] attempt which is the last", attempts, lastAttempt)
+	}
+}
+
+func TestInMemoryQueue_DelaysScheduledTask(t *testing.T) {
+	consumer := newRecordingConsumer(0)
+	queue := newTestInMemoryQueue(consumer, 3)
+
+	delay := 100 * time.Millisecond
+	scheduleTime := time.Now().Add(delay)
+	start := time.Now()
+	if _, err := queue.Enqueue(context.Background(), &Task{URL: "/tasks/1", ScheduleTime: &scheduleTime}); err != nil {
+		t.Fatalf("cannot enqueue task: %v", err)
+	}
+
+	consumer.wait(t, 1)
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("the task was delivered after [%s], want at least [%s]", elapsed, delay)
+	}
+}
+
+func TestInMemoryQueue_DeliversPastScheduledTaskImmediately(t *testing.T) {
+	consumer := newRecordingConsumer(0)
+	queue := newTestInMemoryQueue(consumer, 3)
+
+	scheduleTime := time.Now().Add(-time.Hour)
+	if _, err := queue.Enqueue(context.Background(), &Task{URL: "/tasks/1", ScheduleTime: &scheduleTime}); err != nil {
+		t.Fatalf("cannot enqueue task: %v", err)
+	}
+
+	consumer.wait(t, 1)
+}
+
+func TestGooglePushQueue_HeadersIncludeMaxAttempts(t *testing.T) {
+	queue := &googlePushQueue{}
+
+	headers := queue.headers(&Task{
+		Headers:     map[string]string{"X-Event-Type": "user.created"},
+		RetryPolicy: &RetryPolicy{MaxAttempts: 7},
+	})
+
+	if headers["Content-Type"] != "application/json" {
+		t.Errorf("got Content-Type [%s], want [application/json]", headers["Content-Type"])
+	}
+	if headers["X-Event-Type"] != "user.created" {
+		t.Errorf("got X-Event-Type [%s], want the header of the task", headers["X-Event-Type"])
+	}
+	if headers[HeaderMaxAttempts] != "7" {
+		t.Errorf("got [%s] header [%s], want [7]", HeaderMaxAttempts, headers[HeaderMaxAttempts])
+	}
+}
+
+func TestGooglePushQueue_HeadersWithoutRetryPolicy(t *testing.T) {
+	queue := &googlePushQueue{}
+
+	if _, ok := queue.headers(&Task{})[HeaderMaxAttempts]; ok {
+		t.Errorf("the [%s] header is set for a task without a retry policy", HeaderMaxAttempts)
+	}
+}
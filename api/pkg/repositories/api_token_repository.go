@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// APITokenRepository loads and persists an entities.APIToken
+type APITokenRepository interface {
+	// Store a new entities.APIToken
+	Store(ctx context.Context, token *entities.APIToken) error
+
+	// Update an entities.APIToken
+	Update(ctx context.Context, token *entities.APIToken) error
+
+	// Load an entities.APIToken of a user
+	Load(ctx context.Context, userID entities.UserID, tokenID uuid.UUID) (*entities.APIToken, error)
+
+	// LoadByTokenHash loads the entities.APIToken with the hash of a token
+	LoadByTokenHash(ctx context.Context, tokenHash string) (*entities.APIToken, error)
+
+	// Fetch the entities.APIToken of a user which are not revoked with the newest first
+	Fetch(ctx context.Context, userID entities.UserID) ([]*entities.APIToken, error)
+
+	// UpdateLastUsedAt sets the time when an entities.APIToken was last used
+	UpdateLastUsedAt(ctx context.Context, tokenID uuid.UUID, lastUsedAt time.Time) error
+}
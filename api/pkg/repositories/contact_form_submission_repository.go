@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// ContactFormSubmissionRepository loads and persists an entities.ContactFormSubmission
+type ContactFormSubmissionRepository interface {
+	// Store a new entities.ContactFormSubmission
+	Store(ctx context.Context, submission *entities.ContactFormSubmission) error
+
+	// Index returns a page of entities.ContactFormSubmission for an integration ordered by the newest first
+	Index(ctx context.Context, integrationID uuid.UUID, params IndexParams) ([]*entities.ContactFormSubmission, error)
+
+	// DeleteAll deletes every entities.ContactFormSubmission in a project and returns the number of deleted rows
+	DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/google/uuid"
+)
+
+// EventListenerExecutionRepository loads and persists an entities.EventListenerExecution
+type EventListenerExecutionRepository interface {
+	// Claim reserves an event for a listener for the duration of the lease.
+	// claimed is false when the listener already handled the event, dead-lettered it or holds a lease which has not expired.
+	Claim(ctx context.Context, eventID string, eventType string, listener string, lease time.Duration) (execution *entities.EventListenerExecution, claimed bool, err error)
+
+	// ClaimDeadLettered reserves a dead-lettered entities.EventListenerExecution for a replay for the duration of the lease.
+	// claimed is false when the execution is no longer dead-lettered e.g. when another replay claimed it first.
+	ClaimDeadLettered(ctx context.Context, executionID uuid.UUID, lease time.Duration) (execution *entities.EventListenerExecution, claimed bool, err error)
+
+	// Load an entities.EventListenerExecution by ID
+	Load(ctx context.Context, executionID uuid.UUID) (*entities.EventListenerExecution, error)
+
+	// FetchByStatus returns the entities.EventListenerExecution with a status ordered by the last update in descending order
+	FetchByStatus(ctx context.Context, status entities.EventListenerExecutionStatus, params IndexParams) ([]*entities.EventListenerExecution, error)
+
+	// Update an entities.EventListenerExecution
+	Update(ctx context.Context, execution *entities.EventListenerExecution) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// EventOutboxRepository loads and persists an entities.OutboxEvent
+type EventOutboxRepository interface {
+	// Store a new entities.OutboxEvent. An event which is already in the outbox is ignored.
+	Store(ctx context.Context, event *entities.OutboxEvent) error
+
+	// LockDue locks entities.OutboxEvent which are available for relaying until the transaction in the context ends
+	LockDue(ctx context.Context, limit int) ([]*entities.OutboxEvent, error)
+
+	// Update an entities.OutboxEvent
+	Update(ctx context.Context, event *entities.OutboxEvent) error
+
+	// Delete an entities.OutboxEvent
+	Delete(ctx context.Context, event *entities.OutboxEvent) error
+}
@@ -14,6 +14,9 @@ type EventRepository interface {
 	// Save a new entities.Message
 	Save(ctx context.Context, event *cloudevents.Event) error
 
+	// Load a cloudevents.Event by ID
+	Load(ctx context.Context, eventID string) (*cloudevents.Event, error)
+
 	// FetchAll returns all cloudevents.Event ordered by time in ascending order
 	FetchAll(ctx context.Context) ([]*cloudevents.Event, error)
 }
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+)
+
+// gormAPITokenRepository is responsible for persisting entities.APIToken
+type gormAPITokenRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormAPITokenRepository creates the GORM version of the APITokenRepository
+func NewGormAPITokenRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) APITokenRepository {
+	return &gormAPITokenRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormAPITokenRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormAPITokenRepository) Store(ctx context.Context, token *entities.APIToken) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Create(token).Error; err != nil {
+		msg := fmt.Sprintf("cannot save api token [%s] of user [%s]", token.ID, token.UserID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormAPITokenRepository) Update(ctx context.Context, token *entities.APIToken) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Save(token).Error; err != nil {
+		msg := fmt.Sprintf("cannot update api token [%s] of user [%s]", token.ID, token.UserID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormAPITokenRepository) Load(ctx context.Context, userID entities.UserID, tokenID uuid.UUID) (*entities.APIToken, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	token := new(entities.APIToken)
+	err := gormDB(ctx, repository.db).
+		Where("user_id = ?", userID).
+		Where("id = ?", tokenID).
+		First(token).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("api token [%s] does not exist for user [%s]", tokenID, userID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load api token [%s] of user [%s]", tokenID, userID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return token, nil
+}
+
+func (repository *gormAPITokenRepository) LoadByTokenHash(ctx context.Context, tokenHash string) (*entities.APIToken, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	token := new(entities.APIToken)
+	err := gormDB(ctx, repository.db).Where("token_hash = ?", tokenHash).First(token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := "api token does not exist for hash"
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := "cannot load api token by hash"
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return token, nil
+}
+
+func (repository *gormAPITokenRepository) Fetch(ctx context.Context, userID entities.UserID) ([]*entities.APIToken, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	tokens := make([]*entities.APIToken, 0)
+	err := gormDB(ctx, repository.db).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Order("created_at DESC").
+		Find(&tokens).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch api tokens of user [%s]", userID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return tokens, nil
+}
+
+func (repository *gormAPITokenRepository) UpdateLastUsedAt(ctx context.Context, tokenID uuid.UUID, lastUsedAt time.Time) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	err := gormDB(ctx, repository.db).
+		Model(&entities.APIToken{}).
+		Where("id = ?", tokenID).
+		Update("last_used_at", lastUsedAt).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot update last used time of api token [%s]", tokenID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
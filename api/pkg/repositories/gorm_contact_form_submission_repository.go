@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+)
+
+// gormContactFormSubmissionRepository is responsible for persisting entities.ContactFormSubmission
+type gormContactFormSubmissionRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormContactFormSubmissionRepository creates the GORM version of the ContactFormSubmissionRepository
+func NewGormContactFormSubmissionRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) ContactFormSubmissionRepository {
+	return &gormContactFormSubmissionRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormContactFormSubmissionRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormContactFormSubmissionRepository) Store(ctx context.Context, submission *entities.ContactFormSubmission) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Create(submission).Error; err != nil {
+		msg := fmt.Sprintf("cannot save contact form submission with ID [%s] for integration [%s]", submission.ID, submission.IntegrationID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormContactFormSubmissionRepository) Index(ctx context.Context, integrationID uuid.UUID, params IndexParams) ([]*entities.ContactFormSubmission, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	query := gormDB(ctx, repository.db).
+		Where("integration_id = ?", integrationID)
+
+	if len(params.Query) > 0 {
+		queryPattern := "%" + params.Query + "%"
+		query = query.Where(
+			repository.db.Where("name ILIKE ?", queryPattern).
+				Or("email ILIKE ?", queryPattern).
+				Or("phone_number ILIKE ?", queryPattern).
+				Or("message ILIKE ?", queryPattern),
+		)
+	}
+
+	submissions := make([]*entities.ContactFormSubmission, 0, params.Limit)
+	err := query.Order("created_at DESC").
+		Limit(params.Limit).
+		Offset(params.Skip).
+		Find(&submissions).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch contact form submissions for integration [%s] with params [%+#v]", integrationID, params)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return submissions, nil
+}
+
+func (repository *gormContactFormSubmissionRepository) DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	result := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Delete(&entities.ContactFormSubmission{})
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot delete contact form submissions for project [%s]", projectID)
+		return 0, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	return result.RowsAffected, nil
+}
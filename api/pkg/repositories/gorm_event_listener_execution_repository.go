@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormEventListenerExecutionRepository is responsible for persisting entities.EventListenerExecution
+type gormEventListenerExecutionRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormEventListenerExecutionRepository creates the GORM version of the EventListenerExecutionRepository
+func NewGormEventListenerExecutionRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) EventListenerExecutionRepository {
+	return &gormEventListenerExecutionRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormEventListenerExecutionRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormEventListenerExecutionRepository) Claim(ctx context.Context, eventID string, eventType string, listener string, lease time.Duration) (execution *entities.EventListenerExecution, claimed bool, err error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	err = executeTx(ctx, repository.db, func(tx *gorm.DB) error {
+		claimed = false
+		execution = &entities.EventListenerExecution{
+			ID:        uuid.New(),
+			EventID:   eventID,
+			EventType: eventType,
+			Listener:  listener,
+			Status:    entities.EventListenerExecutionStatusProcessing,
+			Attempts:  0,
+			CreatedAt: time.Now().UTC(),
+			UpdatedAt: time.Now().UTC(),
+		}
+
+		if err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(execution).Error; err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%T] for event [%s] and listener [%s]", execution, eventID, listener))
+		}
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ?", eventID).
+			Where("listener = ?", listener).
+			First(execution).Error
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot lock [%T] for event [%s] and listener [%s]", execution, eventID, listener))
+		}
+
+		now := time.Now().UTC()
+		if execution.Status == entities.EventListenerExecutionStatusSucceeded ||
+			execution.Status == entities.EventListenerExecutionStatusDeadLettered ||
+			(execution.Status == entities.EventListenerExecutionStatusProcessing && execution.Attempts > 0 && repository.isLeased(execution, now)) {
+			return nil
+		}
+
+		claimedUntil := now.Add(lease)
+		execution.Status = entities.EventListenerExecutionStatusProcessing
+		execution.Attempts++
+		execution.ClaimedUntil = &claimedUntil
+		execution.UpdatedAt = now
+		claimed = true
+
+		return tx.Save(execution).Error
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot claim event [%s] with type [%s] for listener [%s]", eventID, eventType, listener)
+		return nil, false, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return execution, claimed, nil
+}
+
+func (repository *gormEventListenerExecutionRepository) ClaimDeadLettered(ctx context.Context, executionID uuid.UUID, lease time.Duration) (execution *entities.EventListenerExecution, claimed bool, err error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	err = executeTx(ctx, repository.db, func(tx *gorm.DB) error {
+		claimed = false
+		execution = new(entities.EventListenerExecution)
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", executionID).First(execution).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return stacktrace.PropagateWithCode(err, ErrCodeNotFound, fmt.Sprintf("[%T] with ID [%s] does not exist", execution, executionID))
+		}
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot lock [%T] with ID [%s]", execution, executionID))
+		}
+
+		if execution.Status != entities.EventListenerExecutionStatusDeadLettered {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		claimedUntil := now.Add(lease)
+		execution.Status = entities.EventListenerExecutionStatusProcessing
+		execution.Attempts++
+		execution.ClaimedUntil = &claimedUntil
+		execution.UpdatedAt = now
+		claimed = true
+
+		return tx.Save(execution).Error
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot claim dead-lettered listener execution with ID [%s]", executionID)
+		return nil, false, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return execution, claimed, nil
+}
+
+// isLeased checks if the lease of a processing execution is still live.
+// Executions without a lease or with an expired lease were abandoned e.g. when the instance crashed.
+func (repository *gormEventListenerExecutionRepository) isLeased(execution *entities.EventListenerExecution, now time.Time) bool {
+	return execution.ClaimedUntil != nil && execution.ClaimedUntil.After(now)
+}
+
+func (repository *gormEventListenerExecutionRepository) Load(ctx context.Context, executionID uuid.UUID) (*entities.EventListenerExecution, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	execution := new(entities.EventListenerExecution)
+	err := gormDB(ctx, repository.db).Where("id = ?", executionID).First(execution).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("[%T] with ID [%s] does not exist", execution, executionID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load [%T] with ID [%s]", execution, executionID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return execution, nil
+}
+
+func (repository *gormEventListenerExecutionRepository) FetchByStatus(ctx context.Context, status entities.EventListenerExecutionStatus, params IndexParams) ([]*entities.EventListenerExecution, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	query := gormDB(ctx, repository.db).Where("status = ?", status)
+	if len(params.Query) > 0 {
+		queryPattern := "%" + params.Query + "%"
+		query = query.Where(
+			repository.db.Where("event_type ILIKE ?", queryPattern).
+				Or("listener ILIKE ?", queryPattern).
+				Or("event_id ILIKE ?", queryPattern),
+		)
+	}
+
+	executions := make([]*entities.EventListenerExecution, 0, params.Limit)
+	err := query.Order("updated_at DESC").
+		Limit(params.Limit).
+		Offset(params.Skip).
+		Find(&executions).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch [%T] with status [%s] and params [%+#v]", executions, status, params)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return executions, nil
+}
+
+func (repository *gormEventListenerExecutionRepository) Update(ctx context.Context, execution *entities.EventListenerExecution) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	execution.UpdatedAt = time.Now().UTC()
+	if err := gormDB(ctx, repository.db).Save(execution).Error; err != nil {
+		msg := fmt.Sprintf("cannot update [%T] with ID [%s]", execution, execution.ID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
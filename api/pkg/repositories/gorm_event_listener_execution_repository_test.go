@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+func TestGormEventListenerExecutionRepository_IsLeased(t *testing.T) {
+	repository := &gormEventListenerExecutionRepository{}
+	now := time.Now().UTC()
+	past := now.Add(-time.Second)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name         string
+		claimedUntil *time.Time
+		want         bool
+	}{
+		{name: "without a lease", claimedUntil: nil, want: false},
+		{name: "with an expired lease", claimedUntil: &past, want: false},
+		{name: "with a lease which ends now", claimedUntil: &now, want: false},
+		{name: "with a live lease", claimedUntil: &future, want: true},
+	}
+
+	for _, test := range tests {
+		execution := &entities.EventListenerExecution{Status: entities.EventListenerExecutionStatusProcessing, ClaimedUntil: test.claimedUntil}
+		if got := repository.isLeased(execution, now); got != test.want {
+			t.Errorf("isLeased %s = [%t], want [%t]", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormEventOutboxRepository is responsible for persisting entities.OutboxEvent
+type gormEventOutboxRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormEventOutboxRepository creates the GORM version of the EventOutboxRepository
+func NewGormEventOutboxRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) EventOutboxRepository {
+	return &gormEventOutboxRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormEventOutboxRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormEventOutboxRepository) Store(ctx context.Context, event *entities.OutboxEvent) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Clauses(clause.OnConflict{DoNothing: true}).Create(event).Error; err != nil {
+		msg := fmt.Sprintf("cannot store [%s] outbox event with ID [%s]", event.Type, event.ID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormEventOutboxRepository) LockDue(ctx context.Context, limit int) ([]*entities.OutboxEvent, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	events := make([]*entities.OutboxEvent, 0, limit)
+	err := gormDB(ctx, repository.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("available_at <= ?", time.Now().UTC()).
+		Order("available_at ASC").
+		Limit(limit).
+		Find(&events).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot lock [%d] outbox events", limit)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return events, nil
+}
+
+func (repository *gormEventOutboxRepository) Update(ctx context.Context, event *entities.OutboxEvent) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Save(event).Error; err != nil {
+		msg := fmt.Sprintf("cannot update [%s] outbox event with ID [%s]", event.Type, event.ID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormEventOutboxRepository) Delete(ctx context.Context, event *entities.OutboxEvent) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Delete(event).Error; err != nil {
+		msg := fmt.Sprintf("cannot delete [%s] outbox event with ID [%s]", event.Type, event.ID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -54,7 +55,7 @@ func (repository *gormEventRepository) FetchAll(ctx context.Context) ([]*cloudev
 	defer span.End()
 
 	var events []GormEvent
-	if err := repository.db.WithContext(ctx).Order("time ASC").Find(&events).Error; err != nil {
+	if err := gormDB(ctx, repository.db).Order("time ASC").Find(&events).Error; err != nil {
 		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, "cannot fetch all cloudevents"))
 	}
 
@@ -70,6 +71,32 @@ func (repository *gormEventRepository) FetchAll(ctx context.Context) ([]*cloudev
 	return results, nil
 }
 
+// Load a cloudevents.Event by ID
+func (repository *gormEventRepository) Load(ctx context.Context, eventID string) (*cloudevents.Event, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	event := new(GormEvent)
+	err := gormDB(ctx, repository.db).Where("id = ?", eventID).First(event).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("event with ID [%s] does not exist", eventID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load event with ID [%s]", eventID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	var cloudevent cloudevents.Event
+	if err = json.Unmarshal(event.Data, &cloudevent); err != nil {
+		msg := fmt.Sprintf("cannot unmarshal [%s] into [%T]", event.Data, cloudevent)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return &cloudevent, nil
+}
+
 // Create creates a new cloudevents.Event
 func (repository *gormEventRepository) Create(ctx context.Context, event *cloudevents.Event) error {
 	ctx, span := repository.tracer.Start(ctx)
@@ -89,7 +116,7 @@ func (repository *gormEventRepository) Create(ctx context.Context, event *cloude
 		Data:      datatypes.JSON(data),
 	}
 
-	if err = repository.db.WithContext(ctx).Create(dbEvent).Error; err != nil {
+	if err = gormDB(ctx, repository.db).Create(dbEvent).Error; err != nil {
 		return stacktrace.Propagate(err, fmt.Sprintf("cannot create event [%s] and type [%s]", event.ID(), event.Type()))
 	}
 
@@ -115,7 +142,7 @@ func (repository *gormEventRepository) Save(ctx context.Context, event *cloudeve
 		Data:      datatypes.JSON(data),
 	}
 
-	if err = repository.db.WithContext(ctx).Save(dbEvent).Error; err != nil {
+	if err = gormDB(ctx, repository.db).Save(dbEvent).Error; err != nil {
 		return stacktrace.Propagate(err, fmt.Sprintf("cannot save event [%s] and type [%s]", event.ID(), event.Type()))
 	}
 
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -11,43 +12,196 @@ import (
 	"gorm.io/gorm"
 )
 
-// gormIntegrationRepository is responsible for persisting integrations
-type gormIntegrationRepository struct {
+// integrationEntity is satisfied by a pointer to an integration settings struct e.g *entities.WhatsappIntegration
+type integrationEntity[T any] interface {
+	*T
+	entities.IntegrationEntity
+}
+
+// gormIntegrationRepository is responsible for persisting an entities.IntegrationEntity
+type gormIntegrationRepository[T any, PT integrationEntity[T]] struct {
 	logger          telemetry.Logger
 	tracer          telemetry.Tracer
 	integrationType entities.IntegrationType
 	db              *gorm.DB
 }
 
-func (repository *gormIntegrationRepository) getPosition(tx *gorm.DB, integration *entities.Integration) (uint, error) {
+// NewGormIntegrationRepository creates the GORM version of the IntegrationRepository for an entities.IntegrationType
+func NewGormIntegrationRepository[T any, PT integrationEntity[T]](
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	integrationType entities.IntegrationType,
+	db *gorm.DB,
+) IntegrationRepository[PT] {
+	return &gormIntegrationRepository[T, PT]{
+		logger:          logger.WithService(fmt.Sprintf("%T", &gormIntegrationRepository[T, PT]{})),
+		tracer:          tracer,
+		integrationType: integrationType,
+		db:              db,
+	}
+}
+
+func (repository *gormIntegrationRepository[T, PT]) FetchMultiple(ctx context.Context, projectID uuid.UUID, integrationIDs []uuid.UUID) ([]PT, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	var integrations []PT
+	err := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Where("id IN ?", integrationIDs).
+		Find(&integrations).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot load [%s] integrations for project [%s] and IDs [%+#v]", repository.integrationType, projectID, integrationIDs)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return integrations, nil
+}
+
+func (repository *gormIntegrationRepository[T, PT]) Store(ctx context.Context, integration PT) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	base := integration.Base()
+	err := executeTx(ctx, repository.db, func(tx *gorm.DB) error {
+		err := tx.Create(integration).Error
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store integration with ID [%s]", base.ID))
+		}
+
+		position, err := repository.getPosition(tx, base)
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot get position of integration with ID [%s]", base.ID))
+		}
+
+		err = tx.Create(base.NewProjectIntegration(repository.integrationType, position)).Error
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store project integration with ID [%s]", base.ID))
+		}
+
+		return nil
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot save [%s] integration with ID [%s] and project [%s]", repository.integrationType, base.ID, base.ProjectID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormIntegrationRepository[T, PT]) Update(ctx context.Context, integration PT) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	base := integration.Base()
+	err := executeTx(ctx, repository.db, func(tx *gorm.DB) error {
+		err := tx.Save(integration).Error
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot save integration with ID [%s]", base.ID))
+		}
+		return repository.updateProjectIntegration(tx, base)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot update [%s] integration with ID [%s] and project [%s]", repository.integrationType, base.ID, base.ProjectID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormIntegrationRepository[T, PT]) Fetch(ctx context.Context, projectID uuid.UUID) ([]PT, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	var integrations []PT
+	err := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Find(&integrations).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot load [%s] integrations for project [%s]", repository.integrationType, projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return integrations, nil
+}
+
+func (repository *gormIntegrationRepository[T, PT]) Delete(ctx context.Context, projectID uuid.UUID, integrationID uuid.UUID) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	err := executeTx(ctx, repository.db, func(tx *gorm.DB) error {
+		err := tx.
+			Where("project_id = ?", projectID).
+			Where("id = ?", integrationID).
+			Delete(PT(new(T))).
+			Error
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot delete [%s] integration with ID [%s]", repository.integrationType, integrationID))
+		}
+		return repository.deleteProjectIntegration(tx, projectID, integrationID)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete integration for project [%s] and integration [%s]", projectID, integrationID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormIntegrationRepository[T, PT]) Load(ctx context.Context, projectID uuid.UUID, integrationID uuid.UUID) (PT, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	integration := PT(new(T))
+	err := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Where("id = ?", integrationID).
+		First(integration).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("[%s] integration with ID [%s] and project [%s] does not exist", repository.integrationType, integrationID, projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load [%s] integration with ID [%s] and project [%s]", repository.integrationType, integrationID, projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return integration, nil
+}
+
+func (repository *gormIntegrationRepository[T, PT]) getPosition(tx *gorm.DB, integration *entities.IntegrationBase) (uint, error) {
 	projectIntegration := new(entities.ProjectIntegration)
-	err := tx.Where("user_id = ?", integration.UserID).
-		Where("project_id = ?", integration.ProjectID).
+	err := tx.Where("project_id = ?", integration.ProjectID).
 		Select("position").Order("position desc").First(projectIntegration).
 		Error
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		msg := fmt.Sprintf("cannot fetch last inegration for project [%s] and user [%s]", integration.ProjectID, integration.UserID)
+		msg := fmt.Sprintf("cannot fetch last inegration for project [%s]", integration.ProjectID)
 		return 0, stacktrace.Propagate(err, msg)
 	}
 	return projectIntegration.Position + 1, nil
 }
 
-func (repository *gormIntegrationRepository) updateProjectIntegration(tx *gorm.DB, integration *entities.Integration) error {
+func (repository *gormIntegrationRepository[T, PT]) updateProjectIntegration(tx *gorm.DB, integration *entities.IntegrationBase) error {
 	err := tx.
 		Model(&entities.ProjectIntegration{}).
-		Where("integration_id = ?", integration.IntegrationID).
+		Where("integration_id = ?", integration.ID).
+		Where("project_id = ?", integration.ProjectID).
 		Updates(map[string]interface{}{"updated_at": integration.UpdatedAt, "name": integration.Name}).
 		Error
 	if err != nil {
-		return stacktrace.Propagate(err, fmt.Sprintf("cannot update project integration with integration ID [%s]", integration.IntegrationID))
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot update project integration with integration ID [%s]", integration.ID))
 	}
 	return nil
 }
 
-func (repository *gormIntegrationRepository) deleteProjectIntegration(tx *gorm.DB, userID entities.UserID, integrationID uuid.UUID) error {
+func (repository *gormIntegrationRepository[T, PT]) deleteProjectIntegration(tx *gorm.DB, projectID uuid.UUID, integrationID uuid.UUID) error {
 	err := tx.
 		Where("integration_id = ?", integrationID).
-		Where("user_id = ?", userID).
+		Where("project_id = ?", projectID).
 		Delete(&entities.ProjectIntegration{}).
 		Error
 	if err != nil {
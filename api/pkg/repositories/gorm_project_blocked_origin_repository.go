@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormProjectBlockedOriginRepository is responsible for persisting entities.ProjectBlockedOrigin
+type gormProjectBlockedOriginRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormProjectBlockedOriginRepository creates the GORM version of the ProjectBlockedOriginRepository
+func NewGormProjectBlockedOriginRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) ProjectBlockedOriginRepository {
+	return &gormProjectBlockedOriginRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormProjectBlockedOriginRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormProjectBlockedOriginRepository) Increment(ctx context.Context, project *entities.Project, origin string) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	blockedOrigin := &entities.ProjectBlockedOrigin{
+		ID:            uuid.New(),
+		UserID:        project.UserID,
+		ProjectID:     project.ID,
+		Origin:        origin,
+		Count:         1,
+		LastBlockedAt: time.Now().UTC(),
+		CreatedAt:     time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+	}
+
+	err := gormDB(ctx, repository.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "project_id"}, {Name: "origin"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"count":           gorm.Expr("project_blocked_origins.count + 1"),
+				"last_blocked_at": blockedOrigin.LastBlockedAt,
+				"updated_at":      blockedOrigin.UpdatedAt,
+			}),
+		}).
+		Create(blockedOrigin).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot increment blocked origin [%s] for project [%s]", origin, project.ID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormProjectBlockedOriginRepository) Fetch(ctx context.Context, projectID uuid.UUID) ([]*entities.ProjectBlockedOrigin, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	var origins []*entities.ProjectBlockedOrigin
+	err := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Order("last_blocked_at desc").
+		Find(&origins).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot load blocked origins for project [%s]", projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return origins, nil
+}
+
+func (repository *gormProjectBlockedOriginRepository) DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	result := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Delete(&entities.ProjectBlockedOrigin{})
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot delete blocked origins for project [%s]", projectID)
+		return 0, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	return result.RowsAffected, nil
+}
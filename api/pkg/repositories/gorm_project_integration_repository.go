@@ -2,11 +2,10 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbgorm"
-
 	"github.com/NdoleStudio/superbutton/pkg/entities"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
 	"github.com/google/uuid"
@@ -34,48 +33,97 @@ func NewGormProjectIntegrationRepository(
 	}
 }
 
-func (repository *gormProjectIntegrationRepository) Fetch(ctx context.Context, userID entities.UserID, projectID uuid.UUID) ([]*entities.ProjectIntegration, error) {
+func (repository *gormProjectIntegrationRepository) Fetch(ctx context.Context, projectID uuid.UUID) ([]*entities.ProjectIntegration, error) {
 	ctx, span := repository.tracer.Start(ctx)
 	defer span.End()
 
 	var integrations []*entities.ProjectIntegration
-	err := repository.db.WithContext(ctx).
-		Where("user_id = ?", userID).
+	err := gormDB(ctx, repository.db).
 		Where("project_id = ?", projectID).
 		Order("position asc").
 		Find(&integrations).
 		Error
 	if err != nil {
-		msg := fmt.Sprintf("cannot load integrations for user with ID [%s] and project [%s]", userID, projectID)
+		msg := fmt.Sprintf("cannot load integrations for project [%s]", projectID)
 		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
 	return integrations, nil
 }
 
-func (repository *gormProjectIntegrationRepository) UpdatePositions(ctx context.Context, userID entities.UserID, integrationIDs []uuid.UUID) error {
+func (repository *gormProjectIntegrationRepository) Load(ctx context.Context, projectID uuid.UUID, integrationID uuid.UUID) (*entities.ProjectIntegration, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	integration := new(entities.ProjectIntegration)
+	err := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Where("integration_id = ?", integrationID).
+		First(integration).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("integration with ID [%s] does not exist in project [%s]", integrationID, projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load integration with ID [%s] in project [%s]", integrationID, projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return integration, nil
+}
+
+func (repository *gormProjectIntegrationRepository) Update(ctx context.Context, integration *entities.ProjectIntegration) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Save(integration).Error; err != nil {
+		msg := fmt.Sprintf("cannot update project integration with ID [%s]", integration.ID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormProjectIntegrationRepository) UpdatePositions(ctx context.Context, projectID uuid.UUID, integrationIDs []uuid.UUID) error {
 	ctx, span := repository.tracer.Start(ctx)
 	defer span.End()
 
 	updatedAt := time.Now().UTC()
-	err := crdbgorm.ExecuteTx(ctx, repository.db, nil, func(tx *gorm.DB) error {
+	err := executeTx(ctx, repository.db, func(tx *gorm.DB) error {
 		for index, integrationID := range integrationIDs {
 			err := tx.
 				Model(&entities.ProjectIntegration{}).
 				Where("integration_id = ?", integrationID).
-				Where("user_id = ?", userID).
+				Where("project_id = ?", projectID).
 				Updates(map[string]interface{}{"updated_at": updatedAt, "position": index}).
 				Error
 			if err != nil {
-				msg := fmt.Sprintf("cannot update integration [%s] with position [%d] for user [%s]", integrationID, index, userID)
+				msg := fmt.Sprintf("cannot update integration [%s] with position [%d] for project [%s]", integrationID, index, projectID)
 				return stacktrace.Propagate(err, msg)
 			}
 		}
 		return nil
 	})
 	if err != nil {
-		msg := fmt.Sprintf("cannot update integration positions for user [%s] with ID's [%+#v]", userID, integrationIDs)
+		msg := fmt.Sprintf("cannot update integration positions for project [%s] with ID's [%+#v]", projectID, integrationIDs)
 		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 	return nil
 }
+
+func (repository *gormProjectIntegrationRepository) DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	result := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Delete(&entities.ProjectIntegration{})
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot delete project integrations for project [%s]", projectID)
+		return 0, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	return result.RowsAffected, nil
+}
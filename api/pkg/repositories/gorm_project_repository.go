@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/palantir/stacktrace"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // gormProjectRepository is responsible for persisting entities.Project
@@ -32,17 +33,16 @@ func NewGormProjectRepository(
 	}
 }
 
-func (repository *gormProjectRepository) Delete(ctx context.Context, userID entities.UserID, projectID uuid.UUID) error {
+func (repository *gormProjectRepository) Delete(ctx context.Context, projectID uuid.UUID) error {
 	ctx, span := repository.tracer.Start(ctx)
 	defer span.End()
 
-	err := repository.db.WithContext(ctx).
-		Where("user_id = ?", userID).
+	err := gormDB(ctx, repository.db).
 		Where("id = ?", projectID).
 		Delete(&entities.Project{}).
 		Error
 	if err != nil {
-		msg := fmt.Sprintf("cannot save project with ID [%s] for user [%s]", projectID, userID)
+		msg := fmt.Sprintf("cannot delete project with ID [%s]", projectID)
 		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 	return nil
@@ -52,7 +52,7 @@ func (repository *gormProjectRepository) Store(ctx context.Context, project *ent
 	ctx, span := repository.tracer.Start(ctx)
 	defer span.End()
 
-	if err := repository.db.WithContext(ctx).Create(project).Error; err != nil {
+	if err := gormDB(ctx, repository.db).Create(project).Error; err != nil {
 		msg := fmt.Sprintf("cannot save project with ID [%s]", project.ID)
 		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
@@ -64,7 +64,7 @@ func (repository *gormProjectRepository) Update(ctx context.Context, project *en
 	ctx, span := repository.tracer.Start(ctx)
 	defer span.End()
 
-	if err := repository.db.WithContext(ctx).Save(project).Error; err != nil {
+	if err := gormDB(ctx, repository.db).Save(project).Error; err != nil {
 		msg := fmt.Sprintf("cannot update project with ID [%s]", project.ID)
 		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
@@ -72,30 +72,145 @@ func (repository *gormProjectRepository) Update(ctx context.Context, project *en
 	return nil
 }
 
-func (repository *gormProjectRepository) Fetch(ctx context.Context, userID entities.UserID) ([]*entities.Project, error) {
+func (repository *gormProjectRepository) Fetch(ctx context.Context, workspaceIDs []uuid.UUID) ([]*entities.Project, error) {
 	ctx, span := repository.tracer.Start(ctx)
 	defer span.End()
 
-	var projects []*entities.Project
-	err := repository.db.WithContext(ctx).
+	projects := make([]*entities.Project, 0)
+	if len(workspaceIDs) == 0 {
+		return projects, nil
+	}
+
+	err := gormDB(ctx, repository.db).
+		Where("workspace_id IN ?", workspaceIDs).
+		Order("created_at ASC").
+		Find(&projects).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot load projects for workspaces [%+#v]", workspaceIDs)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return projects, nil
+}
+
+func (repository *gormProjectRepository) CountByWorkspaceOwner(ctx context.Context, ownerID entities.UserID) (int64, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	var count int64
+	err := gormDB(ctx, repository.db).
+		Model(&entities.Project{}).
+		Joins("JOIN workspaces ON workspaces.id = projects.workspace_id").
+		Where("workspaces.owner_id = ?", ownerID).
+		Count(&count).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot count projects in the workspaces of user [%s]", ownerID)
+		return 0, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return count, nil
+}
+
+func (repository *gormProjectRepository) FetchCreatorsWithoutWorkspace(ctx context.Context) ([]entities.UserID, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	var userIDs []entities.UserID
+	err := gormDB(ctx, repository.db).
+		Model(&entities.Project{}).
+		Where("workspace_id IS NULL").
+		Distinct().
+		Pluck("user_id", &userIDs).
+		Error
+	if err != nil {
+		msg := "cannot fetch the creators of projects without a workspace"
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return userIDs, nil
+}
+
+func (repository *gormProjectRepository) AssignWorkspace(ctx context.Context, userID entities.UserID, workspaceID uuid.UUID) (int64, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	result := gormDB(ctx, repository.db).
+		Model(&entities.Project{}).
 		Where("user_id = ?", userID).
+		Where("workspace_id IS NULL").
+		Update("workspace_id", workspaceID)
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot assign the projects of user [%s] to workspace [%s]", userID, workspaceID)
+		return 0, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (repository *gormProjectRepository) AssignPublishableKeys(ctx context.Context, newKey func() (string, error)) (int64, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	var projectIDs []uuid.UUID
+	err := gormDB(ctx, repository.db).
+		Model(&entities.Project{}).
+		Where("publishable_key IS NULL").
+		Pluck("id", &projectIDs).
+		Error
+	if err != nil {
+		msg := "cannot fetch the projects without a publishable key"
+		return 0, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	for index, projectID := range projectIDs {
+		key, err := newKey()
+		if err != nil {
+			msg := fmt.Sprintf("cannot generate publishable key for project [%s]", projectID)
+			return int64(index), repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+
+		err = gormDB(ctx, repository.db).
+			Model(&entities.Project{}).
+			Where("id = ?", projectID).
+			Update("publishable_key", key).
+			Error
+		if err != nil {
+			msg := fmt.Sprintf("cannot store publishable key of project [%s]", projectID)
+			return int64(index), repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+	}
+
+	return int64(len(projectIDs)), nil
+}
+
+func (repository *gormProjectRepository) FetchAll(ctx context.Context, params IndexParams) ([]*entities.Project, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	projects := make([]*entities.Project, 0, params.Limit)
+	err := gormDB(ctx, repository.db).
+		Order("created_at ASC").
+		Order("id ASC").
+		Limit(params.Limit).
+		Offset(params.Skip).
 		Find(&projects).
 		Error
 	if err != nil {
-		msg := fmt.Sprintf("cannot load projects for user with ID [%s]", userID)
+		msg := fmt.Sprintf("cannot fetch projects with params [%+#v]", params)
 		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
 	return projects, nil
 }
 
-func (repository *gormProjectRepository) Load(ctx context.Context, userID entities.UserID, projectID uuid.UUID) (*entities.Project, error) {
+func (repository *gormProjectRepository) Load(ctx context.Context, projectID uuid.UUID) (*entities.Project, error) {
 	ctx, span := repository.tracer.Start(ctx)
 	defer span.End()
 
 	project := new(entities.Project)
-	err := repository.db.WithContext(ctx).
-		Where("user_id = ?", userID).
+	err := gormDB(ctx, repository.db).
 		Where("id = ?", projectID).
 		First(project).
 		Error
@@ -111,3 +226,48 @@ func (repository *gormProjectRepository) Load(ctx context.Context, userID entiti
 
 	return project, nil
 }
+
+func (repository *gormProjectRepository) LoadForUpdate(ctx context.Context, projectID uuid.UUID) (*entities.Project, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	project := new(entities.Project)
+	err := gormDB(ctx, repository.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", projectID).
+		First(project).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("project with ID [%s] does not exist", projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot lock project with ID [%s]", projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return project, nil
+}
+
+func (repository *gormProjectRepository) LoadByPublishableKey(ctx context.Context, publishableKey string) (*entities.Project, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	project := new(entities.Project)
+	err := gormDB(ctx, repository.db).
+		Where("publishable_key = ?", publishableKey).
+		First(project).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := "project with the publishable key does not exist"
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := "cannot load project by publishable key"
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return project, nil
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormProjectSettingsSnapshotRepository is responsible for persisting entities.ProjectSettingsSnapshot
+type gormProjectSettingsSnapshotRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormProjectSettingsSnapshotRepository creates the GORM version of the ProjectSettingsSnapshotRepository
+func NewGormProjectSettingsSnapshotRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) ProjectSettingsSnapshotRepository {
+	return &gormProjectSettingsSnapshotRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormProjectSettingsSnapshotRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormProjectSettingsSnapshotRepository) Store(ctx context.Context, snapshot *entities.ProjectSettingsSnapshot) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	err := gormDB(ctx, repository.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "project_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"user_id",
+				"publishable_key",
+				"url",
+				"allowed_origins",
+				"timezone",
+				"availability",
+				"display_rules",
+				"version",
+				"etag",
+				"settings",
+				"updated_at",
+			}),
+		}).
+		Create(snapshot).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot store settings snapshot of project [%s]", snapshot.ProjectID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormProjectSettingsSnapshotRepository) Load(ctx context.Context, projectID uuid.UUID) (*entities.ProjectSettingsSnapshot, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	snapshot := new(entities.ProjectSettingsSnapshot)
+	err := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		First(snapshot).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("settings snapshot of project [%s] does not exist", projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load settings snapshot of project [%s]", projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return snapshot, nil
+}
+
+func (repository *gormProjectSettingsSnapshotRepository) Delete(ctx context.Context, projectID uuid.UUID) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	err := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Delete(&entities.ProjectSettingsSnapshot{}).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete settings snapshot of project [%s]", projectID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
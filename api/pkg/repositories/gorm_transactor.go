@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbgorm"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+)
+
+type contextKey string
+
+const contextKeyTransaction = contextKey("repositories.transaction")
+
+// gormTransactor runs functions in a GORM transaction
+type gormTransactor struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormTransactor creates the GORM version of the Transactor
+func NewGormTransactor(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) Transactor {
+	return &gormTransactor{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormTransactor{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (transactor *gormTransactor) Transaction(ctx context.Context, callback func(ctx context.Context) error) error {
+	ctx, span := transactor.tracer.Start(ctx)
+	defer span.End()
+
+	err := executeTx(ctx, transactor.db, func(tx *gorm.DB) error {
+		return callback(context.WithValue(ctx, contextKeyTransaction, tx))
+	})
+	if err != nil {
+		return transactor.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), "cannot execute transaction"))
+	}
+
+	return nil
+}
+
+// gormDB returns the transaction stored in the context.Context or the db when there is no transaction
+func gormDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(contextKeyTransaction).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// ContextDB returns the transaction stored in the context.Context or the db when there is no transaction.
+// It lets packages outside the repositories e.g. the postgres queue write in the transaction of a Transactor.
+func ContextDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return gormDB(ctx, db)
+}
+
+// executeTx runs the callback in the transaction stored in the context.Context or in a new transaction
+func executeTx(ctx context.Context, db *gorm.DB, callback func(tx *gorm.DB) error) error {
+	if tx, ok := ctx.Value(contextKeyTransaction).(*gorm.DB); ok {
+		return callback(tx.WithContext(ctx))
+	}
+	return crdbgorm.ExecuteTx(ctx, db, nil, callback)
+}
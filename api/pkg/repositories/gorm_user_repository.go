@@ -8,9 +8,9 @@ import (
 
 	"github.com/NdoleStudio/superbutton/pkg/entities"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
-	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbgorm"
 	"github.com/palantir/stacktrace"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // gormUserRepository is responsible for persisting entities.User
@@ -38,7 +38,7 @@ func (repository *gormUserRepository) LoadBySubscriptionID(ctx context.Context,
 	defer span.End()
 
 	user := new(entities.User)
-	err := repository.db.WithContext(ctx).
+	err := gormDB(ctx, repository.db).
 		Where("subscription_id = ?", subscriptionID).
 		First(user).
 		Error
@@ -59,7 +59,7 @@ func (repository *gormUserRepository) Store(ctx context.Context, user *entities.
 	ctx, span := repository.tracer.Start(ctx)
 	defer span.End()
 
-	if err := repository.db.WithContext(ctx).Create(user).Error; err != nil {
+	if err := gormDB(ctx, repository.db).Create(user).Error; err != nil {
 		msg := fmt.Sprintf("cannot save user with ID [%s]", user.ID)
 		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
@@ -71,7 +71,7 @@ func (repository *gormUserRepository) Update(ctx context.Context, user *entities
 	ctx, span := repository.tracer.Start(ctx)
 	defer span.End()
 
-	if err := repository.db.WithContext(ctx).Save(user).Error; err != nil {
+	if err := gormDB(ctx, repository.db).Save(user).Error; err != nil {
 		msg := fmt.Sprintf("cannot update user with ID [%s]", user.ID)
 		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
@@ -84,7 +84,7 @@ func (repository *gormUserRepository) Load(ctx context.Context, userID entities.
 	defer span.End()
 
 	user := new(entities.User)
-	err := repository.db.WithContext(ctx).First(user, userID).Error
+	err := gormDB(ctx, repository.db).First(user, userID).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		msg := fmt.Sprintf("user with ID [%s] does not exist", user.ID)
 		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
@@ -98,11 +98,52 @@ func (repository *gormUserRepository) Load(ctx context.Context, userID entities.
 	return user, nil
 }
 
+func (repository *gormUserRepository) LoadForUpdate(ctx context.Context, userID entities.UserID) (*entities.User, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	user := new(entities.User)
+	err := gormDB(ctx, repository.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(user, userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("user with ID [%s] does not exist", userID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot lock user with ID [%s]", userID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return user, nil
+}
+
+func (repository *gormUserRepository) LoadByEmail(ctx context.Context, email string) (*entities.User, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	user := new(entities.User)
+	err := gormDB(ctx, repository.db).
+		Where("LOWER(email) = LOWER(?)", email).
+		First(user).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("user with email [%s] does not exist", email)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user with email [%s]", email)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return user, nil
+}
+
 func (repository *gormUserRepository) LoadOrStore(ctx context.Context, authUser entities.AuthUser) (user *entities.User, created bool, err error) {
 	ctx, span := repository.tracer.Start(ctx)
 	defer span.End()
 
-	err = crdbgorm.ExecuteTx(ctx, repository.db, nil, func(tx *gorm.DB) error {
+	err = executeTx(ctx, repository.db, func(tx *gorm.DB) error {
 		user = new(entities.User)
 		err = tx.First(user, authUser.ID).Error
 		if err == nil {
@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormWebhookDeliveryRepository is responsible for persisting entities.WebhookDelivery
+type gormWebhookDeliveryRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormWebhookDeliveryRepository creates the GORM version of the WebhookDeliveryRepository
+func NewGormWebhookDeliveryRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) WebhookDeliveryRepository {
+	return &gormWebhookDeliveryRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormWebhookDeliveryRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormWebhookDeliveryRepository) Store(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Clauses(clause.OnConflict{DoNothing: true}).Create(delivery).Error; err != nil {
+		msg := fmt.Sprintf("cannot save delivery [%s] of webhook [%s]", delivery.ID, delivery.WebhookID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWebhookDeliveryRepository) Update(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Save(delivery).Error; err != nil {
+		msg := fmt.Sprintf("cannot update delivery [%s] of webhook [%s]", delivery.ID, delivery.WebhookID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWebhookDeliveryRepository) Load(ctx context.Context, webhookID uuid.UUID, deliveryID uuid.UUID) (*entities.WebhookDelivery, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	delivery := new(entities.WebhookDelivery)
+	err := gormDB(ctx, repository.db).
+		Where("webhook_id = ?", webhookID).
+		Where("id = ?", deliveryID).
+		First(delivery).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("delivery [%s] does not exist for webhook [%s]", deliveryID, webhookID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load delivery [%s] of webhook [%s]", deliveryID, webhookID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return delivery, nil
+}
+
+func (repository *gormWebhookDeliveryRepository) LoadByID(ctx context.Context, deliveryID uuid.UUID) (*entities.WebhookDelivery, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	delivery := new(entities.WebhookDelivery)
+	err := gormDB(ctx, repository.db).Where("id = ?", deliveryID).First(delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("webhook delivery [%s] does not exist", deliveryID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load webhook delivery [%s]", deliveryID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return delivery, nil
+}
+
+func (repository *gormWebhookDeliveryRepository) Index(ctx context.Context, webhookID uuid.UUID, params IndexParams) ([]*entities.WebhookDelivery, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	query := gormDB(ctx, repository.db).
+		Where("webhook_id = ?", webhookID)
+
+	if len(params.Query) > 0 {
+		queryPattern := "%" + params.Query + "%"
+		query = query.Where(
+			repository.db.Where("event_type ILIKE ?", queryPattern).
+				Or("event_id ILIKE ?", queryPattern).
+				Or("status ILIKE ?", queryPattern),
+		)
+	}
+
+	deliveries := make([]*entities.WebhookDelivery, 0, params.Limit)
+	err := query.Order("created_at DESC").
+		Limit(params.Limit).
+		Offset(params.Skip).
+		Find(&deliveries).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch deliveries of webhook [%s] with params [%+#v]", webhookID, params)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return deliveries, nil
+}
+
+func (repository *gormWebhookDeliveryRepository) DeleteForWebhook(ctx context.Context, webhookID uuid.UUID) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	err := gormDB(ctx, repository.db).
+		Where("webhook_id = ?", webhookID).
+		Delete(&entities.WebhookDelivery{}).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete the deliveries of webhook [%s]", webhookID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormWebhookRepository is responsible for persisting entities.Webhook
+type gormWebhookRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormWebhookRepository creates the GORM version of the WebhookRepository
+func NewGormWebhookRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) WebhookRepository {
+	return &gormWebhookRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormWebhookRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormWebhookRepository) Store(ctx context.Context, webhook *entities.Webhook) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Create(webhook).Error; err != nil {
+		msg := fmt.Sprintf("cannot save webhook [%s] of project [%s]", webhook.ID, webhook.ProjectID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWebhookRepository) Update(ctx context.Context, webhook *entities.Webhook) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Save(webhook).Error; err != nil {
+		msg := fmt.Sprintf("cannot update webhook [%s] of project [%s]", webhook.ID, webhook.ProjectID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWebhookRepository) Delete(ctx context.Context, webhook *entities.Webhook) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Delete(webhook).Error; err != nil {
+		msg := fmt.Sprintf("cannot delete webhook [%s] of project [%s]", webhook.ID, webhook.ProjectID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWebhookRepository) Load(ctx context.Context, projectID uuid.UUID, webhookID uuid.UUID) (*entities.Webhook, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	webhook := new(entities.Webhook)
+	err := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Where("id = ?", webhookID).
+		First(webhook).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("webhook [%s] does not exist for project [%s]", webhookID, projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load webhook [%s] of project [%s]", webhookID, projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return webhook, nil
+}
+
+func (repository *gormWebhookRepository) LoadByID(ctx context.Context, webhookID uuid.UUID) (*entities.Webhook, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	webhook := new(entities.Webhook)
+	err := gormDB(ctx, repository.db).Where("id = ?", webhookID).First(webhook).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("webhook [%s] does not exist", webhookID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load webhook [%s]", webhookID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return webhook, nil
+}
+
+func (repository *gormWebhookRepository) Fetch(ctx context.Context, projectID uuid.UUID) ([]*entities.Webhook, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	webhooks := make([]*entities.Webhook, 0)
+	err := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Order("created_at DESC").
+		Find(&webhooks).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch webhooks of project [%s]", projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return webhooks, nil
+}
+
+func (repository *gormWebhookRepository) FetchEnabled(ctx context.Context, projectID uuid.UUID) ([]*entities.Webhook, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	webhooks := make([]*entities.Webhook, 0)
+	err := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Where("enabled = ?", true).
+		Find(&webhooks).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch enabled webhooks of project [%s]", projectID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return webhooks, nil
+}
+
+func (repository *gormWebhookRepository) RecordFailure(ctx context.Context, webhookID uuid.UUID) (*entities.Webhook, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	webhook := new(entities.Webhook)
+	result := gormDB(ctx, repository.db).
+		Model(webhook).
+		Clauses(clause.Returning{}).
+		Where("id = ?", webhookID).
+		Updates(map[string]any{
+			"consecutive_failures": gorm.Expr("consecutive_failures + 1"),
+			"updated_at":           time.Now().UTC(),
+		})
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot increment the consecutive failures of webhook [%s]", webhookID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	if result.RowsAffected == 0 {
+		msg := fmt.Sprintf("webhook [%s] does not exist", webhookID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeNotFound, msg))
+	}
+
+	return webhook, nil
+}
+
+func (repository *gormWebhookRepository) ResetFailures(ctx context.Context, webhookID uuid.UUID) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	err := gormDB(ctx, repository.db).
+		Model(&entities.Webhook{}).
+		Where("id = ?", webhookID).
+		Where("consecutive_failures > ?", 0).
+		Updates(map[string]any{
+			"consecutive_failures": 0,
+			"updated_at":           time.Now().UTC(),
+		}).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot reset the consecutive failures of webhook [%s]", webhookID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+)
+
+// widgetEventInsertBatchSize is the maximum number of entities.WidgetEvent in a single insert statement
+const widgetEventInsertBatchSize = 100
+
+// gormWidgetEventRepository is responsible for persisting entities.WidgetEvent
+type gormWidgetEventRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormWidgetEventRepository creates the GORM version of the WidgetEventRepository
+func NewGormWidgetEventRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) WidgetEventRepository {
+	return &gormWidgetEventRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormWidgetEventRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormWidgetEventRepository) StoreBatch(ctx context.Context, events []*entities.WidgetEvent) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := gormDB(ctx, repository.db).CreateInBatches(events, widgetEventInsertBatchSize).Error; err != nil {
+		msg := fmt.Sprintf("cannot store [%d] widget events for project [%s]", len(events), events[0].ProjectID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWidgetEventRepository) DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	result := gormDB(ctx, repository.db).
+		Where("project_id = ?", projectID).
+		Delete(&entities.WidgetEvent{})
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot delete widget events for project [%s]", projectID)
+		return 0, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+)
+
+// gormWidgetEventRollupRepository is responsible for persisting entities.WidgetEventRollup
+type gormWidgetEventRollupRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormWidgetEventRollupRepository creates the GORM version of the WidgetEventRollupRepository
+func NewGormWidgetEventRollupRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) WidgetEventRollupRepository {
+	return &gormWidgetEventRollupRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormWidgetEventRollupRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+// RollupHourly replaces the counts instead of incrementing them so that it is safe to run the rollup again for the same hours
+func (repository *gormWidgetEventRollupRepository) RollupHourly(ctx context.Context, from time.Time, to time.Time) (int64, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	query := `
+INSERT INTO widget_event_hourly_rollups (project_id, integration_id, type, bucket_start, user_id, count, updated_at)
+SELECT
+	project_id,
+	COALESCE(integration_id, ?),
+	type,
+	date_trunc('hour', created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC',
+	MAX(user_id),
+	COUNT(*),
+	NOW()
+FROM widget_events
+WHERE created_at >= ? AND created_at < ?
+GROUP BY 1, 2, 3, 4
+ON CONFLICT (project_id, integration_id, type, bucket_start) DO UPDATE
+SET count = EXCLUDED.count, user_id = EXCLUDED.user_id, updated_at = EXCLUDED.updated_at`
+
+	result := gormDB(ctx, repository.db).Exec(query, uuid.Nil, from, to)
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot rollup hourly widget events between [%s] and [%s]", from, to)
+		return 0, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	return result.RowsAffected, nil
+}
+
+// RollupDaily replaces the counts instead of incrementing them so that it is safe to run the rollup again for the same days
+func (repository *gormWidgetEventRollupRepository) RollupDaily(ctx context.Context, from time.Time, to time.Time) (int64, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	query := `
+INSERT INTO widget_event_daily_rollups (project_id, integration_id, type, bucket_start, user_id, count, updated_at)
+SELECT
+	project_id,
+	integration_id,
+	type,
+	date_trunc('day', bucket_start AT TIME ZONE 'UTC') AT TIME ZONE 'UTC',
+	MAX(user_id),
+	SUM(count),
+	NOW()
+FROM widget_event_hourly_rollups
+WHERE bucket_start >= ? AND bucket_start < ?
+GROUP BY 1, 2, 3, 4
+ON CONFLICT (project_id, integration_id, type, bucket_start) DO UPDATE
+SET count = EXCLUDED.count, user_id = EXCLUDED.user_id, updated_at = EXCLUDED.updated_at`
+
+	result := gormDB(ctx, repository.db).Exec(query, from, to)
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot rollup daily widget events between [%s] and [%s]", from, to)
+		return 0, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (repository *gormWidgetEventRollupRepository) LastHourlyBucket(ctx context.Context) (*time.Time, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	var bucket sql.NullTime
+	err := gormDB(ctx, repository.db).
+		Table(entities.WidgetEventHourlyRollup{}.TableName()).
+		Select("MAX(bucket_start)").
+		Row().
+		Scan(&bucket)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load the newest bucket of [%s]", entities.WidgetEventHourlyRollup{}.TableName())
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if !bucket.Valid {
+		return nil, nil
+	}
+	return &bucket.Time, nil
+}
+
+func (repository *gormWidgetEventRollupRepository) Aggregate(ctx context.Context, projectID uuid.UUID, from time.Time, to time.Time, granularity entities.AnalyticsGranularity, location *time.Location) ([]*entities.WidgetEventRollup, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	query := `
+SELECT
+	project_id,
+	COALESCE(integration_id, ?) AS integration_id,
+	type,
+	date_trunc(?, created_at AT TIME ZONE ?) AT TIME ZONE ? AS bucket_start,
+	MAX(user_id) AS user_id,
+	COUNT(*) AS count
+FROM widget_events
+WHERE project_id = ? AND created_at >= ? AND created_at < ?
+GROUP BY 1, 2, 3, 4
+ORDER BY 4 ASC`
+
+	var rollups []*entities.WidgetEventRollup
+	err := gormDB(ctx, repository.db).
+		Raw(query, uuid.Nil, string(granularity), location.String(), location.String(), projectID, from, to).
+		Scan(&rollups).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot aggregate widget events for project [%s] between [%s] and [%s] by [%s] in [%s]", projectID, from, to, granularity, location)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return rollups, nil
+}
+
+func (repository *gormWidgetEventRollupRepository) FetchHourly(ctx context.Context, projectID uuid.UUID, from time.Time, to time.Time) ([]*entities.WidgetEventRollup, error) {
+	return repository.fetch(ctx, entities.WidgetEventHourlyRollup{}.TableName(), projectID, from, to)
+}
+
+func (repository *gormWidgetEventRollupRepository) FetchDaily(ctx context.Context, projectID uuid.UUID, from time.Time, to time.Time) ([]*entities.WidgetEventRollup, error) {
+	return repository.fetch(ctx, entities.WidgetEventDailyRollup{}.TableName(), projectID, from, to)
+}
+
+func (repository *gormWidgetEventRollupRepository) DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	var count int64
+	for _, table := range []string{entities.WidgetEventHourlyRollup{}.TableName(), entities.WidgetEventDailyRollup{}.TableName()} {
+		result := gormDB(ctx, repository.db).Exec(fmt.Sprintf("DELETE FROM %s WHERE project_id = ?", table), projectID)
+		if result.Error != nil {
+			msg := fmt.Sprintf("cannot delete [%s] for project [%s]", table, projectID)
+			return count, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+		}
+		count += result.RowsAffected
+	}
+
+	return count, nil
+}
+
+func (repository *gormWidgetEventRollupRepository) fetch(ctx context.Context, table string, projectID uuid.UUID, from time.Time, to time.Time) ([]*entities.WidgetEventRollup, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	var rollups []*entities.WidgetEventRollup
+	err := gormDB(ctx, repository.db).
+		Table(table).
+		Where("project_id = ?", projectID).
+		Where("bucket_start >= ?", from).
+		Where("bucket_start < ?", to).
+		Order("bucket_start ASC").
+		Find(&rollups).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch [%s] for project [%s] between [%s] and [%s]", table, projectID, from, to)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return rollups, nil
+}
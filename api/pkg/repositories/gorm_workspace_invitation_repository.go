@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+)
+
+// gormWorkspaceInvitationRepository is responsible for persisting entities.WorkspaceInvitation
+type gormWorkspaceInvitationRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormWorkspaceInvitationRepository creates the GORM version of the WorkspaceInvitationRepository
+func NewGormWorkspaceInvitationRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) WorkspaceInvitationRepository {
+	return &gormWorkspaceInvitationRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormWorkspaceInvitationRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormWorkspaceInvitationRepository) Store(ctx context.Context, invitation *entities.WorkspaceInvitation) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Create(invitation).Error; err != nil {
+		msg := fmt.Sprintf("cannot save invitation [%s] of workspace [%s]", invitation.ID, invitation.WorkspaceID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWorkspaceInvitationRepository) UpdatePending(ctx context.Context, invitation *entities.WorkspaceInvitation, now time.Time) (bool, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	result := gormDB(ctx, repository.db).
+		Model(invitation).
+		Where("accepted_at IS NULL").
+		Where("revoked_at IS NULL").
+		Where("expires_at > ?", now).
+		Select("accepted_at", "accepted_by", "revoked_at", "updated_at").
+		Updates(invitation)
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot update pending invitation [%s] of workspace [%s]", invitation.ID, invitation.WorkspaceID)
+		return false, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (repository *gormWorkspaceInvitationRepository) Load(ctx context.Context, workspaceID uuid.UUID, invitationID uuid.UUID) (*entities.WorkspaceInvitation, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	invitation := new(entities.WorkspaceInvitation)
+	err := gormDB(ctx, repository.db).
+		Where("workspace_id = ?", workspaceID).
+		Where("id = ?", invitationID).
+		First(invitation).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("invitation [%s] does not exist in workspace [%s]", invitationID, workspaceID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load invitation [%s] of workspace [%s]", invitationID, workspaceID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return invitation, nil
+}
+
+func (repository *gormWorkspaceInvitationRepository) LoadByTokenHash(ctx context.Context, tokenHash string) (*entities.WorkspaceInvitation, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	invitation := new(entities.WorkspaceInvitation)
+	err := gormDB(ctx, repository.db).Where("token_hash = ?", tokenHash).First(invitation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := "invitation does not exist for token"
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := "cannot load invitation by token"
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return invitation, nil
+}
+
+func (repository *gormWorkspaceInvitationRepository) Fetch(ctx context.Context, workspaceID uuid.UUID) ([]*entities.WorkspaceInvitation, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	invitations := make([]*entities.WorkspaceInvitation, 0)
+	err := gormDB(ctx, repository.db).
+		Where("workspace_id = ?", workspaceID).
+		Order("created_at DESC").
+		Find(&invitations).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch invitations of workspace [%s]", workspaceID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return invitations, nil
+}
+
+func (repository *gormWorkspaceInvitationRepository) FetchPending(ctx context.Context, email string, now time.Time) ([]*entities.WorkspaceInvitation, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	invitations := make([]*entities.WorkspaceInvitation, 0)
+	err := gormDB(ctx, repository.db).
+		Where("LOWER(email) = LOWER(?)", email).
+		Where("accepted_at IS NULL").
+		Where("revoked_at IS NULL").
+		Where("expires_at > ?", now).
+		Order("created_at ASC").
+		Find(&invitations).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch pending invitations for email [%s]", email)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return invitations, nil
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+)
+
+// gormWorkspaceMemberRepository is responsible for persisting entities.WorkspaceMember
+type gormWorkspaceMemberRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormWorkspaceMemberRepository creates the GORM version of the WorkspaceMemberRepository
+func NewGormWorkspaceMemberRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) WorkspaceMemberRepository {
+	return &gormWorkspaceMemberRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormWorkspaceMemberRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormWorkspaceMemberRepository) Store(ctx context.Context, member *entities.WorkspaceMember) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Create(member).Error; err != nil {
+		msg := fmt.Sprintf("cannot save member [%s] of workspace [%s]", member.UserID, member.WorkspaceID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWorkspaceMemberRepository) Update(ctx context.Context, member *entities.WorkspaceMember) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Save(member).Error; err != nil {
+		msg := fmt.Sprintf("cannot update member [%s] of workspace [%s]", member.UserID, member.WorkspaceID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWorkspaceMemberRepository) Delete(ctx context.Context, workspaceID uuid.UUID, userID entities.UserID) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	err := gormDB(ctx, repository.db).
+		Where("workspace_id = ?", workspaceID).
+		Where("user_id = ?", userID).
+		Delete(&entities.WorkspaceMember{}).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete member [%s] of workspace [%s]", userID, workspaceID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWorkspaceMemberRepository) Load(ctx context.Context, workspaceID uuid.UUID, userID entities.UserID) (*entities.WorkspaceMember, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	member := new(entities.WorkspaceMember)
+	err := gormDB(ctx, repository.db).
+		Where("workspace_id = ?", workspaceID).
+		Where("user_id = ?", userID).
+		First(member).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("user [%s] is not a member of workspace [%s]", userID, workspaceID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load member [%s] of workspace [%s]", userID, workspaceID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return member, nil
+}
+
+func (repository *gormWorkspaceMemberRepository) Fetch(ctx context.Context, workspaceID uuid.UUID) ([]*entities.WorkspaceMember, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	members := make([]*entities.WorkspaceMember, 0)
+	err := gormDB(ctx, repository.db).
+		Where("workspace_id = ?", workspaceID).
+		Order("created_at ASC").
+		Find(&members).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch members of workspace [%s]", workspaceID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return members, nil
+}
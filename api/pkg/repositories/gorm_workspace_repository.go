@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormWorkspaceRepository is responsible for persisting entities.Workspace
+type gormWorkspaceRepository struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+	db     *gorm.DB
+}
+
+// NewGormWorkspaceRepository creates the GORM version of the WorkspaceRepository
+func NewGormWorkspaceRepository(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	db *gorm.DB,
+) WorkspaceRepository {
+	return &gormWorkspaceRepository{
+		logger: logger.WithService(fmt.Sprintf("%T", &gormWorkspaceRepository{})),
+		tracer: tracer,
+		db:     db,
+	}
+}
+
+func (repository *gormWorkspaceRepository) Store(ctx context.Context, workspace *entities.Workspace) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Create(workspace).Error; err != nil {
+		msg := fmt.Sprintf("cannot save workspace with ID [%s]", workspace.ID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWorkspaceRepository) StorePersonal(ctx context.Context, workspace *entities.Workspace) (bool, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	result := gormDB(ctx, repository.db).Clauses(clause.OnConflict{DoNothing: true}).Create(workspace)
+	if result.Error != nil {
+		msg := fmt.Sprintf("cannot save personal workspace with ID [%s] for user [%s]", workspace.ID, workspace.OwnerID)
+		return false, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(result.Error, msg))
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (repository *gormWorkspaceRepository) Update(ctx context.Context, workspace *entities.Workspace) error {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	if err := gormDB(ctx, repository.db).Save(workspace).Error; err != nil {
+		msg := fmt.Sprintf("cannot update workspace with ID [%s]", workspace.ID)
+		return repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (repository *gormWorkspaceRepository) Load(ctx context.Context, workspaceID uuid.UUID) (*entities.Workspace, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	workspace := new(entities.Workspace)
+	err := gormDB(ctx, repository.db).
+		Where("id = ?", workspaceID).
+		First(workspace).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("workspace with ID [%s] does not exist", workspaceID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load workspace with ID [%s]", workspaceID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return workspace, nil
+}
+
+func (repository *gormWorkspaceRepository) LoadPersonal(ctx context.Context, userID entities.UserID) (*entities.Workspace, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	workspace := new(entities.Workspace)
+	err := gormDB(ctx, repository.db).
+		Where("owner_id = ?", userID).
+		Where("personal = ?", true).
+		First(workspace).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		msg := fmt.Sprintf("personal workspace of user [%s] does not exist", userID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, ErrCodeNotFound, msg))
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load personal workspace of user [%s]", userID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return workspace, nil
+}
+
+func (repository *gormWorkspaceRepository) FetchForMember(ctx context.Context, userID entities.UserID) ([]*entities.UserWorkspace, error) {
+	ctx, span := repository.tracer.Start(ctx)
+	defer span.End()
+
+	workspaces := make([]*entities.UserWorkspace, 0)
+	err := gormDB(ctx, repository.db).
+		Model(&entities.Workspace{}).
+		Select("workspaces.*, workspace_members.role").
+		Joins("JOIN workspace_members ON workspace_members.workspace_id = workspaces.id").
+		Where("workspace_members.user_id = ?", userID).
+		Order("workspaces.personal DESC").
+		Order("workspaces.created_at ASC").
+		Scan(&workspaces).
+		Error
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch workspaces of user [%s]", userID)
+		return nil, repository.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return workspaces, nil
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// IntegrationRepository loads and persists an entities.IntegrationEntity together with its entities.ProjectIntegration
+type IntegrationRepository[T entities.IntegrationEntity] interface {
+	// Store a new entities.IntegrationEntity
+	Store(ctx context.Context, integration T) error
+
+	// Update an entities.IntegrationEntity
+	Update(ctx context.Context, integration T) error
+
+	// Fetch all entities.IntegrationEntity for a project
+	Fetch(ctx context.Context, projectID uuid.UUID) ([]T, error)
+
+	// FetchMultiple returns multiple entities.IntegrationEntity in a project
+	FetchMultiple(ctx context.Context, projectID uuid.UUID, integrationIDs []uuid.UUID) ([]T, error)
+
+	// Delete an entities.IntegrationEntity which belongs to a project
+	Delete(ctx context.Context, projectID uuid.UUID, integrationID uuid.UUID) error
+
+	// Load an entities.IntegrationEntity by projectID and integrationID. The caller is responsible for authorizing access to the project.
+	Load(ctx context.Context, projectID uuid.UUID, integrationID uuid.UUID) (T, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// ProjectBlockedOriginRepository loads and persists an entities.ProjectBlockedOrigin
+type ProjectBlockedOriginRepository interface {
+	// Increment the number of blocked requests of an entities.Project from an origin
+	Increment(ctx context.Context, project *entities.Project, origin string) error
+
+	// Fetch all entities.ProjectBlockedOrigin of a project ordered by the most recently blocked
+	Fetch(ctx context.Context, projectID uuid.UUID) ([]*entities.ProjectBlockedOrigin, error)
+
+	// DeleteAll deletes every entities.ProjectBlockedOrigin of a project and returns the number of deleted rows
+	DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error)
+}
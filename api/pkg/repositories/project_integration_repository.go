@@ -11,8 +11,17 @@ import (
 // ProjectIntegrationRepository loads and persists an entities.ProjectIntegration
 type ProjectIntegrationRepository interface {
 	// Fetch all entities.ProjectIntegration for a project
-	Fetch(ctx context.Context, userID entities.UserID, projectID uuid.UUID) ([]*entities.ProjectIntegration, error)
+	Fetch(ctx context.Context, projectID uuid.UUID) ([]*entities.ProjectIntegration, error)
 
-	// UpdatePositions updates the positions of multiple project integrations
-	UpdatePositions(ctx context.Context, userID entities.UserID, integrationIDs []uuid.UUID) error
+	// Load an entities.ProjectIntegration by the ID of its integration
+	Load(ctx context.Context, projectID uuid.UUID, integrationID uuid.UUID) (*entities.ProjectIntegration, error)
+
+	// Update an entities.ProjectIntegration
+	Update(ctx context.Context, integration *entities.ProjectIntegration) error
+
+	// UpdatePositions updates the positions of multiple integrations in a project
+	UpdatePositions(ctx context.Context, projectID uuid.UUID, integrationIDs []uuid.UUID) error
+
+	// DeleteAll deletes every entities.ProjectIntegration in a project and returns the number of deleted rows
+	DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error)
 }
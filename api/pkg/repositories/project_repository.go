@@ -16,12 +16,34 @@ type ProjectRepository interface {
 	// Update a new entities.Project
 	Update(ctx context.Context, user *entities.Project) error
 
-	// Fetch all entities.Project for a user
-	Fetch(ctx context.Context, userID entities.UserID) ([]*entities.Project, error)
+	// Fetch all entities.Project in a list of entities.Workspace
+	Fetch(ctx context.Context, workspaceIDs []uuid.UUID) ([]*entities.Project, error)
 
-	// Load an entities.Project by entities.UserID
-	Load(ctx context.Context, userID entities.UserID, projectID uuid.UUID) (*entities.Project, error)
+	// CountByWorkspaceOwner counts the entities.Project in every entities.Workspace owned by a user
+	CountByWorkspaceOwner(ctx context.Context, ownerID entities.UserID) (int64, error)
 
-	// Delete an entities.Project by entities.UserID and projectID
-	Delete(ctx context.Context, userID entities.UserID, projectID uuid.UUID) error
+	// FetchAll fetches a page of the entities.Project of every user ordered by creation time
+	FetchAll(ctx context.Context, params IndexParams) ([]*entities.Project, error)
+
+	// Load an entities.Project by ID. The caller is responsible for authorizing access to the project.
+	Load(ctx context.Context, projectID uuid.UUID) (*entities.Project, error)
+
+	// LoadForUpdate loads an entities.Project by ID and locks it until the transaction in the context ends
+	LoadForUpdate(ctx context.Context, projectID uuid.UUID) (*entities.Project, error)
+
+	// LoadByPublishableKey loads an entities.Project by its publishable key without an authenticated user
+	LoadByPublishableKey(ctx context.Context, publishableKey string) (*entities.Project, error)
+
+	// Delete an entities.Project by ID
+	Delete(ctx context.Context, projectID uuid.UUID) error
+
+	// FetchCreatorsWithoutWorkspace fetches the users who created an entities.Project which does not belong to an entities.Workspace
+	FetchCreatorsWithoutWorkspace(ctx context.Context) ([]entities.UserID, error)
+
+	// AssignWorkspace moves the entities.Project of a creator which do not belong to an entities.Workspace into a workspace
+	AssignWorkspace(ctx context.Context, userID entities.UserID, workspaceID uuid.UUID) (int64, error)
+
+	// AssignPublishableKeys sets a new key on every entities.Project without a publishable key and returns the number of updated projects.
+	// It should run once in the transaction which adds the publishable key because a project without a key was revoked afterwards.
+	AssignPublishableKeys(ctx context.Context, newKey func() (string, error)) (int64, error)
 }
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// ProjectSettingsSnapshotRepository loads and persists an entities.ProjectSettingsSnapshot
+type ProjectSettingsSnapshotRepository interface {
+	// Store creates or replaces the entities.ProjectSettingsSnapshot of a project
+	Store(ctx context.Context, snapshot *entities.ProjectSettingsSnapshot) error
+
+	// Load the entities.ProjectSettingsSnapshot of a project
+	Load(ctx context.Context, projectID uuid.UUID) (*entities.ProjectSettingsSnapshot, error)
+
+	// Delete the entities.ProjectSettingsSnapshot of a project
+	Delete(ctx context.Context, projectID uuid.UUID) error
+}
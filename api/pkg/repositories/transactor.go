@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+)
+
+// Transactor runs a function in a database transaction which is shared by all repositories through the context.Context
+type Transactor interface {
+	// Transaction executes the callback in a transaction. The callback joins the existing transaction when the context already has one.
+	Transaction(ctx context.Context, callback func(ctx context.Context) error) error
+}
@@ -17,6 +17,12 @@ type UserRepository interface {
 	// Load an entities.User by entities.UserID
 	Load(ctx context.Context, userID entities.UserID) (*entities.User, error)
 
+	// LoadForUpdate loads an entities.User and locks it until the transaction in the context ends
+	LoadForUpdate(ctx context.Context, userID entities.UserID) (*entities.User, error)
+
+	// LoadByEmail loads an entities.User by their email address
+	LoadByEmail(ctx context.Context, email string) (*entities.User, error)
+
 	// LoadBySubscriptionID fetches a user based on the subscriptionID
 	LoadBySubscriptionID(ctx context.Context, subscriptionID string) (*entities.User, error)
 
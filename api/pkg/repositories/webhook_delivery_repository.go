@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WebhookDeliveryRepository loads and persists an entities.WebhookDelivery
+type WebhookDeliveryRepository interface {
+	// Store a new entities.WebhookDelivery. A delivery with the same ID which already exists is left unchanged.
+	Store(ctx context.Context, delivery *entities.WebhookDelivery) error
+
+	// Update an entities.WebhookDelivery
+	Update(ctx context.Context, delivery *entities.WebhookDelivery) error
+
+	// Load an entities.WebhookDelivery of a webhook
+	Load(ctx context.Context, webhookID uuid.UUID, deliveryID uuid.UUID) (*entities.WebhookDelivery, error)
+
+	// LoadByID loads an entities.WebhookDelivery without knowing the webhook
+	LoadByID(ctx context.Context, deliveryID uuid.UUID) (*entities.WebhookDelivery, error)
+
+	// Index returns a page of entities.WebhookDelivery for a webhook ordered by the newest first
+	Index(ctx context.Context, webhookID uuid.UUID, params IndexParams) ([]*entities.WebhookDelivery, error)
+
+	// DeleteForWebhook deletes every entities.WebhookDelivery of a webhook
+	DeleteForWebhook(ctx context.Context, webhookID uuid.UUID) error
+}
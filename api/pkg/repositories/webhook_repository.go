@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WebhookRepository loads and persists an entities.Webhook
+type WebhookRepository interface {
+	// Store a new entities.Webhook
+	Store(ctx context.Context, webhook *entities.Webhook) error
+
+	// Update an entities.Webhook
+	Update(ctx context.Context, webhook *entities.Webhook) error
+
+	// Delete an entities.Webhook
+	Delete(ctx context.Context, webhook *entities.Webhook) error
+
+	// Load an entities.Webhook of a project
+	Load(ctx context.Context, projectID uuid.UUID, webhookID uuid.UUID) (*entities.Webhook, error)
+
+	// LoadByID loads an entities.Webhook without knowing the project
+	LoadByID(ctx context.Context, webhookID uuid.UUID) (*entities.Webhook, error)
+
+	// Fetch the entities.Webhook of a project ordered by the newest first
+	Fetch(ctx context.Context, projectID uuid.UUID) ([]*entities.Webhook, error)
+
+	// FetchEnabled fetches the entities.Webhook of a project which are enabled
+	FetchEnabled(ctx context.Context, projectID uuid.UUID) ([]*entities.Webhook, error)
+
+	// RecordFailure increments the consecutive failures of an entities.Webhook and returns the updated entities.Webhook
+	RecordFailure(ctx context.Context, webhookID uuid.UUID) (*entities.Webhook, error)
+
+	// ResetFailures sets the consecutive failures of an entities.Webhook to 0
+	ResetFailures(ctx context.Context, webhookID uuid.UUID) error
+}
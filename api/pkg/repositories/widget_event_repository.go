@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WidgetEventRepository persists entities.WidgetEvent
+type WidgetEventRepository interface {
+	// StoreBatch stores multiple entities.WidgetEvent with batched inserts
+	StoreBatch(ctx context.Context, events []*entities.WidgetEvent) error
+
+	// DeleteAll deletes every entities.WidgetEvent of a project and returns the number of deleted rows
+	DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error)
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WidgetEventRollupRepository aggregates entities.WidgetEvent into entities.WidgetEventRollup
+type WidgetEventRollupRepository interface {
+	// RollupHourly recomputes the entities.WidgetEventHourlyRollup of the hours between from and to from the entities.WidgetEvent
+	RollupHourly(ctx context.Context, from time.Time, to time.Time) (int64, error)
+
+	// RollupDaily recomputes the entities.WidgetEventDailyRollup of the days between from and to from the entities.WidgetEventHourlyRollup
+	RollupDaily(ctx context.Context, from time.Time, to time.Time) (int64, error)
+
+	// LastHourlyBucket returns the start of the newest entities.WidgetEventHourlyRollup or nil if no events were rolled up
+	LastHourlyBucket(ctx context.Context) (*time.Time, error)
+
+	// Aggregate counts the entities.WidgetEvent of a project between from and to in buckets of the granularity in a location.
+	// It is used for the locations whose offset is not a whole number of hours so that the buckets cannot be built from the hourly rollups.
+	Aggregate(ctx context.Context, projectID uuid.UUID, from time.Time, to time.Time, granularity entities.AnalyticsGranularity, location *time.Location) ([]*entities.WidgetEventRollup, error)
+
+	// FetchHourly fetches the entities.WidgetEventHourlyRollup of a project which start between from and to
+	FetchHourly(ctx context.Context, projectID uuid.UUID, from time.Time, to time.Time) ([]*entities.WidgetEventRollup, error)
+
+	// FetchDaily fetches the entities.WidgetEventDailyRollup of a project which start between from and to
+	FetchDaily(ctx context.Context, projectID uuid.UUID, from time.Time, to time.Time) ([]*entities.WidgetEventRollup, error)
+
+	// DeleteAll deletes the hourly and daily entities.WidgetEventRollup of a project and returns the number of deleted rows
+	DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error)
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WorkspaceInvitationRepository loads and persists an entities.WorkspaceInvitation
+type WorkspaceInvitationRepository interface {
+	// Store a new entities.WorkspaceInvitation
+	Store(ctx context.Context, invitation *entities.WorkspaceInvitation) error
+
+	// UpdatePending stores the accepted and revoked fields of an entities.WorkspaceInvitation only if it is still pending at a time.
+	// updated is false when the invitation was accepted, revoked or expired by another request.
+	UpdatePending(ctx context.Context, invitation *entities.WorkspaceInvitation, now time.Time) (updated bool, err error)
+
+	// Load an entities.WorkspaceInvitation of a workspace
+	Load(ctx context.Context, workspaceID uuid.UUID, invitationID uuid.UUID) (*entities.WorkspaceInvitation, error)
+
+	// LoadByTokenHash loads the entities.WorkspaceInvitation with the hash of a token
+	LoadByTokenHash(ctx context.Context, tokenHash string) (*entities.WorkspaceInvitation, error)
+
+	// Fetch all the entities.WorkspaceInvitation of a workspace with the newest first
+	Fetch(ctx context.Context, workspaceID uuid.UUID) ([]*entities.WorkspaceInvitation, error)
+
+	// FetchPending fetches the entities.WorkspaceInvitation of an email address which are not accepted, revoked or expired at a time
+	FetchPending(ctx context.Context, email string, now time.Time) ([]*entities.WorkspaceInvitation, error)
+}
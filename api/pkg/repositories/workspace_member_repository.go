@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WorkspaceMemberRepository loads and persists an entities.WorkspaceMember
+type WorkspaceMemberRepository interface {
+	// Store a new entities.WorkspaceMember
+	Store(ctx context.Context, member *entities.WorkspaceMember) error
+
+	// Update an entities.WorkspaceMember
+	Update(ctx context.Context, member *entities.WorkspaceMember) error
+
+	// Delete an entities.WorkspaceMember
+	Delete(ctx context.Context, workspaceID uuid.UUID, userID entities.UserID) error
+
+	// Load the entities.WorkspaceMember of a user in a workspace
+	Load(ctx context.Context, workspaceID uuid.UUID, userID entities.UserID) (*entities.WorkspaceMember, error)
+
+	// Fetch all the entities.WorkspaceMember of a workspace ordered by when they joined
+	Fetch(ctx context.Context, workspaceID uuid.UUID) ([]*entities.WorkspaceMember, error)
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WorkspaceRepository loads and persists an entities.Workspace
+type WorkspaceRepository interface {
+	// Store a new entities.Workspace
+	Store(ctx context.Context, workspace *entities.Workspace) error
+
+	// StorePersonal stores a new personal entities.Workspace. stored is false when the owner already has a personal workspace.
+	StorePersonal(ctx context.Context, workspace *entities.Workspace) (stored bool, err error)
+
+	// Update an entities.Workspace
+	Update(ctx context.Context, workspace *entities.Workspace) error
+
+	// Load an entities.Workspace by ID
+	Load(ctx context.Context, workspaceID uuid.UUID) (*entities.Workspace, error)
+
+	// LoadPersonal loads the personal entities.Workspace of a user
+	LoadPersonal(ctx context.Context, userID entities.UserID) (*entities.Workspace, error)
+
+	// FetchForMember fetches every entities.UserWorkspace in which a user is an entities.WorkspaceMember
+	FetchForMember(ctx context.Context, userID entities.UserID) ([]*entities.UserWorkspace, error)
+}
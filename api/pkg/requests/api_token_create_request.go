@@ -0,0 +1,58 @@
+package requests
+
+import (
+	"strings"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+)
+
+// defaultAPITokenExpiresInDays is the number of days before an entities.APIToken expires when the expiry is not set
+const defaultAPITokenExpiresInDays = 90
+
+// APITokenCreateRequest is the payload for creating an entities.APIToken
+type APITokenCreateRequest struct {
+	request
+	Name          string   `json:"name" example:"GitHub Actions"`
+	Scopes        []string `json:"scopes" example:"projects:read,integrations:write"`
+	ExpiresInDays int      `json:"expires_in_days" example:"90"`
+}
+
+// Sanitize the request by stripping whitespaces and setting the default expiry
+func (request *APITokenCreateRequest) Sanitize() *APITokenCreateRequest {
+	request.Name = request.sanitizeString(request.Name)
+
+	scopes := make([]string, 0, len(request.Scopes))
+	seen := map[string]bool{}
+	for _, scope := range request.Scopes {
+		scope = strings.ToLower(request.sanitizeString(scope))
+		if !seen[scope] {
+			scopes = append(scopes, scope)
+			seen[scope] = true
+		}
+	}
+	request.Scopes = scopes
+
+	if request.ExpiresInDays == 0 {
+		request.ExpiresInDays = defaultAPITokenExpiresInDays
+	}
+
+	return request
+}
+
+// ToCreateParams creates services.APITokenCreateParams from APITokenCreateRequest
+func (request *APITokenCreateRequest) ToCreateParams(source string, userID entities.UserID) *services.APITokenCreateParams {
+	scopes := make([]entities.APITokenScope, 0, len(request.Scopes))
+	for _, scope := range request.Scopes {
+		scopes = append(scopes, entities.APITokenScope(scope))
+	}
+
+	return &services.APITokenCreateParams{
+		Source:    source,
+		UserID:    userID,
+		Name:      request.Name,
+		Scopes:    scopes,
+		ExpiresAt: time.Now().UTC().AddDate(0, 0, request.ExpiresInDays),
+	}
+}
@@ -0,0 +1,50 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// ContactFormIntegrationRequest is the payload for creating and updating an entities.IntegrationContactForm
+type ContactFormIntegrationRequest struct {
+	request
+	Name            string `json:"name"`
+	Text            string `json:"text"`
+	NameText        string `json:"name_text"`
+	NameEnabled     bool   `json:"name_enabled"`
+	EmailText       string `json:"email_text"`
+	EmailEnabled    bool   `json:"email_enabled"`
+	PhoneNumberText string `json:"phone_number_text"`
+	PhoneEnabled    bool   `json:"phone_enabled"`
+	MessageText     string `json:"message_text"`
+	MessageEnabled  bool   `json:"message_enabled"`
+	SubmitText      string `json:"submit_text"`
+	SuccessMessage  string `json:"success_message"`
+}
+
+// Sanitize the request by stripping whitespaces
+func (request *ContactFormIntegrationRequest) Sanitize() {
+	request.Name = request.sanitizeString(request.Name)
+	request.Text = request.sanitizeString(request.Text)
+	request.NameText = request.sanitizeString(request.NameText)
+	request.EmailText = request.sanitizeString(request.EmailText)
+	request.PhoneNumberText = request.sanitizeString(request.PhoneNumberText)
+	request.MessageText = request.sanitizeString(request.MessageText)
+	request.SubmitText = request.sanitizeString(request.SubmitText)
+	request.SuccessMessage = request.sanitizeString(request.SuccessMessage)
+}
+
+// Apply copies the request into an entities.IntegrationContactForm
+func (request *ContactFormIntegrationRequest) Apply(integration *entities.IntegrationContactForm) {
+	integration.Name = request.Name
+	integration.Text = request.Text
+	integration.NameText = request.NameText
+	integration.NameEnabled = request.NameEnabled
+	integration.EmailText = request.EmailText
+	integration.EmailEnabled = request.EmailEnabled
+	integration.PhoneNumberText = request.PhoneNumberText
+	integration.PhoneEnabled = request.PhoneEnabled
+	integration.MessageText = request.MessageText
+	integration.MessageEnabled = request.MessageEnabled
+	integration.SubmitText = request.SubmitText
+	integration.SuccessMessage = request.SuccessMessage
+}
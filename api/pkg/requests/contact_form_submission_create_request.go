@@ -0,0 +1,46 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+)
+
+// ContactFormSubmissionCreateRequest is the payload for the /projects/:projectID/contact-form-integrations/:integrationID/submissions endpoint
+type ContactFormSubmissionCreateRequest struct {
+	request
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	PhoneNumber string `json:"phone_number"`
+	Message     string `json:"message"`
+	PageURL     string `json:"page_url"`
+}
+
+// Sanitize the request by stripping whitespaces
+func (request *ContactFormSubmissionCreateRequest) Sanitize() *ContactFormSubmissionCreateRequest {
+	request.Name = request.sanitizeString(request.Name)
+	request.Email = request.sanitizeString(request.Email)
+	request.PhoneNumber = request.sanitizePhoneNumber(request.PhoneNumber)
+	request.Message = request.sanitizeString(request.Message)
+	request.PageURL = request.sanitizeString(request.PageURL)
+	return request
+}
+
+// ToSubmitParams creates services.ContactFormSubmitParams keeping only the fields which are enabled on the form
+func (request *ContactFormSubmissionCreateRequest) ToSubmitParams(source string, integration *entities.IntegrationContactForm) *services.ContactFormSubmitParams {
+	return &services.ContactFormSubmitParams{
+		Name:        request.enabledString(integration.NameEnabled, request.Name),
+		Email:       request.enabledString(integration.EmailEnabled, request.Email),
+		PhoneNumber: request.enabledString(integration.PhoneEnabled, request.PhoneNumber),
+		Message:     request.enabledString(integration.MessageEnabled, request.Message),
+		PageURL:     request.PageURL,
+		Source:      source,
+		Integration: integration,
+	}
+}
+
+func (request *ContactFormSubmissionCreateRequest) enabledString(enabled bool, value string) *string {
+	if !enabled {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,39 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+)
+
+// ContactFormSubmissionIndexRequest is the payload fetching entities.ContactFormSubmission
+type ContactFormSubmissionIndexRequest struct {
+	request
+	ProjectID     string `json:"projectID" swaggerignore:"true"`
+	IntegrationID string `json:"integrationID" swaggerignore:"true"`
+	Skip          string `json:"skip" query:"skip"`
+	Query         string `json:"query" query:"query"`
+	Limit         string `json:"limit" query:"limit"`
+}
+
+// Sanitize sets defaults to ContactFormSubmissionIndexRequest
+func (request *ContactFormSubmissionIndexRequest) Sanitize() *ContactFormSubmissionIndexRequest {
+	if request.Limit == "" {
+		request.Limit = "20"
+	}
+
+	request.Query = request.sanitizeString(request.Query)
+
+	if request.Skip == "" {
+		request.Skip = "0"
+	}
+
+	return request
+}
+
+// ToIndexParams converts ContactFormSubmissionIndexRequest to repositories.IndexParams
+func (request *ContactFormSubmissionIndexRequest) ToIndexParams() repositories.IndexParams {
+	return repositories.IndexParams{
+		Skip:  request.getInt(request.Skip),
+		Query: request.Query,
+		Limit: request.getInt(request.Limit),
+	}
+}
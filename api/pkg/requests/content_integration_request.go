@@ -0,0 +1,30 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// ContentIntegrationRequest is the payload for creating and updating an entities.ContentIntegration
+type ContentIntegrationRequest struct {
+	request
+	Name    string `json:"name"`
+	Text    string `json:"text"`
+	Summary string `json:"summary"`
+	Title   string `json:"title"`
+}
+
+// Sanitize the request by stripping whitespaces
+func (request *ContentIntegrationRequest) Sanitize() {
+	request.Name = request.sanitizeString(request.Name)
+	request.Text = request.sanitizeString(request.Text)
+	request.Summary = request.sanitizeString(request.Summary)
+	request.Title = request.sanitizeString(request.Title)
+}
+
+// Apply copies the request into an entities.ContentIntegration
+func (request *ContentIntegrationRequest) Apply(integration *entities.ContentIntegration) {
+	integration.Name = request.Name
+	integration.Text = request.Text
+	integration.Summary = request.Summary
+	integration.Title = request.Title
+}
@@ -0,0 +1,37 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+)
+
+// EventDeadLetterIndexRequest is the payload for fetching dead-lettered entities.EventListenerExecution
+type EventDeadLetterIndexRequest struct {
+	request
+	Skip  string `json:"skip" query:"skip"`
+	Query string `json:"query" query:"query"`
+	Limit string `json:"limit" query:"limit"`
+}
+
+// Sanitize sets defaults to EventDeadLetterIndexRequest
+func (request *EventDeadLetterIndexRequest) Sanitize() *EventDeadLetterIndexRequest {
+	if request.Limit == "" {
+		request.Limit = "20"
+	}
+
+	request.Query = request.sanitizeString(request.Query)
+
+	if request.Skip == "" {
+		request.Skip = "0"
+	}
+
+	return request
+}
+
+// ToIndexParams converts EventDeadLetterIndexRequest to repositories.IndexParams
+func (request *EventDeadLetterIndexRequest) ToIndexParams() repositories.IndexParams {
+	return repositories.IndexParams{
+		Skip:  request.getInt(request.Skip),
+		Query: request.Query,
+		Limit: request.getInt(request.Limit),
+	}
+}
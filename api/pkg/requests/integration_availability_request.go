@@ -0,0 +1,49 @@
+package requests
+
+import (
+	"strings"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// IntegrationAvailabilityRequest is embedded in the payload of the integrations which can have an entities.AvailabilitySchedule
+type IntegrationAvailabilityRequest struct {
+	Schedule       *entities.AvailabilitySchedule `json:"schedule"`
+	OfflineMessage string                         `json:"offline_message"`
+}
+
+// sanitizeAvailability strips whitespaces and lowercases the days of the schedule
+func (request *IntegrationAvailabilityRequest) sanitizeAvailability() {
+	request.OfflineMessage = strings.TrimSpace(request.OfflineMessage)
+	if request.Schedule == nil {
+		return
+	}
+
+	request.Schedule.WhenOffline = entities.AvailabilityOfflineMode(strings.ToLower(strings.TrimSpace(string(request.Schedule.WhenOffline))))
+	if request.Schedule.WhenOffline == "" {
+		request.Schedule.WhenOffline = entities.AvailabilityOfflineModeShow
+	}
+
+	for _, window := range request.Schedule.Weekly {
+		if window == nil {
+			continue
+		}
+		window.Day = strings.ToLower(strings.TrimSpace(window.Day))
+		window.Start = strings.TrimSpace(window.Start)
+		window.End = strings.TrimSpace(window.End)
+	}
+
+	for _, holiday := range request.Schedule.Holidays {
+		if holiday == nil {
+			continue
+		}
+		holiday.Date = strings.TrimSpace(holiday.Date)
+		holiday.Name = strings.TrimSpace(holiday.Name)
+	}
+}
+
+// applyAvailability copies the request into an entities.IntegrationAvailability
+func (request *IntegrationAvailabilityRequest) applyAvailability(availability *entities.IntegrationAvailability) {
+	availability.Schedule = request.Schedule
+	availability.OfflineMessage = request.OfflineMessage
+}
@@ -0,0 +1,37 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// LinkIntegrationRequest is the payload for creating and updating an entities.LinkIntegration
+type LinkIntegrationRequest struct {
+	request
+	Name    string `json:"name"`
+	Text    string `json:"text"`
+	Icon    string `json:"icon"`
+	Color   string `json:"color"`
+	Website string `json:"website"`
+}
+
+// Sanitize the request by stripping whitespaces
+func (request *LinkIntegrationRequest) Sanitize() {
+	request.Name = request.sanitizeString(request.Name)
+	request.Text = request.sanitizeString(request.Text)
+	request.Icon = request.sanitizeString(request.Icon)
+	request.Website = request.sanitizeString(request.Website)
+
+	request.Color = request.sanitizeString(request.Color)
+	if request.Color == "" {
+		request.Color = "#1E88E5"
+	}
+}
+
+// Apply copies the request into an entities.LinkIntegration
+func (request *LinkIntegrationRequest) Apply(integration *entities.LinkIntegration) {
+	integration.Name = request.Name
+	integration.Text = request.Text
+	integration.Icon = request.Icon
+	integration.Color = request.Color
+	integration.URL = request.Website
+}
@@ -0,0 +1,30 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// PhoneCallIntegrationRequest is the payload for creating and updating an entities.PhoneCallIntegration
+type PhoneCallIntegrationRequest struct {
+	request
+	IntegrationAvailabilityRequest
+	Name        string `json:"name"`
+	Text        string `json:"text"`
+	PhoneNumber string `json:"phone_number"`
+}
+
+// Sanitize the request by stripping whitespaces
+func (request *PhoneCallIntegrationRequest) Sanitize() {
+	request.Name = request.sanitizeString(request.Name)
+	request.Text = request.sanitizeString(request.Text)
+	request.PhoneNumber = request.sanitizePhoneNumber(request.PhoneNumber)
+	request.sanitizeAvailability()
+}
+
+// Apply copies the request into an entities.PhoneCallIntegration
+func (request *PhoneCallIntegrationRequest) Apply(integration *entities.PhoneCallIntegration) {
+	integration.Name = request.Name
+	integration.Text = request.Text
+	integration.PhoneNumber = request.PhoneNumber
+	request.applyAvailability(&integration.IntegrationAvailability)
+}
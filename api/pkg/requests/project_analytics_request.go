@@ -0,0 +1,73 @@
+package requests
+
+import (
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/google/uuid"
+)
+
+// ProjectAnalyticsDateFormat is the format of the dates in ProjectAnalyticsRequest
+const ProjectAnalyticsDateFormat = "2006-01-02"
+
+// ProjectAnalyticsRequest is the payload for fetching entities.ProjectAnalytics
+type ProjectAnalyticsRequest struct {
+	request
+	ProjectID   string `json:"projectID" swaggerignore:"true"`
+	From        string `json:"from" query:"from"`
+	To          string `json:"to" query:"to"`
+	Granularity string `json:"granularity" query:"granularity"`
+	Timezone    string `json:"timezone" query:"timezone"`
+}
+
+// Sanitize sets defaults to ProjectAnalyticsRequest. The default range is the last 30 days including today.
+func (request *ProjectAnalyticsRequest) Sanitize() *ProjectAnalyticsRequest {
+	request.Granularity = request.sanitizeString(request.Granularity)
+	if request.Granularity == "" {
+		request.Granularity = string(entities.AnalyticsGranularityDay)
+	}
+
+	request.Timezone = request.sanitizeString(request.Timezone)
+	if request.Timezone == "" {
+		request.Timezone = "UTC"
+	}
+
+	today := time.Now().In(request.location())
+	request.To = request.sanitizeString(request.To)
+	if request.To == "" {
+		request.To = today.Format(ProjectAnalyticsDateFormat)
+	}
+
+	request.From = request.sanitizeString(request.From)
+	if request.From == "" {
+		request.From = today.AddDate(0, 0, -29).Format(ProjectAnalyticsDateFormat)
+	}
+
+	return request
+}
+
+// ToParams converts ProjectAnalyticsRequest to services.ProjectAnalyticsParams.
+// The range starts at midnight of the From date and ends at midnight after the To date in the timezone.
+func (request *ProjectAnalyticsRequest) ToParams(userID entities.UserID) *services.ProjectAnalyticsParams {
+	location := request.location()
+	from, _ := time.ParseInLocation(ProjectAnalyticsDateFormat, request.From, location)
+	to, _ := time.ParseInLocation(ProjectAnalyticsDateFormat, request.To, location)
+
+	return &services.ProjectAnalyticsParams{
+		UserID:      userID,
+		ProjectID:   uuid.MustParse(request.ProjectID),
+		From:        from,
+		To:          to.AddDate(0, 0, 1),
+		Granularity: entities.AnalyticsGranularity(request.Granularity),
+		Location:    location,
+	}
+}
+
+func (request *ProjectAnalyticsRequest) location() *time.Location {
+	location, err := time.LoadLocation(request.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
@@ -3,28 +3,38 @@ package requests
 import (
 	"github.com/NdoleStudio/superbutton/pkg/entities"
 	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/google/uuid"
 )
 
 // ProjectCreateRequest is the payload for the /projects/create endpoint
 type ProjectCreateRequest struct {
 	request
-	Name    string `json:"name"`
-	Website string `json:"website"`
+	Name        string `json:"name"`
+	Website     string `json:"website"`
+	WorkspaceID string `json:"workspace_id" example:"5a8d3c0e-4b1f-4e3a-9f6d-2c7b8e9a0d1f"`
 }
 
 // Sanitize the request by stripping whitespaces
 func (request *ProjectCreateRequest) Sanitize() *ProjectCreateRequest {
 	request.Name = request.sanitizeString(request.Name)
 	request.Website = request.sanitizeString(request.Website)
+	request.WorkspaceID = request.sanitizeString(request.WorkspaceID)
 	return request
 }
 
-// ToProjectCreateParams creates services.ProjectCreateParams from ProjectCreateRequest
+// ToProjectCreateParams creates services.ProjectCreateParams from ProjectCreateRequest.
+// The project is created in the personal workspace of the user when the WorkspaceID is empty.
 func (request *ProjectCreateRequest) ToProjectCreateParams(source string, userID entities.UserID) *services.ProjectCreateParams {
+	workspaceID := uuid.Nil
+	if request.WorkspaceID != "" {
+		workspaceID = uuid.MustParse(request.WorkspaceID)
+	}
+
 	return &services.ProjectCreateParams{
-		Name:   request.Name,
-		Source: source,
-		URL:    request.baseURL(request.Website),
-		UserID: userID,
+		Name:        request.Name,
+		Source:      source,
+		URL:         request.baseURL(request.Website),
+		UserID:      userID,
+		WorkspaceID: workspaceID,
 	}
 }
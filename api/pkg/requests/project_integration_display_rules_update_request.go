@@ -0,0 +1,89 @@
+package requests
+
+import (
+	"strings"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/google/uuid"
+)
+
+// ProjectIntegrationDisplayRulesUpdateRequest is the payload for updating the entities.DisplayRules of an integration
+type ProjectIntegrationDisplayRulesUpdateRequest struct {
+	request
+	ProjectID     string                      `json:"projectID" swaggerignore:"true"`
+	IntegrationID string                      `json:"integrationID" swaggerignore:"true"`
+	IncludePaths  []*entities.DisplayPathRule `json:"include_paths"`
+	ExcludePaths  []*entities.DisplayPathRule `json:"exclude_paths"`
+	Devices       []string                    `json:"devices" example:"mobile,tablet"`
+	Countries     []string                    `json:"countries" example:"US,CA"`
+}
+
+// Sanitize the request by stripping whitespaces and normalizing the case of the devices and countries
+func (request *ProjectIntegrationDisplayRulesUpdateRequest) Sanitize() *ProjectIntegrationDisplayRulesUpdateRequest {
+	request.IncludePaths = request.sanitizePathRules(request.IncludePaths)
+	request.ExcludePaths = request.sanitizePathRules(request.ExcludePaths)
+
+	devices := make([]string, 0, len(request.Devices))
+	for _, device := range request.Devices {
+		if device = strings.ToLower(request.sanitizeString(device)); device != "" {
+			devices = append(devices, device)
+		}
+	}
+	request.Devices = devices
+
+	countries := make([]string, 0, len(request.Countries))
+	for _, country := range request.Countries {
+		if country = strings.ToUpper(request.sanitizeString(country)); country != "" {
+			countries = append(countries, country)
+		}
+	}
+	request.Countries = countries
+
+	return request
+}
+
+// ToUpdateParams converts ProjectIntegrationDisplayRulesUpdateRequest to services.ProjectIntegrationDisplayRulesUpdateParams.
+// The display rules are removed when the request does not contain any rule.
+func (request *ProjectIntegrationDisplayRulesUpdateRequest) ToUpdateParams(source string, userID entities.UserID) *services.ProjectIntegrationDisplayRulesUpdateParams {
+	rules := &entities.DisplayRules{
+		IncludePaths: request.IncludePaths,
+		ExcludePaths: request.ExcludePaths,
+		Countries:    request.Countries,
+	}
+	for _, device := range request.Devices {
+		rules.Devices = append(rules.Devices, entities.DeviceClass(device))
+	}
+
+	if rules.IsEmpty() {
+		rules = nil
+	}
+
+	return &services.ProjectIntegrationDisplayRulesUpdateParams{
+		Source:        source,
+		UserID:        userID,
+		ProjectID:     uuid.MustParse(request.ProjectID),
+		IntegrationID: uuid.MustParse(request.IntegrationID),
+		DisplayRules:  rules,
+	}
+}
+
+func (request *ProjectIntegrationDisplayRulesUpdateRequest) sanitizePathRules(rules []*entities.DisplayPathRule) []*entities.DisplayPathRule {
+	result := make([]*entities.DisplayPathRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		rule.Pattern = request.sanitizeString(rule.Pattern)
+		rule.Match = entities.DisplayPathMatch(strings.ToLower(request.sanitizeString(string(rule.Match))))
+		if rule.Match == "" {
+			rule.Match = entities.DisplayPathMatchGlob
+		}
+
+		if rule.Pattern != "" {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
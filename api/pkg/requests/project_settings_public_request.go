@@ -0,0 +1,70 @@
+package requests
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// maxPageContextPathLength is the maximum length of the path which is matched against entities.DisplayRules
+const maxPageContextPathLength = 2048
+
+// ProjectSettingsPublicRequest is the page context which is sent by the widget when it loads the public settings
+type ProjectSettingsPublicRequest struct {
+	request
+	Path   string `json:"path" query:"path"`
+	Device string `json:"device" query:"device"`
+}
+
+// ToPageContext converts ProjectSettingsPublicRequest to entities.PageContext.
+// The device is guessed from the User-Agent when the widget does not send it and unknown countries e.g "XX" are ignored.
+func (request *ProjectSettingsPublicRequest) ToPageContext(userAgent string, country string) *entities.PageContext {
+	return &entities.PageContext{
+		Path:    request.path(),
+		Device:  request.device(userAgent),
+		Country: request.country(country),
+	}
+}
+
+// HasDevice checks if the widget sent a valid entities.DeviceClass
+func (request *ProjectSettingsPublicRequest) HasDevice() bool {
+	device := entities.DeviceClass(strings.ToLower(request.sanitizeString(request.Device)))
+	for _, value := range entities.DeviceClasses() {
+		if value == device {
+			return true
+		}
+	}
+	return false
+}
+
+func (request *ProjectSettingsPublicRequest) path() string {
+	path := request.sanitizeString(request.Path)
+	if parsed, err := url.Parse(path); err == nil {
+		path = parsed.Path
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	if len(path) > maxPageContextPathLength {
+		path = path[:maxPageContextPathLength]
+	}
+	return path
+}
+
+func (request *ProjectSettingsPublicRequest) device(userAgent string) entities.DeviceClass {
+	if request.HasDevice() {
+		return entities.DeviceClass(strings.ToLower(request.sanitizeString(request.Device)))
+	}
+	return entities.DeviceClassFromUserAgent(userAgent)
+}
+
+func (request *ProjectSettingsPublicRequest) country(country string) string {
+	country = strings.ToUpper(request.sanitizeString(country))
+	if len(country) != 2 || country == "XX" || country == "T1" {
+		return ""
+	}
+	return country
+}
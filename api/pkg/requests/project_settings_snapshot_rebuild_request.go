@@ -0,0 +1,18 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/services"
+)
+
+// ProjectSettingsSnapshotRebuildRequest is the payload for rebuilding the entities.ProjectSettingsSnapshot of every project
+type ProjectSettingsSnapshotRebuildRequest struct {
+	request
+	DryRun bool `json:"dry_run" query:"dry_run"`
+}
+
+// ToRebuildParams converts ProjectSettingsSnapshotRebuildRequest to services.ProjectSettingsSnapshotRebuildParams
+func (request *ProjectSettingsSnapshotRebuildRequest) ToRebuildParams() *services.ProjectSettingsSnapshotRebuildParams {
+	return &services.ProjectSettingsSnapshotRebuildParams{
+		DryRun: request.DryRun,
+	}
+}
@@ -11,14 +11,16 @@ import (
 // ProjectUpdateRequest is the payload for the /projects/create endpoint
 type ProjectUpdateRequest struct {
 	request
-	ProjectID       string `json:"project_id" swaggerignore:"true"`
-	Source          string `json:"source" swaggerignore:"true"`
-	Name            string `json:"name"`
-	Website         string `json:"website"`
-	Icon            string `json:"icon"`
-	Greeting        string `json:"greeting"`
-	GreetingTimeout uint   `json:"greeting_timeout"`
-	Color           string `json:"color"`
+	ProjectID       string   `json:"project_id" swaggerignore:"true"`
+	Source          string   `json:"source" swaggerignore:"true"`
+	Name            string   `json:"name"`
+	Website         string   `json:"website"`
+	Icon            string   `json:"icon"`
+	Greeting        string   `json:"greeting"`
+	GreetingTimeout uint     `json:"greeting_timeout"`
+	Color           string   `json:"color"`
+	AllowedOrigins  []string `json:"allowed_origins" example:"https://example.com,https://*.example.com"`
+	Timezone        string   `json:"timezone" example:"Europe/Paris"`
 }
 
 // Sanitize the request by stripping whitespaces
@@ -28,6 +30,20 @@ func (request *ProjectUpdateRequest) Sanitize() *ProjectUpdateRequest {
 	request.Icon = request.sanitizeString(request.Icon)
 	request.Greeting = request.sanitizeString(request.Greeting)
 
+	origins := make([]string, 0, len(request.AllowedOrigins))
+	for _, origin := range request.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimRight(request.sanitizeString(origin), "/"))
+		if origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	request.AllowedOrigins = origins
+
+	request.Timezone = request.sanitizeString(request.Timezone)
+	if request.Timezone == "" {
+		request.Timezone = "UTC"
+	}
+
 	request.Color = strings.ToUpper(request.sanitizeString(request.Color))
 	if request.Color == "" {
 		request.Color = "#283593"
@@ -48,5 +64,7 @@ func (request *ProjectUpdateRequest) ToProjectUpdatePrams(source string, userID
 		Source:                 source,
 		GreetingTimeoutSeconds: request.GreetingTimeout,
 		Color:                  request.Color,
+		AllowedOrigins:         request.AllowedOrigins,
+		Timezone:               request.Timezone,
 	}
 }
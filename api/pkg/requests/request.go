@@ -3,6 +3,7 @@ package requests
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -45,3 +46,8 @@ func (request *request) isDigits(value string) bool {
 	}
 	return true
 }
+
+func (request *request) getInt(value string) int {
+	val, _ := strconv.Atoi(value)
+	return val
+}
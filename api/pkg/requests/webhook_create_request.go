@@ -0,0 +1,48 @@
+package requests
+
+import (
+	"strings"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/google/uuid"
+)
+
+// WebhookCreateRequest is the payload for creating an entities.Webhook
+type WebhookCreateRequest struct {
+	request
+	ProjectID  string   `json:"projectID" swaggerignore:"true"`
+	URL        string   `json:"url" example:"https://example.com/webhooks/superbutton"`
+	EventTypes []string `json:"event_types" example:"contact-form.submitted,integration.updated"`
+}
+
+// Sanitize the request by stripping whitespaces and removing duplicate event types
+func (request *WebhookCreateRequest) Sanitize() *WebhookCreateRequest {
+	request.URL = request.sanitizeString(request.URL)
+	request.EventTypes = request.sanitizeEventTypes(request.EventTypes)
+	return request
+}
+
+// ToCreateParams creates services.WebhookCreateParams from WebhookCreateRequest
+func (request *WebhookCreateRequest) ToCreateParams(source string, userID entities.UserID) *services.WebhookCreateParams {
+	return &services.WebhookCreateParams{
+		Source:     source,
+		UserID:     userID,
+		ProjectID:  uuid.MustParse(request.ProjectID),
+		URL:        request.URL,
+		EventTypes: request.EventTypes,
+	}
+}
+
+func (request *request) sanitizeEventTypes(values []string) []string {
+	eventTypes := make([]string, 0, len(values))
+	seen := map[string]bool{}
+	for _, eventType := range values {
+		eventType = strings.ToLower(request.sanitizeString(eventType))
+		if !seen[eventType] {
+			eventTypes = append(eventTypes, eventType)
+			seen[eventType] = true
+		}
+	}
+	return eventTypes
+}
@@ -0,0 +1,39 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+)
+
+// WebhookDeliveryIndexRequest is the payload fetching entities.WebhookDelivery
+type WebhookDeliveryIndexRequest struct {
+	request
+	ProjectID string `json:"projectID" swaggerignore:"true"`
+	WebhookID string `json:"webhookID" swaggerignore:"true"`
+	Skip      string `json:"skip" query:"skip"`
+	Query     string `json:"query" query:"query"`
+	Limit     string `json:"limit" query:"limit"`
+}
+
+// Sanitize sets defaults to WebhookDeliveryIndexRequest
+func (request *WebhookDeliveryIndexRequest) Sanitize() *WebhookDeliveryIndexRequest {
+	if request.Limit == "" {
+		request.Limit = "20"
+	}
+
+	request.Query = request.sanitizeString(request.Query)
+
+	if request.Skip == "" {
+		request.Skip = "0"
+	}
+
+	return request
+}
+
+// ToIndexParams converts WebhookDeliveryIndexRequest to repositories.IndexParams
+func (request *WebhookDeliveryIndexRequest) ToIndexParams() repositories.IndexParams {
+	return repositories.IndexParams{
+		Skip:  request.getInt(request.Skip),
+		Query: request.Query,
+		Limit: request.getInt(request.Limit),
+	}
+}
@@ -0,0 +1,37 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/google/uuid"
+)
+
+// WebhookUpdateRequest is the payload for updating an entities.Webhook
+type WebhookUpdateRequest struct {
+	request
+	ProjectID  string   `json:"projectID" swaggerignore:"true"`
+	WebhookID  string   `json:"webhookID" swaggerignore:"true"`
+	URL        string   `json:"url" example:"https://example.com/webhooks/superbutton"`
+	EventTypes []string `json:"event_types" example:"contact-form.submitted,integration.updated"`
+	Enabled    bool     `json:"enabled" example:"true"`
+}
+
+// Sanitize the request by stripping whitespaces and removing duplicate event types
+func (request *WebhookUpdateRequest) Sanitize() *WebhookUpdateRequest {
+	request.URL = request.sanitizeString(request.URL)
+	request.EventTypes = request.sanitizeEventTypes(request.EventTypes)
+	return request
+}
+
+// ToUpdateParams creates services.WebhookUpdateParams from WebhookUpdateRequest
+func (request *WebhookUpdateRequest) ToUpdateParams(source string, userID entities.UserID) *services.WebhookUpdateParams {
+	return &services.WebhookUpdateParams{
+		Source:     source,
+		UserID:     userID,
+		ProjectID:  uuid.MustParse(request.ProjectID),
+		WebhookID:  uuid.MustParse(request.WebhookID),
+		URL:        request.URL,
+		EventTypes: request.EventTypes,
+		Enabled:    request.Enabled,
+	}
+}
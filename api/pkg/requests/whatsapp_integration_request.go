@@ -0,0 +1,30 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+)
+
+// WhatsappIntegrationRequest is the payload for creating and updating an entities.WhatsappIntegration
+type WhatsappIntegrationRequest struct {
+	request
+	IntegrationAvailabilityRequest
+	Name        string `json:"name"`
+	Text        string `json:"text"`
+	PhoneNumber string `json:"phone_number"`
+}
+
+// Sanitize the request by stripping whitespaces
+func (request *WhatsappIntegrationRequest) Sanitize() {
+	request.Name = request.sanitizeString(request.Name)
+	request.Text = request.sanitizeString(request.Text)
+	request.PhoneNumber = request.sanitizePhoneNumber(request.PhoneNumber)
+	request.sanitizeAvailability()
+}
+
+// Apply copies the request into an entities.WhatsappIntegration
+func (request *WhatsappIntegrationRequest) Apply(integration *entities.WhatsappIntegration) {
+	integration.Name = request.Name
+	integration.Text = request.Text
+	integration.PhoneNumber = request.PhoneNumber
+	request.applyAvailability(&integration.IntegrationAvailability)
+}
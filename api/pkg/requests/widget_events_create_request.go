@@ -0,0 +1,65 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/google/uuid"
+)
+
+// WidgetEventsCreateRequest is the batch of events which the widget sends to the /widget-events endpoint
+type WidgetEventsCreateRequest struct {
+	request
+	Events []*WidgetEventRequest `json:"events"`
+}
+
+// WidgetEventRequest is a single interaction of a website visitor with the widget
+type WidgetEventRequest struct {
+	Key           string `json:"key" example:"pk_5c1f0b1c4e2a4d7b9a3e6f8d2c1b0a9e8f7d6c5b4a3e2d1c"`
+	Type          string `json:"type" example:"integration.clicked"`
+	IntegrationID string `json:"integration_id" example:"8f9c71b8-b84e-4417-8408-a62274f65a08"`
+	PageURL       string `json:"page_url" example:"https://example.com/products/1"`
+}
+
+// Sanitize the request by stripping whitespaces
+func (request *WidgetEventsCreateRequest) Sanitize() *WidgetEventsCreateRequest {
+	for _, event := range request.Events {
+		if event == nil {
+			continue
+		}
+		event.Key = request.sanitizeString(event.Key)
+		event.Type = request.sanitizeString(event.Type)
+		event.IntegrationID = request.sanitizeString(event.IntegrationID)
+		event.PageURL = request.sanitizeString(event.PageURL)
+	}
+	return request
+}
+
+// ToRecordParams converts WidgetEventsCreateRequest to services.WidgetEventsRecordParams
+func (request *WidgetEventsCreateRequest) ToRecordParams(source string, origin string, ipAddress string, userAgent string) *services.WidgetEventsRecordParams {
+	params := &services.WidgetEventsRecordParams{
+		Source:         source,
+		PublishableKey: request.Events[0].Key,
+		Origin:         origin,
+		IPAddress:      ipAddress,
+		UserAgent:      userAgent,
+		Events:         make([]*services.WidgetEventParams, 0, len(request.Events)),
+	}
+
+	for _, event := range request.Events {
+		params.Events = append(params.Events, &services.WidgetEventParams{
+			Type:          entities.WidgetEventType(event.Type),
+			IntegrationID: request.integrationID(event.IntegrationID),
+			PageURL:       event.PageURL,
+		})
+	}
+
+	return params
+}
+
+func (request *WidgetEventsCreateRequest) integrationID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	integrationID := uuid.MustParse(value)
+	return &integrationID
+}
@@ -0,0 +1,27 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+)
+
+// WorkspaceCreateRequest is the payload for the /workspaces create endpoint
+type WorkspaceCreateRequest struct {
+	request
+	Name string `json:"name" example:"Acme Agency"`
+}
+
+// Sanitize the request by stripping whitespaces
+func (request *WorkspaceCreateRequest) Sanitize() *WorkspaceCreateRequest {
+	request.Name = request.sanitizeString(request.Name)
+	return request
+}
+
+// ToCreateParams creates services.WorkspaceCreateParams from WorkspaceCreateRequest
+func (request *WorkspaceCreateRequest) ToCreateParams(source string, userID entities.UserID) *services.WorkspaceCreateParams {
+	return &services.WorkspaceCreateParams{
+		Source: source,
+		UserID: userID,
+		Name:   request.Name,
+	}
+}
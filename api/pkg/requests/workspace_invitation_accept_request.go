@@ -0,0 +1,27 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+)
+
+// WorkspaceInvitationAcceptRequest is the payload for accepting an invitation to a workspace
+type WorkspaceInvitationAcceptRequest struct {
+	request
+	Token string `json:"token" example:"inv_3f2b6c1e9a8d4f7b0c5e2a1d9f8b7c6e5d4a3b2c1f0e9d8c7b6a5f4e3d2c1b0a"`
+}
+
+// Sanitize the request by stripping whitespaces
+func (request *WorkspaceInvitationAcceptRequest) Sanitize() *WorkspaceInvitationAcceptRequest {
+	request.Token = request.sanitizeString(request.Token)
+	return request
+}
+
+// ToAcceptParams creates services.WorkspaceInvitationAcceptParams from WorkspaceInvitationAcceptRequest
+func (request *WorkspaceInvitationAcceptRequest) ToAcceptParams(source string, userID entities.UserID) *services.WorkspaceInvitationAcceptParams {
+	return &services.WorkspaceInvitationAcceptParams{
+		Source: source,
+		UserID: userID,
+		Token:  request.Token,
+	}
+}
@@ -0,0 +1,35 @@
+package requests
+
+import (
+	"strings"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/google/uuid"
+)
+
+// WorkspaceInvitationCreateRequest is the payload for inviting an email address to a workspace
+type WorkspaceInvitationCreateRequest struct {
+	request
+	WorkspaceID string `json:"workspaceID" swaggerignore:"true"`
+	Email       string `json:"email" example:"name@example.com"`
+	Role        string `json:"role" example:"editor"`
+}
+
+// Sanitize the request by stripping whitespaces and normalizing the case of the email and role
+func (request *WorkspaceInvitationCreateRequest) Sanitize() *WorkspaceInvitationCreateRequest {
+	request.Email = strings.ToLower(request.sanitizeString(request.Email))
+	request.Role = strings.ToLower(request.sanitizeString(request.Role))
+	return request
+}
+
+// ToCreateParams creates services.WorkspaceInvitationCreateParams from WorkspaceInvitationCreateRequest
+func (request *WorkspaceInvitationCreateRequest) ToCreateParams(source string, userID entities.UserID) *services.WorkspaceInvitationCreateParams {
+	return &services.WorkspaceInvitationCreateParams{
+		Source:      source,
+		UserID:      userID,
+		WorkspaceID: uuid.MustParse(request.WorkspaceID),
+		Email:       request.Email,
+		Role:        entities.WorkspaceRole(request.Role),
+	}
+}
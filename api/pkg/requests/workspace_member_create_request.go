@@ -0,0 +1,35 @@
+package requests
+
+import (
+	"strings"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/google/uuid"
+)
+
+// WorkspaceMemberCreateRequest is the payload for adding a member to a workspace
+type WorkspaceMemberCreateRequest struct {
+	request
+	WorkspaceID string `json:"workspaceID" swaggerignore:"true"`
+	Email       string `json:"email" example:"name@example.com"`
+	Role        string `json:"role" example:"editor"`
+}
+
+// Sanitize the request by stripping whitespaces and normalizing the case of the email and role
+func (request *WorkspaceMemberCreateRequest) Sanitize() *WorkspaceMemberCreateRequest {
+	request.Email = strings.ToLower(request.sanitizeString(request.Email))
+	request.Role = strings.ToLower(request.sanitizeString(request.Role))
+	return request
+}
+
+// ToAddParams creates services.WorkspaceMemberAddParams from WorkspaceMemberCreateRequest
+func (request *WorkspaceMemberCreateRequest) ToAddParams(source string, userID entities.UserID) *services.WorkspaceMemberAddParams {
+	return &services.WorkspaceMemberAddParams{
+		Source:      source,
+		UserID:      userID,
+		WorkspaceID: uuid.MustParse(request.WorkspaceID),
+		Email:       request.Email,
+		Role:        entities.WorkspaceRole(request.Role),
+	}
+}
@@ -0,0 +1,34 @@
+package requests
+
+import (
+	"strings"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/google/uuid"
+)
+
+// WorkspaceMemberUpdateRequest is the payload for changing the role of a member of a workspace
+type WorkspaceMemberUpdateRequest struct {
+	request
+	WorkspaceID string `json:"workspaceID" swaggerignore:"true"`
+	MemberID    string `json:"userID" swaggerignore:"true"`
+	Role        string `json:"role" example:"viewer"`
+}
+
+// Sanitize the request by stripping whitespaces and normalizing the case of the role
+func (request *WorkspaceMemberUpdateRequest) Sanitize() *WorkspaceMemberUpdateRequest {
+	request.Role = strings.ToLower(request.sanitizeString(request.Role))
+	return request
+}
+
+// ToUpdateParams creates services.WorkspaceMemberUpdateParams from WorkspaceMemberUpdateRequest
+func (request *WorkspaceMemberUpdateRequest) ToUpdateParams(source string, userID entities.UserID) *services.WorkspaceMemberUpdateParams {
+	return &services.WorkspaceMemberUpdateParams{
+		Source:      source,
+		UserID:      userID,
+		WorkspaceID: uuid.MustParse(request.WorkspaceID),
+		MemberID:    entities.UserID(request.MemberID),
+		Role:        entities.WorkspaceRole(request.Role),
+	}
+}
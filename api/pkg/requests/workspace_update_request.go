@@ -0,0 +1,30 @@
+package requests
+
+import (
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/google/uuid"
+)
+
+// WorkspaceUpdateRequest is the payload for the /workspaces/{workspaceID} update endpoint
+type WorkspaceUpdateRequest struct {
+	request
+	WorkspaceID string `json:"workspaceID" swaggerignore:"true"`
+	Name        string `json:"name" example:"Acme Agency"`
+}
+
+// Sanitize the request by stripping whitespaces
+func (request *WorkspaceUpdateRequest) Sanitize() *WorkspaceUpdateRequest {
+	request.Name = request.sanitizeString(request.Name)
+	return request
+}
+
+// ToUpdateParams creates services.WorkspaceUpdateParams from WorkspaceUpdateRequest
+func (request *WorkspaceUpdateRequest) ToUpdateParams(source string, userID entities.UserID) *services.WorkspaceUpdateParams {
+	return &services.WorkspaceUpdateParams{
+		Source:      source,
+		UserID:      userID,
+		WorkspaceID: uuid.MustParse(request.WorkspaceID),
+		Name:        request.Name,
+	}
+}
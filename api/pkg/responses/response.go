@@ -38,6 +38,26 @@ type Unauthorized struct {
 	Data    string `json:"data" example:"Make sure your API key is set in the [X-API-Key] header in the request"`
 }
 
+// PaymentRequired is the response with status code is 402
+type PaymentRequired struct {
+	Status  string `json:"status" example:"error"`
+	Code    string `json:"code" example:"plan_limit_reached"`
+	Message string `json:"message" example:"the [free] plan is limited to [1] projects"`
+}
+
+// Forbidden is the response with status code is 403
+type Forbidden struct {
+	Status  string `json:"status" example:"error"`
+	Code    string `json:"code" example:"plan_feature_unavailable"`
+	Message string `json:"message" example:"the [contact-form] feature is not available on the [free] plan"`
+}
+
+// TooManyRequests is the response with status code is 429
+type TooManyRequests struct {
+	Status  string `json:"status" example:"error"`
+	Message string `json:"message" example:"You have sent too many requests, please try again later."`
+}
+
 // NoContent is the response when status code is 204
 type NoContent struct {
 	Status  string `json:"status" example:"success"`
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/events"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// apiTokenLastUsedInterval limits how often the last used time of an entities.APIToken is written to the database
+const apiTokenLastUsedInterval = time.Minute
+
+// APITokenService is responsible for managing the entities.APIToken of a user
+type APITokenService struct {
+	service
+	logger          telemetry.Logger
+	tracer          telemetry.Tracer
+	transactor      repositories.Transactor
+	repository      repositories.APITokenRepository
+	userRepository  repositories.UserRepository
+	eventDispatcher *EventDispatcher
+}
+
+// NewAPITokenService creates a new APITokenService
+func NewAPITokenService(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	eventDispatcher *EventDispatcher,
+	transactor repositories.Transactor,
+	repository repositories.APITokenRepository,
+	userRepository repositories.UserRepository,
+) (s *APITokenService) {
+	return &APITokenService{
+		logger:          logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:          tracer,
+		eventDispatcher: eventDispatcher,
+		transactor:      transactor,
+		repository:      repository,
+		userRepository:  userRepository,
+	}
+}
+
+// APITokenCreateParams are the parameters for creating an entities.APIToken
+type APITokenCreateParams struct {
+	Source    string
+	UserID    entities.UserID
+	Name      string
+	Scopes    []entities.APITokenScope
+	ExpiresAt time.Time
+}
+
+// Create an entities.APIToken for a user. The token is only returned in the entities.APIToken which is created.
+func (service *APITokenService) Create(ctx context.Context, params *APITokenCreateParams) (*entities.APIToken, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		msg := fmt.Sprintf("cannot generate api token for user [%s]", params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+	secret := entities.APITokenPrefix + hex.EncodeToString(bytes)
+
+	token := &entities.APIToken{
+		ID:        uuid.New(),
+		UserID:    params.UserID,
+		Name:      params.Name,
+		Hint:      secret[:len(entities.APITokenPrefix)+6],
+		TokenHash: service.hashToken(secret),
+		Scopes:    params.Scopes,
+		ExpiresAt: params.ExpiresAt,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	err := service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := service.repository.Store(ctx, token); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store api token for user [%s]", params.UserID))
+		}
+		return service.dispatchAPITokenCreatedEvent(ctx, params.Source, token)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot create api token for user [%s]", params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	token.Token = secret
+	return token, nil
+}
+
+// Index fetches the entities.APIToken of a user which are not revoked
+func (service *APITokenService) Index(ctx context.Context, userID entities.UserID) ([]*entities.APIToken, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	tokens, err := service.repository.Fetch(ctx, userID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch api tokens of user [%s]", userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return tokens, nil
+}
+
+// APITokenRevokeParams are the parameters for revoking an entities.APIToken
+type APITokenRevokeParams struct {
+	Source  string
+	UserID  entities.UserID
+	TokenID uuid.UUID
+}
+
+// Revoke an entities.APIToken so that it can no longer be used
+func (service *APITokenService) Revoke(ctx context.Context, params *APITokenRevokeParams) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	token, err := service.repository.Load(ctx, params.UserID, params.TokenID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load api token [%s] of user [%s]", params.TokenID, params.UserID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if token.RevokedAt != nil {
+		msg := fmt.Sprintf("api token [%s] of user [%s] was already revoked", token.ID, token.UserID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(repositories.ErrCodeNotFound, msg))
+	}
+
+	now := time.Now().UTC()
+	token.RevokedAt = &now
+	token.UpdatedAt = now
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.repository.Update(ctx, token); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot update api token [%s]", token.ID))
+		}
+		return service.dispatchAPITokenRevokedEvent(ctx, params.Source, token)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot revoke api token [%s] of user [%s]", token.ID, token.UserID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// Authenticate resolves an active entities.APIToken to the entities.AuthUser who created it.
+// It returns an error with code repositories.ErrCodeNotFound when the token does not exist, is revoked or has expired.
+func (service *APITokenService) Authenticate(ctx context.Context, secret string) (*entities.AuthUser, error) {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	token, err := service.repository.LoadByTokenHash(ctx, service.hashToken(secret))
+	if err != nil {
+		msg := "cannot load api token"
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	now := time.Now().UTC()
+	if !token.IsActive(now) {
+		msg := fmt.Sprintf("api token [%s] of user [%s] is revoked or expired", token.ID, token.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(repositories.ErrCodeNotFound, msg))
+	}
+
+	user, err := service.userRepository.Load(ctx, token.UserID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user [%s] of api token [%s]", token.UserID, token.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > apiTokenLastUsedInterval {
+		if err = service.repository.UpdateLastUsedAt(ctx, token.ID, now); err != nil {
+			msg := fmt.Sprintf("cannot update last used time of api token [%s]", token.ID)
+			ctxLogger.Error(stacktrace.Propagate(err, msg))
+		}
+	}
+
+	return &entities.AuthUser{
+		ID:         user.ID,
+		Name:       user.Name,
+		Email:      user.Email,
+		APITokenID: &token.ID,
+		Scopes:     token.Scopes,
+	}, nil
+}
+
+func (service *APITokenService) hashToken(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+func (service *APITokenService) dispatchAPITokenCreatedEvent(ctx context.Context, source string, token *entities.APIToken) error {
+	event, err := service.createEvent(events.APITokenCreated, source, &events.APITokenCreatedPayload{
+		UserID:            token.UserID,
+		APITokenID:        token.ID,
+		APITokenName:      token.Name,
+		APITokenScopes:    token.Scopes,
+		APITokenExpiresAt: token.ExpiresAt,
+		APITokenCreatedAt: token.CreatedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for api token [%s]", events.APITokenCreated, token.ID))
+	}
+	return service.dispatchEvent(ctx, token, event)
+}
+
+func (service *APITokenService) dispatchAPITokenRevokedEvent(ctx context.Context, source string, token *entities.APIToken) error {
+	event, err := service.createEvent(events.APITokenRevoked, source, &events.APITokenRevokedPayload{
+		UserID:            token.UserID,
+		APITokenID:        token.ID,
+		APITokenName:      token.Name,
+		APITokenRevokedAt: *token.RevokedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for api token [%s]", events.APITokenRevoked, token.ID))
+	}
+	return service.dispatchEvent(ctx, token, event)
+}
+
+func (service *APITokenService) dispatchEvent(ctx context.Context, token *entities.APIToken, event *cloudevents.Event) error {
+	if err := service.eventDispatcher.Dispatch(ctx, event); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch [%s] event for api token [%s]", event.Type(), token.ID))
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// ErrCodeForbidden is returned when the entities.WorkspaceRole of a user does not have the entities.WorkspacePermission for an action
+const ErrCodeForbidden = stacktrace.ErrorCode(6000)
+
+// AuthorizationService checks the entities.WorkspaceRole of a user before they access an entities.Workspace or its projects
+type AuthorizationService struct {
+	logger            telemetry.Logger
+	tracer            telemetry.Tracer
+	projectRepository repositories.ProjectRepository
+	memberRepository  repositories.WorkspaceMemberRepository
+}
+
+// NewAuthorizationService creates a new AuthorizationService
+func NewAuthorizationService(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	projectRepository repositories.ProjectRepository,
+	memberRepository repositories.WorkspaceMemberRepository,
+) (s *AuthorizationService) {
+	return &AuthorizationService{
+		logger:            logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:            tracer,
+		projectRepository: projectRepository,
+		memberRepository:  memberRepository,
+	}
+}
+
+// AuthorizeProject loads an entities.Project if the user has a permission in the entities.Workspace of the project.
+// It returns an error with code repositories.ErrCodeNotFound if the user is not a member so that the project is not disclosed
+// and an error with code ErrCodeForbidden if the role of the member does not have the permission.
+func (service *AuthorizationService) AuthorizeProject(ctx context.Context, userID entities.UserID, projectID uuid.UUID, permission entities.WorkspacePermission) (*entities.Project, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	project, err := service.projectRepository.Load(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load project [%s] for user [%s]", projectID, userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if _, err = service.AuthorizeWorkspace(ctx, userID, project.WorkspaceID, permission); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot [%s] project [%s]", userID, permission, projectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return project, nil
+}
+
+// AuthorizeWorkspace loads the entities.WorkspaceMember of a user if their role has a permission in the entities.Workspace.
+// It returns an error with code repositories.ErrCodeNotFound if the user is not a member
+// and an error with code ErrCodeForbidden if the role of the member does not have the permission.
+func (service *AuthorizationService) AuthorizeWorkspace(ctx context.Context, userID entities.UserID, workspaceID uuid.UUID, permission entities.WorkspacePermission) (*entities.WorkspaceMember, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	member, err := service.memberRepository.Load(ctx, workspaceID, userID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load membership of user [%s] in workspace [%s]", userID, workspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if !member.Role.Can(permission) {
+		msg := fmt.Sprintf("the [%s] role of user [%s] in workspace [%s] does not have the [%s] permission", member.Role, userID, workspaceID, permission)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeForbidden, msg))
+	}
+
+	return member, nil
+}
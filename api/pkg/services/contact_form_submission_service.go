@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/events"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// ContactFormSubmissionService is responsible for managing entities.ContactFormSubmission
+type ContactFormSubmissionService struct {
+	service
+	tracer               telemetry.Tracer
+	logger               telemetry.Logger
+	eventDispatcher      *EventDispatcher
+	transactor           repositories.Transactor
+	repository           repositories.IntegrationRepository[*entities.IntegrationContactForm]
+	submissionRepository repositories.ContactFormSubmissionRepository
+	authorizationService *AuthorizationService
+	settingsService      *ProjectSettingsService
+}
+
+// NewContactFormSubmissionService creates a new ContactFormSubmissionService
+func NewContactFormSubmissionService(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	eventDispatcher *EventDispatcher,
+	transactor repositories.Transactor,
+	repository repositories.IntegrationRepository[*entities.IntegrationContactForm],
+	submissionRepository repositories.ContactFormSubmissionRepository,
+	authorizationService *AuthorizationService,
+	settingsService *ProjectSettingsService,
+) (s *ContactFormSubmissionService) {
+	return &ContactFormSubmissionService{
+		logger:               logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:               tracer,
+		eventDispatcher:      eventDispatcher,
+		transactor:           transactor,
+		repository:           repository,
+		submissionRepository: submissionRepository,
+		authorizationService: authorizationService,
+		settingsService:      settingsService,
+	}
+}
+
+// GetEnabled returns an enabled entities.IntegrationContactForm for a website visitor.
+// It returns an error with code ErrCodeOriginNotAllowed if the origin is not allowed by the project.
+func (service *ContactFormSubmissionService) GetEnabled(ctx context.Context, projectID uuid.UUID, integrationID uuid.UUID, origin string) (*entities.IntegrationContactForm, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	integration, err := service.repository.Load(ctx, projectID, integrationID)
+	if err != nil {
+		msg := fmt.Sprintf("could not load contact form integration for project with ID [%s] and ID [%s]", projectID, integrationID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if !integration.Enabled {
+		msg := fmt.Sprintf("contact form integration with ID [%s] for project [%s] is disabled", integrationID, projectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(repositories.ErrCodeNotFound, msg))
+	}
+
+	if err = service.settingsService.AuthorizeOrigin(ctx, projectID, origin); err != nil {
+		msg := fmt.Sprintf("cannot submit contact form integration [%s] for project [%s] from origin [%s]", integrationID, projectID, origin)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return integration, nil
+}
+
+// ContactFormSubmitParams are the parameters for submitting a contact form.
+type ContactFormSubmitParams struct {
+	Name        *string
+	Email       *string
+	PhoneNumber *string
+	Message     *string
+	PageURL     string
+	Source      string
+	Integration *entities.IntegrationContactForm
+}
+
+// Submit stores a new entities.ContactFormSubmission sent by a website visitor
+func (service *ContactFormSubmissionService) Submit(ctx context.Context, params *ContactFormSubmitParams) (*entities.ContactFormSubmission, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	submission := &entities.ContactFormSubmission{
+		ID:            uuid.New(),
+		UserID:        params.Integration.UserID,
+		ProjectID:     params.Integration.ProjectID,
+		IntegrationID: params.Integration.ID,
+		Name:          params.Name,
+		Email:         params.Email,
+		PhoneNumber:   params.PhoneNumber,
+		Message:       params.Message,
+		PageURL:       params.PageURL,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	err := service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := service.submissionRepository.Store(ctx, submission); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store submission for contact form integration [%s]", params.Integration.ID))
+		}
+		return service.dispatchContactFormSubmittedEvent(ctx, params.Source, submission)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot submit contact form integration [%s]", params.Integration.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return submission, nil
+}
+
+// IndexSubmissions fetches the entities.ContactFormSubmission of an integration in a project of an authenticated user
+func (service *ContactFormSubmissionService) IndexSubmissions(ctx context.Context, userID entities.UserID, projectID uuid.UUID, integrationID uuid.UUID, params repositories.IndexParams) ([]*entities.ContactFormSubmission, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeProject(ctx, userID, projectID, entities.WorkspacePermissionView); err != nil {
+		msg := fmt.Sprintf("user ID [%s] cannot view project [%s]", userID, projectID)
+		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
+	}
+
+	if _, err := service.repository.Load(ctx, projectID, integrationID); err != nil {
+		msg := fmt.Sprintf("cannot load integrtion [%s] for project [%s]", integrationID, projectID)
+		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
+	}
+
+	submissions, err := service.submissionRepository.Index(ctx, integrationID, params)
+	if err != nil {
+		msg := fmt.Sprintf("could not fetch submissions for contact form integration [%s] and user [%s]", integrationID, userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return submissions, nil
+}
+
+// DeleteAll deletes every entities.ContactFormSubmission of a deleted project and returns the number of deleted submissions
+func (service *ContactFormSubmissionService) DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	count, err := service.submissionRepository.DeleteAll(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete contact form submissions of project [%s]", projectID)
+		return count, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return count, nil
+}
+
+func (service *ContactFormSubmissionService) dispatchContactFormSubmittedEvent(ctx context.Context, source string, submission *entities.ContactFormSubmission) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	event, err := service.createEvent(events.ContactFormSubmitted, source, &events.ContactFormSubmittedPayload{
+		UserID:        submission.UserID,
+		ProjectID:     submission.ProjectID,
+		IntegrationID: submission.IntegrationID,
+		SubmissionID:  submission.ID,
+		Name:          submission.Name,
+		Email:         submission.Email,
+		PhoneNumber:   submission.PhoneNumber,
+		Message:       submission.Message,
+		PageURL:       submission.PageURL,
+		SubmittedAt:   submission.CreatedAt,
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot create [%s] event for submission [%s]", events.ContactFormSubmitted, submission.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err = service.eventDispatcher.Dispatch(ctx, event); err != nil {
+		msg := fmt.Sprintf("cannot dispatch [%s] event for submission [%s]", event.Type(), submission.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
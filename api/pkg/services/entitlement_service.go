@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/palantir/stacktrace"
+)
+
+const (
+	// ErrCodePlanLimitReached is returned when an action exceeds a quota of the user's entities.Plan
+	ErrCodePlanLimitReached = stacktrace.ErrorCode(2000)
+
+	// ErrCodePlanFeatureUnavailable is returned when an entities.PlanFeature is not available on the user's entities.Plan
+	ErrCodePlanFeatureUnavailable = stacktrace.ErrorCode(2001)
+)
+
+// EntitlementService checks the quotas and features of the entities.Plan of a user.
+// The entities.Plan of the owner of an entities.Workspace applies to every project in the workspace.
+type EntitlementService struct {
+	logger                       telemetry.Logger
+	tracer                       telemetry.Tracer
+	userService                  *UserService
+	userRepository               repositories.UserRepository
+	workspaceRepository          repositories.WorkspaceRepository
+	projectRepository            repositories.ProjectRepository
+	projectIntegrationRepository repositories.ProjectIntegrationRepository
+}
+
+// NewEntitlementService creates a new EntitlementService
+func NewEntitlementService(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	userService *UserService,
+	userRepository repositories.UserRepository,
+	workspaceRepository repositories.WorkspaceRepository,
+	projectRepository repositories.ProjectRepository,
+	projectIntegrationRepository repositories.ProjectIntegrationRepository,
+) (s *EntitlementService) {
+	return &EntitlementService{
+		logger:                       logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:                       tracer,
+		userService:                  userService,
+		userRepository:               userRepository,
+		workspaceRepository:          workspaceRepository,
+		projectRepository:            projectRepository,
+		projectIntegrationRepository: projectIntegrationRepository,
+	}
+}
+
+// Plan returns the current entities.Plan of a user
+func (service *EntitlementService) Plan(ctx context.Context, source string, userID entities.UserID) (*entities.Plan, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	user, err := service.userService.Load(ctx, source, userID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user with ID [%s]", userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return user.Plan(), nil
+}
+
+// CanCreateProject returns an ErrCodePlanLimitReached error if another entities.Project cannot be created in a workspace.
+// The projects in every workspace of the owner count towards the limit of their plan.
+// It must be called in the transaction which stores the project because the owner is locked until the transaction ends
+// so that concurrent requests cannot create more projects than the limit.
+func (service *EntitlementService) CanCreateProject(ctx context.Context, workspace *entities.Workspace) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	owner, err := service.userRepository.LoadForUpdate(ctx, workspace.OwnerID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot lock owner with ID [%s] of workspace [%s]", workspace.OwnerID, workspace.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+	plan := owner.Plan()
+
+	count, err := service.projectRepository.CountByWorkspaceOwner(ctx, workspace.OwnerID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot count projects of user with ID [%s]", workspace.OwnerID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if count >= int64(plan.MaxProjects) {
+		msg := fmt.Sprintf("the [%s] plan is limited to [%d] projects", plan.Name, plan.MaxProjects)
+		return service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodePlanLimitReached, msg))
+	}
+
+	return nil
+}
+
+// CanCreateIntegration returns an ErrCodePlanLimitReached or ErrCodePlanFeatureUnavailable error if another integration cannot be added to a project.
+// It must be called in the transaction which stores the integration because the project is locked until the transaction ends
+// so that concurrent requests cannot add more integrations than the limit.
+func (service *EntitlementService) CanCreateIntegration(ctx context.Context, source string, project *entities.Project, feature entities.PlanFeature) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.projectRepository.LoadForUpdate(ctx, project.ID); err != nil {
+		msg := fmt.Sprintf("cannot lock project [%s]", project.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	workspace, err := service.workspaceRepository.Load(ctx, project.WorkspaceID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load workspace [%s] of project [%s]", project.WorkspaceID, project.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	plan, err := service.Plan(ctx, source, workspace.OwnerID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load plan for user with ID [%s]", workspace.OwnerID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if feature != "" && !plan.HasFeature(feature) {
+		msg := fmt.Sprintf("the [%s] feature is not available on the [%s] plan", feature, plan.Name)
+		return service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodePlanFeatureUnavailable, msg))
+	}
+
+	integrations, err := service.projectIntegrationRepository.Fetch(ctx, project.ID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch integrations for project [%s]", project.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if len(integrations) >= plan.MaxIntegrationsPerProject {
+		msg := fmt.Sprintf("the [%s] plan is limited to [%d] integrations per project", plan.Name, plan.MaxIntegrationsPerProject)
+		return service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodePlanLimitReached, msg))
+	}
+
+	return nil
+}
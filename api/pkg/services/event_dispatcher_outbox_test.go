@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/queue"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+)
+
+type testOutboxRepository struct {
+	events map[uuid.UUID]*entities.OutboxEvent
+}
+
+func (repository *testOutboxRepository) Store(_ context.Context, event *entities.OutboxEvent) error {
+	if _, ok := repository.events[event.ID]; !ok {
+		repository.events[event.ID] = event
+	}
+	return nil
+}
+
+func (repository *testOutboxRepository) LockDue(_ context.Context, limit int) ([]*entities.OutboxEvent, error) {
+	var result []*entities.OutboxEvent
+	for _, event := range repository.events {
+		if len(result) < limit && !event.AvailableAt.After(time.Now().UTC()) {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (repository *testOutboxRepository) Update(_ context.Context, event *entities.OutboxEvent) error {
+	repository.events[event.ID] = event
+	return nil
+}
+
+func (repository *testOutboxRepository) Delete(_ context.Context, event *entities.OutboxEvent) error {
+	delete(repository.events, event.ID)
+	return nil
+}
+
+type testQueueClient struct {
+	err   error
+	tasks []*queue.Task
+}
+
+func (client *testQueueClient) Enqueue(_ context.Context, task *queue.Task) (string, error) {
+	if client.err != nil {
+		return "", client.err
+	}
+	client.tasks = append(client.tasks, task)
+	return uuid.NewString(), nil
+}
+
+func newTestOutboxDispatcher(client *testQueueClient) (*EventDispatcher, *testEventRepository, *testOutboxRepository) {
+	logger, tracer := newTestTelemetry()
+	events := &testEventRepository{events: map[string]*cloudevents.Event{}}
+	outbox := &testOutboxRepository{events: map[uuid.UUID]*entities.OutboxEvent{}}
+	return NewEventDispatcher(logger, tracer, &testTransactor{}, events, outbox, newTestExecutionRepository(), client, "/v1/events/consume"), events, outbox
+}
+
+func TestEventDispatcher_DispatchStoresEventInOutbox(t *testing.T) {
+	client := &testQueueClient{}
+	dispatcher, events, outbox := newTestOutboxDispatcher(client)
+
+	event := newTestEvent(t)
+	if err := dispatcher.Dispatch(context.Background(), &event); err != nil {
+		t.Fatalf("cannot dispatch event: %v", err)
+	}
+
+	if _, err := events.Load(context.Background(), event.ID()); err != nil {
+		t.Errorf("the event was not saved: %v", err)
+	}
+
+	outboxEvent, ok := outbox.events[uuid.MustParse(event.ID())]
+	if !ok {
+		t.Fatal("the event was not stored in the outbox")
+	}
+	if outboxEvent.Type != event.Type() || outboxEvent.ScheduleTime != nil {
+		t.Errorf("got outbox event with type [%s] and schedule [%v], want type [%s] without a schedule", outboxEvent.Type, outboxEvent.ScheduleTime, event.Type())
+	}
+
+	if len(client.tasks) != 0 {
+		t.Errorf("got [%d] tasks before the outbox was relayed, want [0]", len(client.tasks))
+	}
+}
+
+func TestEventDispatcher_DispatchRejectsInvalidEvent(t *testing.T) {
+	dispatcher, _, outbox := newTestOutboxDispatcher(&testQueueClient{})
+
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.NewString())
+	if err := dispatcher.Dispatch(context.Background(), &event); err == nil {
+		t.Error("dispatching an event without a type and source did not fail")
+	}
+
+	if len(outbox.events) != 0 {
+		t.Errorf("got [%d] outbox events for an invalid event, want [0]", len(outbox.events))
+	}
+}
+
+func TestEventDispatcher_RelayOutboxEnqueuesDueEvents(t *testing.T) {
+	client := &testQueueClient{}
+	dispatcher, _, outbox := newTestOutboxDispatcher(client)
+
+	event := newTestEvent(t)
+	scheduleTime := time.Now().UTC().Add(time.Hour)
+	if err := dispatcher.DispatchAt(context.Background(), &event, scheduleTime); err != nil {
+		t.Fatalf("cannot dispatch event: %v", err)
+	}
+
+	relayed, err := dispatcher.RelayOutbox(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("cannot relay the outbox: %v", err)
+	}
+
+	if relayed != 1 || len(client.tasks) != 1 || len(outbox.events) != 0 {
+		t.Fatalf("got [%d] relayed events, [%d] tasks and [%d] outbox events, want [1], [1] and [0]", relayed, len(client.tasks), len(outbox.events))
+	}
+
+	task := client.tasks[0]
+	if task.Method != http.MethodPost || task.URL != "/v1/events/consume" || task.DeduplicationKey != event.ID() {
+		t.Errorf("got task [%s %s] with key [%s], want [POST /v1/events/consume] with key [%s]", task.Method, task.URL, task.DeduplicationKey, event.ID())
+	}
+	if task.ScheduleTime == nil || !task.ScheduleTime.Equal(scheduleTime) {
+		t.Errorf("got schedule [%v], want [%s]", task.ScheduleTime, scheduleTime)
+	}
+	if task.RetryPolicy == nil || task.RetryPolicy.MaxAttempts != eventListenerMaxDeliveries {
+		t.Errorf("got retry policy [%+v], want [%d] attempts", task.RetryPolicy, eventListenerMaxDeliveries)
+	}
+
+	decoded := cloudevents.NewEvent()
+	if err = json.Unmarshal(task.Body, &decoded); err != nil || decoded.ID() != event.ID() {
+		t.Errorf("got body [%s] with error [%v], want the event with ID [%s]", task.Body, err, event.ID())
+	}
+}
+
+func TestEventDispatcher_RelayOutboxBacksOffFailedEvents(t *testing.T) {
+	client := &testQueueClient{err: errors.New("queue is unavailable")}
+	dispatcher, _, outbox := newTestOutboxDispatcher(client)
+
+	event := newTestEvent(t)
+	if err := dispatcher.Dispatch(context.Background(), &event); err != nil {
+		t.Fatalf("cannot dispatch event: %v", err)
+	}
+
+	relayed, err := dispatcher.RelayOutbox(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("cannot relay the outbox: %v", err)
+	}
+	if relayed != 0 {
+		t.Errorf("got [%d] relayed events, want [0]", relayed)
+	}
+
+	outboxEvent := outbox.events[uuid.MustParse(event.ID())]
+	if outboxEvent == nil || outboxEvent.Attempts != 1 || outboxEvent.LastError == nil {
+		t.Fatalf("got outbox event [%+v], want an event with [1] attempt and the error", outboxEvent)
+	}
+	if wait := time.Until(outboxEvent.AvailableAt); wait < outboxRelayMinBackoff-time.Second || wait > outboxRelayMinBackoff {
+		t.Errorf("the event is available in [%s], want [%s]", wait, outboxRelayMinBackoff)
+	}
+
+	client.err = nil
+	if relayed, _ = dispatcher.RelayOutbox(context.Background(), 10); relayed != 0 {
+		t.Errorf("got [%d] relayed events before the backoff ended, want [0]", relayed)
+	}
+}
+
+func TestEventDispatcher_OutboxEventFailedCapsBackoff(t *testing.T) {
+	dispatcher, _, _ := newTestOutboxDispatcher(&testQueueClient{})
+
+	outboxEvent := dispatcher.outboxEventFailed(&entities.OutboxEvent{Attempts: 50}, errors.New("queue is unavailable"))
+
+	if wait := time.Until(outboxEvent.AvailableAt); wait > outboxRelayMaxBackoff || wait < outboxRelayMaxBackoff-time.Second {
+		t.Errorf("the event is available in [%s], want [%s]", wait, outboxRelayMaxBackoff)
+	}
+	if outboxEvent.Attempts != 51 {
+		t.Errorf("got [%d] attempts, want [51]", outboxEvent.Attempts)
+	}
+}
@@ -5,89 +5,231 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/NdoleStudio/superbutton/pkg/entities"
 	"github.com/NdoleStudio/superbutton/pkg/queue"
 	"github.com/NdoleStudio/superbutton/pkg/repositories"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
 	"github.com/palantir/stacktrace"
 )
 
 // EventListener is the type for processing events
 type EventListener func(ctx context.Context, event cloudevents.Event) error
 
+const (
+	// ErrCodeEventNotDeadLettered is thrown when replaying a listener execution which is not dead-lettered
+	ErrCodeEventNotDeadLettered = stacktrace.ErrorCode(3000)
+)
+
+const (
+	eventListenerTimeout       = 30 * time.Second
+	eventListenerRetries       = 3
+	eventListenerRetryBackoff  = 500 * time.Millisecond
+	eventListenerMaxDeliveries = 5
+	eventListenerMinBackoff    = 5 * time.Second
+	eventListenerMaxBackoff    = time.Hour
+
+	// eventListenerLease is how long a delivery holds a claim on a listener execution.
+	// It is longer than the listener retries so a live claim is never taken over by a redelivery.
+	eventListenerLease = eventListenerRetries*eventListenerTimeout + time.Minute
+
+	outboxRelayBatchSize  = 100
+	outboxRelayMinBackoff = 5 * time.Second
+	outboxRelayMaxBackoff = 10 * time.Minute
+)
+
+type namedEventListener struct {
+	name     string
+	listener EventListener
+}
+
 // EventDispatcher dispatches a new event
 type EventDispatcher struct {
-	logger      telemetry.Logger
-	tracer      telemetry.Tracer
-	queue       queue.Client
-	consumerURL string
-	listeners   map[string][]EventListener
-	repository  repositories.EventRepository
+	logger              telemetry.Logger
+	tracer              telemetry.Tracer
+	queue               queue.Client
+	consumerURL         string
+	listeners           map[string][]namedEventListener
+	transactor          repositories.Transactor
+	repository          repositories.EventRepository
+	outboxRepository    repositories.EventOutboxRepository
+	executionRepository repositories.EventListenerExecutionRepository
 }
 
 // NewEventDispatcher creates a new EventDispatcher
 func NewEventDispatcher(
 	logger telemetry.Logger,
 	tracer telemetry.Tracer,
+	transactor repositories.Transactor,
 	repository repositories.EventRepository,
+	outboxRepository repositories.EventOutboxRepository,
+	executionRepository repositories.EventListenerExecutionRepository,
 	queue queue.Client,
 	consumerURL string,
 ) (dispatcher *EventDispatcher) {
 	return &EventDispatcher{
-		logger:      logger,
-		listeners:   make(map[string][]EventListener),
-		tracer:      tracer,
-		queue:       queue,
-		consumerURL: consumerURL,
-		repository:  repository,
+		logger:              logger,
+		listeners:           make(map[string][]namedEventListener),
+		tracer:              tracer,
+		queue:               queue,
+		consumerURL:         consumerURL,
+		transactor:          transactor,
+		repository:          repository,
+		outboxRepository:    outboxRepository,
+		executionRepository: executionRepository,
 	}
 }
 
-// Dispatch a new event by adding it to the queue to be processed async
+// Dispatch a new event by storing it in the outbox. When the context has a transaction, the event is only
+// relayed to the queue if the transaction is committed.
 func (dispatcher *EventDispatcher) Dispatch(ctx context.Context, event *cloudevents.Event) error {
+	return dispatcher.dispatch(ctx, event, nil)
+}
+
+// DispatchAt stores a new event in the outbox so that it is processed at the schedule time
+func (dispatcher *EventDispatcher) DispatchAt(ctx context.Context, event *cloudevents.Event, scheduleTime time.Time) error {
+	return dispatcher.dispatch(ctx, event, &scheduleTime)
+}
+
+func (dispatcher *EventDispatcher) dispatch(ctx context.Context, event *cloudevents.Event, scheduleTime *time.Time) error {
 	ctx, span := dispatcher.tracer.Start(ctx)
 	defer span.End()
 
-	ctxLogger := dispatcher.tracer.CtxLogger(dispatcher.logger, span)
-
 	if err := event.Validate(); err != nil {
 		msg := fmt.Sprintf("cannot dispatch event with ID [%s] and type [%s] because it is invalid", event.ID(), event.Type())
 		return dispatcher.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	if err := dispatcher.repository.Save(ctx, event); err != nil {
-		ctxLogger.Error(stacktrace.Propagate(err, fmt.Sprintf("cannot store [%s] event with id [%s]", event.Type(), event.ID())))
-	}
-
-	task, err := dispatcher.createTask(event)
+	data, err := json.Marshal(event)
 	if err != nil {
-		msg := fmt.Sprintf("cannot create push queue task for event with ID [%s] and type [%s]", event.ID(), event.Type())
+		msg := fmt.Sprintf("cannot marshall [%T] with ID [%s]", event, event.ID())
 		return dispatcher.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	taskID, err := dispatcher.queue.Enqueue(ctx, task)
+	outboxEvent := &entities.OutboxEvent{
+		ID:           uuid.MustParse(event.ID()),
+		Type:         event.Type(),
+		Data:         data,
+		ScheduleTime: scheduleTime,
+		AvailableAt:  time.Now().UTC(),
+		CreatedAt:    time.Now().UTC(),
+		UpdatedAt:    time.Now().UTC(),
+	}
+
+	err = dispatcher.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = dispatcher.repository.Save(ctx, event); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store [%s] event with id [%s]", event.Type(), event.ID()))
+		}
+		return dispatcher.outboxRepository.Store(ctx, outboxEvent)
+	})
 	if err != nil {
-		msg := fmt.Sprintf("cannot add event with ID [%s] and type [%s] to producer", event.ID(), event.Type())
+		msg := fmt.Sprintf("cannot add event with ID [%s] and type [%s] to the outbox", event.ID(), event.Type())
 		return dispatcher.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	ctxLogger.Info(fmt.Sprintf("push queue task enqueued with ID [%s]", taskID))
 	return nil
 }
 
+// StartOutboxRelay relays events from the outbox to the queue in the background until the context is done
+func (dispatcher *EventDispatcher) StartOutboxRelay(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			relayed, err := dispatcher.RelayOutbox(ctx, outboxRelayBatchSize)
+			if err != nil {
+				dispatcher.logger.Error(stacktrace.Propagate(err, "cannot relay events from the outbox"))
+			}
+
+			if relayed == outboxRelayBatchSize {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+}
+
+// RelayOutbox adds the events in the outbox which are due to the queue and returns the number of relayed events.
+// Events which cannot be added to the queue are retried with an exponential backoff.
+func (dispatcher *EventDispatcher) RelayOutbox(ctx context.Context, limit int) (relayed int, err error) {
+	ctx, span, ctxLogger := dispatcher.tracer.StartWithLogger(ctx, dispatcher.logger)
+	defer span.End()
+
+	err = dispatcher.transactor.Transaction(ctx, func(ctx context.Context) error {
+		relayed = 0
+		outboxEvents, err := dispatcher.outboxRepository.LockDue(ctx, limit)
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot lock [%d] outbox events", limit))
+		}
+
+		for _, outboxEvent := range outboxEvents {
+			taskID, err := dispatcher.queue.Enqueue(ctx, dispatcher.createTask(outboxEvent))
+			if err != nil {
+				ctxLogger.Warn(stacktrace.Propagate(err, fmt.Sprintf("cannot add event with ID [%s] and type [%s] to the queue", outboxEvent.ID, outboxEvent.Type)))
+				if err = dispatcher.outboxRepository.Update(ctx, dispatcher.outboxEventFailed(outboxEvent, err)); err != nil {
+					return stacktrace.Propagate(err, fmt.Sprintf("cannot update outbox event with ID [%s]", outboxEvent.ID))
+				}
+				continue
+			}
+
+			if err = dispatcher.outboxRepository.Delete(ctx, outboxEvent); err != nil {
+				return stacktrace.Propagate(err, fmt.Sprintf("cannot delete outbox event with ID [%s]", outboxEvent.ID))
+			}
+
+			relayed++
+			ctxLogger.Info(fmt.Sprintf("push queue task enqueued with ID [%s] for event [%s] with schedule [%v]", taskID, outboxEvent.ID, outboxEvent.ScheduleTime))
+		}
+		return nil
+	})
+	if err != nil {
+		return relayed, dispatcher.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, "cannot relay events from the outbox"))
+	}
+
+	return relayed, nil
+}
+
+func (dispatcher *EventDispatcher) outboxEventFailed(outboxEvent *entities.OutboxEvent, err error) *entities.OutboxEvent {
+	backoff := outboxRelayMinBackoff
+	for i := uint(0); i < outboxEvent.Attempts && backoff < outboxRelayMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > outboxRelayMaxBackoff {
+		backoff = outboxRelayMaxBackoff
+	}
+
+	message := err.Error()
+	outboxEvent.Attempts++
+	outboxEvent.LastError = &message
+	outboxEvent.AvailableAt = time.Now().UTC().Add(backoff)
+	outboxEvent.UpdatedAt = time.Now().UTC()
+	return outboxEvent
+}
+
 // Subscribe a listener to an event
 func (dispatcher *EventDispatcher) Subscribe(eventType string, listener EventListener) {
 	if _, ok := dispatcher.listeners[eventType]; !ok {
-		dispatcher.listeners[eventType] = []EventListener{}
+		dispatcher.listeners[eventType] = []namedEventListener{}
 	}
 
-	dispatcher.listeners[eventType] = append(dispatcher.listeners[eventType], listener)
+	dispatcher.listeners[eventType] = append(dispatcher.listeners[eventType], namedEventListener{
+		name:     dispatcher.listenerName(listener),
+		listener: listener,
+	})
 }
 
-// Publish an event to subscribers
-func (dispatcher *EventDispatcher) Publish(ctx context.Context, event cloudevents.Event) {
+// Publish an event to subscribers. Each subscriber handles an event at most once and an error is returned when
+// a subscriber fails so that the event is redelivered.
+func (dispatcher *EventDispatcher) Publish(ctx context.Context, event cloudevents.Event) error {
 	ctx, span := dispatcher.tracer.Start(ctx)
 	defer span.End()
 
@@ -96,33 +238,245 @@ func (dispatcher *EventDispatcher) Publish(ctx context.Context, event cloudevent
 	subscribers, ok := dispatcher.listeners[event.Type()]
 	if !ok {
 		ctxLogger.Info(fmt.Sprintf("no listener is configured for event type [%s]", event.Type()))
-		return
+		return nil
 	}
 
 	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var failed []string
 	for _, sub := range subscribers {
 		wg.Add(1)
-		go func(ctx context.Context, sub EventListener) {
-			if err := sub(ctx, event); err != nil {
-				msg := fmt.Sprintf("subscriber [%T] cannot handle event [%s]", sub, event.Type())
+		go func(ctx context.Context, sub namedEventListener) {
+			defer wg.Done()
+			if err := dispatcher.consume(ctx, event, sub); err != nil {
+				msg := fmt.Sprintf("subscriber [%s] cannot handle event [%s] with ID [%s]", sub.name, event.Type(), event.ID())
 				ctxLogger.Error(stacktrace.Propagate(err, msg))
+
+				mutex.Lock()
+				failed = append(failed, sub.name)
+				mutex.Unlock()
 			}
-			wg.Done()
 		}(ctx, sub)
 	}
 
 	wg.Wait()
+
+	if len(failed) > 0 {
+		msg := fmt.Sprintf("subscribers [%s] cannot handle event [%s] with ID [%s]", strings.Join(failed, ", "), event.Type(), event.ID())
+		return dispatcher.tracer.WrapErrorSpan(span, stacktrace.NewError(msg))
+	}
+
+	return nil
 }
 
-func (dispatcher *EventDispatcher) createTask(event *cloudevents.Event) (*queue.Task, error) {
-	eventContent, err := json.Marshal(event)
+// DeadLetters returns the listener executions which are dead-lettered
+func (dispatcher *EventDispatcher) DeadLetters(ctx context.Context, params repositories.IndexParams) ([]*entities.EventListenerExecution, error) {
+	ctx, span := dispatcher.tracer.Start(ctx)
+	defer span.End()
+
+	executions, err := dispatcher.executionRepository.FetchByStatus(ctx, entities.EventListenerExecutionStatusDeadLettered, params)
 	if err != nil {
-		return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot marshall [%T] with ID [%s]", event, event.ID()))
+		msg := fmt.Sprintf("cannot fetch dead-lettered listener executions with params [%+#v]", params)
+		return nil, dispatcher.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
+	return executions, nil
+}
+
+// Replay claims a dead-lettered execution and runs its listener again
+func (dispatcher *EventDispatcher) Replay(ctx context.Context, executionID uuid.UUID) (*entities.EventListenerExecution, error) {
+	ctx, span, ctxLogger := dispatcher.tracer.StartWithLogger(ctx, dispatcher.logger)
+	defer span.End()
+
+	execution, err := dispatcher.executionRepository.Load(ctx, executionID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load listener execution with ID [%s]", executionID)
+		return nil, dispatcher.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if execution.Status != entities.EventListenerExecutionStatusDeadLettered {
+		msg := fmt.Sprintf("cannot replay listener execution [%s] with status [%s]", execution.ID, execution.Status)
+		return nil, dispatcher.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeEventNotDeadLettered, msg))
+	}
+
+	event, err := dispatcher.repository.Load(ctx, execution.EventID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load event [%s] for listener execution [%s]", execution.EventID, execution.ID)
+		return nil, dispatcher.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	sub, ok := dispatcher.subscriber(event.Type(), execution.Listener)
+	if !ok {
+		msg := fmt.Sprintf("listener [%s] is not subscribed to event [%s]", execution.Listener, event.Type())
+		return nil, dispatcher.tracer.WrapErrorSpan(span, stacktrace.NewError(msg))
+	}
+
+	execution, claimed, err := dispatcher.executionRepository.ClaimDeadLettered(ctx, execution.ID, eventListenerLease)
+	if err != nil {
+		msg := fmt.Sprintf("cannot claim listener execution with ID [%s] for a replay", executionID)
+		return nil, dispatcher.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if !claimed {
+		msg := fmt.Sprintf("cannot replay listener execution [%s] with status [%s]", execution.ID, execution.Status)
+		return nil, dispatcher.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeEventNotDeadLettered, msg))
+	}
+
+	// the queue does not deliver a dead-lettered event again so a failed replay stays dead-lettered
+	if err = dispatcher.execute(ctx, *event, sub, execution, true); err != nil {
+		msg := fmt.Sprintf("replay of event [%s] with ID [%s] failed for listener [%s]", event.Type(), event.ID(), sub.name)
+		ctxLogger.Warn(stacktrace.Propagate(err, msg))
+	}
+
+	return execution, nil
+}
+
+func (dispatcher *EventDispatcher) subscriber(eventType string, name string) (namedEventListener, bool) {
+	for _, sub := range dispatcher.listeners[eventType] {
+		if sub.name == name {
+			return sub, true
+		}
+	}
+	return namedEventListener{}, false
+}
+
+func (dispatcher *EventDispatcher) consume(ctx context.Context, event cloudevents.Event, sub namedEventListener) error {
+	ctx, span := dispatcher.tracer.Start(ctx)
+	defer span.End()
+
+	ctxLogger := dispatcher.tracer.CtxLogger(dispatcher.logger, span)
+
+	execution, claimed, err := dispatcher.executionRepository.Claim(ctx, event.ID(), event.Type(), sub.name, eventListenerLease)
+	if err != nil {
+		msg := fmt.Sprintf("cannot claim event [%s] with ID [%s] for listener [%s]", event.Type(), event.ID(), sub.name)
+		return dispatcher.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if !claimed && execution.Status == entities.EventListenerExecutionStatusProcessing {
+		msg := fmt.Sprintf("listener [%s] is processing event [%s] with ID [%s] until [%s]", sub.name, event.Type(), event.ID(), execution.ClaimedUntil)
+		return dispatcher.tracer.WrapErrorSpan(span, stacktrace.NewError(msg))
+	}
+
+	if !claimed {
+		ctxLogger.Info(fmt.Sprintf("skipping event [%s] with ID [%s] because listener [%s] has status [%s]", event.Type(), event.ID(), sub.name, execution.Status))
+		return nil
+	}
+
+	// this is the last delivery when the queue will not deliver the event again e.g. when the queue allows fewer attempts
+	err = dispatcher.execute(ctx, event, sub, execution, execution.Attempts >= eventListenerMaxDeliveries || queue.IsLastAttempt(ctx))
+	if err != nil && execution.Status == entities.EventListenerExecutionStatusDeadLettered {
+		msg := fmt.Sprintf("event [%s] with ID [%s] is dead-lettered for listener [%s] after [%d] deliveries", event.Type(), event.ID(), sub.name, execution.Attempts)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+		return nil
+	}
+
+	return err
+}
+
+// execute runs the listener with retries and records the outcome on the execution.
+// A failure is dead-lettered on the last delivery because the event will not be delivered again.
+func (dispatcher *EventDispatcher) execute(ctx context.Context, event cloudevents.Event, sub namedEventListener, execution *entities.EventListenerExecution, lastDelivery bool) error {
+	ctx, span := dispatcher.tracer.Start(ctx)
+	defer span.End()
+
+	ctxLogger := dispatcher.tracer.CtxLogger(dispatcher.logger, span)
+
+	listenerErr := dispatcher.run(ctx, event, sub)
+
+	execution.Status = entities.EventListenerExecutionStatusSucceeded
+	execution.Error = nil
+	execution.ClaimedUntil = nil
+	if listenerErr != nil {
+		message := listenerErr.Error()
+		execution.Status = entities.EventListenerExecutionStatusFailed
+		execution.Error = &message
+	}
+
+	if listenerErr != nil && lastDelivery {
+		execution.Status = entities.EventListenerExecutionStatusDeadLettered
+	}
+
+	if err := dispatcher.executionRepository.Update(ctx, execution); err != nil {
+		msg := fmt.Sprintf("cannot record status [%s] of event [%s] with ID [%s] for listener [%s]", execution.Status, event.Type(), event.ID(), sub.name)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+	}
+
+	return listenerErr
+}
+
+// run calls the listener with a timeout and retries failures with an exponential backoff.
+// A listener which timed out may still be running so it is left to the redelivery of the event instead of being retried here.
+func (dispatcher *EventDispatcher) run(ctx context.Context, event cloudevents.Event, sub namedEventListener) (err error) {
+	ctx, span, ctxLogger := dispatcher.tracer.StartWithLogger(ctx, dispatcher.logger)
+	defer span.End()
+
+	timedOut := false
+	backoff := eventListenerRetryBackoff
+	for attempt := 1; attempt <= eventListenerRetries; attempt++ {
+		if timedOut, err = dispatcher.runWithTimeout(ctx, event, sub); err == nil {
+			return nil
+		}
+
+		if timedOut || attempt == eventListenerRetries {
+			break
+		}
+
+		ctxLogger.Warn(stacktrace.Propagate(err, fmt.Sprintf("attempt [%d] of listener [%s] failed for event [%s] with ID [%s], retrying in [%s]", attempt, sub.name, event.Type(), event.ID(), backoff)))
+		select {
+		case <-ctx.Done():
+			return stacktrace.Propagate(ctx.Err(), fmt.Sprintf("context done while retrying listener [%s] after error [%s]", sub.name, err))
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+
+	return err
+}
+
+// runWithTimeout calls the listener and stops waiting for it after the eventListenerTimeout
+func (dispatcher *EventDispatcher) runWithTimeout(ctx context.Context, event cloudevents.Event, sub namedEventListener) (timedOut bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, eventListenerTimeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				result <- stacktrace.NewError(fmt.Sprintf("listener [%s] panicked with [%v]", sub.name, r))
+			}
+		}()
+		result <- sub.listener(ctx, event)
+	}()
+
+	select {
+	case err = <-result:
+		return false, err
+	case <-ctx.Done():
+		return true, stacktrace.Propagate(ctx.Err(), fmt.Sprintf("listener [%s] timed out after [%s]", sub.name, eventListenerTimeout))
+	}
+}
+
+// listenerName returns a stable name e.g. "UserListener.OnUserSubscriptionCreated" for a listener method
+func (dispatcher *EventDispatcher) listenerName(listener EventListener) string {
+	name := runtime.FuncForPC(reflect.ValueOf(listener).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = name[strings.Index(name, ".")+1:]
+	return strings.NewReplacer("(", "", ")", "", "*", "").Replace(name)
+}
+
+func (dispatcher *EventDispatcher) createTask(outboxEvent *entities.OutboxEvent) *queue.Task {
 	return &queue.Task{
-		Method: http.MethodPost,
-		URL:    dispatcher.consumerURL,
-		Body:   eventContent,
-	}, nil
+		Method:           http.MethodPost,
+		URL:              dispatcher.consumerURL,
+		Body:             outboxEvent.Data,
+		ScheduleTime:     outboxEvent.ScheduleTime,
+		DeduplicationKey: outboxEvent.ID.String(),
+		// the queue delivers the event as many times as a listener can fail before it is dead-lettered
+		RetryPolicy: &queue.RetryPolicy{
+			MaxAttempts: eventListenerMaxDeliveries,
+			MinBackoff:  eventListenerMinBackoff,
+			MaxBackoff:  eventListenerMaxBackoff,
+		},
+	}
 }
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+type testTransactor struct{}
+
+func (transactor *testTransactor) Transaction(ctx context.Context, callback func(ctx context.Context) error) error {
+	return callback(ctx)
+}
+
+type testEventRepository struct {
+	mutex  sync.Mutex
+	events map[string]*cloudevents.Event
+}
+
+func (repository *testEventRepository) Create(ctx context.Context, event *cloudevents.Event) error {
+	return repository.Save(ctx, event)
+}
+
+func (repository *testEventRepository) Save(_ context.Context, event *cloudevents.Event) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	repository.events[event.ID()] = event
+	return nil
+}
+
+func (repository *testEventRepository) Load(_ context.Context, eventID string) (*cloudevents.Event, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	event, ok := repository.events[eventID]
+	if !ok {
+		return nil, stacktrace.NewErrorWithCode(repositories.ErrCodeNotFound, "event does not exist")
+	}
+	return event, nil
+}
+
+func (repository *testEventRepository) FetchAll(_ context.Context) ([]*cloudevents.Event, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	result := make([]*cloudevents.Event, 0, len(repository.events))
+	for _, event := range repository.events {
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+// testExecutionRepository claims executions with the same rules as the GORM repository
+type testExecutionRepository struct {
+	mutex      sync.Mutex
+	executions map[string]*entities.EventListenerExecution
+}
+
+func newTestExecutionRepository() *testExecutionRepository {
+	return &testExecutionRepository{executions: map[string]*entities.EventListenerExecution{}}
+}
+
+func (repository *testExecutionRepository) Claim(_ context.Context, eventID string, eventType string, listener string, lease time.Duration) (*entities.EventListenerExecution, bool, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	execution, ok := repository.executions[eventID+listener]
+	if !ok {
+		execution = &entities.EventListenerExecution{
+			ID:        uuid.New(),
+			EventID:   eventID,
+			EventType: eventType,
+			Listener:  listener,
+			Status:    entities.EventListenerExecutionStatusProcessing,
+		}
+		repository.executions[eventID+listener] = execution
+	}
+
+	now := time.Now().UTC()
+	if execution.Status == entities.EventListenerExecutionStatusSucceeded ||
+		execution.Status == entities.EventListenerExecutionStatusDeadLettered ||
+		(execution.Status == entities.EventListenerExecutionStatusProcessing && execution.Attempts > 0 && execution.ClaimedUntil != nil && execution.ClaimedUntil.After(now)) {
+		result := *execution
+		return &result, false, nil
+	}
+
+	claimedUntil := now.Add(lease)
+	execution.Status = entities.EventListenerExecutionStatusProcessing
+	execution.Attempts++
+	execution.ClaimedUntil = &claimedUntil
+
+	result := *execution
+	return &result, true, nil
+}
+
+func (repository *testExecutionRepository) ClaimDeadLettered(_ context.Context, executionID uuid.UUID, lease time.Duration) (*entities.EventListenerExecution, bool, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+
+	for _, execution := range repository.executions {
+		if execution.ID != executionID {
+			continue
+		}
+
+		if execution.Status != entities.EventListenerExecutionStatusDeadLettered {
+			result := *execution
+			return &result, false, nil
+		}
+
+		claimedUntil := time.Now().UTC().Add(lease)
+		execution.Status = entities.EventListenerExecutionStatusProcessing
+		execution.Attempts++
+		execution.ClaimedUntil = &claimedUntil
+
+		result := *execution
+		return &result, true, nil
+	}
+	return nil, false, stacktrace.NewErrorWithCode(repositories.ErrCodeNotFound, "execution does not exist")
+}
+
+func (repository *testExecutionRepository) Load(_ context.Context, executionID uuid.UUID) (*entities.EventListenerExecution, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	for _, execution := range repository.executions {
+		if execution.ID == executionID {
+			result := *execution
+			return &result, nil
+		}
+	}
+	return nil, stacktrace.NewErrorWithCode(repositories.ErrCodeNotFound, "execution does not exist")
+}
+
+func (repository *testExecutionRepository) FetchByStatus(_ context.Context, status entities.EventListenerExecutionStatus, _ repositories.IndexParams) ([]*entities.EventListenerExecution, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	var result []*entities.EventListenerExecution
+	for _, execution := range repository.executions {
+		if execution.Status == status {
+			result = append(result, execution)
+		}
+	}
+	return result, nil
+}
+
+func (repository *testExecutionRepository) Update(_ context.Context, execution *entities.EventListenerExecution) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	result := *execution
+	repository.executions[execution.EventID+execution.Listener] = &result
+	return nil
+}
+
+func (repository *testExecutionRepository) get(eventID string, listener string) *entities.EventListenerExecution {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	return repository.executions[eventID+listener]
+}
+
+// testListener counts its calls and fails until succeedAfter calls were made
+type testListener struct {
+	mutex        sync.Mutex
+	calls        int
+	succeedAfter int
+}
+
+func (listener *testListener) OnEvent(_ context.Context, _ cloudevents.Event) error {
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+	listener.calls++
+	if listener.calls <= listener.succeedAfter {
+		return errors.New("listener failed")
+	}
+	return nil
+}
+
+func (listener *testListener) callCount() int {
+	listener.mutex.Lock()
+	defer listener.mutex.Unlock()
+	return listener.calls
+}
+
+const testListenerName = "testListener.OnEvent"
+
+func newTestEventDispatcher(executionRepository repositories.EventListenerExecutionRepository) (*EventDispatcher, *testEventRepository) {
+	logger, tracer := newTestTelemetry()
+	eventRepository := &testEventRepository{events: map[string]*cloudevents.Event{}}
+	return NewEventDispatcher(logger, tracer, &testTransactor{}, eventRepository, nil, executionRepository, nil, "/v1/events/consume"), eventRepository
+}
+
+func newTestEvent(t *testing.T) cloudevents.Event {
+	t.Helper()
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.NewString())
+	event.SetType("test.event")
+	event.SetSource("/tests")
+	if err := event.SetData(cloudevents.ApplicationJSON, map[string]string{"key": "value"}); err != nil {
+		t.Fatalf("cannot set event data: %v", err)
+	}
+	return event
+}
+
+func TestEventDispatcher_ListenerName(t *testing.T) {
+	dispatcher, _ := newTestEventDispatcher(newTestExecutionRepository())
+
+	if name := dispatcher.listenerName((&testListener{}).OnEvent); name != testListenerName {
+		t.Errorf("got listener name [%s], want [%s]", name, testListenerName)
+	}
+}
+
+func TestEventDispatcher_PublishRunsListenerOnce(t *testing.T) {
+	executions := newTestExecutionRepository()
+	dispatcher, _ := newTestEventDispatcher(executions)
+	listener := &testListener{}
+	dispatcher.Subscribe("test.event", listener.OnEvent)
+
+	event := newTestEvent(t)
+	for i := 0; i < 2; i++ {
+		if err := dispatcher.Publish(context.Background(), event); err != nil {
+			t.Fatalf("cannot publish event: %v", err)
+		}
+	}
+
+	if calls := listener.callCount(); calls != 1 {
+		t.Errorf("got [%d] listener calls after publishing the event twice, want [1]", calls)
+	}
+
+	execution := executions.get(event.ID(), testListenerName)
+	if execution.Status != entities.EventListenerExecutionStatusSucceeded || execution.ClaimedUntil != nil {
+		t.Errorf("got execution with status [%s] and lease [%v], want [%s] without a lease", execution.Status, execution.ClaimedUntil, entities.EventListenerExecutionStatusSucceeded)
+	}
+}
+
+func TestEventDispatcher_PublishFailsWhileAnotherDeliveryHoldsTheLease(t *testing.T) {
+	executions := newTestExecutionRepository()
+	dispatcher, _ := newTestEventDispatcher(executions)
+	listener := &testListener{}
+	dispatcher.Subscribe("test.event", listener.OnEvent)
+
+	event := newTestEvent(t)
+	if _, claimed, _ := executions.Claim(context.Background(), event.ID(), event.Type(), testListenerName, time.Hour); !claimed {
+		t.Fatal("cannot claim the event for the other delivery")
+	}
+
+	if err := dispatcher.Publish(context.Background(), event); err == nil {
+		t.Error("publishing an event which is claimed by another delivery did not fail so it will not be redelivered")
+	}
+
+	if calls := listener.callCount(); calls != 0 {
+		t.Errorf("got [%d] listener calls while another delivery holds the lease, want [0]", calls)
+	}
+}
+
+func TestEventDispatcher_PublishTakesOverExpiredLease(t *testing.T) {
+	executions := newTestExecutionRepository()
+	dispatcher, _ := newTestEventDispatcher(executions)
+	listener := &testListener{}
+	dispatcher.Subscribe("test.event", listener.OnEvent)
+
+	event := newTestEvent(t)
+	if _, claimed, _ := executions.Claim(context.Background(), event.ID(), event.Type(), testListenerName, -time.Second); !claimed {
+		t.Fatal("cannot claim the event for the abandoned delivery")
+	}
+
+	if err := dispatcher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("cannot publish event with an expired lease: %v", err)
+	}
+
+	execution := executions.get(event.ID(), testListenerName)
+	if listener.callCount() != 1 || execution.Attempts != 2 || execution.Status != entities.EventListenerExecutionStatusSucceeded {
+		t.Errorf("got [%d] calls and execution with [%d] attempts and status [%s], want [1] call, [2] attempts and status [%s]", listener.callCount(), execution.Attempts, execution.Status, entities.EventListenerExecutionStatusSucceeded)
+	}
+}
+
+func TestEventDispatcher_PublishRecordsFailure(t *testing.T) {
+	executions := newTestExecutionRepository()
+	dispatcher, _ := newTestEventDispatcher(executions)
+	listener := &testListener{succeedAfter: eventListenerRetries}
+	dispatcher.Subscribe("test.event", listener.OnEvent)
+
+	event := newTestEvent(t)
+	if err := dispatcher.Publish(context.Background(), event); err == nil {
+		t.Fatal("publishing an event to a failing listener did not fail")
+	}
+
+	execution := executions.get(event.ID(), testListenerName)
+	if execution.Status != entities.EventListenerExecutionStatusFailed || execution.Error == nil || execution.ClaimedUntil != nil {
+		t.Errorf("got execution with status [%s], error [%v] and lease [%v], want status [%s] with an error and without a lease", execution.Status, execution.Error, execution.ClaimedUntil, entities.EventListenerExecutionStatusFailed)
+	}
+
+	if err := dispatcher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("cannot publish the redelivered event: %v", err)
+	}
+	if execution = executions.get(event.ID(), testListenerName); execution.Status != entities.EventListenerExecutionStatusSucceeded {
+		t.Errorf("got status [%s] after the redelivery, want [%s]", execution.Status, entities.EventListenerExecutionStatusSucceeded)
+	}
+}
+
+func TestEventDispatcher_PublishDeadLettersAfterMaxDeliveries(t *testing.T) {
+	executions := newTestExecutionRepository()
+	dispatcher, _ := newTestEventDispatcher(executions)
+	listener := &testListener{succeedAfter: 1000}
+	dispatcher.Subscribe("test.event", listener.OnEvent)
+
+	event := newTestEvent(t)
+	executions.executions[event.ID()+testListenerName] = &entities.EventListenerExecution{
+		ID:       uuid.New(),
+		EventID:  event.ID(),
+		Listener: testListenerName,
+		Status:   entities.EventListenerExecutionStatusFailed,
+		Attempts: eventListenerMaxDeliveries - 1,
+	}
+
+	if err := dispatcher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("got error [%v] for the last delivery, want nil so the event is not redelivered", err)
+	}
+
+	if execution := executions.get(event.ID(), testListenerName); execution.Status != entities.EventListenerExecutionStatusDeadLettered {
+		t.Errorf("got status [%s], want [%s]", execution.Status, entities.EventListenerExecutionStatusDeadLettered)
+	}
+
+	calls := listener.callCount()
+	if err := dispatcher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("cannot publish a dead-lettered event: %v", err)
+	}
+	if listener.callCount() != calls {
+		t.Error("the listener ran again for a dead-lettered event")
+	}
+}
+
+func TestEventDispatcher_ReplayRunsDeadLetteredExecution(t *testing.T) {
+	executions := newTestExecutionRepository()
+	dispatcher, events := newTestEventDispatcher(executions)
+	listener := &testListener{}
+	dispatcher.Subscribe("test.event", listener.OnEvent)
+
+	event := newTestEvent(t)
+	if err := events.Save(context.Background(), &event); err != nil {
+		t.Fatalf("cannot save event: %v", err)
+	}
+
+	execution := &entities.EventListenerExecution{
+		ID:       uuid.New(),
+		EventID:  event.ID(),
+		Listener: testListenerName,
+		Status:   entities.EventListenerExecutionStatusDeadLettered,
+		Attempts: eventListenerMaxDeliveries,
+	}
+	executions.executions[event.ID()+testListenerName] = execution
+
+	replayed, err := dispatcher.Replay(context.Background(), execution.ID)
+	if err != nil {
+		t.Fatalf("cannot replay execution: %v", err)
+	}
+
+	if replayed.Status != entities.EventListenerExecutionStatusSucceeded || listener.callCount() != 1 {
+		t.Errorf("got status [%s] after [%d] calls, want [%s] after [1] call", replayed.Status, listener.callCount(), entities.EventListenerExecutionStatusSucceeded)
+	}
+
+	if _, err = dispatcher.Replay(context.Background(), execution.ID); stacktrace.GetCode(err) != ErrCodeEventNotDeadLettered {
+		t.Errorf("got error [%v] when replaying a succeeded execution, want code [%d]", err, ErrCodeEventNotDeadLettered)
+	}
+}
+
+func TestEventDispatcher_FailedReplayStaysDeadLettered(t *testing.T) {
+	executions := newTestExecutionRepository()
+	dispatcher, events := newTestEventDispatcher(executions)
+	listener := &testListener{succeedAfter: 1000}
+	dispatcher.Subscribe("test.event", listener.OnEvent)
+
+	event := newTestEvent(t)
+	if err := events.Save(context.Background(), &event); err != nil {
+		t.Fatalf("cannot save event: %v", err)
+	}
+
+	execution := &entities.EventListenerExecution{
+		ID:       uuid.New(),
+		EventID:  event.ID(),
+		Listener: testListenerName,
+		Status:   entities.EventListenerExecutionStatusDeadLettered,
+		Attempts: 1,
+	}
+	executions.executions[event.ID()+testListenerName] = execution
+
+	replayed, err := dispatcher.Replay(context.Background(), execution.ID)
+	if err != nil {
+		t.Fatalf("cannot replay execution: %v", err)
+	}
+
+	if replayed.Status != entities.EventListenerExecutionStatusDeadLettered || replayed.Attempts != 2 || replayed.ClaimedUntil != nil {
+		t.Errorf("got status [%s] with [%d] attempts and lease [%v], want [%s] with [2] attempts and without a lease", replayed.Status, replayed.Attempts, replayed.ClaimedUntil, entities.EventListenerExecutionStatusDeadLettered)
+	}
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/google/uuid"
+)
+
+// IntegrationRequest is the payload used to create or update an integration
+type IntegrationRequest interface {
+	// Sanitize the request before it is validated
+	Sanitize()
+}
+
+// IntegrationPayload is an IntegrationRequest which can be copied into an integration of type T
+type IntegrationPayload[T entities.IntegrationEntity] interface {
+	IntegrationRequest
+
+	// Apply copies the payload into the integration settings
+	Apply(integration T)
+}
+
+// IntegrationDefinition is used to register an integration type with the IntegrationRegistry
+type IntegrationDefinition[T entities.IntegrationEntity, P IntegrationPayload[T]] struct {
+	// Type is the entities.IntegrationType and the prefix of the /v1/projects/:projectID/{type}-integrations routes
+	Type entities.IntegrationType
+
+	// Feature is the optional entities.PlanFeature which is required to create the integration
+	Feature entities.PlanFeature
+
+	// Repository persists the integration settings
+	Repository repositories.IntegrationRepository[T]
+
+	// NewEntity creates the integration settings with their default values
+	NewEntity func() T
+
+	// NewPayload creates an empty payload for decoding create and update requests
+	NewPayload func() P
+
+	// Rules are the govalidator rules for the payload
+	Rules map[string][]string
+
+	// Messages are custom govalidator messages for the Rules
+	Messages map[string][]string
+
+	// Validate performs optional checks which cannot be expressed as Rules
+	Validate func(payload P) url.Values
+}
+
+// IntegrationCreateParams are the parameters for creating an integration
+type IntegrationCreateParams struct {
+	Source    string
+	ProjectID uuid.UUID
+	UserID    entities.UserID
+	Request   IntegrationRequest
+}
+
+// IntegrationUpdateParams are the parameters for updating an integration
+type IntegrationUpdateParams struct {
+	Source        string
+	ProjectID     uuid.UUID
+	IntegrationID uuid.UUID
+	UserID        entities.UserID
+	Request       IntegrationRequest
+}
+
+// RegisteredIntegration manages an integration type which was registered with the IntegrationRegistry
+type RegisteredIntegration interface {
+	// Type returns the entities.IntegrationType
+	Type() entities.IntegrationType
+
+	// NewRequest creates an empty IntegrationRequest for decoding create and update requests
+	NewRequest() IntegrationRequest
+
+	// Rules returns the govalidator rules and messages for an IntegrationRequest
+	Rules() (rules map[string][]string, messages map[string][]string)
+
+	// Validate performs the checks which cannot be expressed as Rules
+	Validate(request IntegrationRequest) url.Values
+
+	// Get returns an entities.IntegrationEntity in a project of an authenticated user
+	Get(ctx context.Context, userID entities.UserID, projectID uuid.UUID, integrationID uuid.UUID) (entities.IntegrationEntity, error)
+
+	// Create a new entities.IntegrationEntity
+	Create(ctx context.Context, params *IntegrationCreateParams) (entities.IntegrationEntity, error)
+
+	// Update an entities.IntegrationEntity
+	Update(ctx context.Context, params *IntegrationUpdateParams) (entities.IntegrationEntity, error)
+
+	// Delete an entities.IntegrationEntity
+	Delete(ctx context.Context, params *IntegrationDeleteParams) error
+
+	// DeleteAll deletes every entities.IntegrationEntity in a project and returns the number of deleted integrations
+	DeleteAll(ctx context.Context, params *IntegrationDeleteAllParams) (int, error)
+
+	// FetchMultiple returns the settings of multiple integrations in a project which was already authorized
+	FetchMultiple(ctx context.Context, projectID uuid.UUID, integrationIDs []uuid.UUID) ([]entities.IntegrationEntity, error)
+}
+
+// IntegrationRegistry contains all the integration types supported by the API
+type IntegrationRegistry struct {
+	types        []entities.IntegrationType
+	integrations map[entities.IntegrationType]RegisteredIntegration
+}
+
+// NewIntegrationRegistry creates a new IntegrationRegistry
+func NewIntegrationRegistry() *IntegrationRegistry {
+	return &IntegrationRegistry{
+		integrations: map[entities.IntegrationType]RegisteredIntegration{},
+	}
+}
+
+// Register adds a RegisteredIntegration to the registry
+func (registry *IntegrationRegistry) Register(integration RegisteredIntegration) {
+	if _, ok := registry.integrations[integration.Type()]; !ok {
+		registry.types = append(registry.types, integration.Type())
+	}
+	registry.integrations[integration.Type()] = integration
+}
+
+// Get returns the RegisteredIntegration for an entities.IntegrationType
+func (registry *IntegrationRegistry) Get(integrationType entities.IntegrationType) (RegisteredIntegration, bool) {
+	integration, ok := registry.integrations[integrationType]
+	return integration, ok
+}
+
+// All returns every RegisteredIntegration in the order in which they were registered
+func (registry *IntegrationRegistry) All() []RegisteredIntegration {
+	result := make([]RegisteredIntegration, 0, len(registry.types))
+	for _, integrationType := range registry.types {
+		result = append(result, registry.integrations[integrationType])
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/hirosassa/zerodriver"
+	"github.com/rs/zerolog"
+)
+
+func newTestTelemetry() (telemetry.Logger, telemetry.Tracer) {
+	nop := zerolog.Nop()
+	logger := telemetry.NewZerologLogger("test", map[string]string{}, &zerodriver.Logger{Logger: &nop}, nil)
+	return logger, telemetry.NewOtelLogger("test", logger)
+}
+
+type testLinkPayload struct {
+	Text string
+	URL  string
+}
+
+func (payload *testLinkPayload) Sanitize() {}
+
+func (payload *testLinkPayload) Apply(integration *entities.LinkIntegration) {
+	integration.Text = payload.Text
+	integration.URL = payload.URL
+}
+
+type testContentPayload struct{}
+
+func (payload *testContentPayload) Sanitize() {}
+
+func (payload *testContentPayload) Apply(_ *entities.ContentIntegration) {}
+
+func newTestLinkIntegrationService(definition IntegrationDefinition[*entities.LinkIntegration, *testLinkPayload]) *IntegrationService[*entities.LinkIntegration, *testLinkPayload] {
+	logger, tracer := newTestTelemetry()
+	definition.Type = entities.IntegrationTypeLink
+	definition.NewEntity = func() *entities.LinkIntegration { return &entities.LinkIntegration{} }
+	definition.NewPayload = func() *testLinkPayload { return &testLinkPayload{} }
+	return NewIntegrationService(logger, tracer, nil, nil, nil, nil, definition)
+}
+
+func newTestContentIntegrationService() *IntegrationService[*entities.ContentIntegration, *testContentPayload] {
+	logger, tracer := newTestTelemetry()
+	return NewIntegrationService(logger, tracer, nil, nil, nil, nil, IntegrationDefinition[*entities.ContentIntegration, *testContentPayload]{
+		Type:       entities.IntegrationTypeContent,
+		NewEntity:  func() *entities.ContentIntegration { return &entities.ContentIntegration{} },
+		NewPayload: func() *testContentPayload { return &testContentPayload{} },
+	})
+}
+
+func TestIntegrationRegistry_AllReturnsRegistrationOrder(t *testing.T) {
+	registry := NewIntegrationRegistry()
+	registry.Register(newTestLinkIntegrationService(IntegrationDefinition[*entities.LinkIntegration, *testLinkPayload]{}))
+	registry.Register(newTestContentIntegrationService())
+
+	all := registry.All()
+	if len(all) != 2 {
+		t.Fatalf("got [%d] integrations, want [2]", len(all))
+	}
+	if all[0].Type() != entities.IntegrationTypeLink || all[1].Type() != entities.IntegrationTypeContent {
+		t.Errorf("got types [%s, %s], want [%s, %s]", all[0].Type(), all[1].Type(), entities.IntegrationTypeLink, entities.IntegrationTypeContent)
+	}
+}
+
+func TestIntegrationRegistry_RegisterReplacesExistingType(t *testing.T) {
+	registry := NewIntegrationRegistry()
+	registry.Register(newTestLinkIntegrationService(IntegrationDefinition[*entities.LinkIntegration, *testLinkPayload]{}))
+
+	replacement := newTestLinkIntegrationService(IntegrationDefinition[*entities.LinkIntegration, *testLinkPayload]{})
+	registry.Register(replacement)
+
+	if all := registry.All(); len(all) != 1 {
+		t.Fatalf("got [%d] integrations after registering the same type twice, want [1]", len(all))
+	}
+
+	integration, ok := registry.Get(entities.IntegrationTypeLink)
+	if !ok || integration != replacement {
+		t.Errorf("got integration [%v], want the integration which was registered last", integration)
+	}
+}
+
+func TestIntegrationRegistry_GetUnknownType(t *testing.T) {
+	registry := NewIntegrationRegistry()
+
+	if _, ok := registry.Get(entities.IntegrationTypeWhatsapp); ok {
+		t.Error("got an integration for a type which was not registered")
+	}
+}
+
+func TestIntegrationService_ValidateRejectsPayloadOfAnotherType(t *testing.T) {
+	service := newTestLinkIntegrationService(IntegrationDefinition[*entities.LinkIntegration, *testLinkPayload]{})
+
+	if errors := service.Validate(&testContentPayload{}); len(errors["request"]) == 0 {
+		t.Errorf("got errors [%v], want an error for the request", errors)
+	}
+
+	if _, err := service.payload(&testContentPayload{}); err == nil {
+		t.Error("converting a payload of another integration type did not fail")
+	}
+}
+
+func TestIntegrationService_ValidateRunsDefinitionValidate(t *testing.T) {
+	service := newTestLinkIntegrationService(IntegrationDefinition[*entities.LinkIntegration, *testLinkPayload]{
+		Validate: func(payload *testLinkPayload) url.Values {
+			if payload.URL == "" {
+				return url.Values{"url": []string{"the url is required"}}
+			}
+			return url.Values{}
+		},
+	})
+
+	if errors := service.Validate(&testLinkPayload{}); len(errors["url"]) != 1 {
+		t.Errorf("got errors [%v], want an error for the url", errors)
+	}
+
+	if errors := service.Validate(&testLinkPayload{URL: "https://example.com"}); len(errors) != 0 {
+		t.Errorf("got errors [%v] for a valid payload, want none", errors)
+	}
+}
+
+func TestIntegrationService_RulesReturnsCopy(t *testing.T) {
+	definition := IntegrationDefinition[*entities.LinkIntegration, *testLinkPayload]{
+		Rules: map[string][]string{"url": {"required"}},
+	}
+	service := newTestLinkIntegrationService(definition)
+
+	rules, _ := service.Rules()
+	rules["name"] = []string{"required"}
+
+	if _, ok := definition.Rules["name"]; ok {
+		t.Error("changing the rules which were returned changed the rules of the definition")
+	}
+}
+
+func TestIntegrationService_NewRequestAppliesToEntity(t *testing.T) {
+	service := newTestLinkIntegrationService(IntegrationDefinition[*entities.LinkIntegration, *testLinkPayload]{})
+
+	request, ok := service.NewRequest().(*testLinkPayload)
+	if !ok {
+		t.Fatalf("got request of type [%T], want [%T]", service.NewRequest(), &testLinkPayload{})
+	}
+	request.Text = "Visit our FAQ"
+	request.URL = "https://example.com"
+
+	integration := service.definition.NewEntity()
+	request.Apply(integration)
+
+	public, ok := integration.PublicSettings().(*entities.PublicLinkIntegration)
+	if !ok || public.Text != request.Text || public.URL != request.URL {
+		t.Errorf("got public settings [%+v], want the text and URL of the request", integration.PublicSettings())
+	}
+	if integration.Base() != &integration.IntegrationBase {
+		t.Error("Base does not return the embedded integration base")
+	}
+}
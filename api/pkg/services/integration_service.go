@@ -3,12 +3,14 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/NdoleStudio/superbutton/pkg/entities"
 	"github.com/NdoleStudio/superbutton/pkg/events"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/palantir/stacktrace"
@@ -19,10 +21,277 @@ type integrationService struct {
 	integrationType entities.IntegrationType
 	tracer          telemetry.Tracer
 	logger          telemetry.Logger
+	transactor      repositories.Transactor
 	eventDispatcher *EventDispatcher
 }
 
-// IntegrationDeleteParams are the parameters for updating a new whatsapp integration.
+// IntegrationService manages an integration type which is registered with the IntegrationRegistry
+type IntegrationService[T entities.IntegrationEntity, P IntegrationPayload[T]] struct {
+	integrationService
+	authorizationService *AuthorizationService
+	entitlementService   *EntitlementService
+	definition           IntegrationDefinition[T, P]
+}
+
+// NewIntegrationService creates a new IntegrationService from an IntegrationDefinition
+func NewIntegrationService[T entities.IntegrationEntity, P IntegrationPayload[T]](
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	eventDispatcher *EventDispatcher,
+	transactor repositories.Transactor,
+	authorizationService *AuthorizationService,
+	entitlementService *EntitlementService,
+	definition IntegrationDefinition[T, P],
+) (s *IntegrationService[T, P]) {
+	return &IntegrationService[T, P]{
+		authorizationService: authorizationService,
+		entitlementService:   entitlementService,
+		definition:           definition,
+		integrationService: integrationService{
+			integrationType: definition.Type,
+			tracer:          tracer,
+			logger:          logger.WithService(fmt.Sprintf("%T", s)),
+			transactor:      transactor,
+			eventDispatcher: eventDispatcher,
+		},
+	}
+}
+
+// Type returns the entities.IntegrationType managed by the IntegrationService
+func (service *IntegrationService[T, P]) Type() entities.IntegrationType {
+	return service.integrationType
+}
+
+// NewRequest creates an empty IntegrationRequest for decoding create and update requests
+func (service *IntegrationService[T, P]) NewRequest() IntegrationRequest {
+	return service.definition.NewPayload()
+}
+
+// Rules returns the govalidator rules and messages for an IntegrationRequest
+func (service *IntegrationService[T, P]) Rules() (rules map[string][]string, messages map[string][]string) {
+	rules = make(map[string][]string, len(service.definition.Rules))
+	for key, value := range service.definition.Rules {
+		rules[key] = value
+	}
+	return rules, service.definition.Messages
+}
+
+// Validate performs the checks which cannot be expressed as Rules
+func (service *IntegrationService[T, P]) Validate(request IntegrationRequest) url.Values {
+	payload, ok := request.(P)
+	if !ok {
+		return url.Values{"request": []string{fmt.Sprintf("the request is not a valid [%s] integration payload", service.integrationType)}}
+	}
+
+	if service.definition.Validate == nil {
+		return url.Values{}
+	}
+	return service.definition.Validate(payload)
+}
+
+// Get returns an entities.IntegrationEntity in a project of an authenticated user
+func (service *IntegrationService[T, P]) Get(ctx context.Context, userID entities.UserID, projectID uuid.UUID, integrationID uuid.UUID) (entities.IntegrationEntity, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizeProject(ctx, userID, projectID, entities.WorkspacePermissionView); err != nil {
+		return nil, service.tracer.WrapErrorSpan(span, err)
+	}
+
+	integration, err := service.definition.Repository.Load(ctx, projectID, integrationID)
+	if err != nil {
+		msg := fmt.Sprintf("could not load [%s] integration for user with ID [%s], project [%s] and ID [%s]", service.integrationType, userID, projectID, integrationID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return integration, nil
+}
+
+// Create a new entities.IntegrationEntity
+func (service *IntegrationService[T, P]) Create(ctx context.Context, params *IntegrationCreateParams) (entities.IntegrationEntity, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	payload, err := service.payload(params.Request)
+	if err != nil {
+		return nil, service.tracer.WrapErrorSpan(span, err)
+	}
+
+	project, err := service.authorizeProject(ctx, params.UserID, params.ProjectID, entities.WorkspacePermissionEdit)
+	if err != nil {
+		return nil, service.tracer.WrapErrorSpan(span, err)
+	}
+
+	integration := service.definition.NewEntity()
+	payload.Apply(integration)
+
+	base := integration.Base()
+	base.ID = uuid.New()
+	base.UserID = params.UserID
+	base.ProjectID = params.ProjectID
+	base.Enabled = true
+	base.CreatedAt = time.Now().UTC()
+	base.UpdatedAt = time.Now().UTC()
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.entitlementService.CanCreateIntegration(ctx, params.Source, project, service.definition.Feature); err != nil {
+			msg := fmt.Sprintf("user with ID [%s] cannot create a [%s] integration in project [%s]", params.UserID, service.integrationType, params.ProjectID)
+			return stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
+		}
+		if err = service.definition.Repository.Store(ctx, integration); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("could store [%s] integration for user with ID [%s] and project [%s]", service.integrationType, params.UserID, params.ProjectID))
+		}
+		return service.dispatchIntegrationCreatedEvent(ctx, params.Source, base.Integration(service.integrationType))
+	})
+	if err != nil {
+		msg := fmt.Sprintf("could not create [%s] integration for user with ID [%s] and project [%s]", service.integrationType, params.UserID, params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return integration, nil
+}
+
+// Update an entities.IntegrationEntity
+func (service *IntegrationService[T, P]) Update(ctx context.Context, params *IntegrationUpdateParams) (entities.IntegrationEntity, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	payload, err := service.payload(params.Request)
+	if err != nil {
+		return nil, service.tracer.WrapErrorSpan(span, err)
+	}
+
+	if _, err = service.authorizeProject(ctx, params.UserID, params.ProjectID, entities.WorkspacePermissionEdit); err != nil {
+		return nil, service.tracer.WrapErrorSpan(span, err)
+	}
+
+	integration, err := service.definition.Repository.Load(ctx, params.ProjectID, params.IntegrationID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load integrtion [%s] for user ID [%s] and project [%s]", params.IntegrationID, params.UserID, params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	payload.Apply(integration)
+
+	base := integration.Base()
+	base.UpdatedAt = time.Now().UTC()
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.definition.Repository.Update(ctx, integration); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("could update [%s] integration for user with ID [%s] and id [%s]", service.integrationType, params.UserID, params.IntegrationID))
+		}
+		return service.dispatchIntegrationUpdatedEvent(ctx, params.Source, base.Integration(service.integrationType))
+	})
+	if err != nil {
+		msg := fmt.Sprintf("could not update [%s] integration for user with ID [%s] and id [%s]", service.integrationType, params.UserID, params.IntegrationID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return integration, nil
+}
+
+// Delete an entities.IntegrationEntity
+func (service *IntegrationService[T, P]) Delete(ctx context.Context, params *IntegrationDeleteParams) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizeProject(ctx, params.UserID, params.ProjectID, entities.WorkspacePermissionEdit); err != nil {
+		return service.tracer.WrapErrorSpan(span, err)
+	}
+
+	integration, err := service.definition.Repository.Load(ctx, params.ProjectID, params.IntegrationID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load integrtion [%s] for user ID [%s] and project [%s]", params.IntegrationID, params.UserID, params.ProjectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.definition.Repository.Delete(ctx, params.ProjectID, params.IntegrationID); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot delete integrtion [%s] for user ID [%s]", params.IntegrationID, params.UserID))
+		}
+		return service.dispatchIntegrationDeletedEvent(ctx, params.Source, integration.Base().Integration(service.integrationType))
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete integrtion [%s] for user ID [%s]", params.IntegrationID, params.UserID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return nil
+}
+
+// DeleteAll deletes every entities.IntegrationEntity in a project.
+// The events.IntegrationDeleted event IDs are derived from the project so that a retry does not emit duplicates.
+func (service *IntegrationService[T, P]) DeleteAll(ctx context.Context, params *IntegrationDeleteAllParams) (int, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	integrations, err := service.definition.Repository.Fetch(ctx, params.ProjectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch [%s] integrations for project [%s]", service.integrationType, params.ProjectID)
+		return 0, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	for index, integration := range integrations {
+		base := integration.Base()
+		err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+			if err = service.definition.Repository.Delete(ctx, params.ProjectID, base.ID); err != nil {
+				return stacktrace.Propagate(err, fmt.Sprintf("cannot delete [%s] integration [%s]", service.integrationType, base.ID))
+			}
+
+			key := fmt.Sprintf("%s:%s:%s", events.ProjectDeleted, params.ProjectID, base.ID)
+			event, err := service.createIdempotentEvent(key, events.IntegrationDeleted, params.Source, service.integrationDeletedPayload(base.Integration(service.integrationType)))
+			if err != nil {
+				return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for integration [%s]", events.IntegrationDeleted, base.ID))
+			}
+			return service.dispatchEvent(ctx, base.Integration(service.integrationType), event)
+		})
+		if err != nil {
+			msg := fmt.Sprintf("deleted [%d] out of [%d] [%s] integrations in project [%s]", index, len(integrations), service.integrationType, params.ProjectID)
+			return index, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+	}
+
+	return len(integrations), nil
+}
+
+// FetchMultiple returns the settings of multiple integrations in a project which was already authorized
+func (service *IntegrationService[T, P]) FetchMultiple(ctx context.Context, projectID uuid.UUID, integrationIDs []uuid.UUID) ([]entities.IntegrationEntity, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	integrations, err := service.definition.Repository.FetchMultiple(ctx, projectID, integrationIDs)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch [%s] integrations for project [%s] and IDs [%+#v]", service.integrationType, projectID, integrationIDs)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	result := make([]entities.IntegrationEntity, 0, len(integrations))
+	for _, integration := range integrations {
+		result = append(result, integration)
+	}
+	return result, nil
+}
+
+// authorizeProject returns an error with code repositories.ErrCodeNotFound if the user is not a member of the workspace of the project
+// and an error with code ErrCodeForbidden if their role does not have the permission
+func (service *IntegrationService[T, P]) authorizeProject(ctx context.Context, userID entities.UserID, projectID uuid.UUID, permission entities.WorkspacePermission) (*entities.Project, error) {
+	project, err := service.authorizationService.AuthorizeProject(ctx, userID, projectID, permission)
+	if err != nil {
+		msg := fmt.Sprintf("user ID [%s] cannot [%s] project [%s]", userID, permission, projectID)
+		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
+	}
+	return project, nil
+}
+
+func (service *IntegrationService[T, P]) payload(request IntegrationRequest) (P, error) {
+	payload, ok := request.(P)
+	if !ok {
+		return payload, stacktrace.NewError(fmt.Sprintf("request of type [%T] is not a [%s] integration payload", request, service.integrationType))
+	}
+	return payload, nil
+}
+
+// IntegrationDeleteParams are the parameters for deleting an integration.
 type IntegrationDeleteParams struct {
 	Source        string
 	IntegrationID uuid.UUID
@@ -30,71 +299,74 @@ type IntegrationDeleteParams struct {
 	UserID        entities.UserID
 }
 
-func (service *integrationService) dispatchIntegrationDeletedEvent(ctx context.Context, source string, integration *entities.Integration) {
-	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+// IntegrationDeleteAllParams are the parameters for deleting all the integrations in a project.
+type IntegrationDeleteAllParams struct {
+	Source    string
+	ProjectID uuid.UUID
+}
+
+func (service *integrationService) dispatchIntegrationDeletedEvent(ctx context.Context, source string, integration *entities.Integration) error {
+	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
 	event, err := service.createIntegrationDeletedEvent(source, integration)
 	if err != nil {
 		msg := fmt.Sprintf("cannot create [%s] event for integration [%s]", events.IntegrationDeleted, integration.IntegrationID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
-	service.dispatchEvent(ctx, integration, event)
+	return service.dispatchEvent(ctx, integration, event)
 }
 
-func (service *integrationService) dispatchIntegrationUpdatedEvent(ctx context.Context, source string, integration *entities.Integration) {
-	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+func (service *integrationService) dispatchIntegrationUpdatedEvent(ctx context.Context, source string, integration *entities.Integration) error {
+	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
 	event, err := service.createIntegrationUpdatedEvent(source, integration)
 	if err != nil {
 		msg := fmt.Sprintf("cannot create [%s] event for integration [%s]", events.IntegrationUpdated, integration.IntegrationID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
-	service.dispatchEvent(ctx, integration, event)
+	return service.dispatchEvent(ctx, integration, event)
 }
 
-func (service *integrationService) dispatchIntegrationCreatedEvent(ctx context.Context, source string, integration *entities.Integration) {
-	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+func (service *integrationService) dispatchIntegrationCreatedEvent(ctx context.Context, source string, integration *entities.Integration) error {
+	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
 	event, err := service.createIntegrationCreatedEvent(source, integration)
 	if err != nil {
 		msg := fmt.Sprintf("cannot create [%s] event for integration [%s]", events.IntegrationCreated, integration.IntegrationID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
-	service.dispatchEvent(ctx, integration, event)
+	return service.dispatchEvent(ctx, integration, event)
 }
 
-func (service *integrationService) dispatchEvent(ctx context.Context, integration *entities.Integration, event *cloudevents.Event) {
-	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
-	defer span.End()
-
+func (service *integrationService) dispatchEvent(ctx context.Context, integration *entities.Integration, event *cloudevents.Event) error {
 	if err := service.eventDispatcher.Dispatch(ctx, event); err != nil {
-		msg := fmt.Sprintf("cannot dispatch [%s] event for integration [%s]", event.Type(), integration.IntegrationID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch [%s] event for integration [%s]", event.Type(), integration.IntegrationID))
 	}
+	return nil
 }
 
 func (service *integrationService) createIntegrationDeletedEvent(source string, integration *entities.Integration) (*cloudevents.Event, error) {
-	event, err := service.createEvent(events.IntegrationDeleted, source, &events.IntegrationDeletedPayload{
+	event, err := service.createEvent(events.IntegrationDeleted, source, service.integrationDeletedPayload(integration))
+	if err != nil {
+		msg := fmt.Sprintf("cannot create [%s] event for [%s] integration [%s]", events.IntegrationDeleted, integration.Type, integration.IntegrationID)
+		return nil, stacktrace.Propagate(err, msg)
+	}
+
+	return event, nil
+}
+
+func (service *integrationService) integrationDeletedPayload(integration *entities.Integration) *events.IntegrationDeletedPayload {
+	return &events.IntegrationDeletedPayload{
 		UserID:               integration.UserID,
 		ProjectID:            integration.ProjectID,
 		IntegrationID:        integration.IntegrationID,
 		IntegrationType:      integration.Type,
 		IntegrationName:      integration.Name,
 		IntegrationDeletedAt: time.Now().UTC(),
-	})
-	if err != nil {
-		msg := fmt.Sprintf("cannot create [%s] event for [%s] integration [%s]", events.IntegrationDeleted, integration.Type, integration.IntegrationID)
-		return nil, stacktrace.Propagate(err, msg)
 	}
-
-	return event, nil
 }
 
 func (service *integrationService) createIntegrationUpdatedEvent(source string, integration *entities.Integration) (*cloudevents.Event, error) {
@@ -3,7 +3,9 @@ package services
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NdoleStudio/superbutton/pkg/repositories"
 
@@ -14,6 +16,24 @@ import (
 	"github.com/palantir/stacktrace"
 )
 
+// LemonsqueezySubscriptionInvoiceAttributes are attributes for the subscription payment events
+type LemonsqueezySubscriptionInvoiceAttributes struct {
+	StoreID        int       `json:"store_id"`
+	SubscriptionID int       `json:"subscription_id"`
+	BillingReason  string    `json:"billing_reason"`
+	Currency       string    `json:"currency"`
+	Status         string    `json:"status"`
+	Total          int       `json:"total"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// LemonsqueezySubscriptionInvoiceRequest is the webhook request for the `subscription_payment_*` events
+type LemonsqueezySubscriptionInvoiceRequest struct {
+	Meta lemonsqueezy.WebhookRequestMeta                                                 `json:"meta"`
+	Data lemonsqueezy.WebhookRequestData[LemonsqueezySubscriptionInvoiceAttributes, any] `json:"data"`
+}
+
 // LemonsqueezyService is responsible for managing lemonsqueezy events
 type LemonsqueezyService struct {
 	service
@@ -46,21 +66,16 @@ func (service *LemonsqueezyService) HandleSubscriptionCreatedEvent(ctx context.C
 	payload := &events.UserSubscriptionCreatedPayload{
 		UserID:                entities.UserID(request.Meta.CustomData["user_id"].(string)),
 		SubscriptionCreatedAt: request.Data.Attributes.CreatedAt,
+		SubscriptionUpdatedAt: request.Data.Attributes.UpdatedAt,
 		SubscriptionID:        request.Data.ID,
 		SubscriptionName:      service.subscriptionName(request.Data.Attributes.VariantName),
 		SubscriptionRenewsAt:  request.Data.Attributes.RenewsAt,
 		SubscriptionStatus:    request.Data.Attributes.Status,
 	}
 
-	event, err := service.createEvent(events.UserSubscriptionCreated, source, payload)
-	if err != nil {
-		msg := fmt.Sprintf("cannot created [%s] event for user [%s]", events.UserSubscriptionCreated, payload.UserID)
-		return stacktrace.Propagate(err, msg)
-	}
-
-	if err = service.eventDispatcher.Dispatch(ctx, event); err != nil {
-		msg := fmt.Sprintf("cannot dispatch [%s] event for user [%s]", events.UserSubscriptionCreated, payload.UserID)
-		return stacktrace.Propagate(err, msg)
+	key := service.eventKey("subscription_created", request.Data.ID)
+	if err := service.dispatchEvent(ctx, key, events.UserSubscriptionCreated, source, payload.UserID, payload); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for created subscription [%s]", payload.SubscriptionID))
 	}
 	ctxLogger.Info(fmt.Sprintf("[%s] subscription [%s] created for user [%s]", payload.SubscriptionName, payload.SubscriptionID, payload.UserID))
 	return nil
@@ -80,26 +95,260 @@ func (service *LemonsqueezyService) HandleSubscriptionCanceledEvent(ctx context.
 	payload := &events.UserSubscriptionCancelledPayload{
 		UserID:                  user.ID,
 		SubscriptionCancelledAt: request.Data.Attributes.CreatedAt,
+		SubscriptionUpdatedAt:   request.Data.Attributes.UpdatedAt,
 		SubscriptionID:          request.Data.ID,
 		SubscriptionName:        service.subscriptionName(request.Data.Attributes.VariantName),
 		SubscriptionEndsAt:      *request.Data.Attributes.EndsAt,
 		SubscriptionStatus:      request.Data.Attributes.Status,
 	}
 
-	event, err := service.createEvent(events.UserSubscriptionCancelled, source, payload)
+	key := service.eventKey("subscription_cancelled", request.Data.ID, request.Data.Attributes.UpdatedAt.Format(time.RFC3339Nano))
+	if err = service.dispatchEvent(ctx, key, events.UserSubscriptionCancelled, source, payload.UserID, payload); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for cancelled subscription [%s]", payload.SubscriptionID))
+	}
+	ctxLogger.Info(fmt.Sprintf("[%s] subscription [%s] cancelled for user [%s]", payload.SubscriptionName, payload.SubscriptionID, payload.UserID))
+	return nil
+}
+
+// HandleSubscriptionUpdatedEvent handles the subscription_updated lemonsqueezy event
+func (service *LemonsqueezyService) HandleSubscriptionUpdatedEvent(ctx context.Context, source string, request *lemonsqueezy.WebHookRequestSubscription) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	user, err := service.userRepository.LoadBySubscriptionID(ctx, request.Data.ID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user with subscription ID [%s]", request.Data.ID)
+		return stacktrace.Propagate(err, msg)
+	}
+
+	payload := &events.UserSubscriptionUpdatedPayload{
+		UserID:                user.ID,
+		SubscriptionUpdatedAt: request.Data.Attributes.UpdatedAt,
+		SubscriptionID:        request.Data.ID,
+		SubscriptionName:      service.subscriptionName(request.Data.Attributes.VariantName),
+		SubscriptionEndsAt:    request.Data.Attributes.EndsAt,
+		SubscriptionStatus:    request.Data.Attributes.Status,
+	}
+	if !request.Data.Attributes.Cancelled && request.Data.Attributes.Pause == nil {
+		payload.SubscriptionRenewsAt = &request.Data.Attributes.RenewsAt
+	}
+
+	key := service.eventKey("subscription_updated", request.Data.ID, request.Data.Attributes.UpdatedAt.Format(time.RFC3339Nano))
+	if err = service.dispatchEvent(ctx, key, events.UserSubscriptionUpdated, source, user.ID, payload); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for updated subscription [%s]", payload.SubscriptionID))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("[%s] subscription [%s] updated with status [%s] for user [%s]", payload.SubscriptionName, payload.SubscriptionID, payload.SubscriptionStatus, payload.UserID))
+	return nil
+}
+
+// HandleSubscriptionResumedEvent handles the subscription_resumed lemonsqueezy event
+func (service *LemonsqueezyService) HandleSubscriptionResumedEvent(ctx context.Context, source string, request *lemonsqueezy.WebHookRequestSubscription) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	user, err := service.userRepository.LoadBySubscriptionID(ctx, request.Data.ID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user with subscription ID [%s]", request.Data.ID)
+		return stacktrace.Propagate(err, msg)
+	}
+
+	payload := &events.UserSubscriptionResumedPayload{
+		UserID:                user.ID,
+		SubscriptionResumedAt: request.Data.Attributes.UpdatedAt,
+		SubscriptionUpdatedAt: request.Data.Attributes.UpdatedAt,
+		SubscriptionID:        request.Data.ID,
+		SubscriptionName:      service.subscriptionName(request.Data.Attributes.VariantName),
+		SubscriptionRenewsAt:  request.Data.Attributes.RenewsAt,
+		SubscriptionStatus:    request.Data.Attributes.Status,
+	}
+
+	key := service.eventKey("subscription_resumed", request.Data.ID, request.Data.Attributes.UpdatedAt.Format(time.RFC3339Nano))
+	if err = service.dispatchEvent(ctx, key, events.UserSubscriptionResumed, source, user.ID, payload); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for resumed subscription [%s]", payload.SubscriptionID))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("[%s] subscription [%s] resumed for user [%s]", payload.SubscriptionName, payload.SubscriptionID, payload.UserID))
+	return nil
+}
+
+// HandleSubscriptionExpiredEvent handles the subscription_expired lemonsqueezy event
+func (service *LemonsqueezyService) HandleSubscriptionExpiredEvent(ctx context.Context, source string, request *lemonsqueezy.WebHookRequestSubscription) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	user, err := service.userRepository.LoadBySubscriptionID(ctx, request.Data.ID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user with subscription ID [%s]", request.Data.ID)
+		return stacktrace.Propagate(err, msg)
+	}
+
+	payload := &events.UserSubscriptionExpiredPayload{
+		UserID:                user.ID,
+		SubscriptionExpiredAt: request.Data.Attributes.UpdatedAt,
+		SubscriptionUpdatedAt: request.Data.Attributes.UpdatedAt,
+		SubscriptionID:        request.Data.ID,
+		SubscriptionName:      service.subscriptionName(request.Data.Attributes.VariantName),
+		SubscriptionStatus:    request.Data.Attributes.Status,
+	}
+	if request.Data.Attributes.EndsAt != nil {
+		payload.SubscriptionExpiredAt = *request.Data.Attributes.EndsAt
+	}
+
+	key := service.eventKey("subscription_expired", request.Data.ID, request.Data.Attributes.UpdatedAt.Format(time.RFC3339Nano))
+	if err = service.dispatchEvent(ctx, key, events.UserSubscriptionExpired, source, user.ID, payload); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for expired subscription [%s]", payload.SubscriptionID))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("[%s] subscription [%s] expired for user [%s]", payload.SubscriptionName, payload.SubscriptionID, payload.UserID))
+	return nil
+}
+
+// HandleSubscriptionPausedEvent handles the subscription_paused lemonsqueezy event
+func (service *LemonsqueezyService) HandleSubscriptionPausedEvent(ctx context.Context, source string, request *lemonsqueezy.WebHookRequestSubscription) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	user, err := service.userRepository.LoadBySubscriptionID(ctx, request.Data.ID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user with subscription ID [%s]", request.Data.ID)
+		return stacktrace.Propagate(err, msg)
+	}
+
+	payload := &events.UserSubscriptionPausedPayload{
+		UserID:                user.ID,
+		SubscriptionPausedAt:  request.Data.Attributes.UpdatedAt,
+		SubscriptionUpdatedAt: request.Data.Attributes.UpdatedAt,
+		SubscriptionID:        request.Data.ID,
+		SubscriptionName:      service.subscriptionName(request.Data.Attributes.VariantName),
+		SubscriptionStatus:    request.Data.Attributes.Status,
+	}
+	if request.Data.Attributes.Pause != nil && !request.Data.Attributes.Pause.ResumesAt.IsZero() {
+		payload.SubscriptionResumesAt = &request.Data.Attributes.Pause.ResumesAt
+	}
+
+	key := service.eventKey("subscription_paused", request.Data.ID, request.Data.Attributes.UpdatedAt.Format(time.RFC3339Nano))
+	if err = service.dispatchEvent(ctx, key, events.UserSubscriptionPaused, source, user.ID, payload); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for paused subscription [%s]", payload.SubscriptionID))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("[%s] subscription [%s] paused for user [%s]", payload.SubscriptionName, payload.SubscriptionID, payload.UserID))
+	return nil
+}
+
+// HandleSubscriptionUnpausedEvent handles the subscription_unpaused lemonsqueezy event
+func (service *LemonsqueezyService) HandleSubscriptionUnpausedEvent(ctx context.Context, source string, request *lemonsqueezy.WebHookRequestSubscription) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	user, err := service.userRepository.LoadBySubscriptionID(ctx, request.Data.ID)
 	if err != nil {
-		msg := fmt.Sprintf("cannot created [%s] event for user [%s]", events.UserSubscriptionCancelled, payload.UserID)
+		msg := fmt.Sprintf("cannot load user with subscription ID [%s]", request.Data.ID)
+		return stacktrace.Propagate(err, msg)
+	}
+
+	payload := &events.UserSubscriptionUnpausedPayload{
+		UserID:                 user.ID,
+		SubscriptionUnpausedAt: request.Data.Attributes.UpdatedAt,
+		SubscriptionUpdatedAt:  request.Data.Attributes.UpdatedAt,
+		SubscriptionID:         request.Data.ID,
+		SubscriptionName:       service.subscriptionName(request.Data.Attributes.VariantName),
+		SubscriptionRenewsAt:   request.Data.Attributes.RenewsAt,
+		SubscriptionStatus:     request.Data.Attributes.Status,
+	}
+
+	key := service.eventKey("subscription_unpaused", request.Data.ID, request.Data.Attributes.UpdatedAt.Format(time.RFC3339Nano))
+	if err = service.dispatchEvent(ctx, key, events.UserSubscriptionUnpaused, source, user.ID, payload); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for unpaused subscription [%s]", payload.SubscriptionID))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("[%s] subscription [%s] unpaused for user [%s]", payload.SubscriptionName, payload.SubscriptionID, payload.UserID))
+	return nil
+}
+
+// HandleSubscriptionPaymentFailedEvent handles the subscription_payment_failed lemonsqueezy event
+func (service *LemonsqueezyService) HandleSubscriptionPaymentFailedEvent(ctx context.Context, source string, request *LemonsqueezySubscriptionInvoiceRequest) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	subscriptionID := strconv.Itoa(request.Data.Attributes.SubscriptionID)
+	user, err := service.userRepository.LoadBySubscriptionID(ctx, subscriptionID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user with subscription ID [%s]", subscriptionID)
+		return stacktrace.Propagate(err, msg)
+	}
+
+	payload := &events.UserSubscriptionPaymentFailedPayload{
+		UserID:                user.ID,
+		SubscriptionID:        subscriptionID,
+		InvoiceID:             request.Data.ID,
+		InvoiceStatus:         request.Data.Attributes.Status,
+		InvoiceTotal:          request.Data.Attributes.Total,
+		InvoiceCurrency:       request.Data.Attributes.Currency,
+		InvoiceBillingReason:  request.Data.Attributes.BillingReason,
+		SubscriptionPaymentAt: request.Data.Attributes.UpdatedAt,
+	}
+
+	key := service.eventKey("subscription_payment_failed", request.Data.ID)
+	if err = service.dispatchEvent(ctx, key, events.UserSubscriptionPaymentFailed, source, user.ID, payload); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for failed payment of subscription [%s]", payload.SubscriptionID))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("payment [%s] failed for subscription [%s] of user [%s]", payload.InvoiceID, payload.SubscriptionID, payload.UserID))
+	return nil
+}
+
+// HandleSubscriptionPaymentSuccessEvent handles the subscription_payment_success lemonsqueezy event
+func (service *LemonsqueezyService) HandleSubscriptionPaymentSuccessEvent(ctx context.Context, source string, request *LemonsqueezySubscriptionInvoiceRequest) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	subscriptionID := strconv.Itoa(request.Data.Attributes.SubscriptionID)
+	user, err := service.userRepository.LoadBySubscriptionID(ctx, subscriptionID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user with subscription ID [%s]", subscriptionID)
+		return stacktrace.Propagate(err, msg)
+	}
+
+	payload := &events.UserSubscriptionPaymentSucceededPayload{
+		UserID:                user.ID,
+		SubscriptionID:        subscriptionID,
+		InvoiceID:             request.Data.ID,
+		InvoiceStatus:         request.Data.Attributes.Status,
+		InvoiceTotal:          request.Data.Attributes.Total,
+		InvoiceCurrency:       request.Data.Attributes.Currency,
+		InvoiceBillingReason:  request.Data.Attributes.BillingReason,
+		SubscriptionPaymentAt: request.Data.Attributes.UpdatedAt,
+	}
+
+	key := service.eventKey("subscription_payment_success", request.Data.ID)
+	if err = service.dispatchEvent(ctx, key, events.UserSubscriptionPaymentSucceeded, source, user.ID, payload); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for successful payment of subscription [%s]", payload.SubscriptionID))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("payment [%s] succeeded for subscription [%s] of user [%s]", payload.InvoiceID, payload.SubscriptionID, payload.UserID))
+	return nil
+}
+
+func (service *LemonsqueezyService) dispatchEvent(ctx context.Context, key string, eventType string, source string, userID entities.UserID, payload any) error {
+	event, err := service.createIdempotentEvent(key, eventType, source, payload)
+	if err != nil {
+		msg := fmt.Sprintf("cannot created [%s] event for user [%s]", eventType, userID)
 		return stacktrace.Propagate(err, msg)
 	}
 
 	if err = service.eventDispatcher.Dispatch(ctx, event); err != nil {
-		msg := fmt.Sprintf("cannot dispatch [%s] event for user [%s]", events.UserSubscriptionCancelled, payload.UserID)
+		msg := fmt.Sprintf("cannot dispatch [%s] event for user [%s]", eventType, userID)
 		return stacktrace.Propagate(err, msg)
 	}
-	ctxLogger.Info(fmt.Sprintf("[%s] subscription [%s] cancelled for user [%s]", payload.SubscriptionName, payload.SubscriptionID, payload.UserID))
 	return nil
 }
 
+// eventKey identifies a lemonsqueezy webhook so that retried deliveries are dispatched with the same event ID
+func (service *LemonsqueezyService) eventKey(eventName string, parts ...string) string {
+	return "lemonsqueezy:" + eventName + ":" + strings.Join(parts, ":")
+}
+
 func (service *LemonsqueezyService) subscriptionName(variant string) entities.SubscriptionName {
 	if strings.Contains(strings.ToLower(variant), "monthly") {
 		return entities.SubscriptionNameProMonthly
@@ -9,8 +9,8 @@ import (
 
 	"github.com/sendgrid/sendgrid-go"
 
-	"firebase.google.com/go/auth"
 	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/palantir/stacktrace"
@@ -20,7 +20,7 @@ import (
 type MarketingService struct {
 	logger         telemetry.Logger
 	tracer         telemetry.Tracer
-	authClient     *auth.Client
+	userRepository repositories.UserRepository
 	sendgridAPIKey string
 	sendgridListID string
 }
@@ -40,14 +40,14 @@ type sendgridContactRequest struct {
 func NewMarketingService(
 	logger telemetry.Logger,
 	tracer telemetry.Tracer,
-	authClient *auth.Client,
+	userRepository repositories.UserRepository,
 	sendgridAPIKey string,
 	sendgridListID string,
 ) *MarketingService {
 	return &MarketingService{
 		logger:         logger.WithService(fmt.Sprintf("%T", &MarketingService{})),
 		tracer:         tracer,
-		authClient:     authClient,
+		userRepository: userRepository,
 		sendgridAPIKey: sendgridAPIKey,
 		sendgridListID: sendgridListID,
 	}
@@ -58,15 +58,15 @@ func (service *MarketingService) AddToList(ctx context.Context, userID entities.
 	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
 	defer span.End()
 
-	userRecord, err := service.authClient.GetUser(ctx, string(userID))
+	user, err := service.userRepository.Load(ctx, userID)
 	if err != nil {
-		msg := fmt.Sprintf("cannot get auth user with id [%s]", userID)
+		msg := fmt.Sprintf("cannot load user with id [%s]", userID)
 		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
 	id, err := service.addContact(sendgridContactRequest{
 		ListIDs:  []string{service.sendgridListID},
-		Contacts: []sendgridContact{service.toSendgridContact(userRecord)},
+		Contacts: []sendgridContact{service.toSendgridContact(user)},
 	})
 	if err != nil {
 		msg := fmt.Sprintf("cannot add user with id [%s] to list [%s]", userID, service.sendgridListID)
@@ -77,8 +77,8 @@ func (service *MarketingService) AddToList(ctx context.Context, userID entities.
 	return nil
 }
 
-func (service *MarketingService) toSendgridContact(user *auth.UserRecord) sendgridContact {
-	name := strings.TrimSpace(user.DisplayName)
+func (service *MarketingService) toSendgridContact(user *entities.User) sendgridContact {
+	name := strings.TrimSpace(user.Name)
 	if name == "" {
 		return sendgridContact{
 			FirstName: "",
@@ -3,19 +3,29 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/events"
 	"github.com/NdoleStudio/superbutton/pkg/repositories"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
 	"github.com/google/uuid"
 	"github.com/palantir/stacktrace"
 )
 
+// ErrCodeIntegrationOrderMismatch is returned when the integrations being reordered are not exactly the integrations of the project
+const ErrCodeIntegrationOrderMismatch = stacktrace.ErrorCode(4000)
+
+// ProjectIntegrationService manages the entities.ProjectIntegration of a project
 type ProjectIntegrationService struct {
-	tracer          telemetry.Tracer
-	logger          telemetry.Logger
-	eventDispatcher *EventDispatcher
-	repository      repositories.ProjectIntegrationRepository
+	service
+	tracer               telemetry.Tracer
+	logger               telemetry.Logger
+	eventDispatcher      *EventDispatcher
+	transactor           repositories.Transactor
+	authorizationService *AuthorizationService
+	repository           repositories.ProjectIntegrationRepository
+	registry             *IntegrationRegistry
 }
 
 // NewProjectIntegrationService creates a new ProjectIntegrationService
@@ -23,22 +33,33 @@ func NewProjectIntegrationService(
 	logger telemetry.Logger,
 	tracer telemetry.Tracer,
 	eventDispatcher *EventDispatcher,
+	transactor repositories.Transactor,
+	authorizationService *AuthorizationService,
 	repository repositories.ProjectIntegrationRepository,
+	registry *IntegrationRegistry,
 ) (s *ProjectIntegrationService) {
 	return &ProjectIntegrationService{
-		logger:          logger.WithService(fmt.Sprintf("%T", s)),
-		tracer:          tracer,
-		eventDispatcher: eventDispatcher,
-		repository:      repository,
+		logger:               logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:               tracer,
+		eventDispatcher:      eventDispatcher,
+		transactor:           transactor,
+		authorizationService: authorizationService,
+		repository:           repository,
+		registry:             registry,
 	}
 }
 
-// Index fetches all entities.ProjectIntegration for an authenticated user
+// Index fetches all entities.ProjectIntegration in a project which an authenticated user can view
 func (service *ProjectIntegrationService) Index(ctx context.Context, userID entities.UserID, projectID uuid.UUID) ([]*entities.ProjectIntegration, error) {
 	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
-	integrations, err := service.repository.Fetch(ctx, userID, projectID)
+	if _, err := service.authorizationService.AuthorizeProject(ctx, userID, projectID, entities.WorkspacePermissionView); err != nil {
+		msg := fmt.Sprintf("user ID [%s] cannot view project [%s]", userID, projectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	integrations, err := service.repository.Fetch(ctx, projectID)
 	if err != nil {
 		msg := fmt.Sprintf("could fetch project integrations for user with ID [%s] and project [%s]", userID, projectID)
 		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
@@ -47,15 +68,189 @@ func (service *ProjectIntegrationService) Index(ctx context.Context, userID enti
 	return integrations, nil
 }
 
-// Update updates the positions for entities.ProjectIntegration for an authenticated user
-func (service *ProjectIntegrationService) Update(ctx context.Context, userID entities.UserID, integrationIDs []uuid.UUID) error {
+// Update updates the positions of the entities.ProjectIntegration in a project of an authenticated user.
+// The integrationIDs must contain every integration of the project exactly once.
+func (service *ProjectIntegrationService) Update(ctx context.Context, source string, userID entities.UserID, projectID uuid.UUID, integrationIDs []uuid.UUID) error {
 	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
-	if err := service.repository.UpdatePositions(ctx, userID, integrationIDs); err != nil {
-		msg := fmt.Sprintf("could update project integrations for user with ID [%s] and project [%s]", userID, integrationIDs)
+	if _, err := service.authorizationService.AuthorizeProject(ctx, userID, projectID, entities.WorkspacePermissionEdit); err != nil {
+		msg := fmt.Sprintf("user ID [%s] cannot edit project [%s]", userID, projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	integrations, err := service.repository.Fetch(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch integrations of project [%s] for user with ID [%s]", projectID, userID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if !service.isSameIntegrationSet(integrations, integrationIDs) {
+		msg := fmt.Sprintf("the order [%+#v] does not contain exactly the [%d] integrations of project [%s]", integrationIDs, len(integrations), projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeIntegrationOrderMismatch, msg))
+	}
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.repository.UpdatePositions(ctx, projectID, integrationIDs); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("could update project integrations for user with ID [%s] and project [%s]", userID, projectID))
+		}
+		return service.dispatchIntegrationReorderedEvent(ctx, source, userID, projectID, integrationIDs)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot reorder the integrations of project [%s] for user with ID [%s]", projectID, userID)
 		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
 	return nil
 }
+
+// ProjectIntegrationDisplayRulesUpdateParams are the parameters for updating the entities.DisplayRules of an integration
+type ProjectIntegrationDisplayRulesUpdateParams struct {
+	Source        string
+	UserID        entities.UserID
+	ProjectID     uuid.UUID
+	IntegrationID uuid.UUID
+	DisplayRules  *entities.DisplayRules
+}
+
+// UpdateDisplayRules replaces the entities.DisplayRules of an entities.ProjectIntegration of an authenticated user
+func (service *ProjectIntegrationService) UpdateDisplayRules(ctx context.Context, params *ProjectIntegrationDisplayRulesUpdateParams) (*entities.ProjectIntegration, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeProject(ctx, params.UserID, params.ProjectID, entities.WorkspacePermissionEdit); err != nil {
+		msg := fmt.Sprintf("user ID [%s] cannot edit project [%s]", params.UserID, params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	integration, err := service.repository.Load(ctx, params.ProjectID, params.IntegrationID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load integration [%s] of project [%s] for user with ID [%s]", params.IntegrationID, params.ProjectID, params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	integration.DisplayRules = params.DisplayRules
+	integration.UpdatedAt = time.Now().UTC()
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.repository.Update(ctx, integration); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot update display rules of integration [%s] in project [%s]", integration.IntegrationID, integration.ProjectID))
+		}
+		return service.dispatchIntegrationDisplayRulesUpdatedEvent(ctx, params.Source, integration)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot update the display rules of integration [%s] in project [%s] for user with ID [%s]", params.IntegrationID, params.ProjectID, params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return integration, nil
+}
+
+func (service *ProjectIntegrationService) dispatchIntegrationDisplayRulesUpdatedEvent(ctx context.Context, source string, integration *entities.ProjectIntegration) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	event, err := service.createEvent(events.IntegrationDisplayRulesUpdated, source, &events.IntegrationDisplayRulesUpdatedPayload{
+		UserID:                integration.UserID,
+		ProjectID:             integration.ProjectID,
+		IntegrationID:         integration.IntegrationID,
+		IntegrationType:       integration.Type,
+		DisplayRules:          integration.DisplayRules,
+		DisplayRulesUpdatedAt: integration.UpdatedAt,
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot create [%s] event for integration [%s]", events.IntegrationDisplayRulesUpdated, integration.IntegrationID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err = service.eventDispatcher.Dispatch(ctx, event); err != nil {
+		msg := fmt.Sprintf("cannot dispatch [%s] event for integration [%s]", event.Type(), integration.IntegrationID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (service *ProjectIntegrationService) dispatchIntegrationReorderedEvent(ctx context.Context, source string, userID entities.UserID, projectID uuid.UUID, integrationIDs []uuid.UUID) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	event, err := service.createEvent(events.IntegrationReordered, source, &events.IntegrationReorderedPayload{
+		UserID:                 userID,
+		ProjectID:              projectID,
+		IntegrationIDs:         integrationIDs,
+		IntegrationReorderedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot create [%s] event for project [%s]", events.IntegrationReordered, projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err = service.eventDispatcher.Dispatch(ctx, event); err != nil {
+		msg := fmt.Sprintf("cannot dispatch [%s] event for project [%s]", event.Type(), projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (service *ProjectIntegrationService) isSameIntegrationSet(integrations []*entities.ProjectIntegration, integrationIDs []uuid.UUID) bool {
+	if len(integrations) != len(integrationIDs) {
+		return false
+	}
+
+	remaining := make(map[uuid.UUID]bool, len(integrations))
+	for _, integration := range integrations {
+		remaining[integration.IntegrationID] = true
+	}
+
+	for _, integrationID := range integrationIDs {
+		if !remaining[integrationID] {
+			return false
+		}
+		delete(remaining, integrationID)
+	}
+
+	return len(remaining) == 0
+}
+
+// ProjectIntegrationsDeleteParams are the parameters for deleting all the integrations of a project
+type ProjectIntegrationsDeleteParams struct {
+	Source    string
+	ProjectID uuid.UUID
+}
+
+// ProjectIntegrationsDeleteResult is the number of rows which were cleaned up when deleting the integrations of a project
+type ProjectIntegrationsDeleteResult struct {
+	Integrations        map[entities.IntegrationType]int
+	ProjectIntegrations int64
+}
+
+// DeleteAll removes every integration of a deleted project together with the entities.ProjectIntegration ordering rows.
+// It is safe to retry because integrations which were already deleted are no longer fetched.
+func (service *ProjectIntegrationService) DeleteAll(ctx context.Context, params *ProjectIntegrationsDeleteParams) (*ProjectIntegrationsDeleteResult, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	result := &ProjectIntegrationsDeleteResult{Integrations: map[entities.IntegrationType]int{}}
+	for _, integration := range service.registry.All() {
+		count, err := integration.DeleteAll(ctx, &IntegrationDeleteAllParams{
+			Source:    params.Source,
+			ProjectID: params.ProjectID,
+		})
+		if err != nil {
+			msg := fmt.Sprintf("cannot delete [%s] integrations for project [%s]", integration.Type(), params.ProjectID)
+			return result, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+		result.Integrations[integration.Type()] = count
+	}
+
+	count, err := service.repository.DeleteAll(ctx, params.ProjectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete project integrations for project [%s]", params.ProjectID)
+		return result, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+	result.ProjectIntegrations = count
+
+	return result, nil
+}
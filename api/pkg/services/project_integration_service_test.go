@@ -0,0 +1,42 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/google/uuid"
+)
+
+func TestProjectIntegrationService_IsSameIntegrationSet(t *testing.T) {
+	service := &ProjectIntegrationService{}
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	integrations := []*entities.ProjectIntegration{{IntegrationID: first}, {IntegrationID: second}, {IntegrationID: third}}
+
+	tests := []struct {
+		name           string
+		integrationIDs []uuid.UUID
+		want           bool
+	}{
+		{name: "same order", integrationIDs: []uuid.UUID{first, second, third}, want: true},
+		{name: "new order", integrationIDs: []uuid.UUID{third, first, second}, want: true},
+		{name: "missing integration", integrationIDs: []uuid.UUID{first, second}, want: false},
+		{name: "extra integration", integrationIDs: []uuid.UUID{first, second, third, uuid.New()}, want: false},
+		{name: "unknown integration", integrationIDs: []uuid.UUID{first, second, uuid.New()}, want: false},
+		{name: "duplicate integration", integrationIDs: []uuid.UUID{first, first, second}, want: false},
+		{name: "no integrations", integrationIDs: []uuid.UUID{}, want: false},
+	}
+
+	for _, test := range tests {
+		if got := service.isSameIntegrationSet(integrations, test.integrationIDs); got != test.want {
+			t.Errorf("isSameIntegrationSet with %s = [%t], want [%t]", test.name, got, test.want)
+		}
+	}
+}
+
+func TestProjectIntegrationService_IsSameIntegrationSetWithoutIntegrations(t *testing.T) {
+	service := &ProjectIntegrationService{}
+
+	if !service.isSameIntegrationSet(nil, []uuid.UUID{}) {
+		t.Error("an empty order does not match a project without integrations")
+	}
+}
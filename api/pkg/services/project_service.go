@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -18,10 +20,15 @@ import (
 // ProjectService is responsible for managing entities.Project
 type ProjectService struct {
 	service
-	logger          telemetry.Logger
-	tracer          telemetry.Tracer
-	repository      repositories.ProjectRepository
-	eventDispatcher *EventDispatcher
+	logger               telemetry.Logger
+	tracer               telemetry.Tracer
+	transactor           repositories.Transactor
+	repository           repositories.ProjectRepository
+	eventDispatcher      *EventDispatcher
+	entitlementService   *EntitlementService
+	workspaceService     *WorkspaceService
+	authorizationService *AuthorizationService
+	settingsService      *ProjectSettingsService
 }
 
 // NewProjectService creates a new ProjectService
@@ -29,22 +36,43 @@ func NewProjectService(
 	logger telemetry.Logger,
 	tracer telemetry.Tracer,
 	eventDispatcher *EventDispatcher,
+	transactor repositories.Transactor,
 	repository repositories.ProjectRepository,
+	entitlementService *EntitlementService,
+	workspaceService *WorkspaceService,
+	authorizationService *AuthorizationService,
+	settingsService *ProjectSettingsService,
 ) (s *ProjectService) {
 	return &ProjectService{
-		logger:          logger.WithService(fmt.Sprintf("%T", s)),
-		tracer:          tracer,
-		eventDispatcher: eventDispatcher,
-		repository:      repository,
+		logger:               logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:               tracer,
+		eventDispatcher:      eventDispatcher,
+		transactor:           transactor,
+		repository:           repository,
+		entitlementService:   entitlementService,
+		workspaceService:     workspaceService,
+		authorizationService: authorizationService,
+		settingsService:      settingsService,
 	}
 }
 
-// Index fetches all entities.Project for an authenticated user
-func (service *ProjectService) Index(ctx context.Context, userID entities.UserID) ([]*entities.Project, error) {
+// Index fetches all entities.Project in the workspaces of an authenticated user
+func (service *ProjectService) Index(ctx context.Context, source string, userID entities.UserID) ([]*entities.Project, error) {
 	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
-	projects, err := service.repository.Fetch(ctx, userID)
+	workspaces, err := service.workspaceService.Index(ctx, source, userID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch workspaces for user with ID [%s]", userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	workspaceIDs := make([]uuid.UUID, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		workspaceIDs = append(workspaceIDs, workspace.ID)
+	}
+
+	projects, err := service.repository.Fetch(ctx, workspaceIDs)
 	if err != nil {
 		msg := fmt.Sprintf("could fetch projects for user with ID [%s]", userID)
 		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
@@ -53,12 +81,86 @@ func (service *ProjectService) Index(ctx context.Context, userID entities.UserID
 	return projects, nil
 }
 
+// RotatePublishableKey replaces the publishable key of an entities.Project. The previous key stops working immediately.
+func (service *ProjectService) RotatePublishableKey(ctx context.Context, source string, userID entities.UserID, projectID uuid.UUID) (*entities.Project, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	key, err := NewPublishableKey()
+	if err != nil {
+		msg := fmt.Sprintf("cannot generate publishable key for project [%s]", projectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	project, err := service.updatePublishableKey(ctx, source, userID, projectID, &key)
+	if err != nil {
+		msg := fmt.Sprintf("cannot rotate publishable key of project [%s] for user with ID [%s]", projectID, userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return project, nil
+}
+
+// RevokePublishableKey removes the publishable key of an entities.Project so that the widget can no longer load its settings
+func (service *ProjectService) RevokePublishableKey(ctx context.Context, source string, userID entities.UserID, projectID uuid.UUID) (*entities.Project, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	project, err := service.updatePublishableKey(ctx, source, userID, projectID, nil)
+	if err != nil {
+		msg := fmt.Sprintf("cannot revoke publishable key of project [%s] for user with ID [%s]", projectID, userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return project, nil
+}
+
+func (service *ProjectService) updatePublishableKey(ctx context.Context, source string, userID entities.UserID, projectID uuid.UUID, key *string) (*entities.Project, error) {
+	project, err := service.authorizationService.AuthorizeProject(ctx, userID, projectID, entities.WorkspacePermissionManage)
+	if err != nil {
+		msg := fmt.Sprintf("user ID [%s] cannot manage the keys of project [%s]", userID, projectID)
+		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
+	}
+
+	project.PublishableKey = key
+	project.UpdatedAt = time.Now().UTC()
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.repository.Update(ctx, project); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("could update project [%s] for user with ID [%s]", project.ID, project.UserID))
+		}
+
+		// the settings snapshot is refreshed with the project so it is never served with the previous key
+		if err = service.settingsService.RefreshSnapshot(ctx, project.ID); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot refresh settings snapshot of project [%s]", project.ID))
+		}
+
+		return service.dispatchProjectUpdatedEvent(ctx, source, project)
+	})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("could not update publishable key of project [%s]", project.ID))
+	}
+
+	return project, nil
+}
+
+// NewPublishableKey generates a random publishable key for an entities.Project
+func NewPublishableKey() (string, error) {
+	buffer := make([]byte, 24)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", stacktrace.Propagate(err, "cannot read random bytes for publishable key")
+	}
+	return "pk_" + hex.EncodeToString(buffer), nil
+}
+
 // ProjectCreateParams are the parameters for creating a new project.
+// The project is created in the personal workspace of the user when the WorkspaceID is uuid.Nil
 type ProjectCreateParams struct {
-	Name   string
-	Source string
-	URL    string
-	UserID entities.UserID
+	Name        string
+	Source      string
+	URL         string
+	UserID      entities.UserID
+	WorkspaceID uuid.UUID
 }
 
 // Create a new entities.Project
@@ -66,9 +168,23 @@ func (service *ProjectService) Create(ctx context.Context, params *ProjectCreate
 	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
+	workspace, err := service.loadWorkspace(ctx, params)
+	if err != nil {
+		msg := fmt.Sprintf("user with ID [%s] cannot create a project in workspace [%s]", params.UserID, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	publishableKey, err := NewPublishableKey()
+	if err != nil {
+		msg := fmt.Sprintf("cannot generate publishable key for a project of user with ID [%s]", params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
 	project := &entities.Project{
 		ID:                     uuid.New(),
+		PublishableKey:         &publishableKey,
 		UserID:                 params.UserID,
+		WorkspaceID:            workspace.ID,
 		URL:                    params.URL,
 		CreatedAt:              time.Now().UTC(),
 		UpdatedAt:              time.Now().UTC(),
@@ -79,17 +195,30 @@ func (service *ProjectService) Create(ctx context.Context, params *ProjectCreate
 		Color:                  "#283593",
 	}
 
-	err := service.repository.Store(ctx, project)
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := service.entitlementService.CanCreateProject(ctx, workspace); err != nil {
+			return stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), fmt.Sprintf("user with ID [%s] cannot create a new project", params.UserID))
+		}
+		if err := service.repository.Store(ctx, project); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("could store project for user with ID [%s]", params.UserID))
+		}
+		return service.dispatchProjectCreatedEvent(ctx, params.Source, project)
+	})
 	if err != nil {
-		msg := fmt.Sprintf("could store project for user with ID [%s]", params.UserID)
+		msg := fmt.Sprintf("could not create project for user with ID [%s]", params.UserID)
 		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	service.dispatchProjectCreatedEvent(ctx, params.Source, project)
-
 	return project, nil
 }
 
+func (service *ProjectService) loadWorkspace(ctx context.Context, params *ProjectCreateParams) (*entities.Workspace, error) {
+	if params.WorkspaceID == uuid.Nil {
+		return service.workspaceService.Personal(ctx, params.Source, params.UserID)
+	}
+	return service.workspaceService.Load(ctx, params.UserID, params.WorkspaceID, entities.WorkspacePermissionManage)
+}
+
 // ProjectUpdateParams are the parameters for updating a project.
 type ProjectUpdateParams struct {
 	UserID                 entities.UserID
@@ -101,6 +230,8 @@ type ProjectUpdateParams struct {
 	Source                 string
 	GreetingTimeoutSeconds uint
 	Color                  string
+	AllowedOrigins         []string
+	Timezone               string
 }
 
 // Update an entities.Project
@@ -108,9 +239,9 @@ func (service *ProjectService) Update(ctx context.Context, params *ProjectUpdate
 	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
-	project, err := service.repository.Load(ctx, params.UserID, params.ProjectID)
+	project, err := service.authorizationService.AuthorizeProject(ctx, params.UserID, params.ProjectID, entities.WorkspacePermissionEdit)
 	if err != nil {
-		msg := fmt.Sprintf("cannot load project for user ID [%s] and project [%s]", params.UserID, params.ProjectID)
+		msg := fmt.Sprintf("user ID [%s] cannot edit project [%s]", params.UserID, params.ProjectID)
 		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
 	}
 
@@ -121,14 +252,20 @@ func (service *ProjectService) Update(ctx context.Context, params *ProjectUpdate
 	project.Icon = params.Icon
 	project.GreetingTimeoutSeconds = params.GreetingTimeoutSeconds
 	project.Greeting = params.Greeting
-
-	if err = service.repository.Update(ctx, project); err != nil {
-		msg := fmt.Sprintf("could update project [%s] for user with ID [%s]", project.ID, project.UserID)
+	project.AllowedOrigins = params.AllowedOrigins
+	project.Timezone = params.Timezone
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.repository.Update(ctx, project); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("could update project [%s] for user with ID [%s]", project.ID, project.UserID))
+		}
+		return service.dispatchProjectUpdatedEvent(ctx, params.Source, project)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("could not update project [%s] for user with ID [%s]", project.ID, project.UserID)
 		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	service.dispatchProjectUpdatedEvent(ctx, params.Source, project)
-
 	return project, nil
 }
 
@@ -137,23 +274,28 @@ func (service *ProjectService) Delete(ctx context.Context, source string, userID
 	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
-	_, err := service.repository.Load(ctx, userID, projectID)
+	project, err := service.authorizationService.AuthorizeProject(ctx, userID, projectID, entities.WorkspacePermissionManage)
 	if err != nil {
-		msg := fmt.Sprintf("cannot load project [%s] for user ID [%s]", projectID, userID)
+		msg := fmt.Sprintf("user ID [%s] cannot delete project [%s]", userID, projectID)
 		return stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
 	}
 
-	if err = service.repository.Delete(ctx, userID, projectID); err != nil {
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.repository.Delete(ctx, projectID); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot delete project [%s] for user ID [%s]", projectID, userID))
+		}
+		return service.dispatchProjectDeletedEvent(ctx, source, project.UserID, projectID)
+	})
+	if err != nil {
 		msg := fmt.Sprintf("cannot delete project [%s] for user ID [%s]", projectID, userID)
-		return stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
 	}
 
-	service.dispatchProjectDeletedEvent(ctx, source, userID, projectID)
 	return nil
 }
 
-func (service *ProjectService) dispatchProjectUpdatedEvent(ctx context.Context, source string, project *entities.Project) {
-	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+func (service *ProjectService) dispatchProjectUpdatedEvent(ctx context.Context, source string, project *entities.Project) error {
+	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
 	event, err := service.createEvent(events.ProjectUpdated, source, &events.ProjectUpdatedPayload{
@@ -169,15 +311,14 @@ func (service *ProjectService) dispatchProjectUpdatedEvent(ctx context.Context,
 	})
 	if err != nil {
 		msg := fmt.Sprintf("cannot create [%s] event for project [%s]", events.ProjectUpdated, project.ID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	service.dispatchEvent(ctx, project.ID, event)
+	return service.dispatchEvent(ctx, project.ID, event)
 }
 
-func (service *ProjectService) dispatchProjectCreatedEvent(ctx context.Context, source string, project *entities.Project) {
-	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+func (service *ProjectService) dispatchProjectCreatedEvent(ctx context.Context, source string, project *entities.Project) error {
+	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
 	event, err := service.createEvent(events.ProjectCreated, source, &events.ProjectCreatedPayload{
@@ -189,15 +330,14 @@ func (service *ProjectService) dispatchProjectCreatedEvent(ctx context.Context,
 	})
 	if err != nil {
 		msg := fmt.Sprintf("cannot create [%s] event for project [%s]", events.ProjectCreated, project.ID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	service.dispatchEvent(ctx, project.ID, event)
+	return service.dispatchEvent(ctx, project.ID, event)
 }
 
-func (service *ProjectService) dispatchProjectDeletedEvent(ctx context.Context, source string, userID entities.UserID, projectID uuid.UUID) {
-	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+func (service *ProjectService) dispatchProjectDeletedEvent(ctx context.Context, source string, userID entities.UserID, projectID uuid.UUID) error {
+	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
 	event, err := service.createEvent(events.ProjectDeleted, source, &events.ProjectDeletedPayload{
@@ -207,20 +347,15 @@ func (service *ProjectService) dispatchProjectDeletedEvent(ctx context.Context,
 	})
 	if err != nil {
 		msg := fmt.Sprintf("cannot create [%s] event for project [%s]", events.ProjectDeleted, projectID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	service.dispatchEvent(ctx, projectID, event)
+	return service.dispatchEvent(ctx, projectID, event)
 }
 
-func (service *ProjectService) dispatchEvent(ctx context.Context, projectID uuid.UUID, event *cloudevents.Event) {
-	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
-	defer span.End()
-
+func (service *ProjectService) dispatchEvent(ctx context.Context, projectID uuid.UUID, event *cloudevents.Event) error {
 	if err := service.eventDispatcher.Dispatch(ctx, event); err != nil {
-		msg := fmt.Sprintf("cannot dispatch [%s] event for project [%s]", event.Type(), projectID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch [%s] event for project [%s]", event.Type(), projectID))
 	}
+	return nil
 }
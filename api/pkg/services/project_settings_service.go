@@ -2,9 +2,14 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/NdoleStudio/superbutton/pkg/cache"
 	"github.com/NdoleStudio/superbutton/pkg/entities"
 	"github.com/NdoleStudio/superbutton/pkg/repositories"
 	"github.com/NdoleStudio/superbutton/pkg/telemetry"
@@ -14,15 +19,24 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// ErrCodeOriginNotAllowed is returned when the public settings of a project are requested from an origin which is not allowed
+const ErrCodeOriginNotAllowed = stacktrace.ErrorCode(5000)
+
+// maxBlockedOriginLength is the maximum length of an origin which is recorded as an entities.ProjectBlockedOrigin
+const maxBlockedOriginLength = 255
+
+// ProjectSettingsService is responsible for building the entities.ProjectSettings of a project
 type ProjectSettingsService struct {
 	tracer                         telemetry.Tracer
 	logger                         telemetry.Logger
 	projectRepository              repositories.ProjectRepository
-	whatsappIntegrationRepository  repositories.WhatsappIntegrationRepository
-	contentIntegrationRepository   repositories.ContentIntegrationRepository
 	projectIntegrationRepository   repositories.ProjectIntegrationRepository
-	phoneCallIntegrationRepository repositories.PhoneCallIntegrationRepository
-	linkIntegrationRepository      repositories.LinkIntegrationRepository
+	projectBlockedOriginRepository repositories.ProjectBlockedOriginRepository
+	snapshotRepository             repositories.ProjectSettingsSnapshotRepository
+	authorizationService           *AuthorizationService
+	registry                       *IntegrationRegistry
+	cache                          cache.Cache
+	cacheTTL                       time.Duration
 }
 
 // NewProjectSettingsService creates a new ProjectSettingsService
@@ -30,57 +44,607 @@ func NewProjectSettingsService(
 	logger telemetry.Logger,
 	tracer telemetry.Tracer,
 	projectRepository repositories.ProjectRepository,
-	whatsappIntegrationRepository repositories.WhatsappIntegrationRepository,
-	contentIntegrationRepository repositories.ContentIntegrationRepository,
 	projectIntegrationRepository repositories.ProjectIntegrationRepository,
-	phoneCallIntegrationRepository repositories.PhoneCallIntegrationRepository,
-	linkIntegrationRepository repositories.LinkIntegrationRepository,
+	projectBlockedOriginRepository repositories.ProjectBlockedOriginRepository,
+	snapshotRepository repositories.ProjectSettingsSnapshotRepository,
+	authorizationService *AuthorizationService,
+	registry *IntegrationRegistry,
+	cache cache.Cache,
+	cacheTTL time.Duration,
 ) (s *ProjectSettingsService) {
 	return &ProjectSettingsService{
 		logger:                         logger.WithService(fmt.Sprintf("%T", s)),
 		tracer:                         tracer,
 		projectRepository:              projectRepository,
-		whatsappIntegrationRepository:  whatsappIntegrationRepository,
-		contentIntegrationRepository:   contentIntegrationRepository,
 		projectIntegrationRepository:   projectIntegrationRepository,
-		phoneCallIntegrationRepository: phoneCallIntegrationRepository,
-		linkIntegrationRepository:      linkIntegrationRepository,
+		projectBlockedOriginRepository: projectBlockedOriginRepository,
+		snapshotRepository:             snapshotRepository,
+		authorizationService:           authorizationService,
+		registry:                       registry,
+		cache:                          cache,
+		cacheTTL:                       cacheTTL,
 	}
 }
 
-// Get returns the entities.ProjectSettings an entities.Project
+// Get returns the entities.ProjectSettings an entities.Project which an authenticated user can view
 func (service *ProjectSettingsService) Get(ctx context.Context, userID entities.UserID, projectID uuid.UUID) (*entities.ProjectSettings, error) {
-	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
-	project, err := service.projectRepository.Load(ctx, userID, projectID)
+	project, err := service.authorizationService.AuthorizeProject(ctx, userID, projectID, entities.WorkspacePermissionView)
 	if err != nil {
-		msg := fmt.Sprintf("cannot load project [%s] for user ID [%s]", projectID, userID)
+		msg := fmt.Sprintf("user ID [%s] cannot view project [%s]", userID, projectID)
 		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
 	}
 
-	integrations, err := service.projectIntegrationRepository.Fetch(ctx, userID, projectID)
+	return service.get(ctx, project)
+}
+
+func (service *ProjectSettingsService) get(ctx context.Context, project *entities.Project) (*entities.ProjectSettings, error) {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	integrations, err := service.projectIntegrationRepository.Fetch(ctx, project.ID)
 	if err != nil {
-		msg := fmt.Sprintf("cannot load project integrations [%s] for user ID [%s]", projectID, userID)
+		msg := fmt.Sprintf("cannot load project integrations [%s]", project.ID)
 		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
 	}
 
-	ctxLogger.Info(fmt.Sprintf("found [%d] integrations for project [%s] and user [%s]", len(integrations), projectID, userID))
+	ctxLogger.Info(fmt.Sprintf("found [%d] integrations for project [%s]", len(integrations), project.ID))
 	if len(integrations) == 0 {
 		return service.createWithoutIntegrations(project), nil
 	}
 
-	settings, err := service.fetchInParallel(ctx, userID, service.groupByType(integrations))
+	settings, err := service.fetchInParallel(ctx, project.ID, service.groupByType(integrations))
 	if err != nil {
-		msg := fmt.Sprintf("cannot fetch integrations for project [%s] and user [%s]", projectID, userID)
+		msg := fmt.Sprintf("cannot fetch integrations for project [%s]", project.ID)
 		return nil, stacktrace.Propagate(err, msg)
 	}
 
 	return service.creatSortedIntegrations(project, settings, integrations), nil
 }
 
-func (service *ProjectSettingsService) fetchInParallel(ctx context.Context, userID entities.UserID, integrationGroups map[entities.IntegrationType][]uuid.UUID) (map[uuid.UUID]*entities.ProjectSettingsIntegration, error) {
-	ctx, span, _ := service.tracer.StartWithLogger(ctx, service.logger)
+// GetPublic returns the entities.ProjectSettingsSnapshot of an entities.Project which only contains the enabled integrations.
+// The integrations which are outside their entities.AvailabilitySchedule are marked as offline or removed and
+// the integrations whose entities.DisplayRules do not match the page are removed when the page is not nil.
+// It returns an error with code ErrCodeOriginNotAllowed if the origin is not allowed to embed the widget of the project.
+func (service *ProjectSettingsService) GetPublic(ctx context.Context, userID entities.UserID, projectID uuid.UUID, origin string, page *entities.PageContext) (*entities.ProjectSettingsSnapshot, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	snapshot := service.getCached(ctx, service.projectCacheKey(projectID))
+	if snapshot == nil {
+		var err error
+		if snapshot, err = service.loadSnapshot(ctx, projectID); err != nil {
+			msg := fmt.Sprintf("cannot load settings snapshot of project [%s] for user [%s]", projectID, userID)
+			return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+		}
+	}
+
+	if snapshot.UserID != userID {
+		msg := fmt.Sprintf("project with ID [%s] does not exist for user [%s]", projectID, userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(repositories.ErrCodeNotFound, msg))
+	}
+
+	if err := service.authorizeOrigin(ctx, snapshot.Project(), origin); err != nil {
+		msg := fmt.Sprintf("cannot get public settings for project [%s] from origin [%s]", projectID, origin)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return service.applyRules(ctx, snapshot, time.Now(), page)
+}
+
+// GetPublicByKey returns the entities.ProjectSettingsSnapshot of the entities.Project with a publishable key.
+// The key is checked against the project on every request so a rotated or revoked key stops working immediately
+// on every instance, even when the snapshot of the project is still cached.
+func (service *ProjectSettingsService) GetPublicByKey(ctx context.Context, publishableKey string, origin string, page *entities.PageContext) (*entities.ProjectSettingsSnapshot, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	project, err := service.projectRepository.LoadByPublishableKey(ctx, publishableKey)
+	if err != nil {
+		msg := "cannot load project by publishable key"
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	snapshot := service.getCached(ctx, service.projectCacheKey(project.ID))
+	if snapshot == nil || !service.hasPublishableKey(snapshot, publishableKey) {
+		if snapshot, err = service.loadSnapshotWithKey(ctx, project, publishableKey); err != nil {
+			msg := fmt.Sprintf("cannot load settings snapshot of project [%s] by publishable key", project.ID)
+			return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+		}
+	}
+
+	if err = service.authorizeOrigin(ctx, snapshot.Project(), origin); err != nil {
+		msg := fmt.Sprintf("cannot get public settings for project [%s] from origin [%s]", snapshot.ProjectID, origin)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return service.applyRules(ctx, snapshot, time.Now(), page)
+}
+
+// RefreshSnapshot renders the entities.ProjectSettingsSnapshot of a project from the live data and replaces the cached copy.
+// The snapshot is deleted when the project no longer exists.
+func (service *ProjectSettingsService) RefreshSnapshot(ctx context.Context, projectID uuid.UUID) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	project, err := service.projectRepository.Load(ctx, projectID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		return service.deleteSnapshot(ctx, projectID)
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load project [%s]", projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if _, err = service.storeSnapshot(ctx, project); err != nil {
+		msg := fmt.Sprintf("cannot refresh settings snapshot of project [%s]", projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// ProjectSettingsSnapshotRebuildParams are the parameters for rebuilding the entities.ProjectSettingsSnapshot of every project
+type ProjectSettingsSnapshotRebuildParams struct {
+	DryRun bool
+}
+
+// ProjectSettingsSnapshotRebuildResult reports the snapshots which did not match the live data of their project
+type ProjectSettingsSnapshotRebuildResult struct {
+	DryRun   bool        `json:"dry_run" example:"false"`
+	Projects int         `json:"projects" example:"120"`
+	Rebuilt  int         `json:"rebuilt" example:"3"`
+	Missing  []uuid.UUID `json:"missing"`
+	Drifted  []uuid.UUID `json:"drifted"`
+}
+
+// RebuildSnapshots renders the entities.ProjectSettingsSnapshot of every project and compares it with the stored snapshot.
+// Missing and drifted snapshots are replaced unless the params are a dry run.
+func (service *ProjectSettingsService) RebuildSnapshots(ctx context.Context, params *ProjectSettingsSnapshotRebuildParams) (*ProjectSettingsSnapshotRebuildResult, error) {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	result := &ProjectSettingsSnapshotRebuildResult{
+		DryRun:  params.DryRun,
+		Missing: []uuid.UUID{},
+		Drifted: []uuid.UUID{},
+	}
+
+	page := repositories.IndexParams{Limit: 100}
+	for {
+		projects, err := service.projectRepository.FetchAll(ctx, page)
+		if err != nil {
+			msg := fmt.Sprintf("cannot fetch projects with params [%+#v]", page)
+			return result, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+
+		for _, project := range projects {
+			if err = service.rebuildSnapshot(ctx, project, result); err != nil {
+				msg := fmt.Sprintf("cannot rebuild settings snapshot of project [%s]", project.ID)
+				return result, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+			}
+		}
+
+		if len(projects) < page.Limit {
+			break
+		}
+		page.Skip += page.Limit
+	}
+
+	ctxLogger.Info(fmt.Sprintf("checked [%d] settings snapshots with [%d] missing, [%d] drifted and [%d] rebuilt", result.Projects, len(result.Missing), len(result.Drifted), result.Rebuilt))
+	return result, nil
+}
+
+func (service *ProjectSettingsService) rebuildSnapshot(ctx context.Context, project *entities.Project, result *ProjectSettingsSnapshotRebuildResult) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	result.Projects++
+
+	live, err := service.renderSnapshot(ctx, project)
+	if err != nil {
+		msg := fmt.Sprintf("cannot render settings snapshot of project [%s]", project.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	stored, err := service.snapshotRepository.Load(ctx, project.ID)
+	switch {
+	case stacktrace.GetCode(err) == repositories.ErrCodeNotFound:
+		result.Missing = append(result.Missing, project.ID)
+	case err != nil:
+		msg := fmt.Sprintf("cannot load settings snapshot of project [%s]", project.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	case !service.isSameSnapshot(stored, live):
+		result.Drifted = append(result.Drifted, project.ID)
+	default:
+		return nil
+	}
+
+	if result.DryRun {
+		return nil
+	}
+
+	if _, err = service.storeSnapshot(ctx, project); err != nil {
+		msg := fmt.Sprintf("cannot store settings snapshot of project [%s]", project.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	result.Rebuilt++
+	return nil
+}
+
+// loadSnapshot loads the snapshot of a project and renders it when it is missing or was rendered with an older version
+func (service *ProjectSettingsService) loadSnapshot(ctx context.Context, projectID uuid.UUID) (*entities.ProjectSettingsSnapshot, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	snapshot, err := service.snapshotRepository.Load(ctx, projectID)
+	if err == nil && snapshot.IsCurrent() {
+		service.setCached(ctx, snapshot)
+		return snapshot, nil
+	}
+
+	if err != nil && stacktrace.GetCode(err) != repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot load settings snapshot of project [%s]", projectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	project, err := service.projectRepository.Load(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load project [%s]", projectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return service.storeSnapshot(ctx, project)
+}
+
+// loadSnapshotWithKey loads the snapshot of a project and renders it again when it is missing, was rendered
+// with an older version or was rendered before the publishable key of the project changed
+func (service *ProjectSettingsService) loadSnapshotWithKey(ctx context.Context, project *entities.Project, publishableKey string) (*entities.ProjectSettingsSnapshot, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	snapshot, err := service.snapshotRepository.Load(ctx, project.ID)
+	if err == nil && snapshot.IsCurrent() && service.hasPublishableKey(snapshot, publishableKey) {
+		service.setCached(ctx, snapshot)
+		return snapshot, nil
+	}
+
+	if err != nil && stacktrace.GetCode(err) != repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot load settings snapshot of project [%s]", project.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return service.storeSnapshot(ctx, project)
+}
+
+func (service *ProjectSettingsService) hasPublishableKey(snapshot *entities.ProjectSettingsSnapshot, publishableKey string) bool {
+	return snapshot.PublishableKey != nil && *snapshot.PublishableKey == publishableKey
+}
+
+// storeSnapshot renders the snapshot of a project, persists it and replaces the cached copy
+func (service *ProjectSettingsService) storeSnapshot(ctx context.Context, project *entities.Project) (*entities.ProjectSettingsSnapshot, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	snapshot, err := service.renderSnapshot(ctx, project)
+	if err != nil {
+		msg := fmt.Sprintf("cannot render settings snapshot of project [%s]", project.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err = service.snapshotRepository.Store(ctx, snapshot); err != nil {
+		msg := fmt.Sprintf("cannot store settings snapshot of project [%s]", project.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	service.setCached(ctx, snapshot)
+	return snapshot, nil
+}
+
+func (service *ProjectSettingsService) deleteSnapshot(ctx context.Context, projectID uuid.UUID) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if err := service.snapshotRepository.Delete(ctx, projectID); err != nil {
+		msg := fmt.Sprintf("cannot delete settings snapshot of project [%s]", projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err := service.cache.Delete(ctx, service.projectCacheKey(projectID)); err != nil {
+		msg := fmt.Sprintf("cannot delete the cached settings snapshot of project [%s]", projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// renderSnapshot builds the entities.PublicProjectSettings of a project from the live data
+func (service *ProjectSettingsService) renderSnapshot(ctx context.Context, project *entities.Project) (*entities.ProjectSettingsSnapshot, error) {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	settings, err := service.get(ctx, project)
+	if err != nil {
+		msg := fmt.Sprintf("cannot get settings for project [%s]", project.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	public := &entities.PublicProjectSettings{
+		Version:      entities.PublicProjectSettingsVersion,
+		Project:      settings.Project.Public(),
+		Integrations: make([]*entities.PublicProjectIntegration, 0, len(settings.Integrations)),
+	}
+
+	availability := map[uuid.UUID]*entities.IntegrationAvailability{}
+	displayRules := map[uuid.UUID]*entities.DisplayRules{}
+	for _, integration := range settings.Integrations {
+		entity, ok := integration.Settings.(entities.IntegrationEntity)
+		if !ok || !entity.Base().Enabled {
+			continue
+		}
+		public.Integrations = append(public.Integrations, &entities.PublicProjectIntegration{
+			ID:        integration.ID,
+			Type:      integration.Type,
+			Available: true,
+			Settings:  entity.PublicSettings(),
+		})
+
+		if scheduled, ok := entity.(entities.ScheduledIntegration); ok && scheduled.Availability().Schedule != nil {
+			availability[integration.ID] = scheduled.Availability()
+		}
+
+		if integration.DisplayRules != nil && !integration.DisplayRules.IsEmpty() {
+			if err = integration.DisplayRules.Compile(); err != nil {
+				msg := fmt.Sprintf("cannot compile the display rules of integration [%s] in project [%s]", integration.ID, project.ID)
+				ctxLogger.Warn(stacktrace.Propagate(err, msg))
+			}
+			displayRules[integration.ID] = integration.DisplayRules
+		}
+	}
+
+	payload, err := json.Marshal(public)
+	if err != nil {
+		msg := fmt.Sprintf("cannot marshal public settings of project [%s]", project.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return &entities.ProjectSettingsSnapshot{
+		ProjectID:      project.ID,
+		UserID:         project.UserID,
+		PublishableKey: project.PublishableKey,
+		URL:            project.URL,
+		AllowedOrigins: project.AllowedOrigins,
+		Timezone:       project.Timezone,
+		Availability:   availability,
+		DisplayRules:   displayRules,
+		Version:        entities.PublicProjectSettingsVersion,
+		ETag:           service.etag(payload),
+		Settings:       payload,
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}, nil
+}
+
+// isSameSnapshot checks if a stored snapshot serves the same settings to the same origins as the live snapshot
+func (service *ProjectSettingsService) isSameSnapshot(stored *entities.ProjectSettingsSnapshot, live *entities.ProjectSettingsSnapshot) bool {
+	if stored.ETag != live.ETag || stored.Version != live.Version || stored.UserID != live.UserID || stored.URL != live.URL || stored.Timezone != live.Timezone {
+		return false
+	}
+
+	storedAvailability, _ := json.Marshal(stored.Availability)
+	liveAvailability, _ := json.Marshal(live.Availability)
+	if string(storedAvailability) != string(liveAvailability) {
+		return false
+	}
+
+	storedDisplayRules, _ := json.Marshal(stored.DisplayRules)
+	liveDisplayRules, _ := json.Marshal(live.DisplayRules)
+	if string(storedDisplayRules) != string(liveDisplayRules) {
+		return false
+	}
+
+	if (stored.PublishableKey == nil) != (live.PublishableKey == nil) ||
+		(stored.PublishableKey != nil && *stored.PublishableKey != *live.PublishableKey) {
+		return false
+	}
+
+	if len(stored.AllowedOrigins) != len(live.AllowedOrigins) {
+		return false
+	}
+	for i := range stored.AllowedOrigins {
+		if stored.AllowedOrigins[i] != live.AllowedOrigins[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyRules returns a copy of the snapshot in which the integrations outside their entities.AvailabilitySchedule
+// are marked as offline or removed and the integrations whose entities.DisplayRules do not match the page are removed.
+// The ETag of the copy changes with the integrations so that caches revalidate it.
+func (service *ProjectSettingsService) applyRules(ctx context.Context, snapshot *entities.ProjectSettingsSnapshot, now time.Time, page *entities.PageContext) (*entities.ProjectSettingsSnapshot, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if len(snapshot.Availability) == 0 && (page == nil || len(snapshot.DisplayRules) == 0) {
+		return snapshot, nil
+	}
+
+	settings := new(entities.PublicProjectSettings)
+	if err := json.Unmarshal(snapshot.Settings, settings); err != nil {
+		msg := fmt.Sprintf("cannot unmarshal the settings snapshot of project [%s]", snapshot.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	now = now.In(snapshot.Project().Location())
+	integrations := make([]*entities.PublicProjectIntegration, 0, len(settings.Integrations))
+	for _, integration := range settings.Integrations {
+		if rules, ok := snapshot.DisplayRules[integration.ID]; ok && page != nil && !rules.Matches(page) {
+			continue
+		}
+
+		availability, ok := snapshot.Availability[integration.ID]
+		if !ok || availability.Schedule.IsAvailable(now) {
+			integrations = append(integrations, integration)
+			continue
+		}
+
+		if availability.Schedule.WhenOffline == entities.AvailabilityOfflineModeHide {
+			continue
+		}
+
+		integration.Available = false
+		integration.OfflineMessage = availability.OfflineMessage
+		integrations = append(integrations, integration)
+	}
+	settings.Integrations = integrations
+
+	payload, err := json.Marshal(settings)
+	if err != nil {
+		msg := fmt.Sprintf("cannot marshal the available settings of project [%s]", snapshot.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	result := *snapshot
+	result.Settings = payload
+	result.ETag = service.etag(payload)
+	return &result, nil
+}
+
+func (service *ProjectSettingsService) etag(payload []byte) string {
+	hash := sha256.Sum256(payload)
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(hash[:16]))
+}
+
+// getCached returns the cached snapshot at a key. The cache is best effort so errors are logged and treated as a miss.
+func (service *ProjectSettingsService) getCached(ctx context.Context, key string) *entities.ProjectSettingsSnapshot {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	value, ok, err := service.cache.Get(ctx, key)
+	if err != nil {
+		ctxLogger.Error(stacktrace.Propagate(err, fmt.Sprintf("cannot get cached settings snapshot with key [%s]", key)))
+		return nil
+	}
+
+	if !ok {
+		return nil
+	}
+
+	snapshot := new(entities.ProjectSettingsSnapshot)
+	if err = json.Unmarshal(value, snapshot); err != nil {
+		ctxLogger.Error(stacktrace.Propagate(err, fmt.Sprintf("cannot unmarshal cached settings snapshot with key [%s]", key)))
+		return nil
+	}
+
+	return snapshot
+}
+
+// setCached stores a snapshot in the cache. The cache is best effort so errors are only logged.
+func (service *ProjectSettingsService) setCached(ctx context.Context, snapshot *entities.ProjectSettingsSnapshot) {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	value, err := json.Marshal(snapshot)
+	if err != nil {
+		ctxLogger.Error(stacktrace.Propagate(err, fmt.Sprintf("cannot marshal settings snapshot of project [%s]", snapshot.ProjectID)))
+		return
+	}
+
+	if err = service.cache.Set(ctx, service.projectCacheKey(snapshot.ProjectID), value, service.cacheTTL); err != nil {
+		ctxLogger.Error(stacktrace.Propagate(err, fmt.Sprintf("cannot cache settings snapshot of project [%s]", snapshot.ProjectID)))
+	}
+}
+
+func (service *ProjectSettingsService) projectCacheKey(projectID uuid.UUID) string {
+	return fmt.Sprintf("project-settings:v%d:project:%s", entities.PublicProjectSettingsVersion, projectID)
+}
+
+// IndexBlockedOrigins returns the origins which were blocked from loading the public settings of a project
+func (service *ProjectSettingsService) IndexBlockedOrigins(ctx context.Context, userID entities.UserID, projectID uuid.UUID) ([]*entities.ProjectBlockedOrigin, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeProject(ctx, userID, projectID, entities.WorkspacePermissionView); err != nil {
+		msg := fmt.Sprintf("user ID [%s] cannot view project [%s]", userID, projectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	origins, err := service.projectBlockedOriginRepository.Fetch(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch blocked origins for project [%s] and user [%s]", projectID, userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return origins, nil
+}
+
+// DeleteBlockedOrigins deletes every entities.ProjectBlockedOrigin of a deleted project and returns the number of deleted origins
+func (service *ProjectSettingsService) DeleteBlockedOrigins(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	count, err := service.projectBlockedOriginRepository.DeleteAll(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete blocked origins of project [%s]", projectID)
+		return count, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return count, nil
+}
+
+// AuthorizeOrigin returns an error with code ErrCodeOriginNotAllowed if the origin is not allowed by the entities.Project.
+// It is used by the public endpoints which website visitors call without a publishable key.
+func (service *ProjectSettingsService) AuthorizeOrigin(ctx context.Context, projectID uuid.UUID, origin string) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	snapshot := service.getCached(ctx, service.projectCacheKey(projectID))
+	if snapshot == nil {
+		var err error
+		if snapshot, err = service.loadSnapshot(ctx, projectID); err != nil {
+			msg := fmt.Sprintf("cannot load settings snapshot of project [%s]", projectID)
+			return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+		}
+	}
+
+	if err := service.authorizeOrigin(ctx, snapshot.Project(), origin); err != nil {
+		msg := fmt.Sprintf("origin [%s] is not allowed for project [%s]", origin, projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return nil
+}
+
+// authorizeOrigin counts and rejects the requests from origins which are not allowed by the entities.Project
+func (service *ProjectSettingsService) authorizeOrigin(ctx context.Context, project *entities.Project, origin string) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	if project.IsOriginAllowed(origin) {
+		return nil
+	}
+
+	if len(origin) > maxBlockedOriginLength {
+		origin = origin[:maxBlockedOriginLength]
+	}
+
+	if err := service.projectBlockedOriginRepository.Increment(ctx, project, origin); err != nil {
+		msg := fmt.Sprintf("cannot record blocked origin [%s] for project [%s]", origin, project.ID)
+		ctxLogger.Error(stacktrace.Propagate(err, msg))
+	}
+
+	msg := fmt.Sprintf("origin [%s] is not allowed by project [%s] with origins [%+#v]", origin, project.ID, project.Origins())
+	return service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeOriginNotAllowed, msg))
+}
+
+func (service *ProjectSettingsService) fetchInParallel(ctx context.Context, projectID uuid.UUID, integrationGroups map[entities.IntegrationType][]uuid.UUID) (map[uuid.UUID]*entities.ProjectSettingsIntegration, error) {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
 	defer span.End()
 
 	lock := sync.RWMutex{}
@@ -89,80 +653,29 @@ func (service *ProjectSettingsService) fetchInParallel(ctx context.Context, user
 	for key, value := range integrationGroups {
 		ids := value
 		integrationType := key
-		switch key {
-		case entities.IntegrationTypeWhatsapp:
-			errGroup.Go(func() error {
-				integrations, err := service.whatsappIntegrationRepository.FetchMultiple(ctx, userID, ids)
-				if err != nil {
-					return stacktrace.Propagate(err, fmt.Sprintf("cannot fetch whatsapp integraions for userID [%s] and projects [%+#v]", userID, ids))
-				}
-				lock.Lock()
-				defer lock.Unlock()
-
-				for _, integration := range integrations {
-					integrationSettings[integration.ID] = &entities.ProjectSettingsIntegration{
-						Type:     integrationType,
-						ID:       integration.ID,
-						Settings: integration,
-					}
-				}
-				return nil
-			})
-		case entities.IntegrationTypeContent:
-			errGroup.Go(func() error {
-				integrations, err := service.contentIntegrationRepository.FetchMultiple(ctx, userID, ids)
-				if err != nil {
-					return stacktrace.Propagate(err, fmt.Sprintf("cannot fetch [%s] integraions for userID [%s] and projects [%+#v]", integrationType, userID, ids))
-				}
-				lock.Lock()
-				defer lock.Unlock()
-
-				for _, integration := range integrations {
-					integrationSettings[integration.ID] = &entities.ProjectSettingsIntegration{
-						Type:     integrationType,
-						ID:       integration.ID,
-						Settings: integration,
-					}
-				}
-				return nil
-			})
-		case entities.IntegrationTypePhoneCall:
-			errGroup.Go(func() error {
-				integrations, err := service.phoneCallIntegrationRepository.FetchMultiple(ctx, userID, ids)
-				if err != nil {
-					return stacktrace.Propagate(err, fmt.Sprintf("cannot fetch [%s] integraions for userID [%s] and projects [%+#v]", integrationType, userID, ids))
-				}
-				lock.Lock()
-				defer lock.Unlock()
-
-				for _, integration := range integrations {
-					integrationSettings[integration.ID] = &entities.ProjectSettingsIntegration{
-						Type:     integrationType,
-						ID:       integration.ID,
-						Settings: integration,
-					}
-				}
-				return nil
-			})
-		case entities.IntegrationTypeLink:
-			errGroup.Go(func() error {
-				integrations, err := service.linkIntegrationRepository.FetchMultiple(ctx, userID, ids)
-				if err != nil {
-					return stacktrace.Propagate(err, fmt.Sprintf("cannot fetch [%s] integraions for userID [%s] and projects [%+#v]", integrationType, userID, ids))
-				}
-				lock.Lock()
-				defer lock.Unlock()
-
-				for _, integration := range integrations {
-					integrationSettings[integration.ID] = &entities.ProjectSettingsIntegration{
-						Type:     integrationType,
-						ID:       integration.ID,
-						Settings: integration,
-					}
-				}
-				return nil
-			})
+		integration, ok := service.registry.Get(integrationType)
+		if !ok {
+			ctxLogger.Warn(stacktrace.NewError(fmt.Sprintf("integration type [%s] is not registered, skipping integrations [%+#v]", integrationType, ids)))
+			continue
 		}
+
+		errGroup.Go(func() error {
+			integrations, err := integration.FetchMultiple(ctx, projectID, ids)
+			if err != nil {
+				return stacktrace.Propagate(err, fmt.Sprintf("cannot fetch [%s] integraions for project [%s] and IDs [%+#v]", integrationType, projectID, ids))
+			}
+			lock.Lock()
+			defer lock.Unlock()
+
+			for _, settings := range integrations {
+				integrationSettings[settings.Base().ID] = &entities.ProjectSettingsIntegration{
+					Type:     integrationType,
+					ID:       settings.Base().ID,
+					Settings: settings,
+				}
+			}
+			return nil
+		})
 	}
 	if err := errGroup.Wait(); err != nil {
 		msg := fmt.Sprintf("cannot fetch project settings for integraiont groups [%s]", spew.Sdump(integrationGroups))
@@ -181,8 +694,9 @@ func (service *ProjectSettingsService) createWithoutIntegrations(project *entiti
 func (service *ProjectSettingsService) creatSortedIntegrations(project *entities.Project, integrations map[uuid.UUID]*entities.ProjectSettingsIntegration, integrationOrder []*entities.ProjectIntegration) *entities.ProjectSettings {
 	result := service.createWithoutIntegrations(project)
 	for _, integration := range integrationOrder {
-		if _, ok := integrations[integration.IntegrationID]; ok {
-			result.Integrations = append(result.Integrations, integrations[integration.IntegrationID])
+		if settings, ok := integrations[integration.IntegrationID]; ok {
+			settings.DisplayRules = integration.DisplayRules
+			result.Integrations = append(result.Integrations, settings)
 		}
 	}
 	return result
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+type testBlockedOriginRepository struct {
+	origins []string
+}
+
+func (repository *testBlockedOriginRepository) Increment(_ context.Context, _ *entities.Project, origin string) error {
+	repository.origins = append(repository.origins, origin)
+	return nil
+}
+
+func (repository *testBlockedOriginRepository) Fetch(_ context.Context, _ uuid.UUID) ([]*entities.ProjectBlockedOrigin, error) {
+	return nil, nil
+}
+
+func (repository *testBlockedOriginRepository) DeleteAll(_ context.Context, _ uuid.UUID) (int64, error) {
+	return int64(len(repository.origins)), nil
+}
+
+func newTestProjectSettingsService(blockedOrigins *testBlockedOriginRepository) *ProjectSettingsService {
+	logger, tracer := newTestTelemetry()
+	return &ProjectSettingsService{logger: logger, tracer: tracer, projectBlockedOriginRepository: blockedOrigins}
+}
+
+func TestProjectSettingsService_AuthorizeOriginAllowsProjectOrigin(t *testing.T) {
+	blockedOrigins := &testBlockedOriginRepository{}
+	service := newTestProjectSettingsService(blockedOrigins)
+	project := &entities.Project{ID: uuid.New(), URL: "https://example.com"}
+
+	if err := service.authorizeOrigin(context.Background(), project, "https://example.com"); err != nil {
+		t.Fatalf("the origin of the project is not allowed: %v", err)
+	}
+
+	if len(blockedOrigins.origins) != 0 {
+		t.Errorf("got blocked origins [%v] for an allowed origin, want none", blockedOrigins.origins)
+	}
+}
+
+func TestProjectSettingsService_AuthorizeOriginRecordsBlockedOrigin(t *testing.T) {
+	blockedOrigins := &testBlockedOriginRepository{}
+	service := newTestProjectSettingsService(blockedOrigins)
+	project := &entities.Project{ID: uuid.New(), URL: "https://example.com"}
+
+	err := service.authorizeOrigin(context.Background(), project, "https://evil.com")
+	if stacktrace.GetCode(err) != ErrCodeOriginNotAllowed {
+		t.Fatalf("got error [%v], want code [%d]", err, ErrCodeOriginNotAllowed)
+	}
+
+	if len(blockedOrigins.origins) != 1 || blockedOrigins.origins[0] != "https://evil.com" {
+		t.Errorf("got blocked origins [%v], want [https://evil.com]", blockedOrigins.origins)
+	}
+}
+
+func TestProjectSettingsService_AuthorizeOriginTruncatesLongOrigins(t *testing.T) {
+	blockedOrigins := &testBlockedOriginRepository{}
+	service := newTestProjectSettingsService(blockedOrigins)
+	project := &entities.Project{ID: uuid.New(), URL: "https://example.com"}
+
+	origin := "https://" + strings.Repeat("a", 2*maxBlockedOriginLength) + ".com"
+	if err := service.authorizeOrigin(context.Background(), project, origin); err == nil {
+		t.Fatal("a long origin which is not allowed did not fail")
+	}
+
+	if len(blockedOrigins.origins) != 1 || len(blockedOrigins.origins[0]) != maxBlockedOriginLength {
+		t.Errorf("got blocked origins [%v], want one origin with [%d] characters", blockedOrigins.origins, maxBlockedOriginLength)
+	}
+}
@@ -27,3 +27,10 @@ func (service *service) createEvent(eventType string, source string, payload any
 
 	return &event, nil
 }
+
+// createIdempotentEvent creates an event with an ID derived from the key so that a redelivered webhook produces the same event
+func (service *service) createIdempotentEvent(key string, eventType string, source string, payload any) (*cloudevents.Event, error) {
+	event, err := service.createEvent(eventType, source, payload)
+	event.SetID(uuid.NewSHA1(uuid.NameSpaceURL, []byte(key)).String())
+	return event, err
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	lemonsqueezy "github.com/NdoleStudio/lemonsqueezy-go"
 
@@ -21,6 +22,8 @@ type UserService struct {
 	repository         repositories.UserRepository
 	lemonsqueezyClient *lemonsqueezy.Client
 	eventDispatcher    *EventDispatcher
+	transactor         repositories.Transactor
+	invitationService  *WorkspaceInvitationService
 }
 
 // NewUserService creates a new UserService
@@ -28,62 +31,121 @@ func NewUserService(
 	logger telemetry.Logger,
 	tracer telemetry.Tracer,
 	eventDispatcher *EventDispatcher,
+	transactor repositories.Transactor,
 	repository repositories.UserRepository,
 	lemonsqueezyClient *lemonsqueezy.Client,
+	invitationService *WorkspaceInvitationService,
 ) (s *UserService) {
 	return &UserService{
 		logger:             logger.WithService(fmt.Sprintf("%T", s)),
 		tracer:             tracer,
 		lemonsqueezyClient: lemonsqueezyClient,
 		eventDispatcher:    eventDispatcher,
+		transactor:         transactor,
 		repository:         repository,
+		invitationService:  invitationService,
 	}
 }
 
-// Get fetches or creates an entities.User
+// Get fetches or creates an entities.User and accepts the pending invitations which were sent to the verified email address of the user
 func (service *UserService) Get(ctx context.Context, source string, authUser entities.AuthUser) (*entities.User, error) {
 	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
-	user, created, err := service.repository.LoadOrStore(ctx, authUser)
+	var user *entities.User
+	err := service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		stored, created, err := service.repository.LoadOrStore(ctx, authUser)
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("could not load or store [%T] with ID [%s]", stored, authUser.ID))
+		}
+
+		user = stored
+		if created {
+			if err = service.dispatchUserCreatedEvent(ctx, source, user); err != nil {
+				return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for user [%s]", user.ID))
+			}
+		}
+
+		if !authUser.EmailVerified {
+			return nil
+		}
+		return service.invitationService.ClaimPending(ctx, source, user)
+	})
 	if err != nil {
 		msg := fmt.Sprintf("could not get [%T] with from [%+#v]", user, authUser)
 		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	if created {
-		service.dispatchUserCreatedEvent(ctx, source, user)
+	if err = service.downgradeExpiredSubscription(ctx, source, user); err != nil {
+		msg := fmt.Sprintf("could not downgrade expired subscription for user with ID [%s]", user.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
 	return user, nil
 }
 
-// StartSubscription starts a subscription for an entities.User
-func (service *UserService) StartSubscription(ctx context.Context, source string, params *events.UserSubscriptionCreatedPayload) error {
+// Load fetches an entities.User and downgrades the user to the free plan if the subscription has ended
+func (service *UserService) Load(ctx context.Context, source string, userID entities.UserID) (*entities.User, error) {
 	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
-	user, err := service.repository.Load(ctx, params.UserID)
+	user, err := service.repository.Load(ctx, userID)
 	if err != nil {
-		msg := fmt.Sprintf("could not get [%T] with with ID [%s]", user, params.UserID)
-		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		msg := fmt.Sprintf("could not get [%T] with with ID [%s]", user, userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if err = service.downgradeExpiredSubscription(ctx, source, user); err != nil {
+		msg := fmt.Sprintf("could not downgrade expired subscription for user with ID [%s]", user.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	user.SubscriptionID = params.SubscriptionID
-	user.SubscriptionName = params.SubscriptionName
-	user.SubscriptionRenewsAt = &params.SubscriptionRenewsAt
-	user.SubscriptionStatus = params.SubscriptionStatus
-	user.SubscriptionEndsAt = nil
+	return user, nil
+}
 
-	if err = service.repository.Update(ctx, user); err != nil {
-		msg := fmt.Sprintf("could not update [%T] with with ID [%s] after update", user, params.UserID)
+// downgradeExpiredSubscription moves the user to the free plan under the same row lock as the lemonsqueezy webhooks
+// so that a subscription which was renewed concurrently is not downgraded.
+func (service *UserService) downgradeExpiredSubscription(ctx context.Context, source string, user *entities.User) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	if !user.SubscriptionExpired(time.Now().UTC()) {
+		return nil
+	}
+
+	var locked *entities.User
+	err := service.lockAndUpdate(ctx, source, user.ID, func(current *entities.User) bool {
+		locked = current
+		if !current.SubscriptionExpired(time.Now().UTC()) {
+			return false
+		}
+
+		ctxLogger.Info(fmt.Sprintf("subscription [%s] of user [%s] ended at [%s], downgrading to [%s]", current.SubscriptionName, current.ID, current.SubscriptionEndsAt, entities.SubscriptionNameFree))
+		current.SubscriptionName = entities.SubscriptionNameFree
+		current.SubscriptionStatus = "expired"
+		current.SubscriptionRenewsAt = nil
+		return true
+	})
+	if err != nil {
+		msg := fmt.Sprintf("could not update [%T] with with ID [%s] after downgrade", user, user.ID)
 		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	service.dispatchUserUpdatedEvent(ctx, source, user)
+	*user = *locked
 	return nil
 }
 
+// StartSubscription starts a subscription for an entities.User
+func (service *UserService) StartSubscription(ctx context.Context, source string, params *events.UserSubscriptionCreatedPayload) error {
+	return service.updateSubscription(ctx, source, params.UserID, params.SubscriptionUpdatedAt, func(user *entities.User) {
+		user.SubscriptionID = params.SubscriptionID
+		user.SubscriptionName = params.SubscriptionName
+		user.SubscriptionRenewsAt = &params.SubscriptionRenewsAt
+		user.SubscriptionStatus = params.SubscriptionStatus
+		user.SubscriptionEndsAt = nil
+	})
+}
+
 // InitiateSubscriptionCancel initiates the cancelling of a subscription on lemonsqueezy
 func (service *UserService) InitiateSubscriptionCancel(ctx context.Context, userID entities.UserID) error {
 	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
@@ -126,36 +188,150 @@ func (service *UserService) GetSubscriptionUpdateURL(ctx context.Context, userID
 
 // CancelSubscription starts a subscription for an entities.User
 func (service *UserService) CancelSubscription(ctx context.Context, source string, params *events.UserSubscriptionCancelledPayload) error {
-	ctx, span := service.tracer.Start(ctx)
-	defer span.End()
+	return service.updateSubscription(ctx, source, params.UserID, params.SubscriptionUpdatedAt, func(user *entities.User) {
+		user.SubscriptionID = params.SubscriptionID
+		user.SubscriptionName = params.SubscriptionName
+		user.SubscriptionRenewsAt = nil
+		user.SubscriptionStatus = params.SubscriptionStatus
+		user.SubscriptionEndsAt = &params.SubscriptionEndsAt
+	})
+}
 
-	user, err := service.repository.Load(ctx, params.UserID)
-	if err != nil {
-		msg := fmt.Sprintf("could not get [%T] with with ID [%s]", user, params.UserID)
-		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
-	}
+// UpdateSubscription synchronizes the subscription of an entities.User after it is updated on lemonsqueezy
+func (service *UserService) UpdateSubscription(ctx context.Context, source string, params *events.UserSubscriptionUpdatedPayload) error {
+	return service.updateSubscription(ctx, source, params.UserID, params.SubscriptionUpdatedAt, func(user *entities.User) {
+		user.SubscriptionID = params.SubscriptionID
+		user.SubscriptionName = params.SubscriptionName
+		user.SubscriptionStatus = params.SubscriptionStatus
+		user.SubscriptionRenewsAt = params.SubscriptionRenewsAt
+		user.SubscriptionEndsAt = params.SubscriptionEndsAt
+	})
+}
 
-	user.SubscriptionID = params.SubscriptionID
-	user.SubscriptionName = params.SubscriptionName
-	user.SubscriptionRenewsAt = nil
-	user.SubscriptionStatus = params.SubscriptionStatus
-	user.SubscriptionEndsAt = &params.SubscriptionEndsAt
+// ResumeSubscription resumes a cancelled subscription for an entities.User
+func (service *UserService) ResumeSubscription(ctx context.Context, source string, params *events.UserSubscriptionResumedPayload) error {
+	return service.updateSubscription(ctx, source, params.UserID, params.SubscriptionUpdatedAt, func(user *entities.User) {
+		user.SubscriptionID = params.SubscriptionID
+		user.SubscriptionName = params.SubscriptionName
+		user.SubscriptionStatus = params.SubscriptionStatus
+		user.SubscriptionRenewsAt = &params.SubscriptionRenewsAt
+		user.SubscriptionEndsAt = nil
+	})
+}
 
-	if err = service.repository.Update(ctx, user); err != nil {
-		msg := fmt.Sprintf("could not update [%T] with with ID [%s] after update", user, params.UserID)
+// ExpireSubscription downgrades an entities.User to the free plan after the subscription has ended
+func (service *UserService) ExpireSubscription(ctx context.Context, source string, params *events.UserSubscriptionExpiredPayload) error {
+	return service.updateSubscription(ctx, source, params.UserID, params.SubscriptionUpdatedAt, func(user *entities.User) {
+		user.SubscriptionID = params.SubscriptionID
+		user.SubscriptionName = entities.SubscriptionNameFree
+		user.SubscriptionStatus = params.SubscriptionStatus
+		user.SubscriptionRenewsAt = nil
+		user.SubscriptionEndsAt = &params.SubscriptionExpiredAt
+		user.SubscriptionResumesAt = nil
+	})
+}
+
+// PauseSubscription pauses payment collection for the subscription of an entities.User
+func (service *UserService) PauseSubscription(ctx context.Context, source string, params *events.UserSubscriptionPausedPayload) error {
+	return service.updateSubscription(ctx, source, params.UserID, params.SubscriptionUpdatedAt, func(user *entities.User) {
+		user.SubscriptionID = params.SubscriptionID
+		user.SubscriptionName = params.SubscriptionName
+		user.SubscriptionStatus = params.SubscriptionStatus
+		user.SubscriptionRenewsAt = nil
+		user.SubscriptionResumesAt = params.SubscriptionResumesAt
+	})
+}
+
+// UnpauseSubscription restarts payment collection for the subscription of an entities.User
+func (service *UserService) UnpauseSubscription(ctx context.Context, source string, params *events.UserSubscriptionUnpausedPayload) error {
+	return service.updateSubscription(ctx, source, params.UserID, params.SubscriptionUpdatedAt, func(user *entities.User) {
+		user.SubscriptionID = params.SubscriptionID
+		user.SubscriptionName = params.SubscriptionName
+		user.SubscriptionStatus = params.SubscriptionStatus
+		user.SubscriptionRenewsAt = &params.SubscriptionRenewsAt
+		user.SubscriptionResumesAt = nil
+	})
+}
+
+// RecordSubscriptionPaymentFailure marks the subscription payment of an entities.User as failed
+func (service *UserService) RecordSubscriptionPaymentFailure(ctx context.Context, source string, params *events.UserSubscriptionPaymentFailedPayload) error {
+	return service.updateSubscriptionPayment(ctx, source, params.UserID, params.SubscriptionPaymentAt, func(user *entities.User) {
+		user.SubscriptionPaymentFailedAt = &params.SubscriptionPaymentAt
+	})
+}
+
+// RecordSubscriptionPaymentSuccess clears any failed subscription payment of an entities.User
+func (service *UserService) RecordSubscriptionPaymentSuccess(ctx context.Context, source string, params *events.UserSubscriptionPaymentSucceededPayload) error {
+	return service.updateSubscriptionPayment(ctx, source, params.UserID, params.SubscriptionPaymentAt, func(user *entities.User) {
+		user.SubscriptionPaymentFailedAt = nil
+	})
+}
+
+// updateSubscription applies a subscription update from lemonsqueezy unless a newer update was already applied.
+// Webhooks are delivered out of order so the user is locked and the update timestamp is stored with the subscription.
+func (service *UserService) updateSubscription(ctx context.Context, source string, userID entities.UserID, updatedAt time.Time, update func(user *entities.User)) error {
+	return service.lockAndUpdate(ctx, source, userID, func(user *entities.User) bool {
+		if user.IsStaleSubscriptionUpdate(updatedAt) {
+			return false
+		}
+
+		update(user)
+		if !updatedAt.IsZero() {
+			user.SubscriptionUpdatedAt = &updatedAt
+		}
+		return true
+	})
+}
+
+// updateSubscriptionPayment applies a subscription payment from lemonsqueezy unless a newer payment was already applied
+func (service *UserService) updateSubscriptionPayment(ctx context.Context, source string, userID entities.UserID, updatedAt time.Time, update func(user *entities.User)) error {
+	return service.lockAndUpdate(ctx, source, userID, func(user *entities.User) bool {
+		if user.IsStaleSubscriptionPaymentUpdate(updatedAt) {
+			return false
+		}
+
+		update(user)
+		if !updatedAt.IsZero() {
+			user.SubscriptionPaymentUpdatedAt = &updatedAt
+		}
+		return true
+	})
+}
+
+// lockAndUpdate loads and locks an entities.User in a transaction and stores it when apply returns true
+func (service *UserService) lockAndUpdate(ctx context.Context, source string, userID entities.UserID, apply func(user *entities.User) bool) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	err := service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		user, err := service.repository.LoadForUpdate(ctx, userID)
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("could not lock [%T] with with ID [%s]", user, userID))
+		}
+
+		if !apply(user) {
+			ctxLogger.Info(fmt.Sprintf("skipping stale subscription update for user [%s] with subscription [%s]", user.ID, user.SubscriptionID))
+			return nil
+		}
+
+		user.UpdatedAt = time.Now().UTC()
+		if err = service.repository.Update(ctx, user); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("could not update [%T] with with ID [%s]", user, user.ID))
+		}
+		return service.dispatchUserUpdatedEvent(ctx, source, user)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("could not update subscription of user with ID [%s]", userID)
 		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
-	service.dispatchUserUpdatedEvent(ctx, source, user)
 	return nil
 }
 
-func (service *UserService) dispatchUserUpdatedEvent(ctx context.Context, source string, user *entities.User) {
+func (service *UserService) dispatchUserUpdatedEvent(ctx context.Context, source string, user *entities.User) error {
 	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
-	ctxLogger := service.tracer.CtxLogger(service.logger, span)
-
 	event, err := service.createEvent(events.UserUpdated, source, &events.UserUpdatedPayload{
 		UserID:           user.ID,
 		UserUpdatedAt:    user.UpdatedAt,
@@ -164,23 +340,21 @@ func (service *UserService) dispatchUserUpdatedEvent(ctx context.Context, source
 	})
 	if err != nil {
 		msg := fmt.Sprintf("cannot created [%s] event for user [%s]", events.UserUpdated, user.ID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
 	if err = service.eventDispatcher.Dispatch(ctx, event); err != nil {
 		msg := fmt.Sprintf("cannot dispatch [%s] event for user [%s]", events.UserUpdated, user.ID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
+
+	return nil
 }
 
-func (service *UserService) dispatchUserCreatedEvent(ctx context.Context, source string, user *entities.User) {
+func (service *UserService) dispatchUserCreatedEvent(ctx context.Context, source string, user *entities.User) error {
 	ctx, span := service.tracer.Start(ctx)
 	defer span.End()
 
-	ctxLogger := service.tracer.CtxLogger(service.logger, span)
-
 	event, err := service.createEvent(events.UserCreated, source, &events.UserCreatedPayload{
 		UserID:        user.ID,
 		UserCreatedAt: user.CreatedAt,
@@ -189,13 +363,13 @@ func (service *UserService) dispatchUserCreatedEvent(ctx context.Context, source
 	})
 	if err != nil {
 		msg := fmt.Sprintf("cannot created [%s] event for user [%s]", events.UserCreated, user.ID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
 
 	if err = service.eventDispatcher.Dispatch(ctx, event); err != nil {
 		msg := fmt.Sprintf("cannot dispatch [%s] event for user [%s]", events.UserCreated, user.ID)
-		ctxLogger.Error(stacktrace.Propagate(err, msg))
-		return
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
 	}
+
+	return nil
 }
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/palantir/stacktrace"
+)
+
+// ErrCodeWebhookAddressNotAllowed is returned when the URL of an entities.Webhook points to a private, loopback,
+// link-local or metadata address. Requests to these addresses could be used to read responses from internal hosts.
+const ErrCodeWebhookAddressNotAllowed = stacktrace.ErrorCode(6004)
+
+// webhookBlockedNetworks are the networks which are not covered by the net.IP helpers but must not receive webhooks
+var webhookBlockedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",          // "this" network
+	"100.64.0.0/10",      // carrier-grade NAT
+	"192.0.0.0/24",       // IETF protocol assignments
+	"198.18.0.0/15",      // benchmarking
+	"240.0.0.0/4",        // reserved
+	"64:ff9b::/96",       // NAT64 which can be used to reach IPv4 addresses
+	"64:ff9b:1::/48",     // local-use NAT64
+	"2001:db8::/32",      // documentation
+	"fd00:ec2::254/128",  // AWS metadata over IPv6
+	"::ffff:0:0:0/96",    // IPv4-translated
+	"2002::/16",          // 6to4 which embeds an IPv4 address
+	"2001::/32",          // teredo which embeds an IPv4 address
+	"100::/64",           // discard
+	"fec0::/10",          // deprecated site-local
+	"ff00::/8",           // multicast
+	"224.0.0.0/4",        // multicast
+	"169.254.169.254/32", // cloud metadata
+)
+
+// IsPublicWebhookIP returns true when an entities.Webhook can be delivered to the IP address
+func IsPublicWebhookIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+	}
+
+	if ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() {
+		return false
+	}
+
+	for _, network := range webhookBlockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateWebhookHost resolves the host of a webhook URL and makes sure all its addresses are public
+func ValidateWebhookHost(ctx context.Context, resolver *net.Resolver, rawURL string) error {
+	value, err := url.Parse(rawURL)
+	if err != nil {
+		return stacktrace.PropagateWithCode(err, ErrCodeWebhookAddressNotAllowed, fmt.Sprintf("cannot parse url [%s]", rawURL))
+	}
+
+	host := strings.TrimSuffix(value.Hostname(), ".")
+	if host == "" {
+		return stacktrace.NewErrorWithCode(ErrCodeWebhookAddressNotAllowed, fmt.Sprintf("url [%s] has no host", rawURL))
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if !IsPublicWebhookIP(ip) {
+			return stacktrace.NewErrorWithCode(ErrCodeWebhookAddressNotAllowed, fmt.Sprintf("ip [%s] is not public", ip))
+		}
+		return nil
+	}
+
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return stacktrace.NewErrorWithCode(ErrCodeWebhookAddressNotAllowed, fmt.Sprintf("host [%s] is a loopback host", host))
+	}
+
+	addresses, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return stacktrace.PropagateWithCode(err, ErrCodeWebhookAddressNotAllowed, fmt.Sprintf("cannot resolve host [%s]", host))
+	}
+
+	for _, address := range addresses {
+		if !IsPublicWebhookIP(address.IP) {
+			return stacktrace.NewErrorWithCode(ErrCodeWebhookAddressNotAllowed, fmt.Sprintf("host [%s] resolves to ip [%s] which is not public", host, address.IP))
+		}
+	}
+
+	return nil
+}
+
+// NewWebhookHTTPClient creates the http.Client used to send an entities.WebhookDelivery.
+// The address is checked again after DNS resolution when connecting so a host cannot be re-bound to a private
+// address after the webhook is saved, and redirects are not followed because they could point to an internal host.
+func NewWebhookHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   webhookDialControl,
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   timeout,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookDialControl runs with the resolved address just before connecting
+func webhookDialControl(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return stacktrace.PropagateWithCode(err, ErrCodeWebhookAddressNotAllowed, fmt.Sprintf("cannot split address [%s]", address))
+	}
+
+	if !IsPublicWebhookIP(net.ParseIP(host)) {
+		return stacktrace.NewErrorWithCode(ErrCodeWebhookAddressNotAllowed, fmt.Sprintf("cannot connect to [%s] because it is not a public address", address))
+	}
+
+	return nil
+}
+
+func mustParseCIDRs(values ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			panic(fmt.Sprintf("cannot parse CIDR [%s]: %s", value, err))
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
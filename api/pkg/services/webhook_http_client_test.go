@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/palantir/stacktrace"
+)
+
+func TestIsPublicWebhookIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{ip: "127.0.0.1", want: false},
+		{ip: "::1", want: false},
+		{ip: "10.0.0.1", want: false},
+		{ip: "172.16.0.1", want: false},
+		{ip: "192.168.1.1", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "100.64.0.1", want: false},
+		{ip: "0.0.0.0", want: false},
+		{ip: "224.0.0.1", want: false},
+		{ip: "fc00::1", want: false},
+		{ip: "fe80::1", want: false},
+		{ip: "fd00:ec2::254", want: false},
+		{ip: "::ffff:127.0.0.1", want: false},
+		{ip: "64:ff9b::a00:1", want: false},
+		{ip: "2002:a00:1::", want: false},
+	}
+
+	for _, test := range tests {
+		if got := IsPublicWebhookIP(net.ParseIP(test.ip)); got != test.want {
+			t.Errorf("IsPublicWebhookIP(%s) = [%t], want [%t]", test.ip, got, test.want)
+		}
+	}
+
+	if IsPublicWebhookIP(nil) {
+		t.Error("a nil IP is public")
+	}
+}
+
+func TestValidateWebhookHost(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://93.184.216.34/webhooks", want: true},
+		{url: "https://[2606:2800:220:1:248:1893:25c8:1946]/webhooks", want: true},
+		{url: "https://127.0.0.1/webhooks", want: false},
+		{url: "https://[::1]:8080/webhooks", want: false},
+		{url: "http://169.254.169.254/latest/meta-data", want: false},
+		{url: "https://localhost/webhooks", want: false},
+		{url: "https://LOCALHOST./webhooks", want: false},
+		{url: "https://api.localhost/webhooks", want: false},
+		{url: "https:///webhooks", want: false},
+		{url: "://invalid", want: false},
+	}
+
+	for _, test := range tests {
+		err := ValidateWebhookHost(context.Background(), net.DefaultResolver, test.url)
+		if (err == nil) != test.want {
+			t.Errorf("ValidateWebhookHost(%s) returned [%v], want allowed [%t]", test.url, err, test.want)
+		}
+		if err != nil && stacktrace.GetCode(err) != ErrCodeWebhookAddressNotAllowed {
+			t.Errorf("got error code [%d] for [%s], want [%d]", stacktrace.GetCode(err), test.url, ErrCodeWebhookAddressNotAllowed)
+		}
+	}
+}
+
+func TestWebhookDialControl(t *testing.T) {
+	if err := webhookDialControl("tcp", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("cannot connect to a public address: %v", err)
+	}
+
+	for _, address := range []string{"127.0.0.1:443", "[::1]:443", "10.0.0.1:80", "invalid"} {
+		if err := webhookDialControl("tcp", address, nil); stacktrace.GetCode(err) != ErrCodeWebhookAddressNotAllowed {
+			t.Errorf("got error [%v] for address [%s], want code [%d]", err, address, ErrCodeWebhookAddressNotAllowed)
+		}
+	}
+}
+
+func TestNewWebhookHTTPClient_DoesNotFollowRedirects(t *testing.T) {
+	client := NewWebhookHTTPClient(time.Second)
+
+	if err := client.CheckRedirect(&http.Request{}, nil); err != http.ErrUseLastResponse {
+		t.Errorf("got redirect policy [%v], want [%v]", err, http.ErrUseLastResponse)
+	}
+
+	if transport, ok := client.Transport.(*http.Transport); !ok || transport.Proxy != nil {
+		t.Error("the webhook client can send requests through a proxy which is not checked")
+	}
+}
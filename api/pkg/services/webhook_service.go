@@ -0,0 +1,807 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/events"
+	"github.com/NdoleStudio/superbutton/pkg/queue"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// ErrCodeWebhookDisabled is returned when sending an event to an entities.Webhook which is disabled
+const ErrCodeWebhookDisabled = stacktrace.ErrorCode(6003)
+
+const (
+	webhookDeliveryMaxAttempts    = 8
+	webhookDeliveryMinBackoff     = 30 * time.Second
+	webhookDeliveryMaxBackoff     = 2 * time.Hour
+	webhookMaxConsecutiveFailures = 5
+	webhookResponseBodyLimit      = 1024
+
+	webhookHeaderDelivery  = "X-Superbutton-Delivery"
+	webhookHeaderEvent     = "X-Superbutton-Event"
+	webhookHeaderTimestamp = "X-Superbutton-Timestamp"
+	webhookHeaderSignature = "X-Superbutton-Signature"
+)
+
+// WebhookEventTypes returns the types of the events which can be delivered to an entities.Webhook
+func WebhookEventTypes() []string {
+	return []string{
+		events.ContactFormSubmitted,
+		events.IntegrationCreated,
+		events.IntegrationUpdated,
+		events.IntegrationDeleted,
+		events.IntegrationReordered,
+		events.IntegrationDisplayRulesUpdated,
+		events.ProjectUpdated,
+		events.ProjectDeleted,
+	}
+}
+
+// WebhookDeliveryTask is the body of the queue.Task which sends an entities.WebhookDelivery
+type WebhookDeliveryTask struct {
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+// WebhookService is responsible for managing the entities.Webhook of a project and sending events to them
+type WebhookService struct {
+	service
+	logger               telemetry.Logger
+	tracer               telemetry.Tracer
+	transactor           repositories.Transactor
+	repository           repositories.WebhookRepository
+	deliveryRepository   repositories.WebhookDeliveryRepository
+	authorizationService *AuthorizationService
+	eventDispatcher      *EventDispatcher
+	queue                queue.Client
+	consumerURL          string
+	client               *http.Client
+}
+
+// NewWebhookService creates a new WebhookService
+func NewWebhookService(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	eventDispatcher *EventDispatcher,
+	transactor repositories.Transactor,
+	repository repositories.WebhookRepository,
+	deliveryRepository repositories.WebhookDeliveryRepository,
+	authorizationService *AuthorizationService,
+	queue queue.Client,
+	consumerURL string,
+	client *http.Client,
+) (s *WebhookService) {
+	return &WebhookService{
+		logger:               logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:               tracer,
+		eventDispatcher:      eventDispatcher,
+		transactor:           transactor,
+		repository:           repository,
+		deliveryRepository:   deliveryRepository,
+		authorizationService: authorizationService,
+		queue:                queue,
+		consumerURL:          consumerURL,
+		client:               client,
+	}
+}
+
+// WebhookCreateParams are the parameters for creating an entities.Webhook
+type WebhookCreateParams struct {
+	Source     string
+	UserID     entities.UserID
+	ProjectID  uuid.UUID
+	URL        string
+	EventTypes []string
+}
+
+// Create an entities.Webhook with a new signing secret for a project
+func (service *WebhookService) Create(ctx context.Context, params *WebhookCreateParams) (*entities.Webhook, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeProject(ctx, params.UserID, params.ProjectID, entities.WorkspacePermissionManage); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot add a webhook to project [%s]", params.UserID, params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	secret, err := service.generateSecret()
+	if err != nil {
+		msg := fmt.Sprintf("cannot generate signing secret for webhook of project [%s]", params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	webhook := &entities.Webhook{
+		ID:            uuid.New(),
+		UserID:        params.UserID,
+		ProjectID:     params.ProjectID,
+		URL:           params.URL,
+		EventTypes:    params.EventTypes,
+		SigningSecret: secret,
+		Enabled:       true,
+		CreatedAt:     time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+	}
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.repository.Store(ctx, webhook); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store webhook for project [%s]", params.ProjectID))
+		}
+		return service.dispatchWebhookCreatedEvent(ctx, params.Source, webhook)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot create webhook for project [%s]", params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	webhook.Secret = secret
+	return webhook, nil
+}
+
+// Index fetches the entities.Webhook of a project
+func (service *WebhookService) Index(ctx context.Context, userID entities.UserID, projectID uuid.UUID) ([]*entities.Webhook, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeProject(ctx, userID, projectID, entities.WorkspacePermissionManage); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot fetch the webhooks of project [%s]", userID, projectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	webhooks, err := service.repository.Fetch(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch webhooks of project [%s]", projectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return webhooks, nil
+}
+
+// WebhookUpdateParams are the parameters for updating an entities.Webhook
+type WebhookUpdateParams struct {
+	Source     string
+	UserID     entities.UserID
+	ProjectID  uuid.UUID
+	WebhookID  uuid.UUID
+	URL        string
+	EventTypes []string
+	Enabled    bool
+}
+
+// Update an entities.Webhook. The consecutive failures are cleared when a disabled webhook is enabled again.
+func (service *WebhookService) Update(ctx context.Context, params *WebhookUpdateParams) (*entities.Webhook, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	webhook, err := service.load(ctx, params.UserID, params.ProjectID, params.WebhookID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load webhook [%s] of project [%s]", params.WebhookID, params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if params.Enabled && !webhook.Enabled {
+		webhook.ConsecutiveFailures = 0
+		webhook.DisabledAt = nil
+	}
+
+	if !params.Enabled && webhook.Enabled {
+		now := time.Now().UTC()
+		webhook.DisabledAt = &now
+	}
+
+	webhook.URL = params.URL
+	webhook.EventTypes = params.EventTypes
+	webhook.Enabled = params.Enabled
+	webhook.UpdatedAt = time.Now().UTC()
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.repository.Update(ctx, webhook); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot update webhook [%s]", webhook.ID))
+		}
+		return service.dispatchWebhookUpdatedEvent(ctx, params.Source, params.UserID, webhook)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot update webhook [%s] of project [%s]", webhook.ID, webhook.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return webhook, nil
+}
+
+// WebhookDeleteParams are the parameters for deleting an entities.Webhook
+type WebhookDeleteParams struct {
+	Source    string
+	UserID    entities.UserID
+	ProjectID uuid.UUID
+	WebhookID uuid.UUID
+}
+
+// Delete an entities.Webhook and its delivery log
+func (service *WebhookService) Delete(ctx context.Context, params *WebhookDeleteParams) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	webhook, err := service.load(ctx, params.UserID, params.ProjectID, params.WebhookID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load webhook [%s] of project [%s]", params.WebhookID, params.ProjectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.delete(ctx, webhook); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot delete webhook [%s]", webhook.ID))
+		}
+		return service.dispatchWebhookDeletedEvent(ctx, params.Source, params.UserID, webhook)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete webhook [%s] of project [%s]", webhook.ID, webhook.ProjectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// Deliveries fetches the entities.WebhookDelivery of an entities.Webhook
+func (service *WebhookService) Deliveries(ctx context.Context, userID entities.UserID, projectID uuid.UUID, webhookID uuid.UUID, params repositories.IndexParams) ([]*entities.WebhookDelivery, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.load(ctx, userID, projectID, webhookID); err != nil {
+		msg := fmt.Sprintf("cannot load webhook [%s] of project [%s]", webhookID, projectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	deliveries, err := service.deliveryRepository.Index(ctx, webhookID, params)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch deliveries of webhook [%s] with params [%+#v]", webhookID, params)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return deliveries, nil
+}
+
+// WebhookRedeliverParams are the parameters for sending an entities.WebhookDelivery again
+type WebhookRedeliverParams struct {
+	UserID     entities.UserID
+	ProjectID  uuid.UUID
+	WebhookID  uuid.UUID
+	DeliveryID uuid.UUID
+}
+
+// Redeliver sends the event of an entities.WebhookDelivery again in a new entities.WebhookDelivery
+func (service *WebhookService) Redeliver(ctx context.Context, params *WebhookRedeliverParams) (*entities.WebhookDelivery, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	webhook, err := service.loadEnabled(ctx, params.UserID, params.ProjectID, params.WebhookID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load webhook [%s] of project [%s]", params.WebhookID, params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	original, err := service.deliveryRepository.Load(ctx, webhook.ID, params.DeliveryID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load delivery [%s] of webhook [%s]", params.DeliveryID, webhook.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	delivery := service.newDelivery(uuid.New(), webhook, original.EventID, original.EventType, original.Payload)
+	delivery.RedeliveryOf = &original.ID
+
+	if err = service.enqueue(ctx, delivery); err != nil {
+		msg := fmt.Sprintf("cannot redeliver delivery [%s] of webhook [%s]", original.ID, webhook.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return delivery, nil
+}
+
+// WebhookTestParams are the parameters for sending a test event to an entities.Webhook
+type WebhookTestParams struct {
+	Source    string
+	UserID    entities.UserID
+	ProjectID uuid.UUID
+	WebhookID uuid.UUID
+}
+
+// Test sends an events.WebhookTest event to an entities.Webhook.
+// The failures of test deliveries do not count towards disabling the webhook.
+func (service *WebhookService) Test(ctx context.Context, params *WebhookTestParams) (*entities.WebhookDelivery, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	webhook, err := service.loadEnabled(ctx, params.UserID, params.ProjectID, params.WebhookID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load webhook [%s] of project [%s]", params.WebhookID, params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	event, err := service.createEvent(events.WebhookTest, params.Source, &events.WebhookTestPayload{
+		UserID:    params.UserID,
+		ProjectID: webhook.ProjectID,
+		WebhookID: webhook.ID,
+		SentAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot create [%s] event for webhook [%s]", events.WebhookTest, webhook.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		msg := fmt.Sprintf("cannot marshal [%s] event with ID [%s]", event.Type(), event.ID())
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	delivery := service.newDelivery(uuid.New(), webhook, event.ID(), event.Type(), payload)
+	if err = service.enqueue(ctx, delivery); err != nil {
+		msg := fmt.Sprintf("cannot send test event to webhook [%s]", webhook.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return delivery, nil
+}
+
+// Dispatch an event of a project to the enabled entities.Webhook which subscribe to its type.
+// The ID of every entities.WebhookDelivery is derived from the event so a redelivered event is only sent once.
+// When the project is deleted, the webhooks which do not receive the events.ProjectDeleted event are deleted and
+// the others are deleted after the event is delivered.
+func (service *WebhookService) Dispatch(ctx context.Context, projectID uuid.UUID, event cloudevents.Event) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	webhooks, err := service.repository.FetchEnabled(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch enabled webhooks of project [%s]", projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		msg := fmt.Sprintf("cannot marshal [%s] event with ID [%s]", event.Type(), event.ID())
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	delivered := map[uuid.UUID]bool{}
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.Type()) {
+			continue
+		}
+
+		deliveryID := uuid.NewSHA1(webhook.ID, []byte(event.ID()))
+		if err = service.enqueue(ctx, service.newDelivery(deliveryID, webhook, event.ID(), event.Type(), payload)); err != nil {
+			msg := fmt.Sprintf("cannot deliver [%s] event with ID [%s] to webhook [%s]", event.Type(), event.ID(), webhook.ID)
+			return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+
+		delivered[webhook.ID] = true
+		ctxLogger.Info(fmt.Sprintf("[%s] event with ID [%s] queued for webhook [%s] with delivery [%s]", event.Type(), event.ID(), webhook.ID, deliveryID))
+	}
+
+	if event.Type() != events.ProjectDeleted {
+		return nil
+	}
+
+	if err = service.deleteUndelivered(ctx, projectID, delivered); err != nil {
+		msg := fmt.Sprintf("cannot delete the webhooks of deleted project [%s]", projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// deleteUndelivered deletes the entities.Webhook of a deleted project which are not in the delivered set
+func (service *WebhookService) deleteUndelivered(ctx context.Context, projectID uuid.UUID, delivered map[uuid.UUID]bool) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	webhooks, err := service.repository.Fetch(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch webhooks of project [%s]", projectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	for _, webhook := range webhooks {
+		if delivered[webhook.ID] {
+			continue
+		}
+
+		if err = service.transactor.Transaction(ctx, func(ctx context.Context) error { return service.delete(ctx, webhook) }); err != nil {
+			msg := fmt.Sprintf("cannot delete webhook [%s] of deleted project [%s]", webhook.ID, projectID)
+			return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+		ctxLogger.Info(fmt.Sprintf("deleted webhook [%s] of deleted project [%s]", webhook.ID, projectID))
+	}
+
+	return nil
+}
+
+// deleteIfProjectDeleted deletes the entities.Webhook when the events.ProjectDeleted event was delivered to it for the last time
+func (service *WebhookService) deleteIfProjectDeleted(ctx context.Context, webhook *entities.Webhook, delivery *entities.WebhookDelivery) error {
+	if delivery.EventType != events.ProjectDeleted {
+		return nil
+	}
+
+	err := service.transactor.Transaction(ctx, func(ctx context.Context) error { return service.delete(ctx, webhook) })
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot delete webhook [%s] after delivery [%s]", webhook.ID, delivery.ID))
+	}
+
+	return nil
+}
+
+// delete removes an entities.Webhook with its delivery log
+func (service *WebhookService) delete(ctx context.Context, webhook *entities.Webhook) error {
+	if err := service.deliveryRepository.DeleteForWebhook(ctx, webhook.ID); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot delete deliveries of webhook [%s]", webhook.ID))
+	}
+
+	if err := service.repository.Delete(ctx, webhook); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot delete webhook [%s]", webhook.ID))
+	}
+
+	return nil
+}
+
+// Deliver sends the event of an entities.WebhookDelivery to the URL of its entities.Webhook.
+// An error is returned when the attempt fails so that the queue retries the delivery with the backoff of the queue.
+// The delivery is marked as failed after the last attempt and the webhook is disabled when too many deliveries in a row fail.
+func (service *WebhookService) Deliver(ctx context.Context, source string, deliveryID uuid.UUID) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	delivery, err := service.deliveryRepository.LoadByID(ctx, deliveryID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		ctxLogger.Info(fmt.Sprintf("skipping webhook delivery [%s] because it was deleted with its webhook", deliveryID))
+		return nil
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load webhook delivery [%s]", deliveryID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if delivery.Status != entities.WebhookDeliveryStatusPending {
+		ctxLogger.Info(fmt.Sprintf("skipping webhook delivery [%s] with status [%s]", delivery.ID, delivery.Status))
+		return nil
+	}
+
+	webhook, err := service.repository.LoadByID(ctx, delivery.WebhookID)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		ctxLogger.Info(fmt.Sprintf("skipping webhook delivery [%s] because webhook [%s] was deleted", delivery.ID, delivery.WebhookID))
+		return nil
+	}
+
+	if err != nil {
+		msg := fmt.Sprintf("cannot load webhook [%s] of delivery [%s]", delivery.WebhookID, delivery.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if !webhook.Enabled {
+		message := "the webhook is disabled"
+		delivery.Status = entities.WebhookDeliveryStatusFailed
+		delivery.Error = &message
+		delivery.UpdatedAt = time.Now().UTC()
+		if err = service.deliveryRepository.Update(ctx, delivery); err != nil {
+			msg := fmt.Sprintf("cannot update delivery [%s] of disabled webhook [%s]", delivery.ID, webhook.ID)
+			return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+		if err = service.deleteIfProjectDeleted(ctx, webhook, delivery); err != nil {
+			return service.tracer.WrapErrorSpan(span, err)
+		}
+		return nil
+	}
+
+	delivery.Attempts++
+	sendErr := service.send(ctx, webhook, delivery)
+	now := time.Now().UTC()
+	delivery.UpdatedAt = now
+
+	if sendErr == nil {
+		delivery.Status = entities.WebhookDeliveryStatusSucceeded
+		delivery.Error = nil
+		delivery.DeliveredAt = &now
+		if err = service.deliveryRepository.Update(ctx, delivery); err != nil {
+			msg := fmt.Sprintf("cannot update succeeded delivery [%s] of webhook [%s]", delivery.ID, webhook.ID)
+			return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+		if err = service.repository.ResetFailures(ctx, webhook.ID); err != nil {
+			msg := fmt.Sprintf("cannot reset consecutive failures of webhook [%s]", webhook.ID)
+			ctxLogger.Error(stacktrace.Propagate(err, msg))
+		}
+		if err = service.deleteIfProjectDeleted(ctx, webhook, delivery); err != nil {
+			return service.tracer.WrapErrorSpan(span, err)
+		}
+		return nil
+	}
+
+	message := sendErr.Error()
+	delivery.Error = &message
+	if delivery.Attempts < webhookDeliveryMaxAttempts {
+		if err = service.deliveryRepository.Update(ctx, delivery); err != nil {
+			msg := fmt.Sprintf("cannot update delivery [%s] of webhook [%s] after attempt [%d]", delivery.ID, webhook.ID, delivery.Attempts)
+			ctxLogger.Error(stacktrace.Propagate(err, msg))
+		}
+		msg := fmt.Sprintf("attempt [%d] of delivery [%s] to webhook [%s] failed", delivery.Attempts, delivery.ID, webhook.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(sendErr, msg))
+	}
+
+	delivery.Status = entities.WebhookDeliveryStatusFailed
+	if err = service.deliveryRepository.Update(ctx, delivery); err != nil {
+		msg := fmt.Sprintf("cannot update failed delivery [%s] of webhook [%s]", delivery.ID, webhook.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	ctxLogger.Warn(stacktrace.Propagate(sendErr, fmt.Sprintf("delivery [%s] to webhook [%s] failed after [%d] attempts", delivery.ID, webhook.ID, delivery.Attempts)))
+
+	if delivery.EventType == events.WebhookTest {
+		return nil
+	}
+
+	if delivery.EventType == events.ProjectDeleted {
+		if err = service.deleteIfProjectDeleted(ctx, webhook, delivery); err != nil {
+			return service.tracer.WrapErrorSpan(span, err)
+		}
+		return nil
+	}
+
+	if err = service.recordFailure(ctx, source, webhook.ID); err != nil {
+		msg := fmt.Sprintf("cannot record failed delivery [%s] of webhook [%s]", delivery.ID, webhook.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// recordFailure counts a failed delivery and disables the entities.Webhook when too many deliveries in a row failed
+func (service *WebhookService) recordFailure(ctx context.Context, source string, webhookID uuid.UUID) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	return service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		webhook, err := service.repository.RecordFailure(ctx, webhookID)
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot increment consecutive failures of webhook [%s]", webhookID))
+		}
+
+		if !webhook.Enabled || webhook.ConsecutiveFailures < webhookMaxConsecutiveFailures {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		webhook.Enabled = false
+		webhook.DisabledAt = &now
+		webhook.UpdatedAt = now
+
+		if err = service.repository.Update(ctx, webhook); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot disable webhook [%s]", webhook.ID))
+		}
+
+		ctxLogger.Warn(stacktrace.NewError(fmt.Sprintf("webhook [%s] of project [%s] is disabled after [%d] failed deliveries in a row", webhook.ID, webhook.ProjectID, webhook.ConsecutiveFailures)))
+		return service.dispatchWebhookDisabledEvent(ctx, source, webhook)
+	})
+}
+
+// send makes a POST request with the cloud event of the entities.WebhookDelivery and records the response.
+// The X-Superbutton-Signature header is the hex encoded HMAC-SHA256 of "{timestamp}.{body}" with the signing secret.
+func (service *WebhookService) send(ctx context.Context, webhook *entities.Webhook, delivery *entities.WebhookDelivery) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		msg := fmt.Sprintf("cannot create request to URL [%s] of webhook [%s]", webhook.URL, webhook.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	timestamp := strconv.FormatInt(time.Now().UTC().Unix(), 10)
+	request.Header.Set("Content-Type", "application/cloudevents+json")
+	request.Header.Set("User-Agent", "Superbutton-Webhooks")
+	request.Header.Set(webhookHeaderDelivery, delivery.ID.String())
+	request.Header.Set(webhookHeaderEvent, delivery.EventType)
+	request.Header.Set(webhookHeaderTimestamp, timestamp)
+	request.Header.Set(webhookHeaderSignature, "v1="+service.sign(webhook.SigningSecret, timestamp, delivery.Payload))
+
+	response, err := service.client.Do(request)
+	if err != nil {
+		delivery.ResponseCode = nil
+		delivery.ResponseBody = nil
+		msg := fmt.Sprintf("cannot send delivery [%s] to URL [%s] of webhook [%s]", delivery.ID, webhook.URL, webhook.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, webhookResponseBodyLimit))
+	if err != nil {
+		body = []byte{}
+	}
+
+	responseBody := string(body)
+	delivery.ResponseCode = &response.StatusCode
+	delivery.ResponseBody = &responseBody
+
+	if !delivery.IsSuccessful() {
+		msg := fmt.Sprintf("URL [%s] of webhook [%s] responded with status code [%d]", webhook.URL, webhook.ID, response.StatusCode)
+		return service.tracer.WrapErrorSpan(span, stacktrace.NewError(msg))
+	}
+
+	return nil
+}
+
+func (service *WebhookService) sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// enqueue stores a pending entities.WebhookDelivery and adds it to the queue
+func (service *WebhookService) enqueue(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if err := service.deliveryRepository.Store(ctx, delivery); err != nil {
+		msg := fmt.Sprintf("cannot store delivery [%s] of webhook [%s]", delivery.ID, delivery.WebhookID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	body, err := json.Marshal(&WebhookDeliveryTask{DeliveryID: delivery.ID})
+	if err != nil {
+		msg := fmt.Sprintf("cannot marshal task for delivery [%s]", delivery.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	_, err = service.queue.Enqueue(ctx, &queue.Task{
+		Method:           http.MethodPost,
+		URL:              service.consumerURL,
+		Body:             body,
+		DeduplicationKey: delivery.ID.String(),
+		RetryPolicy: &queue.RetryPolicy{
+			MaxAttempts: webhookDeliveryMaxAttempts,
+			MinBackoff:  webhookDeliveryMinBackoff,
+			MaxBackoff:  webhookDeliveryMaxBackoff,
+		},
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot add delivery [%s] of webhook [%s] to the queue", delivery.ID, delivery.WebhookID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+func (service *WebhookService) newDelivery(deliveryID uuid.UUID, webhook *entities.Webhook, eventID string, eventType string, payload []byte) *entities.WebhookDelivery {
+	return &entities.WebhookDelivery{
+		ID:        deliveryID,
+		WebhookID: webhook.ID,
+		ProjectID: webhook.ProjectID,
+		EventID:   eventID,
+		EventType: eventType,
+		Payload:   payload,
+		Status:    entities.WebhookDeliveryStatusPending,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+}
+
+// load an entities.Webhook if the user can manage its project
+func (service *WebhookService) load(ctx context.Context, userID entities.UserID, projectID uuid.UUID, webhookID uuid.UUID) (*entities.Webhook, error) {
+	if _, err := service.authorizationService.AuthorizeProject(ctx, userID, projectID, entities.WorkspacePermissionManage); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot manage the webhooks of project [%s]", userID, projectID)
+		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
+	}
+
+	webhook, err := service.repository.Load(ctx, projectID, webhookID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load webhook [%s] of project [%s]", webhookID, projectID)
+		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg)
+	}
+
+	return webhook, nil
+}
+
+// loadEnabled loads an entities.Webhook and returns an error with code ErrCodeWebhookDisabled if it is disabled
+func (service *WebhookService) loadEnabled(ctx context.Context, userID entities.UserID, projectID uuid.UUID, webhookID uuid.UUID) (*entities.Webhook, error) {
+	webhook, err := service.load(ctx, userID, projectID, webhookID)
+	if err != nil {
+		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), fmt.Sprintf("cannot load webhook [%s]", webhookID))
+	}
+
+	if !webhook.Enabled {
+		msg := fmt.Sprintf("webhook [%s] of project [%s] is disabled", webhook.ID, webhook.ProjectID)
+		return nil, stacktrace.NewErrorWithCode(ErrCodeWebhookDisabled, msg)
+	}
+
+	return webhook, nil
+}
+
+func (service *WebhookService) generateSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", stacktrace.Propagate(err, "cannot read random bytes")
+	}
+	return entities.WebhookSecretPrefix + hex.EncodeToString(bytes), nil
+}
+
+func (service *WebhookService) dispatchWebhookCreatedEvent(ctx context.Context, source string, webhook *entities.Webhook) error {
+	event, err := service.createEvent(events.WebhookCreated, source, &events.WebhookCreatedPayload{
+		UserID:           webhook.UserID,
+		ProjectID:        webhook.ProjectID,
+		WebhookID:        webhook.ID,
+		WebhookURL:       webhook.URL,
+		WebhookEvents:    webhook.EventTypes,
+		WebhookCreatedAt: webhook.CreatedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for webhook [%s]", events.WebhookCreated, webhook.ID))
+	}
+	return service.dispatchEvent(ctx, webhook, event)
+}
+
+func (service *WebhookService) dispatchWebhookUpdatedEvent(ctx context.Context, source string, userID entities.UserID, webhook *entities.Webhook) error {
+	event, err := service.createEvent(events.WebhookUpdated, source, &events.WebhookUpdatedPayload{
+		UserID:           userID,
+		ProjectID:        webhook.ProjectID,
+		WebhookID:        webhook.ID,
+		WebhookURL:       webhook.URL,
+		WebhookEvents:    webhook.EventTypes,
+		WebhookEnabled:   webhook.Enabled,
+		WebhookUpdatedAt: webhook.UpdatedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for webhook [%s]", events.WebhookUpdated, webhook.ID))
+	}
+	return service.dispatchEvent(ctx, webhook, event)
+}
+
+func (service *WebhookService) dispatchWebhookDeletedEvent(ctx context.Context, source string, userID entities.UserID, webhook *entities.Webhook) error {
+	event, err := service.createEvent(events.WebhookDeleted, source, &events.WebhookDeletedPayload{
+		UserID:           userID,
+		ProjectID:        webhook.ProjectID,
+		WebhookID:        webhook.ID,
+		WebhookURL:       webhook.URL,
+		WebhookDeletedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for webhook [%s]", events.WebhookDeleted, webhook.ID))
+	}
+	return service.dispatchEvent(ctx, webhook, event)
+}
+
+func (service *WebhookService) dispatchWebhookDisabledEvent(ctx context.Context, source string, webhook *entities.Webhook) error {
+	event, err := service.createEvent(events.WebhookDisabled, source, &events.WebhookDisabledPayload{
+		UserID:              webhook.UserID,
+		ProjectID:           webhook.ProjectID,
+		WebhookID:           webhook.ID,
+		WebhookURL:          webhook.URL,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		WebhookDisabledAt:   *webhook.DisabledAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for webhook [%s]", events.WebhookDisabled, webhook.ID))
+	}
+	return service.dispatchEvent(ctx, webhook, event)
+}
+
+func (service *WebhookService) dispatchEvent(ctx context.Context, webhook *entities.Webhook, event *cloudevents.Event) error {
+	if err := service.eventDispatcher.Dispatch(ctx, event); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch [%s] event for webhook [%s]", event.Type(), webhook.ID))
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/google/uuid"
+)
+
+func newTestWebhookService(client *http.Client) *WebhookService {
+	logger, tracer := newTestTelemetry()
+	return NewWebhookService(logger, tracer, nil, nil, nil, nil, nil, nil, "/v1/webhooks/deliver", client)
+}
+
+func TestWebhookService_SignIsHMACOfTimestampAndBody(t *testing.T) {
+	service := newTestWebhookService(http.DefaultClient)
+
+	mac := hmac.New(sha256.New, []byte("whsec_secret"))
+	mac.Write([]byte(`1670000000.{"id":"1"}`))
+	want := hex.EncodeToString(mac.Sum(nil))
+
+	if got := service.sign("whsec_secret", "1670000000", []byte(`{"id":"1"}`)); got != want {
+		t.Errorf("got signature [%s], want [%s]", got, want)
+	}
+
+	if service.sign("whsec_other", "1670000000", []byte(`{"id":"1"}`)) == want {
+		t.Error("the signature does not depend on the secret")
+	}
+	if service.sign("whsec_secret", "1670000001", []byte(`{"id":"1"}`)) == want {
+		t.Error("the signature does not depend on the timestamp")
+	}
+}
+
+func TestWebhookService_SendSignsDelivery(t *testing.T) {
+	webhook := &entities.Webhook{ID: uuid.New(), SigningSecret: "whsec_secret"}
+	delivery := &entities.WebhookDelivery{ID: uuid.New(), EventType: "contact-form.submitted", Payload: []byte(`{"id":"1"}`)}
+
+	received := make(chan *http.Request, 1)
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+	webhook.URL = server.URL
+
+	service := newTestWebhookService(server.Client())
+	if err := service.send(context.Background(), webhook, delivery); err != nil {
+		t.Fatalf("cannot send delivery: %v", err)
+	}
+
+	request := <-received
+	timestamp := request.Header.Get(webhookHeaderTimestamp)
+	if seconds, err := strconv.ParseInt(timestamp, 10, 64); err != nil || time.Since(time.Unix(seconds, 0)) > time.Minute {
+		t.Errorf("got timestamp [%s], want the current unix time", timestamp)
+	}
+
+	if got, want := request.Header.Get(webhookHeaderSignature), "v1="+service.sign(webhook.SigningSecret, timestamp, body); got != want {
+		t.Errorf("got signature [%s], want [%s]", got, want)
+	}
+	if request.Header.Get(webhookHeaderDelivery) != delivery.ID.String() || request.Header.Get(webhookHeaderEvent) != delivery.EventType {
+		t.Errorf("got delivery [%s] and event [%s] headers, want [%s] and [%s]", request.Header.Get(webhookHeaderDelivery), request.Header.Get(webhookHeaderEvent), delivery.ID, delivery.EventType)
+	}
+	if string(body) != string(delivery.Payload) {
+		t.Errorf("got body [%s], want [%s]", body, delivery.Payload)
+	}
+
+	if delivery.ResponseCode == nil || *delivery.ResponseCode != http.StatusOK || delivery.ResponseBody == nil || *delivery.ResponseBody != "OK" {
+		t.Errorf("got response [%v %v], want [200 OK]", delivery.ResponseCode, delivery.ResponseBody)
+	}
+}
+
+func TestWebhookService_SendFailsForErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	delivery := &entities.WebhookDelivery{ID: uuid.New(), Payload: []byte(`{}`)}
+	service := newTestWebhookService(server.Client())
+	if err := service.send(context.Background(), &entities.Webhook{ID: uuid.New(), URL: server.URL}, delivery); err == nil {
+		t.Fatal("sending a delivery which got a 500 response did not fail")
+	}
+
+	if delivery.ResponseCode == nil || *delivery.ResponseCode != http.StatusInternalServerError {
+		t.Errorf("got response code [%v], want [500]", delivery.ResponseCode)
+	}
+}
+
+func TestWebhookService_SendDoesNotConnectToPrivateAddresses(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	delivery := &entities.WebhookDelivery{ID: uuid.New(), Payload: []byte(`{}`)}
+	service := newTestWebhookService(NewWebhookHTTPClient(5 * time.Second))
+	if err := service.send(context.Background(), &entities.Webhook{ID: uuid.New(), URL: server.URL}, delivery); err == nil {
+		t.Error("sending a delivery to a loopback address did not fail")
+	}
+
+	if requested {
+		t.Error("the webhook client connected to a loopback address")
+	}
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// WidgetAnalyticsService rolls up entities.WidgetEvent and reports entities.ProjectAnalytics
+type WidgetAnalyticsService struct {
+	logger                       telemetry.Logger
+	tracer                       telemetry.Tracer
+	authorizationService         *AuthorizationService
+	projectIntegrationRepository repositories.ProjectIntegrationRepository
+	rollupRepository             repositories.WidgetEventRollupRepository
+}
+
+// NewWidgetAnalyticsService creates a new WidgetAnalyticsService
+func NewWidgetAnalyticsService(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	authorizationService *AuthorizationService,
+	projectIntegrationRepository repositories.ProjectIntegrationRepository,
+	rollupRepository repositories.WidgetEventRollupRepository,
+) (s *WidgetAnalyticsService) {
+	return &WidgetAnalyticsService{
+		logger:                       logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:                       tracer,
+		authorizationService:         authorizationService,
+		projectIntegrationRepository: projectIntegrationRepository,
+		rollupRepository:             rollupRepository,
+	}
+}
+
+// StartRollups periodically rolls up the entities.WidgetEvent in the background until the context is cancelled
+func (service *WidgetAnalyticsService) StartRollups(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			if err := service.Rollup(ctx, time.Now().UTC()); err != nil {
+				service.logger.Error(stacktrace.Propagate(err, "cannot rollup widget events"))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+}
+
+// Rollup recomputes the rollups from the newest hourly bucket which was rolled up until the current hour and day.
+// The buckets are recomputed from the high-water mark so that the hours and days which were missed while the rollups
+// were not running are counted, and the previous hour is always included so that late events are counted.
+func (service *WidgetAnalyticsService) Rollup(ctx context.Context, now time.Time) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	hour := now.UTC().Truncate(time.Hour)
+	last, err := service.rollupRepository.LastHourlyBucket(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load the last hourly widget event rollup before [%s]", hour)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	from := hour.Add(-time.Hour)
+	if last == nil {
+		from = time.Time{}
+	} else if last.Before(from) {
+		from = last.UTC()
+	}
+
+	hourly, err := service.rollupRepository.RollupHourly(ctx, from, hour.Add(time.Hour))
+	if err != nil {
+		msg := fmt.Sprintf("cannot rollup hourly widget events between [%s] and [%s]", from, hour)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	day := time.Date(hour.Year(), hour.Month(), hour.Day(), 0, 0, 0, 0, time.UTC)
+	daily, err := service.rollupRepository.RollupDaily(ctx, fromDay, day.AddDate(0, 0, 1))
+	if err != nil {
+		msg := fmt.Sprintf("cannot rollup daily widget events between [%s] and [%s]", fromDay, day)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("rolled up [%d] hourly and [%d] daily widget event buckets between [%s] and [%s]", hourly, daily, from, hour))
+	return nil
+}
+
+// ProjectAnalyticsParams are the parameters for fetching entities.ProjectAnalytics
+type ProjectAnalyticsParams struct {
+	UserID      entities.UserID
+	ProjectID   uuid.UUID
+	From        time.Time
+	To          time.Time
+	Granularity entities.AnalyticsGranularity
+	Location    *time.Location
+}
+
+// Get returns the entities.ProjectAnalytics of a project between params.From and params.To.
+// The daily rollups are stored in UTC so the hourly rollups are used when the analytics are in another timezone,
+// and the events are counted directly when the offset of the timezone is not a whole number of hours e.g. Asia/Kolkata.
+func (service *WidgetAnalyticsService) Get(ctx context.Context, params *ProjectAnalyticsParams) (*entities.ProjectAnalytics, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeProject(ctx, params.UserID, params.ProjectID, entities.WorkspacePermissionView); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot view project [%s]", params.UserID, params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	projectIntegrations, err := service.projectIntegrationRepository.Fetch(ctx, params.ProjectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch project integrations for project [%s] and user [%s]", params.ProjectID, params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	series := service.createSeries(params)
+	rollups, err := service.fetchRollups(ctx, params, series)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch widget event rollups for project [%s]", params.ProjectID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	analytics := &entities.ProjectAnalytics{
+		ProjectID:    params.ProjectID,
+		From:         params.From,
+		To:           params.To,
+		Granularity:  params.Granularity,
+		Timezone:     params.Location.String(),
+		Series:       series,
+		Integrations: make([]*entities.IntegrationAnalytics, 0, len(projectIntegrations)),
+	}
+
+	buckets := map[int64]*entities.AnalyticsBucket{}
+	for _, bucket := range analytics.Series {
+		buckets[bucket.Start.Unix()] = bucket
+	}
+
+	clicks := map[uuid.UUID]uint{}
+	for _, rollup := range rollups {
+		analytics.Totals.Add(rollup.Type, rollup.Count)
+		if bucket, ok := buckets[service.bucketStart(rollup.BucketStart, params).Unix()]; ok {
+			bucket.Add(rollup.Type, rollup.Count)
+		}
+		if rollup.Type == entities.WidgetEventTypeIntegrationClicked {
+			clicks[rollup.IntegrationID] += rollup.Count
+		}
+	}
+
+	analytics.Totals.ComputeRates(analytics.Totals.Impressions)
+	for _, bucket := range analytics.Series {
+		bucket.ComputeRates(bucket.Impressions)
+	}
+
+	for _, projectIntegration := range projectIntegrations {
+		integration := &entities.IntegrationAnalytics{
+			IntegrationID: projectIntegration.IntegrationID,
+			Type:          projectIntegration.Type,
+			Name:          projectIntegration.Name,
+			Position:      projectIntegration.Position,
+			AnalyticsMetrics: entities.AnalyticsMetrics{
+				Impressions: analytics.Totals.Impressions,
+				Opens:       analytics.Totals.Opens,
+				Clicks:      clicks[projectIntegration.IntegrationID],
+			},
+		}
+		integration.ComputeRates(integration.Impressions)
+		analytics.Integrations = append(analytics.Integrations, integration)
+	}
+
+	return analytics, nil
+}
+
+// DeleteAll deletes the hourly and daily entities.WidgetEventRollup of a deleted project.
+// The widget events must be deleted first so that a rollup which runs at the same time does not recreate them.
+func (service *WidgetAnalyticsService) DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	count, err := service.rollupRepository.DeleteAll(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete widget event rollups of project [%s]", projectID)
+		return count, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return count, nil
+}
+
+func (service *WidgetAnalyticsService) fetchRollups(ctx context.Context, params *ProjectAnalyticsParams, series []*entities.AnalyticsBucket) ([]*entities.WidgetEventRollup, error) {
+	if !service.hasWholeHourOffsets(series) {
+		return service.rollupRepository.Aggregate(ctx, params.ProjectID, params.From, params.To, params.Granularity, params.Location)
+	}
+	if params.Granularity == entities.AnalyticsGranularityDay && params.Location == time.UTC {
+		return service.rollupRepository.FetchDaily(ctx, params.ProjectID, params.From, params.To)
+	}
+	return service.rollupRepository.FetchHourly(ctx, params.ProjectID, params.From, params.To)
+}
+
+// hasWholeHourOffsets checks if the UTC hourly rollups line up with every bucket of the series.
+// The offset is checked for every bucket because it can change by 30 minutes with daylight saving time e.g. Australia/Lord_Howe.
+func (service *WidgetAnalyticsService) hasWholeHourOffsets(series []*entities.AnalyticsBucket) bool {
+	for _, bucket := range series {
+		if _, offset := bucket.Start.Zone(); offset%int(time.Hour/time.Second) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// createSeries creates an empty entities.AnalyticsBucket for every bucket between params.From and params.To so that the series has no gaps
+func (service *WidgetAnalyticsService) createSeries(params *ProjectAnalyticsParams) []*entities.AnalyticsBucket {
+	var series []*entities.AnalyticsBucket
+	for start := params.From.In(params.Location); start.Before(params.To); start = service.nextBucket(start, params.Granularity) {
+		series = append(series, &entities.AnalyticsBucket{Start: start})
+	}
+	return series
+}
+
+func (service *WidgetAnalyticsService) nextBucket(start time.Time, granularity entities.AnalyticsGranularity) time.Time {
+	if granularity == entities.AnalyticsGranularityHour {
+		return start.Add(time.Hour)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// bucketStart returns the start of the bucket in params.Location which contains a UTC rollup
+func (service *WidgetAnalyticsService) bucketStart(timestamp time.Time, params *ProjectAnalyticsParams) time.Time {
+	local := timestamp.In(params.Location)
+	if params.Granularity == entities.AnalyticsGranularityHour {
+		return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, params.Location)
+	}
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, params.Location)
+}
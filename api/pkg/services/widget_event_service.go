@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/events"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// WidgetEventService records the entities.WidgetEvent which are sent by the widget
+type WidgetEventService struct {
+	service
+	tracer          telemetry.Tracer
+	logger          telemetry.Logger
+	eventDispatcher *EventDispatcher
+	transactor      repositories.Transactor
+	settingsService *ProjectSettingsService
+	repository      repositories.WidgetEventRepository
+	visitorSalt     string
+}
+
+// NewWidgetEventService creates a new WidgetEventService
+func NewWidgetEventService(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	eventDispatcher *EventDispatcher,
+	transactor repositories.Transactor,
+	settingsService *ProjectSettingsService,
+	repository repositories.WidgetEventRepository,
+	visitorSalt string,
+) (s *WidgetEventService) {
+	return &WidgetEventService{
+		logger:          logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:          tracer,
+		eventDispatcher: eventDispatcher,
+		transactor:      transactor,
+		settingsService: settingsService,
+		repository:      repository,
+		visitorSalt:     visitorSalt,
+	}
+}
+
+// WidgetEventParams is a single interaction of a visitor with the widget
+type WidgetEventParams struct {
+	Type          entities.WidgetEventType
+	IntegrationID *uuid.UUID
+	PageURL       string
+}
+
+// WidgetEventsRecordParams are the parameters for recording a batch of widget events
+type WidgetEventsRecordParams struct {
+	Source         string
+	PublishableKey string
+	Origin         string
+	IPAddress      string
+	UserAgent      string
+	Events         []*WidgetEventParams
+}
+
+// Record stores a batch of widget events for the project with the publishable key and returns the number of stored events.
+// Events for integrations which are not enabled on the project are dropped because the widget may render stale settings.
+func (service *WidgetEventService) Record(ctx context.Context, params *WidgetEventsRecordParams) (int, error) {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	// the page is not known for a batch so the integrations are not filtered by their display rules
+	snapshot, err := service.settingsService.GetPublicByKey(ctx, params.PublishableKey, params.Origin, nil)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load the settings for the publishable key from origin [%s]", params.Origin)
+		return 0, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	integrationIDs, err := service.integrationIDs(snapshot)
+	if err != nil {
+		msg := fmt.Sprintf("cannot decode the integrations in the settings snapshot of project [%s]", snapshot.ProjectID)
+		return 0, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	visitorID := service.visitorID(snapshot.ProjectID, params.IPAddress, params.UserAgent)
+	widgetEvents := make([]*entities.WidgetEvent, 0, len(params.Events))
+	counts := map[entities.WidgetEventType]int{}
+	for _, event := range params.Events {
+		if event.IntegrationID != nil && !integrationIDs[*event.IntegrationID] {
+			ctxLogger.Warn(stacktrace.NewError(fmt.Sprintf("dropping [%s] event for integration [%s] which is not enabled on project [%s]", event.Type, event.IntegrationID, snapshot.ProjectID)))
+			continue
+		}
+
+		counts[event.Type]++
+		widgetEvents = append(widgetEvents, &entities.WidgetEvent{
+			ID:            uuid.New(),
+			UserID:        snapshot.UserID,
+			ProjectID:     snapshot.ProjectID,
+			IntegrationID: event.IntegrationID,
+			Type:          event.Type,
+			VisitorID:     visitorID,
+			PageURL:       event.PageURL,
+			CreatedAt:     time.Now().UTC(),
+		})
+	}
+
+	if len(widgetEvents) == 0 {
+		return 0, nil
+	}
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.repository.StoreBatch(ctx, widgetEvents); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store [%d] widget events for project [%s]", len(widgetEvents), snapshot.ProjectID))
+		}
+		return service.dispatchWidgetEventsRecordedEvent(ctx, params.Source, snapshot, counts)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot record [%d] widget events for project [%s]", len(widgetEvents), snapshot.ProjectID)
+		return 0, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return len(widgetEvents), nil
+}
+
+// DeleteAll deletes every entities.WidgetEvent of a deleted project and returns the number of deleted events
+func (service *WidgetEventService) DeleteAll(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	count, err := service.repository.DeleteAll(ctx, projectID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot delete widget events of project [%s]", projectID)
+		return count, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return count, nil
+}
+
+// visitorID hashes the IP address and user agent of a visitor with a salt which changes every day.
+// The same visitor has the same ID on a project for a day but the ID cannot be reversed or linked across projects and days.
+func (service *WidgetEventService) visitorID(projectID uuid.UUID, ipAddress string, userAgent string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s|%s", service.visitorSalt, time.Now().UTC().Format("2006-01-02"), projectID, ipAddress, userAgent)))
+	return hex.EncodeToString(hash[:16])
+}
+
+func (service *WidgetEventService) integrationIDs(snapshot *entities.ProjectSettingsSnapshot) (map[uuid.UUID]bool, error) {
+	settings := new(entities.PublicProjectSettings)
+	if err := json.Unmarshal(snapshot.Settings, settings); err != nil {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot unmarshal settings snapshot of project [%s]", snapshot.ProjectID))
+	}
+
+	result := make(map[uuid.UUID]bool, len(settings.Integrations))
+	for _, integration := range settings.Integrations {
+		result[integration.ID] = true
+	}
+
+	// integrations which are hidden outside their schedule were displayed to visitors who loaded the widget earlier
+	for integrationID := range snapshot.Availability {
+		result[integrationID] = true
+	}
+	return result, nil
+}
+
+func (service *WidgetEventService) dispatchWidgetEventsRecordedEvent(ctx context.Context, source string, snapshot *entities.ProjectSettingsSnapshot, counts map[entities.WidgetEventType]int) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	event, err := service.createEvent(events.WidgetEventsRecorded, source, &events.WidgetEventsRecordedPayload{
+		UserID:     snapshot.UserID,
+		ProjectID:  snapshot.ProjectID,
+		Counts:     counts,
+		RecordedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot create [%s] event for project [%s]", events.WidgetEventsRecorded, snapshot.ProjectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if err = service.eventDispatcher.Dispatch(ctx, event); err != nil {
+		msg := fmt.Sprintf("cannot dispatch [%s] event for project [%s]", event.Type(), snapshot.ProjectID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
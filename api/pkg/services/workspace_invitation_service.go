@@ -0,0 +1,510 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/events"
+	"github.com/NdoleStudio/superbutton/pkg/mailer"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// ErrCodeInvitationNotPending is returned when an entities.WorkspaceInvitation is used after it was accepted, revoked or expired
+const ErrCodeInvitationNotPending = stacktrace.ErrorCode(6002)
+
+const (
+	workspaceInvitationTokenPrefix = "inv_"
+	workspaceInvitationTTL         = 7 * 24 * time.Hour
+)
+
+// WorkspaceInvitationService is responsible for inviting email addresses to join an entities.Workspace
+type WorkspaceInvitationService struct {
+	service
+	logger               telemetry.Logger
+	tracer               telemetry.Tracer
+	transactor           repositories.Transactor
+	repository           repositories.WorkspaceInvitationRepository
+	memberRepository     repositories.WorkspaceMemberRepository
+	workspaceRepository  repositories.WorkspaceRepository
+	userRepository       repositories.UserRepository
+	authorizationService *AuthorizationService
+	eventDispatcher      *EventDispatcher
+	mailer               mailer.Mailer
+	webAppURL            string
+}
+
+// NewWorkspaceInvitationService creates a new WorkspaceInvitationService
+func NewWorkspaceInvitationService(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	eventDispatcher *EventDispatcher,
+	transactor repositories.Transactor,
+	repository repositories.WorkspaceInvitationRepository,
+	memberRepository repositories.WorkspaceMemberRepository,
+	workspaceRepository repositories.WorkspaceRepository,
+	userRepository repositories.UserRepository,
+	authorizationService *AuthorizationService,
+	mailer mailer.Mailer,
+	webAppURL string,
+) (s *WorkspaceInvitationService) {
+	return &WorkspaceInvitationService{
+		logger:               logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:               tracer,
+		eventDispatcher:      eventDispatcher,
+		transactor:           transactor,
+		repository:           repository,
+		memberRepository:     memberRepository,
+		workspaceRepository:  workspaceRepository,
+		userRepository:       userRepository,
+		authorizationService: authorizationService,
+		mailer:               mailer,
+		webAppURL:            strings.TrimRight(webAppURL, "/"),
+	}
+}
+
+// WorkspaceInvitationCreateParams are the parameters for inviting an email address to a workspace
+type WorkspaceInvitationCreateParams struct {
+	Source      string
+	UserID      entities.UserID
+	WorkspaceID uuid.UUID
+	Email       string
+	Role        entities.WorkspaceRole
+}
+
+// Create an entities.WorkspaceInvitation and email the token to the invited address.
+// The pending invitations which were sent to the same email address for the workspace are revoked.
+func (service *WorkspaceInvitationService) Create(ctx context.Context, params *WorkspaceInvitationCreateParams) (*entities.WorkspaceInvitation, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeWorkspace(ctx, params.UserID, params.WorkspaceID, params.Role.GrantPermission()); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot invite an [%s] to workspace [%s]", params.UserID, params.Role, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	workspace, err := service.workspaceRepository.Load(ctx, params.WorkspaceID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load workspace [%s]", params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if err = service.ensureNotMember(ctx, workspace.ID, params.Email); err != nil {
+		msg := fmt.Sprintf("cannot invite [%s] to workspace [%s]", params.Email, workspace.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	inviter, err := service.userRepository.Load(ctx, params.UserID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user [%s]", params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	token, err := service.generateToken()
+	if err != nil {
+		msg := fmt.Sprintf("cannot generate token to invite [%s] to workspace [%s]", params.Email, workspace.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	invitation := &entities.WorkspaceInvitation{
+		ID:          uuid.New(),
+		WorkspaceID: workspace.ID,
+		Email:       params.Email,
+		Role:        params.Role,
+		TokenHash:   service.hashToken(token),
+		InvitedBy:   params.UserID,
+		ExpiresAt:   time.Now().UTC().Add(workspaceInvitationTTL),
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	}
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.revokePending(ctx, params.Source, workspace.ID, params.Email, params.UserID); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot revoke pending invitations of [%s] to workspace [%s]", params.Email, workspace.ID))
+		}
+		if err = service.repository.Store(ctx, invitation); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store invitation of [%s] to workspace [%s]", params.Email, workspace.ID))
+		}
+		return service.dispatchWorkspaceInvitationCreatedEvent(ctx, params.Source, invitation)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot invite [%s] to workspace [%s]", params.Email, workspace.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	// the email is sent after the commit so that the token is never emailed for an invitation which was rolled back.
+	// The invitation is sent again by inviting the same email address which revokes this invitation.
+	if err = service.mailer.Send(ctx, service.invitationMessage(workspace, inviter, invitation, token)); err != nil {
+		msg := fmt.Sprintf("cannot email invitation [%s] to [%s] for workspace [%s]", invitation.ID, params.Email, workspace.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return invitation, nil
+}
+
+// Index fetches the entities.WorkspaceInvitation of a workspace of an authenticated user
+func (service *WorkspaceInvitationService) Index(ctx context.Context, userID entities.UserID, workspaceID uuid.UUID) ([]*entities.WorkspaceInvitation, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeWorkspace(ctx, userID, workspaceID, entities.WorkspacePermissionManage); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot view the invitations of workspace [%s]", userID, workspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	invitations, err := service.repository.Fetch(ctx, workspaceID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch invitations of workspace [%s]", workspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return invitations, nil
+}
+
+// WorkspaceInvitationRevokeParams are the parameters for revoking an entities.WorkspaceInvitation
+type WorkspaceInvitationRevokeParams struct {
+	Source       string
+	UserID       entities.UserID
+	WorkspaceID  uuid.UUID
+	InvitationID uuid.UUID
+}
+
+// Revoke a pending entities.WorkspaceInvitation so that its token can no longer be accepted
+func (service *WorkspaceInvitationService) Revoke(ctx context.Context, params *WorkspaceInvitationRevokeParams) (*entities.WorkspaceInvitation, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeWorkspace(ctx, params.UserID, params.WorkspaceID, entities.WorkspacePermissionManage); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot revoke invitations of workspace [%s]", params.UserID, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	invitation, err := service.repository.Load(ctx, params.WorkspaceID, params.InvitationID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load invitation [%s] of workspace [%s]", params.InvitationID, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if status := invitation.Status(time.Now().UTC()); status != entities.WorkspaceInvitationStatusPending {
+		msg := fmt.Sprintf("invitation [%s] cannot be revoked because it is [%s]", invitation.ID, status)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeInvitationNotPending, msg))
+	}
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		return service.revoke(ctx, params.Source, invitation, params.UserID)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot revoke invitation [%s] of workspace [%s]", invitation.ID, invitation.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return invitation, nil
+}
+
+// WorkspaceInvitationAcceptParams are the parameters for accepting an entities.WorkspaceInvitation
+type WorkspaceInvitationAcceptParams struct {
+	Source string
+	UserID entities.UserID
+	Token  string
+}
+
+// Accept an entities.WorkspaceInvitation with the token which was sent by email.
+// The invitation can only be accepted by the user with the email address which was invited.
+func (service *WorkspaceInvitationService) Accept(ctx context.Context, params *WorkspaceInvitationAcceptParams) (*entities.WorkspaceMember, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	invitation, err := service.repository.LoadByTokenHash(ctx, service.hashToken(params.Token))
+	if err != nil {
+		msg := fmt.Sprintf("cannot load invitation for user [%s]", params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	if status := invitation.Status(time.Now().UTC()); status != entities.WorkspaceInvitationStatusPending {
+		msg := fmt.Sprintf("invitation [%s] cannot be accepted because it is [%s]", invitation.ID, status)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeInvitationNotPending, msg))
+	}
+
+	user, err := service.userRepository.Load(ctx, params.UserID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user [%s]", params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		msg := fmt.Sprintf("invitation [%s] was not sent to the email of user [%s]", invitation.ID, user.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeForbidden, msg))
+	}
+
+	var member *entities.WorkspaceMember
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		member, err = service.claim(ctx, params.Source, invitation, user)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("user [%s] cannot accept invitation [%s]", user.ID, invitation.ID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return member, nil
+}
+
+// ClaimPending accepts every pending entities.WorkspaceInvitation which was sent to the email address of a user.
+// It is used when a user signs up after being invited. The email address of the user must be verified.
+func (service *WorkspaceInvitationService) ClaimPending(ctx context.Context, source string, user *entities.User) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	invitations, err := service.repository.FetchPending(ctx, user.Email, time.Now().UTC())
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch pending invitations of user [%s]", user.ID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	for _, invitation := range invitations {
+		_, err = service.claim(ctx, source, invitation, user)
+		if stacktrace.GetCode(err) == ErrCodeInvitationNotPending {
+			ctxLogger.Info(fmt.Sprintf("skipping invitation [%s] because it is no longer pending", invitation.ID))
+			continue
+		}
+		if err != nil {
+			msg := fmt.Sprintf("user [%s] cannot claim invitation [%s]", user.ID, invitation.ID)
+			return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+		ctxLogger.Info(fmt.Sprintf("user [%s] claimed invitation [%s] to workspace [%s]", user.ID, invitation.ID, invitation.WorkspaceID))
+	}
+
+	return nil
+}
+
+// claim adds the user to the workspace of the invitation and marks the invitation as accepted.
+// A user who is already a member of the workspace keeps their role.
+func (service *WorkspaceInvitationService) claim(ctx context.Context, source string, invitation *entities.WorkspaceInvitation, user *entities.User) (*entities.WorkspaceMember, error) {
+	now := time.Now().UTC()
+	invitation.AcceptedAt = &now
+	invitation.AcceptedBy = &user.ID
+	invitation.UpdatedAt = now
+
+	updated, err := service.repository.UpdatePending(ctx, invitation, now)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot update invitation [%s]", invitation.ID))
+	}
+
+	if !updated {
+		msg := fmt.Sprintf("invitation [%s] was accepted, revoked or expired by another request", invitation.ID)
+		return nil, stacktrace.NewErrorWithCode(ErrCodeInvitationNotPending, msg)
+	}
+
+	if err := service.dispatchWorkspaceInvitationAcceptedEvent(ctx, source, invitation); err != nil {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for invitation [%s]", invitation.ID))
+	}
+
+	member, err := service.memberRepository.Load(ctx, invitation.WorkspaceID, user.ID)
+	if err == nil {
+		return member, nil
+	}
+
+	if stacktrace.GetCode(err) != repositories.ErrCodeNotFound {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot load member [%s] of workspace [%s]", user.ID, invitation.WorkspaceID))
+	}
+
+	member = &entities.WorkspaceMember{
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      user.ID,
+		Email:       user.Email,
+		Role:        invitation.Role,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err = service.memberRepository.Store(ctx, member); err != nil {
+		return nil, stacktrace.Propagate(err, fmt.Sprintf("cannot store member [%s] of workspace [%s]", member.UserID, member.WorkspaceID))
+	}
+
+	return member, service.dispatchWorkspaceMemberAddedEvent(ctx, source, member, invitation.InvitedBy)
+}
+
+func (service *WorkspaceInvitationService) ensureNotMember(ctx context.Context, workspaceID uuid.UUID, email string) error {
+	user, err := service.userRepository.LoadByEmail(ctx, email)
+	if stacktrace.GetCode(err) == repositories.ErrCodeNotFound {
+		return nil
+	}
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot load user with email [%s]", email))
+	}
+
+	member, err := service.memberRepository.Load(ctx, workspaceID, user.ID)
+	if err == nil {
+		return stacktrace.NewErrorWithCode(ErrCodeWorkspaceMemberExists, fmt.Sprintf("user [%s] is already an [%s] of workspace [%s]", user.ID, member.Role, workspaceID))
+	}
+	if stacktrace.GetCode(err) != repositories.ErrCodeNotFound {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot load member [%s] of workspace [%s]", user.ID, workspaceID))
+	}
+
+	return nil
+}
+
+func (service *WorkspaceInvitationService) revokePending(ctx context.Context, source string, workspaceID uuid.UUID, email string, userID entities.UserID) error {
+	invitations, err := service.repository.FetchPending(ctx, email, time.Now().UTC())
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot fetch pending invitations for [%s]", email))
+	}
+
+	for _, invitation := range invitations {
+		if invitation.WorkspaceID != workspaceID {
+			continue
+		}
+		err = service.revoke(ctx, source, invitation, userID)
+		if stacktrace.GetCode(err) == ErrCodeInvitationNotPending {
+			continue
+		}
+		if err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot revoke invitation [%s]", invitation.ID))
+		}
+	}
+
+	return nil
+}
+
+func (service *WorkspaceInvitationService) revoke(ctx context.Context, source string, invitation *entities.WorkspaceInvitation, userID entities.UserID) error {
+	now := time.Now().UTC()
+	invitation.RevokedAt = &now
+	invitation.UpdatedAt = now
+
+	updated, err := service.repository.UpdatePending(ctx, invitation, now)
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot update invitation [%s]", invitation.ID))
+	}
+
+	if !updated {
+		msg := fmt.Sprintf("invitation [%s] was accepted, revoked or expired by another request", invitation.ID)
+		return stacktrace.NewErrorWithCode(ErrCodeInvitationNotPending, msg)
+	}
+
+	return service.dispatchWorkspaceInvitationRevokedEvent(ctx, source, invitation, userID)
+}
+
+// generateToken creates a random token which is only known by the invited email address
+func (service *WorkspaceInvitationService) generateToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", stacktrace.Propagate(err, "cannot read random bytes")
+	}
+	return workspaceInvitationTokenPrefix + hex.EncodeToString(bytes), nil
+}
+
+func (service *WorkspaceInvitationService) hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func (service *WorkspaceInvitationService) invitationMessage(workspace *entities.Workspace, inviter *entities.User, invitation *entities.WorkspaceInvitation, token string) *mailer.Message {
+	inviterName := inviter.Name
+	if inviterName == "" {
+		inviterName = inviter.Email
+	}
+
+	link := fmt.Sprintf("%s/invitations/accept?token=%s", service.webAppURL, url.QueryEscape(token))
+	subject := fmt.Sprintf("%s invited you to %s on Superbutton", inviterName, workspace.Name)
+	expiresAt := invitation.ExpiresAt.Format("January 2, 2006")
+
+	return &mailer.Message{
+		ToEmail: invitation.Email,
+		Subject: subject,
+		Text: fmt.Sprintf(
+			"%s invited you to join the %s workspace on Superbutton with the %s role.\n\nAccept the invitation before %s:\n%s\n",
+			inviterName,
+			workspace.Name,
+			invitation.Role,
+			expiresAt,
+			link,
+		),
+		HTML: fmt.Sprintf(
+			"<p>%s invited you to join the <strong>%s</strong> workspace on Superbutton with the %s role.</p><p><a href=\"%s\">Accept the invitation</a> before %s.</p>",
+			html.EscapeString(inviterName),
+			html.EscapeString(workspace.Name),
+			invitation.Role,
+			html.EscapeString(link),
+			expiresAt,
+		),
+	}
+}
+
+func (service *WorkspaceInvitationService) dispatchWorkspaceInvitationCreatedEvent(ctx context.Context, source string, invitation *entities.WorkspaceInvitation) error {
+	event, err := service.createEvent(events.WorkspaceInvitationCreated, source, &events.WorkspaceInvitationCreatedPayload{
+		WorkspaceID:         invitation.WorkspaceID,
+		InvitationID:        invitation.ID,
+		InvitationEmail:     invitation.Email,
+		InvitationRole:      invitation.Role,
+		InvitedBy:           invitation.InvitedBy,
+		InvitationExpiresAt: invitation.ExpiresAt,
+		InvitationCreatedAt: invitation.CreatedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for invitation [%s]", events.WorkspaceInvitationCreated, invitation.ID))
+	}
+	return service.dispatchEvent(ctx, invitation.WorkspaceID, event)
+}
+
+func (service *WorkspaceInvitationService) dispatchWorkspaceInvitationRevokedEvent(ctx context.Context, source string, invitation *entities.WorkspaceInvitation, revokedBy entities.UserID) error {
+	event, err := service.createEvent(events.WorkspaceInvitationRevoked, source, &events.WorkspaceInvitationRevokedPayload{
+		WorkspaceID:         invitation.WorkspaceID,
+		InvitationID:        invitation.ID,
+		InvitationEmail:     invitation.Email,
+		RevokedBy:           revokedBy,
+		InvitationRevokedAt: *invitation.RevokedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for invitation [%s]", events.WorkspaceInvitationRevoked, invitation.ID))
+	}
+	return service.dispatchEvent(ctx, invitation.WorkspaceID, event)
+}
+
+func (service *WorkspaceInvitationService) dispatchWorkspaceInvitationAcceptedEvent(ctx context.Context, source string, invitation *entities.WorkspaceInvitation) error {
+	event, err := service.createEvent(events.WorkspaceInvitationAccepted, source, &events.WorkspaceInvitationAcceptedPayload{
+		WorkspaceID:          invitation.WorkspaceID,
+		InvitationID:         invitation.ID,
+		UserID:               *invitation.AcceptedBy,
+		InvitationEmail:      invitation.Email,
+		InvitationRole:       invitation.Role,
+		InvitationAcceptedAt: *invitation.AcceptedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for invitation [%s]", events.WorkspaceInvitationAccepted, invitation.ID))
+	}
+	return service.dispatchEvent(ctx, invitation.WorkspaceID, event)
+}
+
+func (service *WorkspaceInvitationService) dispatchWorkspaceMemberAddedEvent(ctx context.Context, source string, member *entities.WorkspaceMember, addedBy entities.UserID) error {
+	event, err := service.createEvent(events.WorkspaceMemberAdded, source, &events.WorkspaceMemberAddedPayload{
+		WorkspaceID:   member.WorkspaceID,
+		UserID:        member.UserID,
+		MemberEmail:   member.Email,
+		MemberRole:    member.Role,
+		AddedBy:       addedBy,
+		MemberAddedAt: member.CreatedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for member [%s]", events.WorkspaceMemberAdded, member.UserID))
+	}
+	return service.dispatchEvent(ctx, member.WorkspaceID, event)
+}
+
+func (service *WorkspaceInvitationService) dispatchEvent(ctx context.Context, workspaceID uuid.UUID, event *cloudevents.Event) error {
+	if err := service.eventDispatcher.Dispatch(ctx, event); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch [%s] event for workspace [%s]", event.Type(), workspaceID))
+	}
+	return nil
+}
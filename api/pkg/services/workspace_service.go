@@ -0,0 +1,525 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/events"
+	"github.com/NdoleStudio/superbutton/pkg/repositories"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/google/uuid"
+	"github.com/palantir/stacktrace"
+)
+
+// ErrCodeWorkspaceMemberExists is returned when a user is added to a workspace in which they are already a member
+const ErrCodeWorkspaceMemberExists = stacktrace.ErrorCode(6001)
+
+// WorkspaceService is responsible for managing entities.Workspace and their entities.WorkspaceMember
+type WorkspaceService struct {
+	service
+	logger               telemetry.Logger
+	tracer               telemetry.Tracer
+	transactor           repositories.Transactor
+	repository           repositories.WorkspaceRepository
+	memberRepository     repositories.WorkspaceMemberRepository
+	projectRepository    repositories.ProjectRepository
+	userRepository       repositories.UserRepository
+	authorizationService *AuthorizationService
+	eventDispatcher      *EventDispatcher
+}
+
+// NewWorkspaceService creates a new WorkspaceService
+func NewWorkspaceService(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+	eventDispatcher *EventDispatcher,
+	transactor repositories.Transactor,
+	repository repositories.WorkspaceRepository,
+	memberRepository repositories.WorkspaceMemberRepository,
+	projectRepository repositories.ProjectRepository,
+	userRepository repositories.UserRepository,
+	authorizationService *AuthorizationService,
+) (s *WorkspaceService) {
+	return &WorkspaceService{
+		logger:               logger.WithService(fmt.Sprintf("%T", s)),
+		tracer:               tracer,
+		eventDispatcher:      eventDispatcher,
+		transactor:           transactor,
+		repository:           repository,
+		memberRepository:     memberRepository,
+		projectRepository:    projectRepository,
+		userRepository:       userRepository,
+		authorizationService: authorizationService,
+	}
+}
+
+// Index fetches every entities.UserWorkspace of an authenticated user. The personal workspace of the user is created if it does not exist.
+func (service *WorkspaceService) Index(ctx context.Context, source string, userID entities.UserID) ([]*entities.UserWorkspace, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.Personal(ctx, source, userID); err != nil {
+		msg := fmt.Sprintf("cannot load personal workspace of user [%s]", userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	workspaces, err := service.repository.FetchForMember(ctx, userID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch workspaces of user [%s]", userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return workspaces, nil
+}
+
+// Personal loads the personal entities.Workspace of a user and creates it if it does not exist
+func (service *WorkspaceService) Personal(ctx context.Context, source string, userID entities.UserID) (*entities.Workspace, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	workspace, err := service.repository.LoadPersonal(ctx, userID)
+	if err == nil {
+		return workspace, nil
+	}
+
+	if stacktrace.GetCode(err) != repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot load personal workspace of user [%s]", userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	workspace, err = service.Create(ctx, &WorkspaceCreateParams{
+		Source:   source,
+		UserID:   userID,
+		Name:     "Personal",
+		Personal: true,
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot create personal workspace of user [%s]", userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return workspace, nil
+}
+
+// Load an entities.Workspace if the role of the user in the workspace has a permission
+func (service *WorkspaceService) Load(ctx context.Context, userID entities.UserID, workspaceID uuid.UUID, permission entities.WorkspacePermission) (*entities.Workspace, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeWorkspace(ctx, userID, workspaceID, permission); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot [%s] workspace [%s]", userID, permission, workspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	workspace, err := service.repository.Load(ctx, workspaceID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load workspace [%s]", workspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	return workspace, nil
+}
+
+// WorkspaceCreateParams are the parameters for creating an entities.Workspace
+type WorkspaceCreateParams struct {
+	Source   string
+	UserID   entities.UserID
+	Name     string
+	Personal bool
+}
+
+// Create a new entities.Workspace with the user as its owner
+func (service *WorkspaceService) Create(ctx context.Context, params *WorkspaceCreateParams) (*entities.Workspace, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	user, err := service.userRepository.Load(ctx, params.UserID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user [%s]", params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	workspace := &entities.Workspace{
+		ID:        uuid.New(),
+		OwnerID:   params.UserID,
+		Name:      params.Name,
+		Personal:  params.Personal,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	member := &entities.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+		Email:       user.Email,
+		Role:        entities.WorkspaceRoleOwner,
+		CreatedAt:   workspace.CreatedAt,
+		UpdatedAt:   workspace.UpdatedAt,
+	}
+
+	stored := true
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if stored, err = service.store(ctx, workspace); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store workspace for user [%s]", params.UserID))
+		}
+		if !stored {
+			return nil
+		}
+		if err = service.memberRepository.Store(ctx, member); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store owner of workspace [%s]", workspace.ID))
+		}
+		if err = service.dispatchWorkspaceCreatedEvent(ctx, params.Source, workspace); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch event for workspace [%s]", workspace.ID))
+		}
+		return service.dispatchWorkspaceMemberAddedEvent(ctx, params.Source, member, params.UserID)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot create workspace for user [%s]", params.UserID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	if !stored {
+		return service.loadPersonal(ctx, params.UserID)
+	}
+
+	return workspace, nil
+}
+
+// store saves a new entities.Workspace. A personal workspace is not stored when a concurrent request already created one.
+func (service *WorkspaceService) store(ctx context.Context, workspace *entities.Workspace) (bool, error) {
+	if !workspace.Personal {
+		return true, service.repository.Store(ctx, workspace)
+	}
+	return service.repository.StorePersonal(ctx, workspace)
+}
+
+// loadPersonal loads the personal entities.Workspace which was created by a concurrent request
+func (service *WorkspaceService) loadPersonal(ctx context.Context, userID entities.UserID) (*entities.Workspace, error) {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	workspace, err := service.repository.LoadPersonal(ctx, userID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load the existing personal workspace of user [%s]", userID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	ctxLogger.Info(fmt.Sprintf("user [%s] already has personal workspace [%s]", userID, workspace.ID))
+	return workspace, nil
+}
+
+// WorkspaceUpdateParams are the parameters for updating an entities.Workspace
+type WorkspaceUpdateParams struct {
+	Source      string
+	UserID      entities.UserID
+	WorkspaceID uuid.UUID
+	Name        string
+}
+
+// Update an entities.Workspace of an authenticated user
+func (service *WorkspaceService) Update(ctx context.Context, params *WorkspaceUpdateParams) (*entities.Workspace, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	workspace, err := service.Load(ctx, params.UserID, params.WorkspaceID, entities.WorkspacePermissionManage)
+	if err != nil {
+		msg := fmt.Sprintf("user [%s] cannot update workspace [%s]", params.UserID, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	workspace.Name = params.Name
+	workspace.UpdatedAt = time.Now().UTC()
+
+	if err = service.repository.Update(ctx, workspace); err != nil {
+		msg := fmt.Sprintf("cannot update workspace [%s]", params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return workspace, nil
+}
+
+// IndexMembers fetches the entities.WorkspaceMember of a workspace of an authenticated user
+func (service *WorkspaceService) IndexMembers(ctx context.Context, userID entities.UserID, workspaceID uuid.UUID) ([]*entities.WorkspaceMember, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeWorkspace(ctx, userID, workspaceID, entities.WorkspacePermissionView); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot view the members of workspace [%s]", userID, workspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	members, err := service.memberRepository.Fetch(ctx, workspaceID)
+	if err != nil {
+		msg := fmt.Sprintf("cannot fetch members of workspace [%s]", workspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return members, nil
+}
+
+// WorkspaceMemberAddParams are the parameters for adding a user to an entities.Workspace
+type WorkspaceMemberAddParams struct {
+	Source      string
+	UserID      entities.UserID
+	WorkspaceID uuid.UUID
+	Email       string
+	Role        entities.WorkspaceRole
+}
+
+// AddMember adds the user with an email address to a workspace.
+// Only the owners of the workspace can add another owner.
+func (service *WorkspaceService) AddMember(ctx context.Context, params *WorkspaceMemberAddParams) (*entities.WorkspaceMember, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	if _, err := service.authorizationService.AuthorizeWorkspace(ctx, params.UserID, params.WorkspaceID, params.Role.GrantPermission()); err != nil {
+		msg := fmt.Sprintf("user [%s] cannot add an [%s] to workspace [%s]", params.UserID, params.Role, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	user, err := service.userRepository.LoadByEmail(ctx, params.Email)
+	if err != nil {
+		msg := fmt.Sprintf("cannot load user with email [%s]", params.Email)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	member, err := service.memberRepository.Load(ctx, params.WorkspaceID, user.ID)
+	if err == nil {
+		msg := fmt.Sprintf("user [%s] is already an [%s] of workspace [%s]", user.ID, member.Role, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.NewErrorWithCode(ErrCodeWorkspaceMemberExists, msg))
+	}
+
+	if stacktrace.GetCode(err) != repositories.ErrCodeNotFound {
+		msg := fmt.Sprintf("cannot load member [%s] of workspace [%s]", user.ID, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	member = &entities.WorkspaceMember{
+		WorkspaceID: params.WorkspaceID,
+		UserID:      user.ID,
+		Email:       user.Email,
+		Role:        params.Role,
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	}
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.memberRepository.Store(ctx, member); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot store member [%s] of workspace [%s]", member.UserID, member.WorkspaceID))
+		}
+		return service.dispatchWorkspaceMemberAddedEvent(ctx, params.Source, member, params.UserID)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot add user [%s] to workspace [%s]", user.ID, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return member, nil
+}
+
+// WorkspaceMemberUpdateParams are the parameters for changing the role of an entities.WorkspaceMember
+type WorkspaceMemberUpdateParams struct {
+	Source      string
+	UserID      entities.UserID
+	WorkspaceID uuid.UUID
+	MemberID    entities.UserID
+	Role        entities.WorkspaceRole
+}
+
+// UpdateMember changes the role of a member of a workspace.
+// Only the owners of the workspace can change the role of an owner or promote a member to owner,
+// and the role of the user who created the workspace cannot be changed.
+func (service *WorkspaceService) UpdateMember(ctx context.Context, params *WorkspaceMemberUpdateParams) (*entities.WorkspaceMember, error) {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	member, err := service.authorizeMemberChange(ctx, params.UserID, params.WorkspaceID, params.MemberID, params.Role.GrantPermission())
+	if err != nil {
+		msg := fmt.Sprintf("user [%s] cannot change the role of member [%s] in workspace [%s]", params.UserID, params.MemberID, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	previousRole := member.Role
+	member.Role = params.Role
+	member.UpdatedAt = time.Now().UTC()
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.memberRepository.Update(ctx, member); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot update member [%s] of workspace [%s]", member.UserID, member.WorkspaceID))
+		}
+		return service.dispatchWorkspaceMemberRoleUpdatedEvent(ctx, params.Source, member, previousRole, params.UserID)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot change the role of member [%s] in workspace [%s]", params.MemberID, params.WorkspaceID)
+		return nil, service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return member, nil
+}
+
+// WorkspaceMemberRemoveParams are the parameters for removing an entities.WorkspaceMember
+type WorkspaceMemberRemoveParams struct {
+	Source      string
+	UserID      entities.UserID
+	WorkspaceID uuid.UUID
+	MemberID    entities.UserID
+}
+
+// RemoveMember removes a member from a workspace. Every member can leave a workspace except the user who created it.
+func (service *WorkspaceService) RemoveMember(ctx context.Context, params *WorkspaceMemberRemoveParams) error {
+	ctx, span := service.tracer.Start(ctx)
+	defer span.End()
+
+	permission := entities.WorkspacePermissionManage
+	if params.UserID == params.MemberID {
+		permission = entities.WorkspacePermissionView
+	}
+
+	member, err := service.authorizeMemberChange(ctx, params.UserID, params.WorkspaceID, params.MemberID, permission)
+	if err != nil {
+		msg := fmt.Sprintf("user [%s] cannot remove member [%s] from workspace [%s]", params.UserID, params.MemberID, params.WorkspaceID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), msg))
+	}
+
+	err = service.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err = service.memberRepository.Delete(ctx, member.WorkspaceID, member.UserID); err != nil {
+			return stacktrace.Propagate(err, fmt.Sprintf("cannot delete member [%s] of workspace [%s]", member.UserID, member.WorkspaceID))
+		}
+		return service.dispatchWorkspaceMemberRemovedEvent(ctx, params.Source, member, params.UserID)
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot remove member [%s] from workspace [%s]", params.MemberID, params.WorkspaceID)
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	return nil
+}
+
+// MigratePersonalWorkspaces moves the projects which were created before workspaces existed into the personal workspace of their creator
+func (service *WorkspaceService) MigratePersonalWorkspaces(ctx context.Context, source string) error {
+	ctx, span, ctxLogger := service.tracer.StartWithLogger(ctx, service.logger)
+	defer span.End()
+
+	userIDs, err := service.projectRepository.FetchCreatorsWithoutWorkspace(ctx)
+	if err != nil {
+		msg := "cannot fetch the creators of projects without a workspace"
+		return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+	}
+
+	for _, userID := range userIDs {
+		workspace, err := service.Personal(ctx, source, userID)
+		if err != nil {
+			msg := fmt.Sprintf("cannot load personal workspace of user [%s]", userID)
+			return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+
+		count, err := service.projectRepository.AssignWorkspace(ctx, userID, workspace.ID)
+		if err != nil {
+			msg := fmt.Sprintf("cannot move the projects of user [%s] to workspace [%s]", userID, workspace.ID)
+			return service.tracer.WrapErrorSpan(span, stacktrace.Propagate(err, msg))
+		}
+
+		ctxLogger.Info(fmt.Sprintf("moved [%d] projects of user [%s] to personal workspace [%s]", count, userID, workspace.ID))
+	}
+
+	return nil
+}
+
+// authorizeMemberChange loads a member who is changed by a user with a permission.
+// Changing an owner requires the entities.WorkspacePermissionOwn permission and the creator of the workspace cannot be changed.
+func (service *WorkspaceService) authorizeMemberChange(ctx context.Context, userID entities.UserID, workspaceID uuid.UUID, memberID entities.UserID, permission entities.WorkspacePermission) (*entities.WorkspaceMember, error) {
+	if _, err := service.authorizationService.AuthorizeWorkspace(ctx, userID, workspaceID, permission); err != nil {
+		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), fmt.Sprintf("user [%s] does not have the [%s] permission", userID, permission))
+	}
+
+	workspace, err := service.repository.Load(ctx, workspaceID)
+	if err != nil {
+		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), fmt.Sprintf("cannot load workspace [%s]", workspaceID))
+	}
+
+	if workspace.OwnerID == memberID {
+		return nil, stacktrace.NewErrorWithCode(ErrCodeForbidden, fmt.Sprintf("user [%s] created workspace [%s] and cannot be changed", memberID, workspaceID))
+	}
+
+	member, err := service.memberRepository.Load(ctx, workspaceID, memberID)
+	if err != nil {
+		return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), fmt.Sprintf("cannot load member [%s] of workspace [%s]", memberID, workspaceID))
+	}
+
+	if member.Role == entities.WorkspaceRoleOwner && userID != memberID {
+		if _, err = service.authorizationService.AuthorizeWorkspace(ctx, userID, workspaceID, entities.WorkspacePermissionOwn); err != nil {
+			return nil, stacktrace.PropagateWithCode(err, stacktrace.GetCode(err), fmt.Sprintf("user [%s] cannot change owner [%s]", userID, memberID))
+		}
+	}
+
+	return member, nil
+}
+
+func (service *WorkspaceService) dispatchWorkspaceCreatedEvent(ctx context.Context, source string, workspace *entities.Workspace) error {
+	event, err := service.createEvent(events.WorkspaceCreated, source, &events.WorkspaceCreatedPayload{
+		UserID:             workspace.OwnerID,
+		WorkspaceID:        workspace.ID,
+		WorkspaceName:      workspace.Name,
+		WorkspacePersonal:  workspace.Personal,
+		WorkspaceCreatedAt: workspace.CreatedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for workspace [%s]", events.WorkspaceCreated, workspace.ID))
+	}
+	return service.dispatchEvent(ctx, workspace.ID, event)
+}
+
+func (service *WorkspaceService) dispatchWorkspaceMemberAddedEvent(ctx context.Context, source string, member *entities.WorkspaceMember, addedBy entities.UserID) error {
+	event, err := service.createEvent(events.WorkspaceMemberAdded, source, &events.WorkspaceMemberAddedPayload{
+		WorkspaceID:   member.WorkspaceID,
+		UserID:        member.UserID,
+		MemberEmail:   member.Email,
+		MemberRole:    member.Role,
+		AddedBy:       addedBy,
+		MemberAddedAt: member.CreatedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for member [%s]", events.WorkspaceMemberAdded, member.UserID))
+	}
+	return service.dispatchEvent(ctx, member.WorkspaceID, event)
+}
+
+func (service *WorkspaceService) dispatchWorkspaceMemberRoleUpdatedEvent(ctx context.Context, source string, member *entities.WorkspaceMember, previousRole entities.WorkspaceRole, updatedBy entities.UserID) error {
+	event, err := service.createEvent(events.WorkspaceMemberRoleUpdated, source, &events.WorkspaceMemberRoleUpdatedPayload{
+		WorkspaceID:         member.WorkspaceID,
+		UserID:              member.UserID,
+		PreviousRole:        previousRole,
+		MemberRole:          member.Role,
+		UpdatedBy:           updatedBy,
+		MemberRoleUpdatedAt: member.UpdatedAt,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for member [%s]", events.WorkspaceMemberRoleUpdated, member.UserID))
+	}
+	return service.dispatchEvent(ctx, member.WorkspaceID, event)
+}
+
+func (service *WorkspaceService) dispatchWorkspaceMemberRemovedEvent(ctx context.Context, source string, member *entities.WorkspaceMember, removedBy entities.UserID) error {
+	event, err := service.createEvent(events.WorkspaceMemberRemoved, source, &events.WorkspaceMemberRemovedPayload{
+		WorkspaceID:     member.WorkspaceID,
+		UserID:          member.UserID,
+		MemberRole:      member.Role,
+		RemovedBy:       removedBy,
+		MemberRemovedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot create [%s] event for member [%s]", events.WorkspaceMemberRemoved, member.UserID))
+	}
+	return service.dispatchEvent(ctx, member.WorkspaceID, event)
+}
+
+func (service *WorkspaceService) dispatchEvent(ctx context.Context, workspaceID uuid.UUID, event *cloudevents.Event) error {
+	if err := service.eventDispatcher.Dispatch(ctx, event); err != nil {
+		return stacktrace.Propagate(err, fmt.Sprintf("cannot dispatch [%s] event for workspace [%s]", event.Type(), workspaceID))
+	}
+	return nil
+}
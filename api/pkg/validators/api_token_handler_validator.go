@@ -0,0 +1,71 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// APITokenHandlerValidator validates models used in handlers.APITokenHandler
+type APITokenHandlerValidator struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewAPITokenHandlerValidator creates a new handlers.APITokenHandler validator
+func NewAPITokenHandlerValidator(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) (v *APITokenHandlerValidator) {
+	return &APITokenHandlerValidator{
+		logger: logger.WithService(fmt.Sprintf("%T", v)),
+		tracer: tracer,
+	}
+}
+
+// ValidateCreate validates requests.APITokenCreateRequest
+func (validator *APITokenHandlerValidator) ValidateCreate(ctx context.Context, request *requests.APITokenCreateRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"name": []string{
+				"required",
+				"min:1",
+				"max:50",
+			},
+			"expires_in_days": []string{
+				"numeric_between:1,365",
+			},
+		},
+	})
+
+	result := v.ValidateStruct()
+	if len(request.Scopes) == 0 {
+		result.Add("scopes", "The scopes field must contain at least 1 scope")
+	}
+
+	for index, scope := range request.Scopes {
+		if !validator.isScope(scope) {
+			result.Add(fmt.Sprintf("scopes.%d", index), fmt.Sprintf("The scope [%s] must be one of %v", scope, entities.APITokenScopes()))
+		}
+	}
+
+	return result
+}
+
+func (validator *APITokenHandlerValidator) isScope(scope string) bool {
+	for _, value := range entities.APITokenScopes() {
+		if string(value) == scope {
+			return true
+		}
+	}
+	return false
+}
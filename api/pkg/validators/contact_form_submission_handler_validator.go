@@ -0,0 +1,113 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// ContactFormSubmissionHandlerValidator validates models used in handlers.ContactFormSubmissionHandler
+type ContactFormSubmissionHandlerValidator struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewContactFormSubmissionHandlerValidator creates a new handlers.ContactFormSubmissionHandler validator
+func NewContactFormSubmissionHandlerValidator(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) (v *ContactFormSubmissionHandlerValidator) {
+	return &ContactFormSubmissionHandlerValidator{
+		logger: logger.WithService(fmt.Sprintf("%T", v)),
+		tracer: tracer,
+	}
+}
+
+// ValidateSubmit validates requests.ContactFormSubmissionCreateRequest against the fields enabled on the entities.IntegrationContactForm
+func (validator *ContactFormSubmissionHandlerValidator) ValidateSubmit(ctx context.Context, request *requests.ContactFormSubmissionCreateRequest, integration *entities.IntegrationContactForm) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	rules := govalidator.MapData{
+		"page_url": []string{
+			"url",
+			"max:500",
+		},
+	}
+
+	if integration.NameEnabled {
+		rules["name"] = []string{
+			"required",
+			"min:1",
+			"max:100",
+		}
+	}
+
+	if integration.EmailEnabled {
+		rules["email"] = []string{
+			"required",
+			"email",
+			"max:255",
+		}
+	}
+
+	if integration.PhoneEnabled {
+		rules["phone_number"] = []string{
+			"required",
+			phoneNumberRule,
+		}
+	}
+
+	if integration.MessageEnabled {
+		rules["message"] = []string{
+			"required",
+			"min:1",
+			"max:2000",
+		}
+	}
+
+	v := govalidator.New(govalidator.Options{
+		Data:  request,
+		Rules: rules,
+	})
+	return v.ValidateStruct()
+}
+
+// ValidateIndexSubmissions validates requests.ContactFormSubmissionIndexRequest
+func (validator *ContactFormSubmissionHandlerValidator) ValidateIndexSubmissions(ctx context.Context, request *requests.ContactFormSubmissionIndexRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"limit": []string{
+				"required",
+				"numeric",
+				"numeric_between:1,100",
+			},
+			"skip": []string{
+				"required",
+				"numeric",
+				"numeric_between:0,",
+			},
+			"query": []string{
+				"max:100",
+			},
+			"projectID": []string{
+				"required",
+				"uuid",
+			},
+			"integrationID": []string{
+				"required",
+				"uuid",
+			},
+		},
+	})
+	return v.ValidateStruct()
+}
@@ -0,0 +1,54 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// EventsHandlerValidator validates models used in handlers.EventsHandler
+type EventsHandlerValidator struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewEventsHandlerValidator creates a new handlers.EventsHandler validator
+func NewEventsHandlerValidator(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) (v *EventsHandlerValidator) {
+	return &EventsHandlerValidator{
+		logger: logger.WithService(fmt.Sprintf("%T", v)),
+		tracer: tracer,
+	}
+}
+
+// ValidateIndexDeadLetters validates requests.EventDeadLetterIndexRequest
+func (validator *EventsHandlerValidator) ValidateIndexDeadLetters(ctx context.Context, request *requests.EventDeadLetterIndexRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"limit": []string{
+				"required",
+				"numeric",
+				"numeric_between:1,100",
+			},
+			"skip": []string{
+				"required",
+				"numeric",
+				"numeric_between:0,",
+			},
+			"query": []string{
+				"max:100",
+			},
+		},
+	})
+	return v.ValidateStruct()
+}
@@ -0,0 +1,49 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// IntegrationHandlerValidator validates models used in handlers.IntegrationHandler
+type IntegrationHandlerValidator struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewIntegrationHandlerValidator creates a new handlers.IntegrationHandler validator
+func NewIntegrationHandlerValidator(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) (v *IntegrationHandlerValidator) {
+	return &IntegrationHandlerValidator{
+		logger: logger.WithService(fmt.Sprintf("%T", v)),
+		tracer: tracer,
+	}
+}
+
+// ValidateRequest validates a services.IntegrationRequest using the rules of the services.RegisteredIntegration
+func (validator *IntegrationHandlerValidator) ValidateRequest(ctx context.Context, integration services.RegisteredIntegration, request services.IntegrationRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	rules, messages := integration.Rules()
+	v := govalidator.New(govalidator.Options{
+		Data:     request,
+		Rules:    rules,
+		Messages: messages,
+	})
+
+	result := v.ValidateStruct()
+	for key, values := range integration.Validate(request) {
+		for _, value := range values {
+			result.Add(key, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,258 @@
+package validators
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/thedevsaddam/govalidator"
+)
+
+const (
+	maxAvailabilityWindows  = 50
+	maxAvailabilityHolidays = 100
+)
+
+// WhatsappIntegrationRules are the rules for requests.WhatsappIntegrationRequest
+func WhatsappIntegrationRules() govalidator.MapData {
+	return govalidator.MapData{
+		"name": []string{
+			"required",
+			"min:1",
+			"max:30",
+		},
+		"text": []string{
+			"required",
+			"min:1",
+			"max:30",
+		},
+		"phone_number": []string{
+			"required",
+			phoneNumberRule,
+			"max:30",
+		},
+		"offline_message": []string{
+			"max:100",
+		},
+	}
+}
+
+// ValidateWhatsappIntegration validates the schedule of a requests.WhatsappIntegrationRequest
+func ValidateWhatsappIntegration(request *requests.WhatsappIntegrationRequest) url.Values {
+	return validateAvailability(&request.IntegrationAvailabilityRequest)
+}
+
+// PhoneCallIntegrationRules are the rules for requests.PhoneCallIntegrationRequest
+func PhoneCallIntegrationRules() govalidator.MapData {
+	return govalidator.MapData{
+		"name": []string{
+			"required",
+			"min:1",
+			"max:30",
+		},
+		"text": []string{
+			"required",
+			"min:1",
+			"max:30",
+		},
+		"phone_number": []string{
+			"required",
+			phoneNumberRule,
+			"max:30",
+		},
+		"offline_message": []string{
+			"max:100",
+		},
+	}
+}
+
+// ValidatePhoneCallIntegration validates the schedule of a requests.PhoneCallIntegrationRequest
+func ValidatePhoneCallIntegration(request *requests.PhoneCallIntegrationRequest) url.Values {
+	return validateAvailability(&request.IntegrationAvailabilityRequest)
+}
+
+// ContentIntegrationRules are the rules for requests.ContentIntegrationRequest
+func ContentIntegrationRules() govalidator.MapData {
+	return govalidator.MapData{
+		"name": []string{
+			"required",
+			"min:1",
+			"max:30",
+		},
+		"title": []string{
+			"required",
+			"min:1",
+			"max:50",
+		},
+		"text": []string{
+			"required",
+			"min:1",
+			"max:1000",
+		},
+		"summary": []string{
+			"required",
+			"min:1",
+			"max:300",
+		},
+	}
+}
+
+// LinkIntegrationRules are the rules for requests.LinkIntegrationRequest
+func LinkIntegrationRules() govalidator.MapData {
+	return govalidator.MapData{
+		"name": []string{
+			"required",
+			"min:1",
+			"max:30",
+		},
+		"icon": []string{
+			"required",
+			"in:link,documentation,mail,github,map",
+		},
+		"color": []string{
+			"required",
+			"regex:^#[0-9A-F]{6}$",
+		},
+		"text": []string{
+			"required",
+			"min:1",
+			"max:30",
+		},
+		"website": []string{
+			"required",
+			"url",
+			"max:255",
+		},
+	}
+}
+
+// LinkIntegrationMessages are the custom messages for LinkIntegrationRules
+func LinkIntegrationMessages() govalidator.MapData {
+	return govalidator.MapData{
+		"color": {
+			"regex:The color must be valid HEX color e.g #283593",
+		},
+	}
+}
+
+// ContactFormIntegrationRules are the rules for requests.ContactFormIntegrationRequest
+func ContactFormIntegrationRules() govalidator.MapData {
+	return govalidator.MapData{
+		"name": []string{
+			"required",
+			"min:1",
+			"max:30",
+		},
+		"text": []string{
+			"required",
+			"min:1",
+			"max:50",
+		},
+		"name_text": []string{
+			"max:30",
+		},
+		"email_text": []string{
+			"max:30",
+		},
+		"phone_number_text": []string{
+			"max:30",
+		},
+		"message_text": []string{
+			"max:30",
+		},
+		"submit_text": []string{
+			"required",
+			"min:1",
+			"max:30",
+		},
+		"success_message": []string{
+			"max:255",
+		},
+	}
+}
+
+// ValidateContactFormIntegration ensures that at least one field is enabled on a requests.ContactFormIntegrationRequest
+func ValidateContactFormIntegration(request *requests.ContactFormIntegrationRequest) url.Values {
+	result := url.Values{}
+	if !request.NameEnabled && !request.EmailEnabled && !request.PhoneEnabled && !request.MessageEnabled {
+		result.Add("message_enabled", "at least one field must be enabled on the contact form")
+	}
+	return result
+}
+
+// validateAvailability checks the windows and holidays of the entities.AvailabilitySchedule in a requests.IntegrationAvailabilityRequest
+func validateAvailability(request *requests.IntegrationAvailabilityRequest) url.Values {
+	result := url.Values{}
+	schedule := request.Schedule
+	if schedule == nil {
+		return result
+	}
+
+	if schedule.WhenOffline != entities.AvailabilityOfflineModeShow && schedule.WhenOffline != entities.AvailabilityOfflineModeHide {
+		result.Add("schedule.when_offline", fmt.Sprintf("The when_offline field must be [%s] or [%s]", entities.AvailabilityOfflineModeShow, entities.AvailabilityOfflineModeHide))
+	}
+
+	if len(schedule.Weekly) > maxAvailabilityWindows {
+		result.Add("schedule.weekly", fmt.Sprintf("The weekly field cannot contain more than %d windows", maxAvailabilityWindows))
+	}
+
+	for index, window := range schedule.Weekly {
+		field := fmt.Sprintf("schedule.weekly.%d", index)
+		if window == nil {
+			result.Add(field, "The window must be an object")
+			continue
+		}
+
+		if !isWeekday(window.Day) {
+			result.Add(field+".day", "The day must be a day of the week e.g monday")
+		}
+
+		start, startOk := entities.ParseAvailabilityMinute(window.Start)
+		if !startOk || start == 24*60 {
+			result.Add(field+".start", "The start must be a time in the format HH:MM e.g 09:00")
+		}
+
+		end, endOk := entities.ParseAvailabilityMinute(window.End)
+		if !endOk {
+			result.Add(field+".end", "The end must be a time in the format HH:MM e.g 17:00 or 24:00 for the end of the day")
+		}
+
+		if startOk && endOk && end <= start {
+			result.Add(field+".end", "The end must be after the start. Split windows which span midnight into one window per day")
+		}
+	}
+
+	if len(schedule.Holidays) > maxAvailabilityHolidays {
+		result.Add("schedule.holidays", fmt.Sprintf("The holidays field cannot contain more than %d dates", maxAvailabilityHolidays))
+	}
+
+	for index, holiday := range schedule.Holidays {
+		field := fmt.Sprintf("schedule.holidays.%d", index)
+		if holiday == nil {
+			result.Add(field, "The holiday must be an object")
+			continue
+		}
+
+		if _, err := time.Parse(entities.AvailabilityDateFormat, holiday.Date); err != nil {
+			result.Add(field+".date", "The date must be in the format YYYY-MM-DD e.g 2022-12-25")
+		}
+
+		if len(holiday.Name) > 50 {
+			result.Add(field+".name", "The name cannot be longer than 50 characters")
+		}
+	}
+
+	return result
+}
+
+func isWeekday(value string) bool {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.ToLower(day.String()) == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+)
+
+func TestValidateAvailability_AcceptsValidSchedule(t *testing.T) {
+	request := &requests.IntegrationAvailabilityRequest{Schedule: &entities.AvailabilitySchedule{
+		WhenOffline: entities.AvailabilityOfflineModeHide,
+		Weekly:      []*entities.AvailabilityWindow{{Day: "friday", Start: "18:00", End: "24:00"}},
+		Holidays:    []*entities.AvailabilityHoliday{{Date: "2022-12-25", Name: "Christmas"}},
+	}}
+
+	if errors := validateAvailability(request); len(errors) != 0 {
+		t.Errorf("got errors [%v] for a valid schedule, want none", errors)
+	}
+}
+
+func TestValidateAvailability_WithoutSchedule(t *testing.T) {
+	if errors := validateAvailability(&requests.IntegrationAvailabilityRequest{}); len(errors) != 0 {
+		t.Errorf("got errors [%v] without a schedule, want none", errors)
+	}
+}
+
+func TestValidateAvailability_RejectsInvalidSchedule(t *testing.T) {
+	request := &requests.IntegrationAvailabilityRequest{Schedule: &entities.AvailabilitySchedule{
+		WhenOffline: "sometimes",
+		Weekly: []*entities.AvailabilityWindow{
+			{Day: "funday", Start: "09:00", End: "17:00"},
+			{Day: "monday", Start: "22:00", End: "02:00"},
+			{Day: "monday", Start: "24:00", End: "24:00"},
+			nil,
+		},
+		Holidays: []*entities.AvailabilityHoliday{{Date: "25/12/2022"}},
+	}}
+
+	errors := validateAvailability(request)
+	for _, field := range []string{
+		"schedule.when_offline",
+		"schedule.weekly.0.day",
+		"schedule.weekly.1.end",
+		"schedule.weekly.2.start",
+		"schedule.weekly.3",
+		"schedule.holidays.0.date",
+	} {
+		if len(errors[field]) == 0 {
+			t.Errorf("got errors [%v], want an error for [%s]", errors, field)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/thedevsaddam/govalidator"
+)
+
+const (
+	maxAnalyticsDays       = 366
+	maxHourlyAnalyticsDays = 31
+)
+
+// ProjectAnalyticsHandlerValidator validates models used in handlers.ProjectAnalyticsHandler
+type ProjectAnalyticsHandlerValidator struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewProjectAnalyticsHandlerValidator creates a new handlers.ProjectAnalyticsHandler validator
+func NewProjectAnalyticsHandlerValidator(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) (v *ProjectAnalyticsHandlerValidator) {
+	return &ProjectAnalyticsHandlerValidator{
+		logger: logger.WithService(fmt.Sprintf("%T", v)),
+		tracer: tracer,
+	}
+}
+
+// ValidateGet validates requests.ProjectAnalyticsRequest
+func (validator *ProjectAnalyticsHandlerValidator) ValidateGet(ctx context.Context, request *requests.ProjectAnalyticsRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"projectID": []string{
+				"required",
+				"uuid",
+			},
+			"granularity": []string{
+				"required",
+				fmt.Sprintf("in:%s,%s", entities.AnalyticsGranularityHour, entities.AnalyticsGranularityDay),
+			},
+			"timezone": []string{
+				"required",
+				"max:64",
+			},
+		},
+	})
+
+	result := v.ValidateStruct()
+	if _, err := time.LoadLocation(request.Timezone); err != nil {
+		result.Add("timezone", fmt.Sprintf("The timezone [%s] must be an IANA timezone e.g Europe/Paris", request.Timezone))
+	}
+
+	from, err := time.Parse(requests.ProjectAnalyticsDateFormat, request.From)
+	if err != nil {
+		result.Add("from", "The from field must be a date in the format YYYY-MM-DD")
+	}
+
+	to, err := time.Parse(requests.ProjectAnalyticsDateFormat, request.To)
+	if err != nil {
+		result.Add("to", "The to field must be a date in the format YYYY-MM-DD")
+	}
+
+	if len(result) != 0 {
+		return result
+	}
+
+	if to.Before(from) {
+		result.Add("to", "The to field must be a date after or equal to the from field")
+	}
+
+	maxDays := maxAnalyticsDays
+	if request.Granularity == string(entities.AnalyticsGranularityHour) {
+		maxDays = maxHourlyAnalyticsDays
+	}
+
+	if days := int(to.Sub(from).Hours()/24) + 1; days > maxDays {
+		result.Add("from", fmt.Sprintf("The date range cannot be longer than %d days when the granularity is [%s]", maxDays, request.Granularity))
+	}
+
+	return result
+}
@@ -0,0 +1,112 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/thedevsaddam/govalidator"
+)
+
+const (
+	maxDisplayPathRules     = 20
+	maxDisplayPathLength    = 255
+	maxDisplayRuleCountries = 250
+)
+
+var countryCodeRegex = regexp.MustCompile("^[A-Z]{2}$")
+
+// ProjectIntegrationHandlerValidator validates models used in handlers.ProjectIntegrationHandler
+type ProjectIntegrationHandlerValidator struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewProjectIntegrationHandlerValidator creates a new handlers.ProjectIntegrationHandler validator
+func NewProjectIntegrationHandlerValidator(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) (v *ProjectIntegrationHandlerValidator) {
+	return &ProjectIntegrationHandlerValidator{
+		logger: logger.WithService(fmt.Sprintf("%T", v)),
+		tracer: tracer,
+	}
+}
+
+// ValidateUpdateDisplayRules validates requests.ProjectIntegrationDisplayRulesUpdateRequest
+func (validator *ProjectIntegrationHandlerValidator) ValidateUpdateDisplayRules(ctx context.Context, request *requests.ProjectIntegrationDisplayRulesUpdateRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"projectID": []string{
+				"required",
+				"uuid",
+			},
+			"integrationID": []string{
+				"required",
+				"uuid",
+			},
+		},
+	})
+
+	result := v.ValidateStruct()
+	validator.validatePathRules(result, "include_paths", request.IncludePaths)
+	validator.validatePathRules(result, "exclude_paths", request.ExcludePaths)
+
+	for index, device := range request.Devices {
+		if !validator.isDeviceClass(device) {
+			result.Add(fmt.Sprintf("devices.%d", index), fmt.Sprintf("The device [%s] must be one of %v", device, entities.DeviceClasses()))
+		}
+	}
+
+	if len(request.Countries) > maxDisplayRuleCountries {
+		result.Add("countries", fmt.Sprintf("You cannot add more than %d countries", maxDisplayRuleCountries))
+	}
+
+	for index, country := range request.Countries {
+		if !countryCodeRegex.MatchString(country) {
+			result.Add(fmt.Sprintf("countries.%d", index), fmt.Sprintf("The country [%s] must be an ISO 3166-1 alpha-2 code e.g US", country))
+		}
+	}
+
+	return result
+}
+
+func (validator *ProjectIntegrationHandlerValidator) validatePathRules(result url.Values, key string, rules []*entities.DisplayPathRule) {
+	if len(rules) > maxDisplayPathRules {
+		result.Add(key, fmt.Sprintf("You cannot add more than %d path rules", maxDisplayPathRules))
+	}
+
+	for index, rule := range rules {
+		ruleKey := fmt.Sprintf("%s.%d", key, index)
+		if rule.Match != entities.DisplayPathMatchGlob && rule.Match != entities.DisplayPathMatchRegex {
+			result.Add(ruleKey+".match", fmt.Sprintf("The match must be [%s] or [%s]", entities.DisplayPathMatchGlob, entities.DisplayPathMatchRegex))
+			continue
+		}
+
+		if len(rule.Pattern) > maxDisplayPathLength {
+			result.Add(ruleKey+".pattern", fmt.Sprintf("The pattern cannot be longer than %d characters", maxDisplayPathLength))
+			continue
+		}
+
+		if _, err := rule.Regexp(); err != nil {
+			result.Add(ruleKey+".pattern", fmt.Sprintf("The pattern [%s] is not a valid %s", rule.Pattern, rule.Match))
+		}
+	}
+}
+
+func (validator *ProjectIntegrationHandlerValidator) isDeviceClass(device string) bool {
+	for _, value := range entities.DeviceClasses() {
+		if string(value) == device {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/NdoleStudio/superbutton/pkg/requests"
 
@@ -11,6 +13,9 @@ import (
 	"github.com/thedevsaddam/govalidator"
 )
 
+// maxAllowedOrigins is the maximum number of origins which can embed the widget of a project
+const maxAllowedOrigins = 20
+
 // ProjectHandlerValidator validates models used in handlers.ProjectHandler
 type ProjectHandlerValidator struct {
 	logger telemetry.Logger
@@ -70,7 +75,37 @@ func (validator *ProjectHandlerValidator) ValidateUpdate(ctx context.Context, re
 			},
 		},
 	})
-	return v.ValidateStruct()
+
+	result := v.ValidateStruct()
+	if len(request.AllowedOrigins) > maxAllowedOrigins {
+		result.Add("allowed_origins", fmt.Sprintf("The allowed_origins field cannot contain more than %d origins", maxAllowedOrigins))
+	}
+
+	for _, origin := range request.AllowedOrigins {
+		if !validator.isOrigin(origin) {
+			result.Add("allowed_origins", fmt.Sprintf("The origin [%s] must look like https://example.com or https://*.example.com", origin))
+		}
+	}
+
+	if _, err := time.LoadLocation(request.Timezone); err != nil || len(request.Timezone) > 64 {
+		result.Add("timezone", fmt.Sprintf("The timezone [%s] must be an IANA timezone e.g Europe/Paris", request.Timezone))
+	}
+	return result
+}
+
+// isOrigin checks that a value is an http(s) origin without a path. The host may start with a "*." wildcard.
+func (validator *ProjectHandlerValidator) isOrigin(value string) bool {
+	origin, err := url.Parse(value)
+	if err != nil || (origin.Scheme != "http" && origin.Scheme != "https") || origin.Host == "" {
+		return false
+	}
+
+	if origin.Path != "" || origin.RawQuery != "" || origin.Fragment != "" || origin.User != nil {
+		return false
+	}
+
+	host := strings.TrimPrefix(origin.Hostname(), "*.")
+	return host != "" && !strings.Contains(host, "*")
 }
 
 func (validator *ProjectHandlerValidator) ValidateCreate(ctx context.Context, request *requests.ProjectCreateRequest) url.Values {
@@ -90,6 +125,9 @@ func (validator *ProjectHandlerValidator) ValidateCreate(ctx context.Context, re
 				"url",
 				"max:255",
 			},
+			"workspace_id": []string{
+				"uuid",
+			},
 		},
 	})
 	return v.ValidateStruct()
@@ -0,0 +1,164 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/services"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/palantir/stacktrace"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// WebhookHandlerValidator validates models used in handlers.WebhookHandler
+type WebhookHandlerValidator struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewWebhookHandlerValidator creates a new handlers.WebhookHandler validator
+func NewWebhookHandlerValidator(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) (v *WebhookHandlerValidator) {
+	return &WebhookHandlerValidator{
+		logger: logger.WithService(fmt.Sprintf("%T", v)),
+		tracer: tracer,
+	}
+}
+
+// ValidateCreate validates requests.WebhookCreateRequest
+func (validator *WebhookHandlerValidator) ValidateCreate(ctx context.Context, request *requests.WebhookCreateRequest) url.Values {
+	ctx, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"url": []string{
+				"required",
+				"url",
+				"max:255",
+			},
+			"projectID": []string{
+				"required",
+				"uuid",
+			},
+		},
+	})
+
+	result := v.ValidateStruct()
+	validator.validateURL(ctx, result, request.URL)
+	validator.validateEventTypes(result, request.EventTypes)
+	return result
+}
+
+// ValidateUpdate validates requests.WebhookUpdateRequest
+func (validator *WebhookHandlerValidator) ValidateUpdate(ctx context.Context, request *requests.WebhookUpdateRequest) url.Values {
+	ctx, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"url": []string{
+				"required",
+				"url",
+				"max:255",
+			},
+			"projectID": []string{
+				"required",
+				"uuid",
+			},
+			"webhookID": []string{
+				"required",
+				"uuid",
+			},
+		},
+	})
+
+	result := v.ValidateStruct()
+	validator.validateURL(ctx, result, request.URL)
+	validator.validateEventTypes(result, request.EventTypes)
+	return result
+}
+
+// ValidateIndexDeliveries validates requests.WebhookDeliveryIndexRequest
+func (validator *WebhookHandlerValidator) ValidateIndexDeliveries(ctx context.Context, request *requests.WebhookDeliveryIndexRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"limit": []string{
+				"required",
+				"numeric",
+				"numeric_between:1,100",
+			},
+			"skip": []string{
+				"required",
+				"numeric",
+				"numeric_between:0,",
+			},
+			"query": []string{
+				"max:100",
+			},
+			"projectID": []string{
+				"required",
+				"uuid",
+			},
+			"webhookID": []string{
+				"required",
+				"uuid",
+			},
+		},
+	})
+	return v.ValidateStruct()
+}
+
+// validateURL makes sure events are only sent over HTTPS because they contain the messages of website visitors
+// and that the host does not resolve to a private, loopback, link-local or metadata address.
+func (validator *WebhookHandlerValidator) validateURL(ctx context.Context, result url.Values, value string) {
+	ctx, span, ctxLogger := validator.tracer.StartWithLogger(ctx, validator.logger)
+	defer span.End()
+
+	if value == "" || len(result["url"]) != 0 {
+		return
+	}
+
+	if !strings.HasPrefix(strings.ToLower(value), "https://") {
+		result.Add("url", "The url field must start with https://")
+		return
+	}
+
+	if err := services.ValidateWebhookHost(ctx, net.DefaultResolver, value); err != nil {
+		ctxLogger.Warn(stacktrace.Propagate(err, fmt.Sprintf("webhook url [%s] is not allowed", value)))
+		result.Add("url", "The url field must point to a public host which can be resolved")
+	}
+}
+
+func (validator *WebhookHandlerValidator) validateEventTypes(result url.Values, eventTypes []string) {
+	if len(eventTypes) == 0 {
+		result.Add("event_types", "The event_types field must contain at least 1 event type")
+	}
+
+	for index, eventType := range eventTypes {
+		if !validator.isEventType(eventType) {
+			result.Add(fmt.Sprintf("event_types.%d", index), fmt.Sprintf("The event type [%s] must be one of %v", eventType, services.WebhookEventTypes()))
+		}
+	}
+}
+
+func (validator *WebhookHandlerValidator) isEventType(eventType string) bool {
+	for _, value := range services.WebhookEventTypes() {
+		if value == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/NdoleStudio/superbutton/pkg/entities"
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// maxWidgetEventsPerRequest is the maximum number of events which the widget can send in a batch
+const maxWidgetEventsPerRequest = 50
+
+// WidgetEventHandlerValidator validates models used in handlers.WidgetEventHandler
+type WidgetEventHandlerValidator struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewWidgetEventHandlerValidator creates a new handlers.WidgetEventHandler validator
+func NewWidgetEventHandlerValidator(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) (v *WidgetEventHandlerValidator) {
+	return &WidgetEventHandlerValidator{
+		logger: logger.WithService(fmt.Sprintf("%T", v)),
+		tracer: tracer,
+	}
+}
+
+// ValidateCreate validates requests.WidgetEventsCreateRequest
+func (validator *WidgetEventHandlerValidator) ValidateCreate(ctx context.Context, request *requests.WidgetEventsCreateRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	result := url.Values{}
+	if len(request.Events) == 0 || len(request.Events) > maxWidgetEventsPerRequest {
+		result.Add("events", fmt.Sprintf("The events field must contain between 1 and %d events", maxWidgetEventsPerRequest))
+		return result
+	}
+
+	types := make([]string, 0, len(entities.WidgetEventTypes()))
+	for _, eventType := range entities.WidgetEventTypes() {
+		types = append(types, string(eventType))
+	}
+
+	for index, event := range request.Events {
+		if event == nil {
+			result.Add(fmt.Sprintf("events.%d", index), "The event must be an object")
+			continue
+		}
+
+		rules := govalidator.MapData{
+			"key": []string{
+				"required",
+				"max:100",
+			},
+			"type": []string{
+				"required",
+				"in:" + strings.Join(types, ","),
+			},
+			"integration_id": []string{
+				"uuid",
+			},
+			"page_url": []string{
+				"required",
+				"url",
+				"max:2048",
+			},
+		}
+
+		if event.Type == string(entities.WidgetEventTypeIntegrationClicked) {
+			rules["integration_id"] = []string{
+				"required",
+				"uuid",
+			}
+		}
+
+		v := govalidator.New(govalidator.Options{
+			Data:  event,
+			Rules: rules,
+		})
+
+		for field, errors := range v.ValidateStruct() {
+			for _, err := range errors {
+				result.Add(fmt.Sprintf("events.%d.%s", index, field), err)
+			}
+		}
+
+		if event.Key != request.Events[0].Key {
+			result.Add(fmt.Sprintf("events.%d.key", index), "All the events in a batch must have the same key")
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,122 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// workspaceRoleRule restricts a role to one of the entities.WorkspaceRoles
+const workspaceRoleRule = "in:owner,admin,editor,viewer"
+
+// WorkspaceHandlerValidator validates models used in handlers.WorkspaceHandler
+type WorkspaceHandlerValidator struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewWorkspaceHandlerValidator creates a new handlers.WorkspaceHandler validator
+func NewWorkspaceHandlerValidator(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) (v *WorkspaceHandlerValidator) {
+	return &WorkspaceHandlerValidator{
+		logger: logger.WithService(fmt.Sprintf("%T", v)),
+		tracer: tracer,
+	}
+}
+
+// ValidateCreate validates requests.WorkspaceCreateRequest
+func (validator *WorkspaceHandlerValidator) ValidateCreate(ctx context.Context, request *requests.WorkspaceCreateRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"name": []string{
+				"required",
+				"min:1",
+				"max:50",
+			},
+		},
+	})
+	return v.ValidateStruct()
+}
+
+// ValidateUpdate validates requests.WorkspaceUpdateRequest
+func (validator *WorkspaceHandlerValidator) ValidateUpdate(ctx context.Context, request *requests.WorkspaceUpdateRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"workspaceID": []string{
+				"required",
+				"uuid",
+			},
+			"name": []string{
+				"required",
+				"min:1",
+				"max:50",
+			},
+		},
+	})
+	return v.ValidateStruct()
+}
+
+// ValidateCreateMember validates requests.WorkspaceMemberCreateRequest
+func (validator *WorkspaceHandlerValidator) ValidateCreateMember(ctx context.Context, request *requests.WorkspaceMemberCreateRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"workspaceID": []string{
+				"required",
+				"uuid",
+			},
+			"email": []string{
+				"required",
+				"email",
+				"max:255",
+			},
+			"role": []string{
+				"required",
+				workspaceRoleRule,
+			},
+		},
+	})
+	return v.ValidateStruct()
+}
+
+// ValidateUpdateMember validates requests.WorkspaceMemberUpdateRequest
+func (validator *WorkspaceHandlerValidator) ValidateUpdateMember(ctx context.Context, request *requests.WorkspaceMemberUpdateRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"workspaceID": []string{
+				"required",
+				"uuid",
+			},
+			"userID": []string{
+				"required",
+				"max:255",
+			},
+			"role": []string{
+				"required",
+				workspaceRoleRule,
+			},
+		},
+	})
+	return v.ValidateStruct()
+}
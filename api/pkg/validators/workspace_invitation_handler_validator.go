@@ -0,0 +1,71 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/NdoleStudio/superbutton/pkg/requests"
+	"github.com/NdoleStudio/superbutton/pkg/telemetry"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// WorkspaceInvitationHandlerValidator validates models used in handlers.WorkspaceInvitationHandler
+type WorkspaceInvitationHandlerValidator struct {
+	logger telemetry.Logger
+	tracer telemetry.Tracer
+}
+
+// NewWorkspaceInvitationHandlerValidator creates a new handlers.WorkspaceInvitationHandler validator
+func NewWorkspaceInvitationHandlerValidator(
+	logger telemetry.Logger,
+	tracer telemetry.Tracer,
+) (v *WorkspaceInvitationHandlerValidator) {
+	return &WorkspaceInvitationHandlerValidator{
+		logger: logger.WithService(fmt.Sprintf("%T", v)),
+		tracer: tracer,
+	}
+}
+
+// ValidateCreate validates requests.WorkspaceInvitationCreateRequest
+func (validator *WorkspaceInvitationHandlerValidator) ValidateCreate(ctx context.Context, request *requests.WorkspaceInvitationCreateRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"workspaceID": []string{
+				"required",
+				"uuid",
+			},
+			"email": []string{
+				"required",
+				"email",
+				"max:255",
+			},
+			"role": []string{
+				"required",
+				workspaceRoleRule,
+			},
+		},
+	})
+	return v.ValidateStruct()
+}
+
+// ValidateAccept validates requests.WorkspaceInvitationAcceptRequest
+func (validator *WorkspaceInvitationHandlerValidator) ValidateAccept(ctx context.Context, request *requests.WorkspaceInvitationAcceptRequest) url.Values {
+	_, span := validator.tracer.Start(ctx)
+	defer span.End()
+
+	v := govalidator.New(govalidator.Options{
+		Data: request,
+		Rules: govalidator.MapData{
+			"token": []string{
+				"required",
+				"max:255",
+			},
+		},
+	})
+	return v.ValidateStruct()
+}
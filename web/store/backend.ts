@@ -9,6 +9,78 @@
  * ---------------------------------------------------------------
  */
 
+export interface EntitiesAPIToken {
+  /** @example "2022-06-05T14:26:02.302718+03:00" */
+  created_at: string
+  /** @example "2023-06-05T14:26:02.302718+03:00" */
+  expires_at: string
+  /** @example "sbt_3f2b6c" */
+  hint: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  id: string
+  /** @example "2022-06-06T14:26:02.302718+03:00" */
+  last_used_at: string | null
+  /** @example "GitHub Actions" */
+  name: string
+  /** @example "2022-06-07T14:26:02.302718+03:00" */
+  revoked_at: string | null
+  /** @example ["projects:read","integrations:write"] */
+  scopes: string[]
+  /** @example "sbt_3f2b6c1e9a8d4f7b0c5e2a1d9f8b7c6e5d4a3b2c1f0e9d8c7b6a5f4e3d2c1b0a" */
+  token?: string
+  /** @example "2022-06-05T14:26:10.303278+03:00" */
+  updated_at: string
+  /** @example "WB7DRDWrJZRGbYrv2CKGkqbzvqdC" */
+  user_id: string
+}
+
+export interface EntitiesAnalyticsBucket {
+  /** @example 0.05 */
+  click_through_rate: number
+  /** @example 60 */
+  clicks: number
+  /** @example 1200 */
+  impressions: number
+  /** @example 150 */
+  opens: number
+  /** @example "2022-06-05T00:00:00+02:00" */
+  start: string
+}
+
+export interface EntitiesAnalyticsMetrics {
+  /** @example 0.05 */
+  click_through_rate: number
+  /** @example 60 */
+  clicks: number
+  /** @example 1200 */
+  impressions: number
+  /** @example 150 */
+  opens: number
+}
+
+export interface EntitiesAvailabilityHoliday {
+  /** @example "2022-12-25" */
+  date: string
+  /** @example "Christmas" */
+  name: string
+}
+
+export interface EntitiesAvailabilitySchedule {
+  holidays: EntitiesAvailabilityHoliday[]
+  weekly: EntitiesAvailabilityWindow[]
+  /** @example "show" */
+  when_offline: string
+}
+
+export interface EntitiesAvailabilityWindow {
+  /** @example "monday" */
+  day: string
+  /** @example "17:00" */
+  end: string
+  /** @example "09:00" */
+  start: string
+}
+
 export interface EntitiesContentIntegration {
   /** @example "2022-06-05T14:26:02.302718+03:00" */
   created_at: string
@@ -32,6 +104,41 @@ export interface EntitiesContentIntegration {
   user_id: string
 }
 
+export interface EntitiesDisplayPathRule {
+  /** @example "glob" */
+  match: string
+  /** @example "/products/**" */
+  pattern: string
+}
+
+export interface EntitiesDisplayRules {
+  /** @example ["US","CA"] */
+  countries: string[]
+  /** @example ["mobile","tablet"] */
+  devices: string[]
+  exclude_paths: EntitiesDisplayPathRule[]
+  include_paths: EntitiesDisplayPathRule[]
+}
+
+export interface EntitiesIntegrationAnalytics {
+  /** @example 0.05 */
+  click_through_rate: number
+  /** @example 60 */
+  clicks: number
+  /** @example 1200 */
+  impressions: number
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  integration_id: string
+  /** @example "Customer Support" */
+  name: string
+  /** @example 150 */
+  opens: number
+  /** @example 1 */
+  position: number
+  /** @example "whatsapp" */
+  type: string
+}
+
 export interface EntitiesLinkIntegration {
   /** @example "#1E88E5" */
   color: string
@@ -68,10 +175,13 @@ export interface EntitiesPhoneCallIntegration {
   id: string
   /** @example "Customer Service" */
   name: string
+  /** @example "We are closed, call us back tomorrow from 9am" */
+  offline_message: string
   /** @example "+18005550199" */
   phone_number: string
   /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
   project_id: string
+  schedule: EntitiesAvailabilitySchedule | null
   /** @example "Call us on +18005550199" */
   text: string
   /** @example "2022-06-05T14:26:10.303278+03:00" */
@@ -81,6 +191,8 @@ export interface EntitiesPhoneCallIntegration {
 }
 
 export interface EntitiesProject {
+  /** @example ["https://example.com","https://*.example.com"] */
+  allowed_origins: string[] | null
   /** @example "#283593" */
   color: string
   /** @example "2022-06-05T14:26:02.302718+03:00" */
@@ -95,17 +207,40 @@ export interface EntitiesProject {
   id: string
   /** @example "Joe's Store" */
   name: string
+  /** @example "pk_5c1f0b1c4e2a4d7b9a3e6f8d2c1b0a9e8f7d6c5b4a3e2d1c" */
+  publishable_key: string | null
+  /** @example "Europe/Paris" */
+  timezone: string
   /** @example "2022-06-05T14:26:10.303278+03:00" */
   updated_at: string
   /** @example "https://example.com" */
   url: string
   /** @example "WB7DRDWrJZRGbYrv2CKGkqbzvqdC" */
   user_id: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  workspace_id: string
+}
+
+export interface EntitiesProjectAnalytics {
+  /** @example "2022-06-01T00:00:00+02:00" */
+  from: string
+  /** @example "day" */
+  granularity: string
+  integrations: EntitiesIntegrationAnalytics[]
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  project_id: string
+  series: EntitiesAnalyticsBucket[]
+  /** @example "Europe/Paris" */
+  timezone: string
+  /** @example "2022-07-01T00:00:00+02:00" */
+  to: string
+  totals: EntitiesAnalyticsMetrics
 }
 
 export interface EntitiesProjectIntegration {
   /** @example "2022-06-05T14:26:02.302718+03:00" */
   created_at: string
+  display_rules: EntitiesDisplayRules | null
   /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
   id: string
   /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
@@ -129,6 +264,7 @@ export interface EntitiesProjectSettings {
 }
 
 export interface EntitiesProjectSettingsIntegration {
+  display_rules: EntitiesDisplayRules | null
   id: string
   settings: any
   type: string
@@ -157,6 +293,79 @@ export interface EntitiesUser {
   updated_at: string
 }
 
+export interface EntitiesUserWorkspace {
+  /** @example "2022-06-05T14:26:02.302718+03:00" */
+  created_at: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  id: string
+  /** @example "Acme Agency" */
+  name: string
+  /** @example "WB7DRDWrJZRGbYrv2CKGkqbzvqdC" */
+  owner_id: string
+  /** @example false */
+  personal: boolean
+  /** @example "editor" */
+  role: string
+  /** @example "2022-06-05T14:26:10.303278+03:00" */
+  updated_at: string
+}
+
+export interface EntitiesWebhook {
+  /** @example 0 */
+  consecutive_failures: number
+  /** @example "2022-06-05T14:26:02.302718+03:00" */
+  created_at: string
+  /** @example "2022-06-07T14:26:02.302718+03:00" */
+  disabled_at: string | null
+  /** @example true */
+  enabled: boolean
+  /** @example ["contact-form.submitted","integration.updated"] */
+  event_types: string[]
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  id: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  project_id: string
+  /** @example "whsec_3f2b6c1e9a8d4f7b0c5e2a1d9f8b7c6e5d4a3b2c1f0e9d8c7b6a5f4e3d2c1b0a" */
+  signing_secret?: string
+  /** @example "2022-06-05T14:26:10.303278+03:00" */
+  updated_at: string
+  /** @example "https://example.com/webhooks/superbutton" */
+  url: string
+  /** @example "WB7DRDWrJZRGbYrv2CKGkqbzvqdC" */
+  user_id: string
+}
+
+export interface EntitiesWebhookDelivery {
+  /** @example 1 */
+  attempts: number
+  /** @example "2022-06-05T14:26:02.302718+03:00" */
+  created_at: string
+  /** @example "2022-06-05T14:26:10.303278+03:00" */
+  delivered_at: string | null
+  /** @example "context deadline exceeded" */
+  error: string | null
+  /** @example "c9b1a4f0-6bb0-4bd3-9e9f-1c6c0a8e33e5" */
+  event_id: string
+  /** @example "contact-form.submitted" */
+  event_type: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  id: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  project_id: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  redelivery_of: string | null
+  /** @example "OK" */
+  response_body: string | null
+  /** @example 200 */
+  response_code: number | null
+  /** @example "succeeded" */
+  status: string
+  /** @example "2022-06-05T14:26:10.303278+03:00" */
+  updated_at: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  webhook_id: string
+}
+
 export interface EntitiesWhatsappIntegration {
   /** @example "2022-06-05T14:26:02.302718+03:00" */
   created_at: string
@@ -168,10 +377,13 @@ export interface EntitiesWhatsappIntegration {
   id: string
   /** @example "FAQ" */
   name: string
+  /** @example "We are closed, call us back tomorrow from 9am" */
+  offline_message: string
   /** @example "+18005550199" */
   phone_number: string
   /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
   project_id: string
+  schedule: EntitiesAvailabilitySchedule | null
   /** @example "Contact us on WhatsApp" */
   text: string
   /** @example "2022-06-05T14:26:10.303278+03:00" */
@@ -180,6 +392,70 @@ export interface EntitiesWhatsappIntegration {
   user_id: string
 }
 
+export interface EntitiesWorkspace {
+  /** @example "2022-06-05T14:26:02.302718+03:00" */
+  created_at: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  id: string
+  /** @example "Acme Agency" */
+  name: string
+  /** @example "WB7DRDWrJZRGbYrv2CKGkqbzvqdC" */
+  owner_id: string
+  /** @example false */
+  personal: boolean
+  /** @example "2022-06-05T14:26:10.303278+03:00" */
+  updated_at: string
+}
+
+export interface EntitiesWorkspaceInvitation {
+  /** @example "2022-06-06T14:26:02.302718+03:00" */
+  accepted_at: string | null
+  /** @example "WB7DRDWrJZRGbYrv2CKGkqbzvqdC" */
+  accepted_by: string | null
+  /** @example "2022-06-05T14:26:02.302718+03:00" */
+  created_at: string
+  /** @example "name@email.com" */
+  email: string
+  /** @example "2022-06-12T14:26:02.302718+03:00" */
+  expires_at: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  id: string
+  /** @example "WB7DRDWrJZRGbYrv2CKGkqbzvqdC" */
+  invited_by: string
+  /** @example "2022-06-06T14:26:02.302718+03:00" */
+  revoked_at: string | null
+  /** @example "editor" */
+  role: string
+  /** @example "2022-06-05T14:26:10.303278+03:00" */
+  updated_at: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  workspace_id: string
+}
+
+export interface EntitiesWorkspaceMember {
+  /** @example "2022-06-05T14:26:02.302718+03:00" */
+  created_at: string
+  /** @example "name@email.com" */
+  email: string
+  /** @example "editor" */
+  role: string
+  /** @example "2022-06-05T14:26:10.303278+03:00" */
+  updated_at: string
+  /** @example "WB7DRDWrJZRGbYrv2CKGkqbzvqdC" */
+  user_id: string
+  /** @example "8f9c71b8-b84e-4417-8408-a62274f65a08" */
+  workspace_id: string
+}
+
+export interface RequestsAPITokenCreateRequest {
+  /** @example 90 */
+  expires_in_days: number
+  /** @example "GitHub Actions" */
+  name: string
+  /** @example ["projects:read","integrations:write"] */
+  scopes: string[]
+}
+
 export interface RequestsCloudEvent {
   data: any
   datacontenttype: string
@@ -223,19 +499,34 @@ export interface RequestsLinkIntegrationUpdateRequest {
 
 export interface RequestsPhoneCallIntegrationCreateRequest {
   name: string
+  offline_message: string
   phone_number: string
+  schedule: EntitiesAvailabilitySchedule | null
   text: string
 }
 
 export interface RequestsPhoneCallIntegrationUpdateRequest {
   name: string
+  offline_message: string
   phone_number: string
+  schedule: EntitiesAvailabilitySchedule | null
   text: string
 }
 
 export interface RequestsProjectCreateRequest {
   name: string
   website: string
+  /** @example "5a8d3c0e-4b1f-4e3a-9f6d-2c7b8e9a0d1f" */
+  workspace_id?: string
+}
+
+export interface RequestsProjectIntegrationDisplayRulesUpdateRequest {
+  /** @example ["US","CA"] */
+  countries: string[]
+  /** @example ["mobile","tablet"] */
+  devices: string[]
+  exclude_paths: EntitiesDisplayPathRule[]
+  include_paths: EntitiesDisplayPathRule[]
 }
 
 export interface RequestsProjectIntegrationsUpdateRequest {
@@ -243,26 +534,82 @@ export interface RequestsProjectIntegrationsUpdateRequest {
 }
 
 export interface RequestsProjectUpdateRequest {
+  allowed_origins: string[]
   color: string
   greeting: string
   greeting_timeout: number
   icon: string
   name: string
+  timezone: string
   website: string
 }
 
+export interface RequestsWebhookCreateRequest {
+  /** @example ["contact-form.submitted","integration.updated"] */
+  event_types: string[]
+  /** @example "https://example.com/webhooks/superbutton" */
+  url: string
+}
+
+export interface RequestsWebhookUpdateRequest {
+  /** @example true */
+  enabled: boolean
+  /** @example ["contact-form.submitted","integration.updated"] */
+  event_types: string[]
+  /** @example "https://example.com/webhooks/superbutton" */
+  url: string
+}
+
 export interface RequestsWhatsappIntegrationCreateRequest {
   name: string
+  offline_message: string
   phone_number: string
+  schedule: EntitiesAvailabilitySchedule | null
   text: string
 }
 
 export interface RequestsWhatsappIntegrationUpdateRequest {
   name: string
+  offline_message: string
   phone_number: string
+  schedule: EntitiesAvailabilitySchedule | null
   text: string
 }
 
+export interface RequestsWorkspaceCreateRequest {
+  /** @example "Acme Agency" */
+  name: string
+}
+
+export interface RequestsWorkspaceInvitationAcceptRequest {
+  /** @example "inv_3f2b6c1e9a8d4f7b0c5e2a1d9f8b7c6e5d4a3b2c1f0e9d8c7b6a5f4e3d2c1b0a" */
+  token: string
+}
+
+export interface RequestsWorkspaceInvitationCreateRequest {
+  /** @example "name@example.com" */
+  email: string
+  /** @example "editor" */
+  role: string
+}
+
+export interface RequestsWorkspaceMemberCreateRequest {
+  /** @example "name@example.com" */
+  email: string
+  /** @example "editor" */
+  role: string
+}
+
+export interface RequestsWorkspaceMemberUpdateRequest {
+  /** @example "viewer" */
+  role: string
+}
+
+export interface RequestsWorkspaceUpdateRequest {
+  /** @example "Acme Agency" */
+  name: string
+}
+
 export interface ResponsesBadRequest {
   /** @example "The request body is not a valid JSON string" */
   data: string
@@ -293,6 +640,14 @@ export interface ResponsesNotFound {
   status: string
 }
 
+export interface ResponsesOkArrayEntitiesAPIToken {
+  data: EntitiesAPIToken[]
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
 export interface ResponsesOkArrayEntitiesProject {
   data: EntitiesProject[]
   /** @example "Request handled successfully" */
@@ -309,6 +664,62 @@ export interface ResponsesOkArrayEntitiesProjectIntegration {
   status: string
 }
 
+export interface ResponsesOkArrayEntitiesUserWorkspace {
+  data: EntitiesUserWorkspace[]
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
+export interface ResponsesOkArrayEntitiesWebhook {
+  data: EntitiesWebhook[]
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
+export interface ResponsesOkArrayEntitiesWebhookDelivery {
+  data: EntitiesWebhookDelivery[]
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
+export interface ResponsesOkArrayEntitiesWorkspaceInvitation {
+  data: EntitiesWorkspaceInvitation[]
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
+export interface ResponsesOkArrayEntitiesWorkspaceMember {
+  data: EntitiesWorkspaceMember[]
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
+export interface ResponsesOkEntitiesAPIToken {
+  data: EntitiesAPIToken
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
+export interface ResponsesOkEntitiesProjectIntegration {
+  data: EntitiesProjectIntegration
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
 export interface ResponsesOkEntitiesContentIntegration {
   data: EntitiesContentIntegration
   /** @example "Request handled successfully" */
@@ -341,6 +752,14 @@ export interface ResponsesOkEntitiesProject {
   status: string
 }
 
+export interface ResponsesOkEntitiesProjectAnalytics {
+  data: EntitiesProjectAnalytics
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
 export interface ResponsesOkEntitiesProjectSettings {
   data: EntitiesProjectSettings
   /** @example "Request handled successfully" */
@@ -357,6 +776,22 @@ export interface ResponsesOkEntitiesUser {
   status: string
 }
 
+export interface ResponsesOkEntitiesWebhook {
+  data: EntitiesWebhook
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
+export interface ResponsesOkEntitiesWebhookDelivery {
+  data: EntitiesWebhookDelivery
+  /** @example "Request handled successfully" */
+  message: string
+  /** @example "success" */
+  status: string
+}
+
 export interface ResponsesOkEntitiesWhatsappIntegration {
   data: EntitiesWhatsappIntegration
   /** @example "Request handled successfully" */
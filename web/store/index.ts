@@ -4,20 +4,30 @@ import {
   AddContentIntegrationRequest,
   AddLinkIntegrationRequest,
   AddPhoneCallIntegrationRequest,
+  AddWebhookRequest,
   AddWhatsappIntegrationRequest,
+  AddWorkspaceMemberRequest,
   AppData,
   AuthUser,
+  InviteWorkspaceMemberRequest,
   NotificationRequest,
   ProjectIntegrationIdRequest,
   State,
   UpdateContentIntegrationRequest,
+  UpdateIntegrationDisplayRulesRequest,
   UpdateLinkIntegrationRequest,
   UpdatePhoneCallIntegrationRequest,
   UpdateProjectIntegrationsRequest,
   UpdateProjectRequest,
+  UpdateWebhookRequest,
   UpdateWhatsappIntegrationRequest,
+  WebhookDeliveryIdRequest,
+  WebhookIdRequest,
+  WorkspaceInvitationIdRequest,
+  WorkspaceMemberIdRequest,
 } from '~/store/types'
 import {
+  EntitiesAPIToken,
   EntitiesContentIntegration,
   EntitiesLinkIntegration,
   EntitiesPhoneCallIntegration,
@@ -25,16 +35,32 @@ import {
   EntitiesProjectIntegration,
   EntitiesProjectSettings,
   EntitiesUser,
+  EntitiesUserWorkspace,
+  EntitiesWebhook,
+  EntitiesWebhookDelivery,
   EntitiesWhatsappIntegration,
+  EntitiesWorkspaceInvitation,
+  EntitiesWorkspaceMember,
+  RequestsAPITokenCreateRequest,
   ResponsesNoContent,
+  ResponsesOkArrayEntitiesAPIToken,
   ResponsesOkArrayEntitiesProject,
   ResponsesOkArrayEntitiesProjectIntegration,
+  ResponsesOkArrayEntitiesUserWorkspace,
+  ResponsesOkArrayEntitiesWebhook,
+  ResponsesOkArrayEntitiesWebhookDelivery,
+  ResponsesOkArrayEntitiesWorkspaceInvitation,
+  ResponsesOkArrayEntitiesWorkspaceMember,
+  ResponsesOkEntitiesAPIToken,
   ResponsesOkEntitiesContentIntegration,
   ResponsesOkEntitiesLinkIntegration,
   ResponsesOkEntitiesPhoneCallIntegration,
+  ResponsesOkEntitiesProjectIntegration,
   ResponsesOkEntitiesProject,
   ResponsesOkEntitiesProjectSettings,
   ResponsesOkEntitiesUser,
+  ResponsesOkEntitiesWebhook,
+  ResponsesOkEntitiesWebhookDelivery,
   ResponsesOkEntitiesWhatsappIntegration,
   ResponsesOkString,
 } from '~/store/backend'
@@ -368,7 +394,7 @@ export const actions: ActionTree<RootState, RootState> = {
     return new Promise<EntitiesProjectSettings>((resolve, reject) => {
       axios
         .get<ResponsesOkEntitiesProjectSettings>(
-          `/v1/settings/${context.state.authUser?.uid}/projects/${projectId}`
+          `/v1/projects/${projectId}/settings`
         )
         .then((response: AxiosResponse<ResponsesOkEntitiesProjectSettings>) => {
           resolve(response.data.data)
@@ -989,6 +1015,553 @@ export const actions: ActionTree<RootState, RootState> = {
         })
     })
   },
+  updateIntegrationDisplayRules(
+    context: ActionContext<RootState, RootState>,
+    payload: UpdateIntegrationDisplayRulesRequest
+  ) {
+    return new Promise<EntitiesProjectIntegration>((resolve, reject) => {
+      context.commit('clearErrorMessages')
+      axios
+        .put<ResponsesOkEntitiesProjectIntegration>(
+          `/v1/projects/${payload.projectId}/integrations/${payload.integrationId}/display-rules`,
+          payload
+        )
+        .then(
+          async (
+            response: AxiosResponse<ResponsesOkEntitiesProjectIntegration>
+          ) => {
+            await Promise.all([
+              context.dispatch('addNotification', {
+                message:
+                  response.data.message ?? 'Display rules updated successfully',
+                type: 'success',
+              }),
+            ])
+            resolve(response.data.data)
+          }
+        )
+        .catch(async (error: AxiosError) => {
+          await Promise.all([
+            context.commit('setErrorMessages', getErrorMessages(error)),
+            context.dispatch('addNotification', {
+              message:
+                error.response?.data?.message ??
+                'Validation errors while updating display rules',
+              type: 'error',
+            }),
+          ])
+          reject(error)
+        })
+    })
+  },
+
+  getWorkspaces(context: ActionContext<RootState, RootState>) {
+    return new Promise<EntitiesUserWorkspace[]>((resolve, reject) => {
+      axios
+        .get<ResponsesOkArrayEntitiesUserWorkspace>('/v1/workspaces')
+        .then(
+          (response: AxiosResponse<ResponsesOkArrayEntitiesUserWorkspace>) => {
+            resolve(response.data.data)
+          }
+        )
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ?? 'Error while fetching workspaces',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  getWorkspaceMembers(
+    context: ActionContext<RootState, RootState>,
+    workspaceId: string
+  ) {
+    return new Promise<EntitiesWorkspaceMember[]>((resolve, reject) => {
+      axios
+        .get<ResponsesOkArrayEntitiesWorkspaceMember>(
+          `/v1/workspaces/${workspaceId}/members`
+        )
+        .then(
+          (
+            response: AxiosResponse<ResponsesOkArrayEntitiesWorkspaceMember>
+          ) => {
+            resolve(response.data.data)
+          }
+        )
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ??
+              'Error while fetching workspace members',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  addWorkspaceMember(
+    context: ActionContext<RootState, RootState>,
+    payload: AddWorkspaceMemberRequest
+  ) {
+    return new Promise<EntitiesWorkspaceMember>((resolve, reject) => {
+      context.commit('clearErrorMessages')
+      axios
+        .post(`/v1/workspaces/${payload.workspaceId}/members`, payload)
+        .then(async (response: AxiosResponse) => {
+          await context.dispatch('addNotification', {
+            message: response.data.message ?? 'Member added successfully',
+            type: 'success',
+          })
+          resolve(response.data.data)
+        })
+        .catch(async (error: AxiosError) => {
+          await Promise.all([
+            context.commit('setErrorMessages', getErrorMessages(error)),
+            context.dispatch('addNotification', {
+              message:
+                error.response?.data?.message ??
+                'Validation errors while adding member',
+              type: 'error',
+            }),
+          ])
+          reject(error)
+        })
+    })
+  },
+
+  removeWorkspaceMember(
+    context: ActionContext<RootState, RootState>,
+    payload: WorkspaceMemberIdRequest
+  ) {
+    return new Promise<boolean>((resolve, reject) => {
+      axios
+        .delete<ResponsesNoContent>(
+          `/v1/workspaces/${payload.workspaceId}/members/${payload.userId}`
+        )
+        .then(async (response: AxiosResponse<ResponsesNoContent>) => {
+          await Promise.all([
+            context.dispatch('addNotification', {
+              message: response.data.message ?? 'Member removed successfully',
+              type: 'success',
+            }),
+            context.dispatch('loadProjects'),
+          ])
+          resolve(true)
+        })
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ?? 'Error while removing member',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  getWorkspaceInvitations(
+    context: ActionContext<RootState, RootState>,
+    workspaceId: string
+  ) {
+    return new Promise<EntitiesWorkspaceInvitation[]>((resolve, reject) => {
+      axios
+        .get<ResponsesOkArrayEntitiesWorkspaceInvitation>(
+          `/v1/workspaces/${workspaceId}/invitations`
+        )
+        .then(
+          (
+            response: AxiosResponse<ResponsesOkArrayEntitiesWorkspaceInvitation>
+          ) => {
+            resolve(response.data.data)
+          }
+        )
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ??
+              'Error while fetching invitations',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  inviteWorkspaceMember(
+    context: ActionContext<RootState, RootState>,
+    payload: InviteWorkspaceMemberRequest
+  ) {
+    return new Promise<EntitiesWorkspaceInvitation>((resolve, reject) => {
+      context.commit('clearErrorMessages')
+      axios
+        .post(`/v1/workspaces/${payload.workspaceId}/invitations`, payload)
+        .then(async (response: AxiosResponse) => {
+          await context.dispatch('addNotification', {
+            message: response.data.message ?? 'Invitation sent successfully',
+            type: 'success',
+          })
+          resolve(response.data.data)
+        })
+        .catch(async (error: AxiosError) => {
+          await Promise.all([
+            context.commit('setErrorMessages', getErrorMessages(error)),
+            context.dispatch('addNotification', {
+              message:
+                error.response?.data?.message ??
+                'Validation errors while sending invitation',
+              type: 'error',
+            }),
+          ])
+          reject(error)
+        })
+    })
+  },
+
+  revokeWorkspaceInvitation(
+    context: ActionContext<RootState, RootState>,
+    payload: WorkspaceInvitationIdRequest
+  ) {
+    return new Promise<boolean>((resolve, reject) => {
+      axios
+        .delete(
+          `/v1/workspaces/${payload.workspaceId}/invitations/${payload.invitationId}`
+        )
+        .then(async (response: AxiosResponse) => {
+          await context.dispatch('addNotification', {
+            message: response.data.message ?? 'Invitation revoked successfully',
+            type: 'success',
+          })
+          resolve(true)
+        })
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ?? 'Error while revoking invitation',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  acceptWorkspaceInvitation(
+    context: ActionContext<RootState, RootState>,
+    token: string
+  ) {
+    return new Promise<EntitiesWorkspaceMember>((resolve, reject) => {
+      axios
+        .post('/v1/invitations/accept', { token })
+        .then(async (response: AxiosResponse) => {
+          await Promise.all([
+            context.dispatch('addNotification', {
+              message:
+                response.data.message ?? 'Invitation accepted successfully',
+              type: 'success',
+            }),
+            context.dispatch('loadProjects'),
+          ])
+          resolve(response.data.data)
+        })
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ?? 'Error while accepting invitation',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  getAPITokens(context: ActionContext<RootState, RootState>) {
+    return new Promise<EntitiesAPIToken[]>((resolve, reject) => {
+      axios
+        .get<ResponsesOkArrayEntitiesAPIToken>('/v1/api-tokens')
+        .then((response: AxiosResponse<ResponsesOkArrayEntitiesAPIToken>) => {
+          resolve(response.data.data)
+        })
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ?? 'Error while fetching API tokens',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  createAPIToken(
+    context: ActionContext<RootState, RootState>,
+    payload: RequestsAPITokenCreateRequest
+  ) {
+    return new Promise<EntitiesAPIToken>((resolve, reject) => {
+      context.commit('clearErrorMessages')
+      axios
+        .post<ResponsesOkEntitiesAPIToken>('/v1/api-tokens', payload)
+        .then(
+          async (response: AxiosResponse<ResponsesOkEntitiesAPIToken>) => {
+            await context.dispatch('addNotification', {
+              message: response.data.message ?? 'API token created successfully',
+              type: 'success',
+            })
+            resolve(response.data.data)
+          }
+        )
+        .catch(async (error: AxiosError) => {
+          await Promise.all([
+            context.commit('setErrorMessages', getErrorMessages(error)),
+            context.dispatch('addNotification', {
+              message:
+                error.response?.data?.message ??
+                'Validation errors while creating API token',
+              type: 'error',
+            }),
+          ])
+          reject(error)
+        })
+    })
+  },
+
+  revokeAPIToken(context: ActionContext<RootState, RootState>, id: string) {
+    return new Promise<boolean>((resolve, reject) => {
+      axios
+        .delete<ResponsesNoContent>(`/v1/api-tokens/${id}`)
+        .then(async (response: AxiosResponse<ResponsesNoContent>) => {
+          await context.dispatch('addNotification', {
+            message: response.data.message ?? 'API token revoked successfully',
+            type: 'success',
+          })
+          resolve(true)
+        })
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ?? 'Error while revoking API token',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  getWebhooks(context: ActionContext<RootState, RootState>, projectId: string) {
+    return new Promise<EntitiesWebhook[]>((resolve, reject) => {
+      axios
+        .get<ResponsesOkArrayEntitiesWebhook>(
+          `/v1/projects/${projectId}/webhooks`
+        )
+        .then((response: AxiosResponse<ResponsesOkArrayEntitiesWebhook>) => {
+          resolve(response.data.data)
+        })
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ?? 'Error while fetching webhooks',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  addWebhook(
+    context: ActionContext<RootState, RootState>,
+    payload: AddWebhookRequest
+  ) {
+    return new Promise<EntitiesWebhook>((resolve, reject) => {
+      context.commit('clearErrorMessages')
+      axios
+        .post<ResponsesOkEntitiesWebhook>(
+          `/v1/projects/${payload.projectId}/webhooks`,
+          {
+            url: payload.url,
+            event_types: payload.event_types,
+          }
+        )
+        .then(async (response: AxiosResponse<ResponsesOkEntitiesWebhook>) => {
+          await context.dispatch('addNotification', {
+            message: response.data.message ?? 'Webhook created successfully',
+            type: 'success',
+          })
+          resolve(response.data.data)
+        })
+        .catch(async (error: AxiosError) => {
+          await Promise.all([
+            context.commit('setErrorMessages', getErrorMessages(error)),
+            context.dispatch('addNotification', {
+              message:
+                error.response?.data?.message ??
+                'Validation errors while creating webhook',
+              type: 'error',
+            }),
+          ])
+          reject(error)
+        })
+    })
+  },
+
+  updateWebhook(
+    context: ActionContext<RootState, RootState>,
+    payload: UpdateWebhookRequest
+  ) {
+    return new Promise<EntitiesWebhook>((resolve, reject) => {
+      context.commit('clearErrorMessages')
+      axios
+        .put<ResponsesOkEntitiesWebhook>(
+          `/v1/projects/${payload.projectId}/webhooks/${payload.webhookId}`,
+          {
+            url: payload.url,
+            event_types: payload.event_types,
+            enabled: payload.enabled,
+          }
+        )
+        .then(async (response: AxiosResponse<ResponsesOkEntitiesWebhook>) => {
+          await context.dispatch('addNotification', {
+            message: response.data.message ?? 'Webhook updated successfully',
+            type: 'success',
+          })
+          resolve(response.data.data)
+        })
+        .catch(async (error: AxiosError) => {
+          await Promise.all([
+            context.commit('setErrorMessages', getErrorMessages(error)),
+            context.dispatch('addNotification', {
+              message:
+                error.response?.data?.message ??
+                'Validation errors while updating webhook',
+              type: 'error',
+            }),
+          ])
+          reject(error)
+        })
+    })
+  },
+
+  deleteWebhook(
+    context: ActionContext<RootState, RootState>,
+    payload: WebhookIdRequest
+  ) {
+    return new Promise<boolean>((resolve, reject) => {
+      axios
+        .delete<ResponsesNoContent>(
+          `/v1/projects/${payload.projectId}/webhooks/${payload.webhookId}`
+        )
+        .then(async (response: AxiosResponse<ResponsesNoContent>) => {
+          await context.dispatch('addNotification', {
+            message: response.data.message ?? 'Webhook deleted successfully',
+            type: 'success',
+          })
+          resolve(true)
+        })
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ?? 'Error while deleting webhook',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  testWebhook(
+    context: ActionContext<RootState, RootState>,
+    payload: WebhookIdRequest
+  ) {
+    return new Promise<EntitiesWebhookDelivery>((resolve, reject) => {
+      axios
+        .post<ResponsesOkEntitiesWebhookDelivery>(
+          `/v1/projects/${payload.projectId}/webhooks/${payload.webhookId}/test`
+        )
+        .then(
+          async (
+            response: AxiosResponse<ResponsesOkEntitiesWebhookDelivery>
+          ) => {
+            await context.dispatch('addNotification', {
+              message: response.data.message ?? 'Test event queued successfully',
+              type: 'success',
+            })
+            resolve(response.data.data)
+          }
+        )
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ??
+              'Error while sending the test event',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  getWebhookDeliveries(
+    context: ActionContext<RootState, RootState>,
+    payload: WebhookIdRequest
+  ) {
+    return new Promise<EntitiesWebhookDelivery[]>((resolve, reject) => {
+      axios
+        .get<ResponsesOkArrayEntitiesWebhookDelivery>(
+          `/v1/projects/${payload.projectId}/webhooks/${payload.webhookId}/deliveries`,
+          { params: { limit: 100 } }
+        )
+        .then(
+          (
+            response: AxiosResponse<ResponsesOkArrayEntitiesWebhookDelivery>
+          ) => {
+            resolve(response.data.data)
+          }
+        )
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ??
+              'Error while fetching webhook deliveries',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
+  redeliverWebhookDelivery(
+    context: ActionContext<RootState, RootState>,
+    payload: WebhookDeliveryIdRequest
+  ) {
+    return new Promise<EntitiesWebhookDelivery>((resolve, reject) => {
+      axios
+        .post<ResponsesOkEntitiesWebhookDelivery>(
+          `/v1/projects/${payload.projectId}/webhooks/${payload.webhookId}/deliveries/${payload.deliveryId}/redeliver`
+        )
+        .then(
+          async (
+            response: AxiosResponse<ResponsesOkEntitiesWebhookDelivery>
+          ) => {
+            await context.dispatch('addNotification', {
+              message: response.data.message ?? 'Delivery queued successfully',
+              type: 'success',
+            })
+            resolve(response.data.data)
+          }
+        )
+        .catch(async (error: AxiosError) => {
+          await context.dispatch('addNotification', {
+            message:
+              error.response?.data?.message ??
+              'Error while redelivering the webhook event',
+            type: 'error',
+          })
+          reject(error)
+        })
+    })
+  },
+
   getSubscriptionUpdateLink(context: ActionContext<RootState, RootState>) {
     return new Promise<string>((resolve, reject) => {
       axios
@@ -8,10 +8,15 @@ import {
   RequestsLinkIntegrationUpdateRequest,
   RequestsPhoneCallIntegrationCreateRequest,
   RequestsPhoneCallIntegrationUpdateRequest,
+  RequestsProjectIntegrationDisplayRulesUpdateRequest,
   RequestsProjectIntegrationsUpdateRequest,
   RequestsProjectUpdateRequest,
+  RequestsWebhookCreateRequest,
+  RequestsWebhookUpdateRequest,
   RequestsWhatsappIntegrationCreateRequest,
   RequestsWhatsappIntegrationUpdateRequest,
+  RequestsWorkspaceInvitationCreateRequest,
+  RequestsWorkspaceMemberCreateRequest,
 } from '~/store/backend'
 import { ErrorMessagesSerialized } from '~/plugins/errors'
 
@@ -85,6 +90,49 @@ export interface UpdateProjectIntegrationsRequest
   projectId: string
 }
 
+export interface UpdateIntegrationDisplayRulesRequest
+  extends RequestsProjectIntegrationDisplayRulesUpdateRequest {
+  projectId: string
+  integrationId: string
+}
+
+export interface AddWorkspaceMemberRequest
+  extends RequestsWorkspaceMemberCreateRequest {
+  workspaceId: string
+}
+
+export interface WorkspaceMemberIdRequest {
+  workspaceId: string
+  userId: string
+}
+
+export interface InviteWorkspaceMemberRequest
+  extends RequestsWorkspaceInvitationCreateRequest {
+  workspaceId: string
+}
+
+export interface WorkspaceInvitationIdRequest {
+  workspaceId: string
+  invitationId: string
+}
+
+export interface AddWebhookRequest extends RequestsWebhookCreateRequest {
+  projectId: string
+}
+
+export interface WebhookIdRequest {
+  projectId: string
+  webhookId: string
+}
+
+export interface UpdateWebhookRequest
+  extends RequestsWebhookUpdateRequest,
+    WebhookIdRequest {}
+
+export interface WebhookDeliveryIdRequest extends WebhookIdRequest {
+  deliveryId: string
+}
+
 export type AppData = {
   url: string
   name: string
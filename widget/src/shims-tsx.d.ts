@@ -10,6 +10,7 @@ declare global {
   }
 
   interface Window {
+    SB_PUBLISHABLE_KEY?: string;
     SB_USER_ID?: string;
     SB_PROJECT_ID?: string;
   }